@@ -2,110 +2,132 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"go-boilerplate/configs"
+	"go-boilerplate/datasources"
+	"go-boilerplate/pkg/lifecycle"
+	"go-boilerplate/pkg/metrics"
 	"go-boilerplate/pkg/tracer"
 	"go-boilerplate/transports/event_consumer"
 	"go-boilerplate/transports/grpc"
 	"go-boilerplate/transports/http"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/fikri240794/gotask"
-	"github.com/fikri240794/goteletracer"
 	"github.com/spf13/cobra"
 )
 
 var (
-	appCmd *cobra.Command
+	sharedDatasources *datasources.Datasources
+	appCmd            *cobra.Command
 )
 
+// cleanupApp closes the services and middlewares of the transports built so
+// far, a failed build leaves its cleanup nil.
+func cleanupApp() {
+	for _, cleanup := range []func(){httpCleanup, grpcCleanup, eventConsumerCleanup} {
+		if cleanup != nil {
+			cleanup()
+		}
+	}
+}
+
 func initApp() {
 	appCmd = &cobra.Command{
 		Use:   "app",
 		Short: "app",
 		Long:  "app command",
-		PreRun: func(cmd *cobra.Command, args []string) {
+		PreRunE: func(cmd *cobra.Command, args []string) (err error) {
+			var errTask gotask.ErrorTask
+
 			defer func() {
 				if r := recover(); r != nil {
-					log.Printf("[ERROR] Panic recovered in PreRun: %v", r)
+					log.Printf("[ERROR] Panic recovered in PreRunE: %v", r)
+					err = fmt.Errorf("panic recovered in PreRunE: %v", r)
 				}
 			}()
 
-			cfg = configs.Read(cfgPath)
+			err = bootstrapServer(cmd)
+			if err != nil {
+				return err
+			}
 
-			tracer.NewTracer(&goteletracer.Config{
-				ServiceName:         cfg.Server.Tracer.ServiceName,
-				ExporterGRPCAddress: cfg.Server.Tracer.ExporterGRPCAddress,
-			})
+			// every transport shares the same pools, clients and producer
+			sharedDatasources, err = datasources.BuildDatasources(cfg)
+			if err != nil {
+				return err
+			}
 
-			var task gotask.Task = gotask.NewTask(3)
+			errTask, _ = gotask.NewErrorTask(context.Background(), 3)
 
-			task.Go(func() {
-				defer func() {
-					if r := recover(); r != nil {
-						log.Printf("[ERROR] Panic recovered while building HTTP server: %v", r)
-					}
-				}()
-				httpServer = http.BuildHTTPServer(cfg)
+			errTask.Go(func() error {
+				var err error
+				httpServer, httpCleanup, err = http.BuildHTTPServerWithDatasources(cfg, sharedDatasources)
+				return err
 			})
 
-			task.Go(func() {
-				defer func() {
-					if r := recover(); r != nil {
-						log.Printf("[ERROR] Panic recovered while building GRPC server: %v", r)
-					}
-				}()
-				grpcServer = grpc.BuildGRPCServer(cfg)
+			errTask.Go(func() error {
+				var err error
+				grpcServer, grpcCleanup, err = grpc.BuildGRPCServerWithDatasources(cfg, sharedDatasources)
+				return err
 			})
 
-			task.Go(func() {
-				defer func() {
-					if r := recover(); r != nil {
-						log.Printf("[ERROR] Panic recovered while building event consumer: %v", r)
-					}
-				}()
-				eventConsumer = event_consumer.BuildEventConsumer(cfg)
+			errTask.Go(func() error {
+				var err error
+				eventConsumer, eventConsumerCleanup, err = event_consumer.BuildEventConsumerWithDatasources(cfg, sharedDatasources)
+				return err
 			})
 
-			task.Wait()
+			err = errTask.Wait()
+			if err != nil {
+				cleanupApp()
+				sharedDatasources.Disconnect()
+				return err
+			}
+
+			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var (
-				errTask gotask.ErrorTask
-				err     error
+				ctx     context.Context
+				stop    context.CancelFunc
+				manager *lifecycle.Manager
 			)
 
-			errTask, _ = gotask.NewErrorTask(context.Background(), 3)
+			ctx, stop = signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
 
-			errTask.Go(func() error {
-				defer func() {
-					if r := recover(); r != nil {
-						log.Printf("[ERROR] Panic recovered while serving HTTP: %v", r)
-					}
-				}()
-				return httpServer.ServeHTTP()
+			// components stop in the reverse order: transports first, then the
+			// consumers drain, then the services close, the datasources close,
+			// the tracer flushes and the metrics server stops
+			manager = lifecycle.NewManager(&lifecycle.Config{
+				PreStopDelay: cfg.Server.Shutdown.PreStopDelay,
 			})
-
-			errTask.Go(func() error {
-				defer func() {
-					if r := recover(); r != nil {
-						log.Printf("[ERROR] Panic recovered while serving GRPC: %v", r)
-					}
-				}()
-				return grpcServer.ServeGRPC()
+			manager.Append("metrics", &lifecycle.Hook{
+				OnStop: metrics.Shutdown,
 			})
-
-			errTask.Go(func() error {
-				defer func() {
-					if r := recover(); r != nil {
-						log.Printf("[ERROR] Panic recovered while consuming events: %v", r)
-					}
-				}()
-				return eventConsumer.ConsumeEvents()
+			manager.Append("tracer", &lifecycle.Hook{
+				OnStop: tracer.Flush,
 			})
+			manager.Append("datasources", &lifecycle.Hook{
+				OnStop: func(ctx context.Context) error {
+					return sharedDatasources.Disconnect()
+				},
+			})
+			manager.Append("services", &lifecycle.Hook{
+				OnStop: func(ctx context.Context) error {
+					cleanupApp()
+					return nil
+				},
+			})
+			manager.Append("event consumer", eventConsumer)
+			manager.Append("grpc server", grpcServer)
+			manager.Append("http server", httpServer)
 
-			err = errTask.Wait()
-
-			return err
+			return manager.Run(ctx)
 		},
 	}
 	appCmd.Flags().
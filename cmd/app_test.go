@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"go-boilerplate/configs"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -53,12 +54,12 @@ func TestInitApp(t *testing.T) {
 			},
 		},
 		{
-			name: "should have PreRun function",
+			name: "should have PreRunE function",
 			setupFunc: func(t *testing.T) {
 				appCmd = nil
 			},
 			validateFunc: func(t *testing.T) {
-				assert.NotNil(t, appCmd.PreRun)
+				assert.NotNil(t, appCmd.PreRunE)
 			},
 		},
 		{
@@ -149,7 +150,7 @@ func TestInitApp(t *testing.T) {
 			},
 		},
 		{
-			name: "should execute PreRun and cover lines 26-47",
+			name: "should execute PreRunE and return datasource error",
 			setupFunc: func(t *testing.T) {
 				// Will be executed BEFORE initApp()
 				// We'll create temp file but set cfgPath AFTER initApp() in postInitFunc
@@ -223,9 +224,9 @@ GUEST.EVENT.UPDATED.TOPIC=guest-updated`
 				})
 			},
 			validateFunc: func(t *testing.T) {
-				// Execute PreRun to cover lines 26-68
-				// With valid config file and panic recovery, lines should be covered
-				appCmd.PreRun(appCmd, []string{})
+				// Execute PreRunE, the unreachable datasources should fail the build
+				err := appCmd.PreRunE(appCmd, []string{})
+				assert.Error(t, err)
 
 				// Log cfg to see if it was successfully read
 				if cfg != nil {
@@ -236,12 +237,12 @@ GUEST.EVENT.UPDATED.TOPIC=guest-updated`
 			},
 		},
 		{
-			name: "should execute PreRun with panic to cover line 29",
+			name: "should execute PreRunE and return config error",
 			setupFunc: func(t *testing.T) {
-				// Don't set up any config file - this will cause configs.Read() to panic
+				// Don't set up any config file - this will cause configs.Read() to fail
 			},
 			postInitFunc: func(t *testing.T) {
-				// Set cfgPath to non-existent file to trigger panic
+				// Set cfgPath to non-existent file to trigger the read error
 				tempCfgPath := cfgPath
 				cfgPath = "./non-existent-file.env"
 
@@ -250,11 +251,10 @@ GUEST.EVENT.UPDATED.TOPIC=guest-updated`
 				})
 			},
 			validateFunc: func(t *testing.T) {
-				// Execute PreRun - configs.Read() will panic and be recovered
-				// This covers line 29 (log statement in PreRun panic recovery)
-				appCmd.PreRun(appCmd, []string{})
+				// Execute PreRunE - configs.Read() returns the error
+				err := appCmd.PreRunE(appCmd, []string{})
 
-				t.Log("PreRun panic was recovered and logged")
+				assert.ErrorContains(t, err, "failed to read config file ./non-existent-file.env")
 			},
 		},
 		{
@@ -262,6 +262,11 @@ GUEST.EVENT.UPDATED.TOPIC=guest-updated`
 			setupFunc: func(t *testing.T) {
 				// Empty servers will be used by RunE
 				// With panic recovery, they won't crash the test
+				originalCfg := cfg
+				cfg = &configs.Config{}
+				t.Cleanup(func() {
+					cfg = originalCfg
+				})
 			},
 			validateFunc: func(t *testing.T) {
 				assert.NotNil(t, appCmd.RunE)
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"go-boilerplate/configs"
+	"go-boilerplate/pkg/encryption"
+	"go-boilerplate/pkg/logger"
+	"go-boilerplate/pkg/metrics"
+	"go-boilerplate/pkg/redactor"
+	"go-boilerplate/pkg/tracer"
+
+	"github.com/spf13/cobra"
+)
+
+// bootstrap reads the config and sets up the logger, tracer, keyring and
+// redactor every command relies on.
+func bootstrap(cmd *cobra.Command) error {
+	var err error
+
+	// neither an invalid config nor a dependency staying unavailable is a
+	// usage error
+	cmd.SilenceUsage = true
+
+	cfg, err = configs.Read(cfgPath)
+	if err != nil {
+		return err
+	}
+
+	logger.NewLogger(&logger.Config{
+		Level:           cfg.Server.LogLevel,
+		Format:          cfg.Server.Log.Format,
+		ComponentLevels: cfg.Server.Log.ComponentLevels,
+		File: logger.FileConfig{
+			Enable:     cfg.Server.Log.File.Enable,
+			Path:       cfg.Server.Log.File.Path,
+			MaxSizeMB:  cfg.Server.Log.File.MaxSizeMB,
+			MaxAgeDays: cfg.Server.Log.File.MaxAgeDays,
+			MaxBackups: cfg.Server.Log.File.MaxBackups,
+			Compress:   cfg.Server.Log.File.Compress,
+		},
+	})
+	tracer.NewTracer(&tracer.Config{
+		ServiceName:         cfg.Server.Tracer.ServiceName,
+		ServiceVersion:      cfg.Server.Tracer.ServiceVersion,
+		Environment:         cfg.Environment,
+		Exporter:            cfg.Server.Tracer.Exporter,
+		ExporterGRPCAddress: cfg.Server.Tracer.ExporterGRPCAddress,
+		ExporterFilePath:    cfg.Server.Tracer.ExporterFilePath,
+		SampleRatio:         cfg.Server.Tracer.SampleRatio,
+		Metrics: tracer.MetricsConfig{
+			Enable:         cfg.Server.Tracer.Metrics.Enable,
+			ExportInterval: cfg.Server.Tracer.Metrics.ExportInterval,
+			Gatherer:       metrics.Registry(),
+		},
+	})
+	err = encryption.NewKeyring(&encryption.Config{
+		Enable:          cfg.Server.Encryption.Enable,
+		KeyringFilePath: cfg.Server.Encryption.KeyringFilePath,
+	})
+	if err != nil {
+		return err
+	}
+	redactor.NewRedactor(&redactor.Config{
+		Headers:           cfg.Server.Log.Redaction.Headers,
+		Fields:            cfg.Server.Log.Redaction.Fields,
+		MaxBodyBytes:      cfg.Server.Log.Redaction.MaxBodyBytes,
+		SuccessSampleRate: cfg.Server.Log.SuccessSampleRate,
+		Secrets:           cfg.Secrets(),
+	})
+
+	return nil
+}
+
+// bootstrapServer also starts the metrics server and the config hot reload of
+// the long running commands.
+func bootstrapServer(cmd *cobra.Command) error {
+	var err error
+
+	err = bootstrap(cmd)
+	if err != nil {
+		return err
+	}
+
+	metrics.NewMetrics(&metrics.Config{
+		Enable: cfg.Server.Metrics.Enable,
+		Port:   cfg.Server.Metrics.Port,
+		Path:   cfg.Server.Metrics.Path,
+	})
+
+	return watchConfig(cfgPath, cfg)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"go-boilerplate/configs"
+	"go-boilerplate/transports/cli/handlers"
+	"go-boilerplate/transports/cli/models/vms"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	cachePattern  string
+	cacheDepth    int
+	warmCacheCmd  *cobra.Command
+	cacheStatsCmd *cobra.Command
+	flushCacheCmd *cobra.Command
+	cacheCmd      *cobra.Command
+)
+
+func setCacheFlags(cmd *cobra.Command) {
+	cmd.Flags().
+		StringVarP(
+			&cfgPath,
+			"cfgpath",
+			"c",
+			configs.DefaultConfigPath,
+			".env config path",
+		)
+}
+
+func initWarmCacheCmd() {
+	warmCacheCmd = &cobra.Command{
+		Use:     "warm",
+		Short:   "warm caches",
+		Long:    "load the GUEST.CACHE.WARM.PAGES first guest pages and the GUEST.CACHE.WARM.IDS guests into the cache",
+		PreRunE: buildCLI,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCLI(func(h *handlers.Handlers) error {
+				return h.Cache.Warm(
+					context.Background(),
+					cmd.OutOrStdout(),
+				)
+			})
+		},
+	}
+	setCacheFlags(warmCacheCmd)
+}
+
+func initCacheStatsCmd() {
+	cacheStatsCmd = &cobra.Command{
+		Use:     "stats",
+		Short:   "cache stats",
+		Long:    "count the cache keys and their memory per prefix, the keys are walked with SCAN",
+		PreRunE: buildCLI,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCLI(func(h *handlers.Handlers) error {
+				return h.Cache.Stats(
+					context.Background(),
+					cmd.OutOrStdout(),
+					&vms.CacheStatsRequestVM{
+						Pattern: cachePattern,
+						Depth:   cacheDepth,
+					},
+				)
+			})
+		},
+	}
+	setCacheFlags(cacheStatsCmd)
+	cacheStatsCmd.Flags().
+		StringVarP(
+			&cachePattern,
+			"pattern",
+			"p",
+			"",
+			"key pattern, every guest cache key by default",
+		)
+	cacheStatsCmd.Flags().
+		IntVarP(
+			&cacheDepth,
+			"depth",
+			"d",
+			0,
+			"number of key segments a prefix keeps, one past the cache prefix by default",
+		)
+}
+
+func initFlushCacheCmd() {
+	flushCacheCmd = &cobra.Command{
+		Use:     "flush",
+		Short:   "flush caches",
+		Long:    "delete the cache keys matching the pattern with SCAN, the pattern has to start with the guest cache prefix",
+		PreRunE: buildCLI,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCLI(func(h *handlers.Handlers) error {
+				return h.Cache.Flush(
+					context.Background(),
+					cmd.OutOrStdout(),
+					&vms.FlushCacheRequestVM{
+						Pattern: cachePattern,
+					},
+				)
+			})
+		},
+	}
+	setCacheFlags(flushCacheCmd)
+	flushCacheCmd.Flags().
+		StringVarP(
+			&cachePattern,
+			"pattern",
+			"p",
+			"",
+			"key pattern",
+		)
+	flushCacheCmd.MarkFlagRequired("pattern")
+}
+
+func initCache() {
+	initWarmCacheCmd()
+	initCacheStatsCmd()
+	initFlushCacheCmd()
+
+	cacheCmd = &cobra.Command{
+		Use:   "cache",
+		Short: "cache management",
+		Long:  "cache warm up, stats and flush command",
+	}
+
+	cacheCmd.AddCommand(
+		warmCacheCmd,
+		cacheStatsCmd,
+		flushCacheCmd,
+	)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitCache(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupFunc    func(t *testing.T)
+		validateFunc func(t *testing.T)
+	}{
+		{
+			name: "should initialize cacheCmd successfully",
+			setupFunc: func(t *testing.T) {
+				cacheCmd = nil
+			},
+			validateFunc: func(t *testing.T) {
+				assert.NotNil(t, cacheCmd)
+				assert.Equal(t, "cache", cacheCmd.Use)
+				assert.Equal(t, "cache management", cacheCmd.Short)
+				assert.Equal(t, "cache warm up, stats and flush command", cacheCmd.Long)
+			},
+		},
+		{
+			name: "should add warm, stats and flush subcommands",
+			setupFunc: func(t *testing.T) {
+				cacheCmd = nil
+			},
+			validateFunc: func(t *testing.T) {
+				subCommands := cacheCmd.Commands()
+				assert.Equal(t, 3, len(subCommands))
+
+				names := map[string]bool{}
+				for _, subCommand := range subCommands {
+					names[subCommand.Name()] = true
+				}
+				assert.True(t, names["warm"])
+				assert.True(t, names["stats"])
+				assert.True(t, names["flush"])
+			},
+		},
+		{
+			name: "should define warm flags",
+			setupFunc: func(t *testing.T) {
+				warmCacheCmd = nil
+			},
+			validateFunc: func(t *testing.T) {
+				flagCount := 0
+				warmCacheCmd.Flags().VisitAll(func(f *pflag.Flag) {
+					flagCount++
+				})
+				assert.Equal(t, 1, flagCount)
+				assert.Equal(t, "cfgpath", warmCacheCmd.Flags().ShorthandLookup("c").Name)
+				assert.NotNil(t, warmCacheCmd.PreRunE)
+				assert.NotNil(t, warmCacheCmd.RunE)
+			},
+		},
+		{
+			name: "should define stats flags",
+			setupFunc: func(t *testing.T) {
+				cacheStatsCmd = nil
+			},
+			validateFunc: func(t *testing.T) {
+				flagCount := 0
+				cacheStatsCmd.Flags().VisitAll(func(f *pflag.Flag) {
+					flagCount++
+				})
+				assert.Equal(t, 3, flagCount)
+				assert.Equal(t, "pattern", cacheStatsCmd.Flags().ShorthandLookup("p").Name)
+				assert.Equal(t, "depth", cacheStatsCmd.Flags().ShorthandLookup("d").Name)
+				assert.Empty(t, cacheStatsCmd.Flags().Lookup("pattern").Annotations, "stats pattern should be optional")
+				assert.NotNil(t, cacheStatsCmd.PreRunE)
+				assert.NotNil(t, cacheStatsCmd.RunE)
+			},
+		},
+		{
+			name: "should mark flush pattern flag as required",
+			setupFunc: func(t *testing.T) {
+				flushCacheCmd = nil
+			},
+			validateFunc: func(t *testing.T) {
+				flagCount := 0
+				flushCacheCmd.Flags().VisitAll(func(f *pflag.Flag) {
+					flagCount++
+				})
+				assert.Equal(t, 2, flagCount)
+				assert.Equal(t, []string{"true"}, flushCacheCmd.Flags().Lookup("pattern").Annotations[cobra.BashCompOneRequiredFlag])
+				assert.NotNil(t, flushCacheCmd.PreRunE)
+				assert.NotNil(t, flushCacheCmd.RunE)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setupFunc != nil {
+				tt.setupFunc(t)
+			}
+
+			initCache()
+
+			if tt.validateFunc != nil {
+				tt.validateFunc(t)
+			}
+		})
+	}
+}
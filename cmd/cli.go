@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"go-boilerplate/transports/cli"
+	"go-boilerplate/transports/cli/handlers"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// adminCLI runs the one-off guest and cache commands
+	adminCLI *cli.CLI
+	// adminCLICleanup closes the services of adminCLI
+	adminCLICleanup func()
+)
+
+func buildCLI(cmd *cobra.Command, args []string) error {
+	var err error
+
+	err = bootstrap(cmd)
+	if err != nil {
+		return err
+	}
+
+	adminCLI, adminCLICleanup, err = cli.BuildCLI(cfg)
+
+	return err
+}
+
+// runCLI runs fn on adminCLI, then closes its services.
+func runCLI(fn func(h *handlers.Handlers) error) error {
+	defer adminCLICleanup()
+
+	return adminCLI.Run(fn)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"go-boilerplate/configs"
+	"go-boilerplate/pkg/logger"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkConfigCmd *cobra.Command
+	configCmd      *cobra.Command
+)
+
+func reloadLogLevels(change *configs.Change) {
+	var (
+		componentLevels map[string]zerolog.Level
+		err             error
+	)
+
+	if !change.Changed("SERVER.LOG_LEVEL") && !change.Changed("SERVER.LOG.COMPONENT_LEVELS") {
+		return
+	}
+
+	componentLevels, err = logger.ParseComponentLevels(change.Current.Server.Log.ComponentLevels)
+	if err != nil {
+		log.Err(err).Msg("[cmd][reloadLogLevels][ParseComponentLevels] failed to parse reloaded component levels")
+		return
+	}
+
+	err = logger.GetLevels().Replace(zerolog.Level(change.Current.Server.LogLevel), componentLevels)
+	if err != nil {
+		log.Err(err).Msg("[cmd][reloadLogLevels][Replace] failed to replace log levels")
+	}
+}
+
+// watchConfig hot reloads the reloadable keys when enabled, it must run
+// before the servers are built so their components can subscribe.
+func watchConfig(cfgpath string, cfg *configs.Config) error {
+	var (
+		watcher *configs.Watcher
+		err     error
+	)
+
+	if !cfg.Server.HotReload.Enable {
+		return nil
+	}
+
+	watcher, err = configs.Watch(cfgpath, cfg)
+	if err != nil {
+		return err
+	}
+
+	watcher.Subscribe(reloadLogLevels)
+
+	return nil
+}
+
+func checkConfig(cmd *cobra.Command, cfgpath string) error {
+	var (
+		config *configs.Config
+		err    error
+	)
+
+	config, err = configs.Load(cfgpath)
+	if err != nil {
+		return err
+	}
+
+	err = config.Dump(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "config is valid")
+
+	return nil
+}
+
+func initCheckConfigCmd() {
+	checkConfigCmd = &cobra.Command{
+		Use:   "check",
+		Short: "check config",
+		Long:  "print the effective config with secrets masked and validate it",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// an invalid config is not a usage error
+			cmd.SilenceUsage = true
+			return checkConfig(cmd, cfgPath)
+		},
+	}
+	checkConfigCmd.Flags().
+		StringVarP(
+			&cfgPath,
+			"cfgpath",
+			"c",
+			configs.DefaultConfigPath,
+			".env, .yaml or .toml config path",
+		)
+}
+
+func initConfig() {
+	initCheckConfigCmd()
+
+	configCmd = &cobra.Command{
+		Use:   "config",
+		Short: "config",
+		Long:  "config command",
+	}
+
+	configCmd.AddCommand(checkConfigCmd)
+}
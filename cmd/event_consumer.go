@@ -2,16 +2,16 @@ package cmd
 
 import (
 	"go-boilerplate/configs"
-	"go-boilerplate/pkg/tracer"
 	"go-boilerplate/transports/event_consumer"
 
-	"github.com/fikri240794/goteletracer"
 	"github.com/spf13/cobra"
 )
 
 var (
-	eventConsumer    *event_consumer.EventConsumer
-	eventConsumerCmd *cobra.Command
+	eventConsumer *event_consumer.EventConsumer
+	// eventConsumerCleanup closes the services of eventConsumer
+	eventConsumerCleanup func()
+	eventConsumerCmd     *cobra.Command
 )
 
 func initEventConsumer() {
@@ -19,15 +19,21 @@ func initEventConsumer() {
 		Use:   "event-consumer",
 		Short: "event consumer",
 		Long:  "event consumer command",
-		PreRun: func(cmd *cobra.Command, args []string) {
-			cfg = configs.Read(cfgPath)
-			tracer.NewTracer(&goteletracer.Config{
-				ServiceName:         cfg.Server.Tracer.ServiceName,
-				ExporterGRPCAddress: cfg.Server.Tracer.ExporterGRPCAddress,
-			})
-			eventConsumer = event_consumer.BuildEventConsumer(cfg)
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			err = bootstrapServer(cmd)
+			if err != nil {
+				return err
+			}
+
+			eventConsumer, eventConsumerCleanup, err = event_consumer.BuildEventConsumer(cfg)
+
+			return err
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defer eventConsumerCleanup()
+
 			return eventConsumer.ConsumeEvents()
 		},
 	}
@@ -15,6 +15,7 @@ func TestInitEventConsumer(t *testing.T) {
 	tests := []struct {
 		name         string
 		setupFunc    func(t *testing.T)
+		postInitFunc func(t *testing.T) // Called AFTER initEventConsumer()
 		validateFunc func(t *testing.T)
 	}{
 		{
@@ -54,12 +55,12 @@ func TestInitEventConsumer(t *testing.T) {
 			},
 		},
 		{
-			name: "should have PreRun function",
+			name: "should have PreRunE function",
 			setupFunc: func(t *testing.T) {
 				eventConsumerCmd = nil
 			},
 			validateFunc: func(t *testing.T) {
-				assert.NotNil(t, eventConsumerCmd.PreRun)
+				assert.NotNil(t, eventConsumerCmd.PreRunE)
 			},
 		},
 		{
@@ -150,8 +151,8 @@ func TestInitEventConsumer(t *testing.T) {
 			},
 		},
 		{
-			name: "should execute PreRun and cover lines 23-28",
-			setupFunc: func(t *testing.T) {
+			name: "should execute PreRunE and return datasource error",
+			postInitFunc: func(t *testing.T) {
 				// Create temporary .env file for testing
 				tempEnv := `ENVIRONMENT=test
 SERVER.NAME=test-server
@@ -211,7 +212,7 @@ GUEST.EVENT.UPDATED.TOPIC=guest-updated`
 					os.Remove(tmpFile.Name())
 				})
 
-				// Set cfgPath to temp file before calling PreRun
+				// Set cfgPath AFTER init, the flag default overrides it otherwise
 				tempCfgPath := cfgPath
 				cfgPath = tmpFile.Name()
 				t.Cleanup(func() {
@@ -227,8 +228,9 @@ GUEST.EVENT.UPDATED.TOPIC=guest-updated`
 					}
 				}()
 
-				// Execute PreRun to cover lines 23-28
-				eventConsumerCmd.PreRun(eventConsumerCmd, []string{})
+				// Execute PreRunE, the unreachable datasources should fail the build
+				err := eventConsumerCmd.PreRunE(eventConsumerCmd, []string{})
+				assert.Error(t, err)
 
 				// Verify that cfg is populated
 				assert.NotNil(t, cfg)
@@ -268,6 +270,10 @@ GUEST.EVENT.UPDATED.TOPIC=guest-updated`
 
 			initEventConsumer()
 
+			if tt.postInitFunc != nil {
+				tt.postInitFunc(t)
+			}
+
 			if tt.validateFunc != nil {
 				tt.validateFunc(t)
 			}
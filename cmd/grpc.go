@@ -2,16 +2,16 @@ package cmd
 
 import (
 	"go-boilerplate/configs"
-	"go-boilerplate/pkg/tracer"
 	"go-boilerplate/transports/grpc"
 
-	"github.com/fikri240794/goteletracer"
 	"github.com/spf13/cobra"
 )
 
 var (
 	grpcServer *grpc.GRPCServer
-	grpcCmd    *cobra.Command
+	// grpcCleanup closes the services and middlewares of grpcServer
+	grpcCleanup func()
+	grpcCmd     *cobra.Command
 )
 
 func initGRPC() {
@@ -19,15 +19,21 @@ func initGRPC() {
 		Use:   "grpc",
 		Short: "grpc server",
 		Long:  "grpc server command",
-		PreRun: func(cmd *cobra.Command, args []string) {
-			cfg = configs.Read(cfgPath)
-			tracer.NewTracer(&goteletracer.Config{
-				ServiceName:         cfg.Server.Tracer.ServiceName,
-				ExporterGRPCAddress: cfg.Server.Tracer.ExporterGRPCAddress,
-			})
-			grpcServer = grpc.BuildGRPCServer(cfg)
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			err = bootstrapServer(cmd)
+			if err != nil {
+				return err
+			}
+
+			grpcServer, grpcCleanup, err = grpc.BuildGRPCServer(cfg)
+
+			return err
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defer grpcCleanup()
+
 			return grpcServer.ServeGRPC()
 		},
 	}
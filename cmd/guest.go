@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"go-boilerplate/configs"
+	"go-boilerplate/transports/cli/handlers"
+	"go-boilerplate/transports/cli/models/vms"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	guestID        string
+	guestErasedBy  string
+	eraseGuestCmd  *cobra.Command
+	exportGuestCmd *cobra.Command
+	purgeCachesCmd *cobra.Command
+	guestCmd       *cobra.Command
+)
+
+func setGuestFlags(cmd *cobra.Command) {
+	cmd.Flags().
+		StringVarP(
+			&cfgPath,
+			"cfgpath",
+			"c",
+			configs.DefaultConfigPath,
+			".env config path",
+		)
+	cmd.Flags().
+		StringVarP(
+			&guestID,
+			"id",
+			"i",
+			"",
+			"guest id",
+		)
+	cmd.MarkFlagRequired("id")
+}
+
+func initEraseGuestCmd() {
+	eraseGuestCmd = &cobra.Command{
+		Use:     "erase",
+		Short:   "erase guest",
+		Long:    "anonymise guest personal data, remove related caches and publish guest erased event",
+		PreRunE: buildCLI,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCLI(func(h *handlers.Handlers) error {
+				return h.Guest.EraseByID(
+					context.Background(),
+					cmd.OutOrStdout(),
+					&vms.EraseGuestByIDRequestVM{
+						ID:       guestID,
+						ErasedBy: guestErasedBy,
+					},
+				)
+			})
+		},
+	}
+	setGuestFlags(eraseGuestCmd)
+	eraseGuestCmd.Flags().
+		StringVarP(
+			&guestErasedBy,
+			"erased_by",
+			"b",
+			"",
+			"name of the operator requesting the erasure",
+		)
+	eraseGuestCmd.MarkFlagRequired("erased_by")
+}
+
+func initExportGuestCmd() {
+	exportGuestCmd = &cobra.Command{
+		Use:     "export",
+		Short:   "export guest",
+		Long:    "export everything stored about a guest as JSON",
+		PreRunE: buildCLI,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCLI(func(h *handlers.Handlers) error {
+				return h.Guest.ExportByID(
+					context.Background(),
+					cmd.OutOrStdout(),
+					&vms.ExportGuestByIDRequestVM{
+						ID: guestID,
+					},
+				)
+			})
+		},
+	}
+	setGuestFlags(exportGuestCmd)
+}
+
+func initPurgeCachesCmd() {
+	purgeCachesCmd = &cobra.Command{
+		Use:     "purge-caches",
+		Short:   "purge guest caches",
+		Long:    "delete every guest cache with SCAN, writes already invalidate the caches they touch",
+		PreRunE: buildCLI,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCLI(func(h *handlers.Handlers) error {
+				return h.Guest.PurgeCaches(
+					context.Background(),
+					cmd.OutOrStdout(),
+				)
+			})
+		},
+	}
+	purgeCachesCmd.Flags().
+		StringVarP(
+			&cfgPath,
+			"cfgpath",
+			"c",
+			configs.DefaultConfigPath,
+			".env config path",
+		)
+}
+
+func initGuest() {
+	initEraseGuestCmd()
+	initExportGuestCmd()
+	initPurgeCachesCmd()
+
+	guestCmd = &cobra.Command{
+		Use:   "guest",
+		Short: "guest data",
+		Long:  "guest personal data command",
+	}
+
+	guestCmd.AddCommand(
+		eraseGuestCmd,
+		exportGuestCmd,
+		purgeCachesCmd,
+	)
+}
@@ -2,16 +2,16 @@ package cmd
 
 import (
 	"go-boilerplate/configs"
-	"go-boilerplate/pkg/tracer"
 	"go-boilerplate/transports/http"
 
-	"github.com/fikri240794/goteletracer"
 	"github.com/spf13/cobra"
 )
 
 var (
 	httpServer *http.HTTPServer
-	httpCmd    *cobra.Command
+	// httpCleanup closes the services and middlewares of httpServer
+	httpCleanup func()
+	httpCmd     *cobra.Command
 )
 
 func initHTTP() {
@@ -19,15 +19,21 @@ func initHTTP() {
 		Use:   "http",
 		Short: "http server",
 		Long:  "http server command",
-		PreRun: func(cmd *cobra.Command, args []string) {
-			cfg = configs.Read(cfgPath)
-			tracer.NewTracer(&goteletracer.Config{
-				ServiceName:         cfg.Server.Tracer.ServiceName,
-				ExporterGRPCAddress: cfg.Server.Tracer.ExporterGRPCAddress,
-			})
-			httpServer = http.BuildHTTPServer(cfg)
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			err = bootstrapServer(cmd)
+			if err != nil {
+				return err
+			}
+
+			httpServer, httpCleanup, err = http.BuildHTTPServer(cfg)
+
+			return err
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defer httpCleanup()
+
 			return httpServer.ServeHTTP()
 		},
 	}
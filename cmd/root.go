@@ -18,6 +18,9 @@ func init() {
 	initGRPC()
 	initEventConsumer()
 	initApp()
+	initGuest()
+	initCache()
+	initConfig()
 	rootCmd = &cobra.Command{
 		Long: "boilerplate",
 	}
@@ -27,12 +30,14 @@ func init() {
 		grpcCmd,
 		eventConsumerCmd,
 		appCmd,
+		guestCmd,
+		cacheCmd,
+		configCmd,
 	)
 }
 
-func Execute() {
-	var err error = rootCmd.Execute()
-	if err != nil {
-		panic(err)
-	}
+// Execute returns the error cobra already printed, e.g. which dependency
+// stayed unavailable, so main can exit non-zero.
+func Execute() error {
+	return rootCmd.Execute()
 }
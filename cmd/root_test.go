@@ -41,6 +41,18 @@ func TestInit(t *testing.T) {
 				assert.NotNil(t, appCmd)
 			},
 		},
+		{
+			name: "should initialize guestCmd",
+			validateFunc: func(t *testing.T) {
+				assert.NotNil(t, guestCmd)
+			},
+		},
+		{
+			name: "should initialize configCmd",
+			validateFunc: func(t *testing.T) {
+				assert.NotNil(t, configCmd)
+			},
+		},
 		{
 			name: "should initialize rootCmd",
 			validateFunc: func(t *testing.T) {
@@ -124,10 +136,52 @@ func TestInit(t *testing.T) {
 			},
 		},
 		{
-			name: "should have exactly 5 subcommands",
+			name: "should add guestCmd to rootCmd",
+			validateFunc: func(t *testing.T) {
+				commands := rootCmd.Commands()
+				var found bool
+				for _, cmd := range commands {
+					if cmd.Use == "guest" || cmd.Name() == "guest" {
+						found = true
+						break
+					}
+				}
+				assert.True(t, found, "guestCmd should be added to rootCmd")
+			},
+		},
+		{
+			name: "should add cacheCmd to rootCmd",
+			validateFunc: func(t *testing.T) {
+				commands := rootCmd.Commands()
+				var found bool
+				for _, cmd := range commands {
+					if cmd.Use == "cache" || cmd.Name() == "cache" {
+						found = true
+						break
+					}
+				}
+				assert.True(t, found, "cacheCmd should be added to rootCmd")
+			},
+		},
+		{
+			name: "should add configCmd to rootCmd",
+			validateFunc: func(t *testing.T) {
+				commands := rootCmd.Commands()
+				found := false
+				for _, cmd := range commands {
+					if cmd.Use == "config" || cmd.Name() == "config" {
+						found = true
+						break
+					}
+				}
+				assert.True(t, found, "configCmd should be added to rootCmd")
+			},
+		},
+		{
+			name: "should have exactly 8 subcommands",
 			validateFunc: func(t *testing.T) {
 				commands := rootCmd.Commands()
-				assert.Equal(t, 5, len(commands))
+				assert.Equal(t, 8, len(commands))
 			},
 		},
 	}
@@ -148,23 +202,16 @@ func TestExecute(t *testing.T) {
 		validateFunc func(t *testing.T)
 	}{
 		{
-			name: "should panic when Execute returns error",
+			name: "should return error when command is unknown",
 			setupFunc: func(t *testing.T) {
 				// Setup rootCmd with invalid command to trigger error
 				rootCmd.SetArgs([]string{"invalid-command"})
 			},
 			validateFunc: func(t *testing.T) {
-				defer func() {
-					if r := recover(); r != nil {
-						// Expected panic from Execute()
-						t.Logf("Expected panic recovered: %v", r)
-						assert.NotNil(t, r)
-					} else {
-						t.Error("Expected Execute() to panic but it didn't")
-					}
-				}()
+				err := Execute()
 
-				Execute()
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "unknown command")
 			},
 		},
 		{
@@ -182,7 +229,8 @@ func TestExecute(t *testing.T) {
 
 				// Execute with --help should not panic
 				// Note: This will exit with code 0, but in test it just returns
-				Execute()
+				err := Execute()
+				assert.NoError(t, err)
 			},
 		},
 	}
@@ -1,6 +1,13 @@
 package configs
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -9,127 +16,364 @@ import (
 const DefaultConfigPath string = "./configs/.env"
 
 type Config struct {
-	Environment string `mapstructure:"ENVIRONMENT"`
+	Environment string `mapstructure:"ENVIRONMENT" validate:"required"`
 	Server      struct {
-		Name     string `mapstructure:"NAME"`
-		LogLevel int8   `mapstructure:"LOG_LEVEL"`
-		HTTP     struct {
-			Port                       int           `mapstructure:"PORT"`
+		Name     string `mapstructure:"NAME" validate:"required"`
+		LogLevel int8   `mapstructure:"LOG_LEVEL" validate:"min=-1,max=7"`
+		Log      struct {
+			Format string `mapstructure:"FORMAT" validate:"omitempty,oneof=console json"`
+			File   struct {
+				Enable     bool   `mapstructure:"ENABLE"`
+				Path       string `mapstructure:"PATH" validate:"required_if=Enable true"`
+				MaxSizeMB  int    `mapstructure:"MAX_SIZE_MB" validate:"min=0"`
+				MaxAgeDays int    `mapstructure:"MAX_AGE_DAYS" validate:"min=0"`
+				MaxBackups int    `mapstructure:"MAX_BACKUPS" validate:"min=0"`
+				Compress   bool   `mapstructure:"COMPRESS"`
+			} `mapstructure:"FILE"`
+			ComponentLevels   []string `mapstructure:"COMPONENT_LEVELS"`
+			SuccessSampleRate float64  `mapstructure:"SUCCESS_SAMPLE_RATE" validate:"min=0,max=1"`
+			Redaction         struct {
+				Headers      []string `mapstructure:"HEADERS"`
+				Fields       []string `mapstructure:"FIELDS"`
+				MaxBodyBytes int      `mapstructure:"MAX_BODY_BYTES" validate:"min=0"`
+			} `mapstructure:"REDACTION"`
+		} `mapstructure:"LOG"`
+		HTTP struct {
+			Port                       int           `mapstructure:"PORT" validate:"required,min=1,max=65535"`
 			Prefork                    bool          `mapstructure:"PREFORK"`
 			PrintRoutes                bool          `mapstructure:"PRINT_ROUTES"`
 			RequestTimeout             time.Duration `mapstructure:"REQUEST_TIMEOUT"`
-			GracefullyShutdownDuration time.Duration `mapstructure:"GRACEFULLY_SHUTDOWN_DURATION"`
+			GracefullyShutdownDuration time.Duration `mapstructure:"GRACEFULLY_SHUTDOWN_DURATION" validate:"min=0"`
 			CORS                       struct {
 				AllowOrigins string `mapstructure:"ALLOW_ORIGINS"`
 				AllowMethods string `mapstructure:"ALLOW_METHODS"`
 			} `mapstructure:"CORS"`
+			Admin struct {
+				// Token is the bearer token of the /admin routes and of the
+				// gRPC erase and export methods, they reject every request
+				// while it is empty.
+				Token string `mapstructure:"TOKEN" secret:"true"`
+			} `mapstructure:"ADMIN"`
 			Docs struct {
 				Swagger struct {
 					Enable   bool   `mapstructure:"ENABLE"`
-					FilePath string `mapstructure:"FILE_PATH"`
-					Path     string `mapstructure:"PATH"`
+					FilePath string `mapstructure:"FILE_PATH" validate:"required_if=Enable true"`
+					Path     string `mapstructure:"PATH" validate:"required_if=Enable true"`
 					Title    string `mapstructure:"TITLE"`
 				} `mapstructure:"SWAGGER"`
 			} `mapstructure:"DOCS"`
 		} `mapstructure:"HTTP"`
 		GRPC struct {
-			Port           int           `mapstructure:"PORT"`
-			RequestTimeout time.Duration `mapstructure:"REQUEST_TIMEOUT"`
+			Port                       int           `mapstructure:"PORT" validate:"required,min=1,max=65535"`
+			RequestTimeout             time.Duration `mapstructure:"REQUEST_TIMEOUT"`
+			GracefullyShutdownDuration time.Duration `mapstructure:"GRACEFULLY_SHUTDOWN_DURATION" validate:"min=0"`
 		} `mapstructure:"GRPC"`
 		EventConsumer struct {
-			DataSourceName string `mapstructure:"DATA_SOURCE_NAME"`
+			DataSourceName             string        `mapstructure:"DATA_SOURCE_NAME" validate:"required"`
+			HealthPort                 int           `mapstructure:"HEALTH_PORT" validate:"min=0,max=65535"`
+			GracefullyShutdownDuration time.Duration `mapstructure:"GRACEFULLY_SHUTDOWN_DURATION" validate:"min=0"`
 		} `mapstructure:"EVENT_CONSUMER"`
 		Tracer struct {
-			ServiceName         string `mapstructure:"SERVICE_NAME"`
-			ExporterGRPCAddress string `mapstructure:"EXPORTER_GRPC_ADDRESS"`
+			ServiceName         string  `mapstructure:"SERVICE_NAME"`
+			ServiceVersion      string  `mapstructure:"SERVICE_VERSION"`
+			Exporter            string  `mapstructure:"EXPORTER" validate:"omitempty,oneof=otlp stdout file none"`
+			ExporterGRPCAddress string  `mapstructure:"EXPORTER_GRPC_ADDRESS"`
+			ExporterFilePath    string  `mapstructure:"EXPORTER_FILE_PATH" validate:"required_if=Exporter file"`
+			SampleRatio         float64 `mapstructure:"SAMPLE_RATIO" validate:"min=0,max=1"`
+			Metrics             struct {
+				Enable         bool          `mapstructure:"ENABLE"`
+				ExportInterval time.Duration `mapstructure:"EXPORT_INTERVAL"`
+			} `mapstructure:"METRICS"`
 		} `mapstructure:"TRACER"`
+		Encryption struct {
+			Enable          bool   `mapstructure:"ENABLE"`
+			KeyringFilePath string `mapstructure:"KEYRING_FILE_PATH" validate:"required_if=Enable true"`
+		} `mapstructure:"ENCRYPTION"`
+		Metrics struct {
+			Enable bool   `mapstructure:"ENABLE"`
+			Port   int    `mapstructure:"PORT" validate:"required_if=Enable true,min=0,max=65535"`
+			Path   string `mapstructure:"PATH" validate:"required_if=Enable true"`
+		} `mapstructure:"METRICS"`
+		Health struct {
+			Timeout       time.Duration `mapstructure:"TIMEOUT"`
+			CacheDuration time.Duration `mapstructure:"CACHE_DURATION"`
+		} `mapstructure:"HEALTH"`
+		Shutdown struct {
+			PreStopDelay time.Duration `mapstructure:"PRE_STOP_DELAY"`
+		} `mapstructure:"SHUTDOWN"`
+		HotReload struct {
+			Enable bool `mapstructure:"ENABLE"`
+		} `mapstructure:"HOT_RELOAD"`
 	} `mapstructure:"SERVER"`
 	Datasource struct {
 		BoilerplateDatabase struct {
 			Master struct {
-				DriverName                  string        `mapstructure:"DRIVER_NAME"`
-				DataSourceName              string        `mapstructure:"DATA_SOURCE_NAME"`
-				MaximumOpenConnections      int           `mapstructure:"MAXIMUM_OPEN_CONNECTIONS"`
-				MaximumIddleConnections     int           `mapstructure:"MAXIMUM_IDLE_CONNECTIONS"`
+				DriverName                  string        `mapstructure:"DRIVER_NAME" validate:"required"`
+				DataSourceName              string        `mapstructure:"DATA_SOURCE_NAME" validate:"required" secret:"true"`
+				MaximumOpenConnections      int           `mapstructure:"MAXIMUM_OPEN_CONNECTIONS" validate:"min=0"`
+				MaximumIddleConnections     int           `mapstructure:"MAXIMUM_IDLE_CONNECTIONS" validate:"min=0"`
 				ConnectionMaximumIdleTime   time.Duration `mapstructure:"CONNECTION_MAXIMUM_IDLE_TIME"`
 				ConnectionMaximumLifeTime   time.Duration `mapstructure:"CONNECTION_MAXIMUM_LIFE_TIME"`
 				MaximumQueryDurationWarning time.Duration `mapstructure:"MAXIMUM_QUERY_DURATION_WARNING"`
 			} `mapstructure:"MASTER"`
 			Slave struct {
-				DriverName                  string        `mapstructure:"DRIVER_NAME"`
-				DataSourceName              string        `mapstructure:"DATA_SOURCE_NAME"`
-				MaximumOpenConnections      int           `mapstructure:"MAXIMUM_OPEN_CONNECTIONS"`
-				MaximumIddleConnections     int           `mapstructure:"MAXIMUM_IDLE_CONNECTIONS"`
+				DriverName                  string        `mapstructure:"DRIVER_NAME" validate:"required"`
+				DataSourceName              string        `mapstructure:"DATA_SOURCE_NAME" validate:"required" secret:"true"`
+				MaximumOpenConnections      int           `mapstructure:"MAXIMUM_OPEN_CONNECTIONS" validate:"min=0"`
+				MaximumIddleConnections     int           `mapstructure:"MAXIMUM_IDLE_CONNECTIONS" validate:"min=0"`
 				ConnectionMaximumIdleTime   time.Duration `mapstructure:"CONNECTION_MAXIMUM_IDLE_TIME"`
 				ConnectionMaximumLifeTime   time.Duration `mapstructure:"CONNECTION_MAXIMUM_LIFE_TIME"`
 				MaximumQueryDurationWarning time.Duration `mapstructure:"MAXIMUM_QUERY_DURATION_WARNING"`
 			} `mapstructure:"SLAVE"`
+			Retry struct {
+				Attempts       int           `mapstructure:"ATTEMPTS" validate:"min=0"`
+				InitialBackoff time.Duration `mapstructure:"INITIAL_BACKOFF"`
+				MaxBackoff     time.Duration `mapstructure:"MAX_BACKOFF"`
+				Deadline       time.Duration `mapstructure:"DEADLINE"`
+			} `mapstructure:"RETRY"`
 		} `mapstructure:"BOILERPLATE_DATABASE"`
 		InMemoryDatabase struct {
-			DataSourceName string `mapstructure:"DATA_SOURCE_NAME"`
+			// Driver is redis or memory, memory keeps the keys in the process
+			// and ignores Mode, DataSourceName and Retry.
+			Driver string `mapstructure:"DRIVER" validate:"omitempty,oneof=redis memory"`
+			// Mode is standalone, sentinel or cluster, each reads DataSourceName
+			// in its own format.
+			Mode           string `mapstructure:"MODE" validate:"omitempty,oneof=standalone sentinel cluster"`
+			DataSourceName string `mapstructure:"DATA_SOURCE_NAME" validate:"required_unless=Driver memory" secret:"true"`
+			// Memory is read by the memory driver, MaxBytes counts the keys and
+			// values and 0 is no limit. Only keys with an expiry are evicted.
+			Memory struct {
+				MaxBytes        int64         `mapstructure:"MAX_BYTES" validate:"min=0"`
+				CleanupInterval time.Duration `mapstructure:"CLEANUP_INTERVAL"`
+			} `mapstructure:"MEMORY"`
+			Retry struct {
+				Attempts       int           `mapstructure:"ATTEMPTS" validate:"min=0"`
+				InitialBackoff time.Duration `mapstructure:"INITIAL_BACKOFF"`
+				MaxBackoff     time.Duration `mapstructure:"MAX_BACKOFF"`
+				Deadline       time.Duration `mapstructure:"DEADLINE"`
+			} `mapstructure:"RETRY"`
 		} `mapstructure:"IN_MEMORY_DATABASE"`
 		EventProducer struct {
-			DataSourceName string `mapstructure:"DATA_SOURCE_NAME"`
+			DataSourceName string `mapstructure:"DATA_SOURCE_NAME" validate:"required" secret:"true"`
+			Retry          struct {
+				Attempts       int           `mapstructure:"ATTEMPTS" validate:"min=0"`
+				InitialBackoff time.Duration `mapstructure:"INITIAL_BACKOFF"`
+				MaxBackoff     time.Duration `mapstructure:"MAX_BACKOFF"`
+				Deadline       time.Duration `mapstructure:"DEADLINE"`
+			} `mapstructure:"RETRY"`
 		} `mapstructure:"EVENT_PRODUCER"`
 		WebhookSiteHTTPClient struct {
-			BaseURL  string `mapstructure:"BASE_URL"`
+			BaseURL  string `mapstructure:"BASE_URL" validate:"required,url"`
 			Endpoint struct {
-				Webhook string `mapstructure:"WEBHOOK"`
+				Webhook string `mapstructure:"WEBHOOK" validate:"required" secret:"true"`
 			} `mapstructure:"ENDPOINT"`
 		} `mapstructure:"WEBHOOK_SITE_HTTP_CLIENT"`
 	} `mapstructure:"DATASOURCE"`
+	FeatureFlag struct {
+		Backend  string `mapstructure:"BACKEND" validate:"omitempty,oneof=file redis"`
+		FilePath string `mapstructure:"FILE_PATH" validate:"required_if=Backend file"`
+		Keyf     string `mapstructure:"KEYF" validate:"required_if=Backend redis"`
+		// CacheDuration is how long an instance reuses a flag read from the
+		// redis backend, a change made on another instance shows up after it.
+		CacheDuration time.Duration `mapstructure:"CACHE_DURATION" validate:"min=0"`
+	} `mapstructure:"FEATURE_FLAG"`
 	Guest struct {
 		Cache struct {
 			Enable   bool          `mapstructure:"ENABLE"`
-			Keyf     string        `mapstructure:"KEYF"`
+			Keyf     string        `mapstructure:"KEYF" validate:"required,contains=%s"`
 			Duration time.Duration `mapstructure:"DURATION"`
+			// LoadTimeout bounds a database load shared by concurrent misses,
+			// it outlives the request that started it.
+			LoadTimeout time.Duration `mapstructure:"LOAD_TIMEOUT" validate:"min=0"`
+			// Codec only picks how new values are written, the cached ones
+			// are read with the codec they were written with.
+			Codec       string `mapstructure:"CODEC" validate:"omitempty,oneof=json msgpack protobuf"`
+			Compression struct {
+				Algorithm string `mapstructure:"ALGORITHM" validate:"omitempty,oneof=none zstd snappy"`
+				Threshold int    `mapstructure:"THRESHOLD" validate:"min=0"`
+			} `mapstructure:"COMPRESSION"`
+			Entity struct {
+				SoftDuration     time.Duration `mapstructure:"SOFT_DURATION" validate:"min=0"`
+				HardDuration     time.Duration `mapstructure:"HARD_DURATION" validate:"min=0"`
+				NotFoundDuration time.Duration `mapstructure:"NOT_FOUND_DURATION" validate:"min=0"`
+			} `mapstructure:"ENTITY"`
+			List struct {
+				SoftDuration time.Duration `mapstructure:"SOFT_DURATION" validate:"min=0"`
+				HardDuration time.Duration `mapstructure:"HARD_DURATION" validate:"min=0"`
+			} `mapstructure:"LIST"`
+			Count struct {
+				SoftDuration time.Duration `mapstructure:"SOFT_DURATION" validate:"min=0"`
+				HardDuration time.Duration `mapstructure:"HARD_DURATION" validate:"min=0"`
+			} `mapstructure:"COUNT"`
+			// WriteThrough writes the committed entity to its ID key instead
+			// of deleting it, per operation, the list and count caches are
+			// invalidated either way.
+			WriteThrough struct {
+				Create     bool `mapstructure:"CREATE"`
+				UpdateByID bool `mapstructure:"UPDATE_BY_ID"`
+				BulkCreate bool `mapstructure:"BULK_CREATE"`
+				BulkUpdate bool `mapstructure:"BULK_UPDATE"`
+			} `mapstructure:"WRITE_THROUGH"`
+			Warm struct {
+				// Pages is the number of default FindAll pages cache warm
+				// loads, IDs are the guests it loads, the hottest first.
+				Pages int      `mapstructure:"PAGES" validate:"min=0"`
+				IDs   []string `mapstructure:"IDS" validate:"dive,uuid_rfc4122"`
+			} `mapstructure:"WARM"`
+			L1 struct {
+				Enable   bool          `mapstructure:"ENABLE"`
+				Capacity int           `mapstructure:"CAPACITY" validate:"required_if=Enable true,min=0"`
+				Duration time.Duration `mapstructure:"DURATION" validate:"required_if=Enable true,min=0"`
+				Channel  string        `mapstructure:"CHANNEL" validate:"required_if=Enable true"`
+				Stats    bool          `mapstructure:"STATS"`
+			} `mapstructure:"L1"`
 		} `mapstructure:"CACHE"`
 		Event struct {
 			Created struct {
 				Enable bool   `mapstructure:"ENABLE"`
-				Topic  string `mapstructure:"TOPIC"`
+				Topic  string `mapstructure:"TOPIC" validate:"required_if=Enable true"`
 			} `mapstructure:"CREATED"`
 			Deleted struct {
 				Enable bool   `mapstructure:"ENABLE"`
-				Topic  string `mapstructure:"TOPIC"`
+				Topic  string `mapstructure:"TOPIC" validate:"required_if=Enable true"`
 			} `mapstructure:"DELETED"`
 			Updated struct {
 				Enable bool   `mapstructure:"ENABLE"`
-				Topic  string `mapstructure:"TOPIC"`
+				Topic  string `mapstructure:"TOPIC" validate:"required_if=Enable true"`
 			} `mapstructure:"UPDATED"`
 			BulkCreated struct {
 				Enable bool   `mapstructure:"ENABLE"`
-				Topic  string `mapstructure:"TOPIC"`
+				Topic  string `mapstructure:"TOPIC" validate:"required_if=Enable true"`
 			} `mapstructure:"BULK_CREATED"`
 			BulkUpdated struct {
 				Enable bool   `mapstructure:"ENABLE"`
-				Topic  string `mapstructure:"TOPIC"`
+				Topic  string `mapstructure:"TOPIC" validate:"required_if=Enable true"`
 			} `mapstructure:"BULK_UPDATED"`
 			BulkDeleted struct {
 				Enable bool   `mapstructure:"ENABLE"`
-				Topic  string `mapstructure:"TOPIC"`
+				Topic  string `mapstructure:"TOPIC" validate:"required_if=Enable true"`
 			} `mapstructure:"BULK_DELETED"`
+			Erased struct {
+				Enable bool   `mapstructure:"ENABLE"`
+				Topic  string `mapstructure:"TOPIC" validate:"required_if=Enable true"`
+			} `mapstructure:"ERASED"`
 		} `mapstructure:"EVENT"`
 	} `mapstructure:"GUEST"`
+
+	// secretKeys are the keys resolved from a secret reference
+	secretKeys map[string]bool
 }
 
-func Read(cfgpath string) *Config {
+// configType derives the viper config type from the extension, so ".env",
+// "config.yaml" and "config.toml" are all read with the right parser.
+func configType(cfgpath string) string {
+	return strings.TrimPrefix(filepath.Ext(cfgpath), ".")
+}
+
+// environmentConfigPath returns the file layered on top of cfgpath for the
+// environment, ".env.production" for ".env" and "config.production.yaml" for
+// "config.yaml".
+func environmentConfigPath(cfgpath string, environment string) string {
+	var ext string = filepath.Ext(cfgpath)
+
+	if environment == "" {
+		return ""
+	}
+
+	if filepath.Base(cfgpath) == ext {
+		return cfgpath + "." + environment
+	}
+
+	return strings.TrimSuffix(cfgpath, ext) + "." + environment + ext
+}
+
+// bindEnvs binds every key of t, viper only looks up environment variables of
+// keys it already knows when unmarshalling.
+func bindEnvs(v *viper.Viper) {
+	walk(reflect.ValueOf(Config{}), "", func(key string, field reflect.StructField, value reflect.Value) error {
+		return v.BindEnv(key)
+	})
+}
+
+func mergeConfigFile(v *viper.Viper, cfgpath string) error {
+	var (
+		file *os.File
+		err  error
+	)
+
+	file, err = os.Open(cfgpath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return v.MergeConfig(file)
+}
+
+// Load reads cfgpath, layers the environment file on top of it when present
+// and applies environment variable overrides, e.g. SERVER_HTTP_PORT for
+// SERVER.HTTP.PORT. Secret references such as file:///run/secrets/db_dsn are
+// resolved, the result is not validated.
+func Load(cfgpath string) (*Config, error) {
+	var (
+		v                 *viper.Viper
+		environmentConfig string
+		config            *Config
+		err               error
+	)
+
+	v = viper.New()
+	v.SetConfigFile(cfgpath)
+	v.SetConfigType(configType(cfgpath))
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	bindEnvs(v)
+	v.SetDefault("SERVER.LOG.SUCCESS_SAMPLE_RATE", 1)
+	v.SetDefault("SERVER.TRACER.SAMPLE_RATIO", 1)
+
+	err = v.ReadInConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", cfgpath, err)
+	}
+
+	environmentConfig = environmentConfigPath(cfgpath, v.GetString("ENVIRONMENT"))
+	if environmentConfig != "" {
+		err = mergeConfigFile(v, environmentConfig)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read config file %s: %w", environmentConfig, err)
+		}
+	}
+
+	config = &Config{}
+	err = v.Unmarshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	err = config.resolveSecrets(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Read loads and validates the config.
+func Read(cfgpath string) (*Config, error) {
 	var (
 		config *Config
 		err    error
 	)
 
-	viper.SetConfigFile(cfgpath)
-	err = viper.ReadInConfig()
+	config, err = Load(cfgpath)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	config = &Config{}
-	err = viper.Unmarshal(&config)
+	err = config.Validate()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	return config
+	return config, nil
 }
@@ -0,0 +1,108 @@
+package configs
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const maskedValue string = "******"
+
+// maskSecret keeps the scheme, user and host of a URL so the target stays
+// visible, anything else is masked entirely.
+func maskSecret(value string) string {
+	var (
+		u   *url.URL
+		err error
+	)
+
+	if value == "" {
+		return ""
+	}
+
+	u, err = url.Parse(value)
+	if err == nil && u.Scheme != "" && u.Host != "" {
+		if _, ok := u.User.Password(); ok {
+			return u.Redacted()
+		}
+	}
+
+	return maskedValue
+}
+
+func formatValue(value reflect.Value) string {
+	var (
+		values []string
+		i      int
+	)
+
+	switch v := value.Interface().(type) {
+	case time.Duration:
+		return v.String()
+	case []string:
+		return strings.Join(v, ",")
+	}
+
+	if value.Kind() == reflect.Slice {
+		for i = 0; i < value.Len(); i++ {
+			values = append(values, fmt.Sprint(value.Index(i).Interface()))
+		}
+		return strings.Join(values, ",")
+	}
+
+	return fmt.Sprint(value.Interface())
+}
+
+// walk calls fn for every leaf field of value with its dotted key, e.g.
+// SERVER.HTTP.PORT.
+func walk(value reflect.Value, prefix string, fn func(key string, field reflect.StructField, value reflect.Value) error) error {
+	var (
+		field reflect.StructField
+		key   string
+		i     int
+		err   error
+	)
+
+	for i = 0; i < value.NumField(); i++ {
+		field = value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key = prefix + field.Tag.Get("mapstructure")
+
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Duration(0)) {
+			err = walk(value.Field(i), key+".", fn)
+		} else {
+			err = fn(key, field, value.Field(i))
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Dump writes the effective config in the .env format with the fields tagged
+// secret and the resolved secret references masked.
+func (c *Config) Dump(w io.Writer) error {
+	return walk(reflect.ValueOf(c).Elem(), "", func(key string, field reflect.StructField, value reflect.Value) error {
+		var (
+			formatted string = formatValue(value)
+			err       error
+		)
+
+		if c.isSecret(key, field) {
+			formatted = maskSecret(formatted)
+		}
+
+		_, err = fmt.Fprintf(w, "%s=%s\n", key, formatted)
+
+		return err
+	})
+}
@@ -0,0 +1,192 @@
+package configs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SecretProvider resolves the secret references of one scheme, e.g.
+// file:///run/secrets/db_dsn or env://DB_DSN. Implementations backed by an
+// external secret store are registered with RegisterSecretProvider.
+type SecretProvider interface {
+	Scheme() string
+	Resolve(ctx context.Context, name string) (string, error)
+}
+
+// FileSecretProvider reads file://<path> references, e.g. docker or
+// kubernetes secrets mounted as files.
+type FileSecretProvider struct{}
+
+func NewFileSecretProvider() *FileSecretProvider {
+	return &FileSecretProvider{}
+}
+
+func (p *FileSecretProvider) Scheme() string {
+	return "file"
+}
+
+func (p *FileSecretProvider) Resolve(ctx context.Context, name string) (string, error) {
+	var (
+		content []byte
+		err     error
+	)
+
+	content, err = os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	// secret files usually end with a newline that is not part of the secret
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// EnvSecretProvider reads env://<VAR> references.
+type EnvSecretProvider struct{}
+
+func NewEnvSecretProvider() *EnvSecretProvider {
+	return &EnvSecretProvider{}
+}
+
+func (p *EnvSecretProvider) Scheme() string {
+	return "env"
+}
+
+func (p *EnvSecretProvider) Resolve(ctx context.Context, name string) (string, error) {
+	var (
+		value string
+		found bool
+	)
+
+	value, found = os.LookupEnv(name)
+	if !found {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	return value, nil
+}
+
+var (
+	secretProvidersMutex sync.RWMutex
+	secretProviders      map[string]SecretProvider = map[string]SecretProvider{}
+)
+
+func init() {
+	RegisterSecretProvider(NewFileSecretProvider())
+	RegisterSecretProvider(NewEnvSecretProvider())
+}
+
+// RegisterSecretProvider makes provider resolve the references of its scheme,
+// replacing any provider registered for the same scheme.
+func RegisterSecretProvider(provider SecretProvider) {
+	secretProvidersMutex.Lock()
+	defer secretProvidersMutex.Unlock()
+
+	secretProviders[provider.Scheme()] = provider
+}
+
+// secretProvider returns the provider of value when value is a secret
+// reference, values of other schemes such as postgres:// are not references.
+func secretProvider(value string) (SecretProvider, string, bool) {
+	var (
+		scheme   string
+		name     string
+		found    bool
+		provider SecretProvider
+	)
+
+	scheme, name, found = strings.Cut(value, "://")
+	if !found {
+		return nil, "", false
+	}
+
+	secretProvidersMutex.RLock()
+	provider, found = secretProviders[scheme]
+	secretProvidersMutex.RUnlock()
+
+	return provider, name, found
+}
+
+// resolveSecrets replaces every secret reference of c with its value and
+// marks the key as secret.
+func (c *Config) resolveSecrets(ctx context.Context) error {
+	return walk(reflect.ValueOf(c).Elem(), "", func(key string, field reflect.StructField, value reflect.Value) error {
+		var (
+			provider SecretProvider
+			name     string
+			found    bool
+			secret   string
+			err      error
+		)
+
+		if value.Kind() != reflect.String {
+			return nil
+		}
+
+		provider, name, found = secretProvider(value.String())
+		if !found {
+			return nil
+		}
+
+		secret, err = provider.Resolve(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to resolve secret %s: %w", key, err)
+		}
+
+		value.SetString(secret)
+		if c.secretKeys == nil {
+			c.secretKeys = map[string]bool{}
+		}
+		c.secretKeys[key] = true
+
+		return nil
+	})
+}
+
+// isSecret reports whether key is tagged secret or was resolved from a secret
+// reference.
+func (c *Config) isSecret(key string, field reflect.StructField) bool {
+	return field.Tag.Get("secret") == "true" || c.secretKeys[key]
+}
+
+// Secrets returns the secret values of c, including the password of URLs, so
+// they can be masked in logs.
+func (c *Config) Secrets() []string {
+	var secrets []string
+
+	walk(reflect.ValueOf(c).Elem(), "", func(key string, field reflect.StructField, value reflect.Value) error {
+		var (
+			u        *url.URL
+			password string
+			ok       bool
+			err      error
+		)
+
+		if value.Kind() != reflect.String || value.String() == "" || !c.isSecret(key, field) {
+			return nil
+		}
+
+		if !slices.Contains(secrets, value.String()) {
+			secrets = append(secrets, value.String())
+		}
+
+		u, err = url.Parse(value.String())
+		if err != nil {
+			return nil
+		}
+
+		password, ok = u.User.Password()
+		if ok && password != "" && !slices.Contains(secrets, password) {
+			secrets = append(secrets, password)
+		}
+
+		return nil
+	})
+
+	return secrets
+}
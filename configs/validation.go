@@ -0,0 +1,91 @@
+package configs
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+)
+
+var (
+	validate   *validator.Validate
+	translator ut.Translator
+)
+
+func fieldFromMapstructureTag(fld reflect.StructField) string {
+	return fld.Tag.Get("mapstructure")
+}
+
+func translateErrorToEnglish() {
+	var (
+		localeEnglish       locales.Translator
+		universalTranslator *ut.UniversalTranslator
+	)
+
+	localeEnglish = en.New()
+	universalTranslator = ut.New(localeEnglish)
+	translator, _ = universalTranslator.GetTranslator("en")
+	en_translations.RegisterDefaultTranslations(validate, translator)
+}
+
+func init() {
+	validate = validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(fieldFromMapstructureTag)
+	translateErrorToEnglish()
+}
+
+// ValidationError reports every invalid key at once, keyed the same way as
+// the config file.
+type ValidationError struct {
+	Fields map[string]string
+	keys   []string
+}
+
+func (e *ValidationError) Error() string {
+	var (
+		builder strings.Builder
+		key     string
+	)
+
+	builder.WriteString("invalid config:")
+	for _, key = range e.keys {
+		builder.WriteString("\n  ")
+		builder.WriteString(key)
+		builder.WriteString(": ")
+		builder.WriteString(e.Fields[key])
+	}
+
+	return builder.String()
+}
+
+func (c *Config) Validate() error {
+	var (
+		validationErrors validator.ValidationErrors
+		validationError  *ValidationError
+		key              string
+		err              error
+	)
+
+	err = validate.Struct(c)
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	validationError = &ValidationError{
+		Fields: make(map[string]string, len(validationErrors)),
+	}
+
+	for _, fieldError := range validationErrors {
+		// the namespace starts with the struct name, e.g. Config.SERVER.HTTP.PORT
+		key = fieldError.Namespace()[strings.Index(fieldError.Namespace(), ".")+1:]
+		validationError.keys = append(validationError.keys, key)
+		validationError.Fields[key] = fieldError.Translate(translator)
+	}
+
+	return validationError
+}
@@ -0,0 +1,374 @@
+package configs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+)
+
+// reloadableKeys are safe to change without a restart, every other key is
+// read once while building the servers, pools and consumers.
+var reloadableKeys []string = []string{
+	"SERVER.LOG_LEVEL",
+	"SERVER.LOG.COMPONENT_LEVELS",
+	"SERVER.HTTP.REQUEST_TIMEOUT",
+	"SERVER.GRPC.REQUEST_TIMEOUT",
+	"GUEST.CACHE.ENABLE",
+	"GUEST.CACHE.DURATION",
+	"GUEST.CACHE.LOAD_TIMEOUT",
+	"GUEST.CACHE.ENTITY.SOFT_DURATION",
+	"GUEST.CACHE.ENTITY.HARD_DURATION",
+	"GUEST.CACHE.ENTITY.NOT_FOUND_DURATION",
+	"GUEST.CACHE.LIST.SOFT_DURATION",
+	"GUEST.CACHE.LIST.HARD_DURATION",
+	"GUEST.CACHE.COUNT.SOFT_DURATION",
+	"GUEST.CACHE.COUNT.HARD_DURATION",
+	"GUEST.CACHE.WRITE_THROUGH.CREATE",
+	"GUEST.CACHE.WRITE_THROUGH.UPDATE_BY_ID",
+	"GUEST.CACHE.WRITE_THROUGH.BULK_CREATE",
+	"GUEST.CACHE.WRITE_THROUGH.BULK_UPDATE",
+	"GUEST.EVENT.CREATED.ENABLE",
+	"GUEST.EVENT.DELETED.ENABLE",
+	"GUEST.EVENT.UPDATED.ENABLE",
+	"GUEST.EVENT.BULK_CREATED.ENABLE",
+	"GUEST.EVENT.BULK_UPDATED.ENABLE",
+	"GUEST.EVENT.BULK_DELETED.ENABLE",
+	"GUEST.EVENT.ERASED.ENABLE",
+}
+
+var globalWatcher *Watcher
+
+// Change is sent to the subscribers after a reload applied at least one key.
+type Change struct {
+	Previous *Config
+	Current  *Config
+	Keys     []string
+}
+
+func (c *Change) Changed(key string) bool {
+	return slices.Contains(c.Keys, key)
+}
+
+type subscription struct {
+	id         uint64
+	subscriber func(change *Change)
+}
+
+// Watcher keeps an atomic snapshot of the config and applies the reloadable
+// keys of the config file whenever it changes.
+type Watcher struct {
+	cfgpath       string
+	current       atomic.Pointer[Config]
+	mutex         sync.Mutex
+	subscriptions []subscription
+	lastID        uint64
+}
+
+func NewWatcher(cfgpath string, cfg *Config) *Watcher {
+	var watcher *Watcher = &Watcher{
+		cfgpath: cfgpath,
+	}
+
+	watcher.current.Store(cfg)
+
+	return watcher
+}
+
+// Current returns the latest snapshot, it must not be modified.
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// Subscribe returns the func removing subscriber, calling it more than once
+// does nothing.
+func (w *Watcher) Subscribe(subscriber func(change *Change)) func() {
+	var id uint64
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.lastID++
+	id = w.lastID
+	w.subscriptions = append(w.subscriptions, subscription{
+		id:         id,
+		subscriber: subscriber,
+	})
+
+	return func() {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+
+		w.subscriptions = slices.DeleteFunc(w.subscriptions, func(s subscription) bool {
+			return s.id == id
+		})
+	}
+}
+
+func flatten(cfg *Config) map[string]string {
+	var values map[string]string = map[string]string{}
+
+	walk(reflect.ValueOf(cfg).Elem(), "", func(key string, field reflect.StructField, value reflect.Value) error {
+		values[key] = formatValue(value)
+		return nil
+	})
+
+	return values
+}
+
+func fieldByKey(value reflect.Value, key string) reflect.Value {
+	var (
+		name  string
+		field reflect.StructField
+		found bool
+		i     int
+	)
+
+	for _, name = range strings.Split(key, ".") {
+		found = false
+		for i = 0; i < value.NumField(); i++ {
+			field = value.Type().Field(i)
+			if field.Tag.Get("mapstructure") == name {
+				value = value.Field(i)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return reflect.Value{}
+		}
+	}
+
+	return value
+}
+
+// Reload reads and validates the config file again, applies the changed
+// reloadable keys to a new snapshot and notifies the subscribers. Changes to
+// other keys are only logged, they need a restart.
+func (w *Watcher) Reload() error {
+	var (
+		loaded         *Config
+		previous       *Config
+		next           Config
+		previousValues map[string]string
+		loadedValues   map[string]string
+		changedKeys    []string
+		ignoredKeys    []string
+		subscriptions  []subscription
+		change         *Change
+		key            string
+		err            error
+	)
+
+	loaded, err = Load(w.cfgpath)
+	if err != nil {
+		return err
+	}
+
+	err = loaded.Validate()
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+
+	previous = w.current.Load()
+	previousValues = flatten(previous)
+	loadedValues = flatten(loaded)
+	next = *previous
+
+	for key = range loadedValues {
+		if loadedValues[key] == previousValues[key] {
+			continue
+		}
+
+		if !slices.Contains(reloadableKeys, key) {
+			ignoredKeys = append(ignoredKeys, key)
+			continue
+		}
+
+		fieldByKey(reflect.ValueOf(&next).Elem(), key).
+			Set(fieldByKey(reflect.ValueOf(loaded).Elem(), key))
+		changedKeys = append(changedKeys, key)
+	}
+
+	if len(ignoredKeys) > 0 {
+		slices.Sort(ignoredKeys)
+		log.Warn().
+			Strs("keys", ignoredKeys).
+			Msg("[Watcher][Reload] config keys changed that need a restart")
+	}
+
+	if len(changedKeys) == 0 {
+		w.mutex.Unlock()
+		return nil
+	}
+
+	slices.Sort(changedKeys)
+	w.current.Store(&next)
+	subscriptions = slices.Clone(w.subscriptions)
+	w.mutex.Unlock()
+
+	log.Info().
+		Strs("keys", changedKeys).
+		Msg("[Watcher][Reload] config reloaded")
+
+	change = &Change{
+		Previous: previous,
+		Current:  &next,
+		Keys:     changedKeys,
+	}
+
+	for _, subscription := range subscriptions {
+		subscription.subscriber(change)
+	}
+
+	return nil
+}
+
+// watchedPaths are the config file and the environment file layered on top
+// of it, the latter may not exist yet.
+func (w *Watcher) watchedPaths() []string {
+	var (
+		paths             []string = []string{filepath.Clean(w.cfgpath)}
+		environmentConfig string   = environmentConfigPath(w.cfgpath, w.Current().Environment)
+	)
+
+	if environmentConfig != "" {
+		paths = append(paths, filepath.Clean(environmentConfig))
+	}
+
+	return paths
+}
+
+// Watch reloads the config whenever the config file or its environment file
+// is written, created or removed, an invalid file keeps the current snapshot.
+func (w *Watcher) Watch() error {
+	var (
+		paths     []string
+		dirs      []string
+		dir       string
+		fsWatcher *fsnotify.Watcher
+		err       error
+	)
+
+	_, err = os.Stat(w.cfgpath)
+	if err != nil {
+		return fmt.Errorf("failed to watch config file %s: %w", w.cfgpath, err)
+	}
+
+	fsWatcher, err = fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch config file %s: %w", w.cfgpath, err)
+	}
+
+	// the directories are watched, editors and ConfigMaps replace the files
+	// instead of writing them
+	paths = w.watchedPaths()
+	for _, path := range paths {
+		dir = filepath.Dir(path)
+		if slices.Contains(dirs, dir) {
+			continue
+		}
+
+		err = fsWatcher.Add(dir)
+		if err != nil {
+			fsWatcher.Close()
+			return fmt.Errorf("failed to watch config directory %s: %w", dir, err)
+		}
+		dirs = append(dirs, dir)
+	}
+
+	go w.watch(fsWatcher, paths)
+
+	return nil
+}
+
+func (w *Watcher) watch(fsWatcher *fsnotify.Watcher, paths []string) {
+	var (
+		realPaths map[string]string = map[string]string{}
+		realPath  string
+		event     fsnotify.Event
+		changed   bool
+		ok        bool
+		err       error
+	)
+
+	defer fsWatcher.Close()
+
+	for _, path := range paths {
+		realPaths[path], _ = filepath.EvalSymlinks(path)
+	}
+
+	for {
+		select {
+		case event, ok = <-fsWatcher.Events:
+			if !ok {
+				return
+			}
+
+			changed = false
+			for _, path := range paths {
+				// a changed symlink target is a ConfigMap update
+				realPath, _ = filepath.EvalSymlinks(path)
+				if realPath != realPaths[path] ||
+					(filepath.Clean(event.Name) == path &&
+						event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename)) {
+					realPaths[path] = realPath
+					changed = true
+				}
+			}
+
+			if !changed {
+				continue
+			}
+
+			err = w.Reload()
+			if err != nil {
+				log.Err(err).Msg("[Watcher][watch][Reload] failed to reload config, keeping the current one")
+			}
+		case err, ok = <-fsWatcher.Errors:
+			if !ok {
+				return
+			}
+
+			log.Err(err).Msg("[Watcher][watch] failed to watch config files")
+		}
+	}
+}
+
+// Watch starts watching cfgpath and makes the watcher global, so components
+// built afterwards can Subscribe.
+func Watch(cfgpath string, cfg *Config) (*Watcher, error) {
+	var (
+		watcher *Watcher = NewWatcher(cfgpath, cfg)
+		err     error
+	)
+
+	err = watcher.Watch()
+	if err != nil {
+		return nil, err
+	}
+
+	globalWatcher = watcher
+
+	return watcher, nil
+}
+
+// Subscribe registers subscriber on the global watcher and returns the func
+// removing it, both do nothing when hot reload is off.
+func Subscribe(subscriber func(change *Change)) func() {
+	if globalWatcher == nil {
+		return func() {}
+	}
+
+	return globalWatcher.Subscribe(subscriber)
+}
@@ -3,6 +3,8 @@ package boilerplate_database
 import (
 	"context"
 	"go-boilerplate/configs"
+	"go-boilerplate/pkg/metrics"
+	"go-boilerplate/pkg/retry"
 	"time"
 
 	"github.com/fikri240794/gotask"
@@ -17,18 +19,72 @@ type BoilerplateDatabase struct {
 	SlaveMaxQueryDurationWarning  time.Duration
 }
 
-func Connect(cfg *configs.Config) *BoilerplateDatabase {
-	var connection *BoilerplateDatabase = &BoilerplateDatabase{
-		Master: otelsqlx.MustConnect(
-			cfg.Datasource.BoilerplateDatabase.Master.DriverName,
-			cfg.Datasource.BoilerplateDatabase.Master.DataSourceName,
-		),
+type sqlConnector func(ctx context.Context, driverName string, dataSourceName string) (*sqlx.DB, error)
+
+var defaultSQLConnector sqlConnector = func(ctx context.Context, driverName string, dataSourceName string) (*sqlx.DB, error) {
+	return otelsqlx.ConnectContext(ctx, driverName, dataSourceName)
+}
+
+func connectWithRetry(cfg *configs.Config, name string, driverName string, dataSourceName string, fn sqlConnector) (*sqlx.DB, error) {
+	var (
+		db  *sqlx.DB
+		err error
+	)
+
+	err = retry.Do(
+		context.Background(),
+		name,
+		&retry.Policy{
+			Attempts:       cfg.Datasource.BoilerplateDatabase.Retry.Attempts,
+			InitialBackoff: cfg.Datasource.BoilerplateDatabase.Retry.InitialBackoff,
+			MaxBackoff:     cfg.Datasource.BoilerplateDatabase.Retry.MaxBackoff,
+			Deadline:       cfg.Datasource.BoilerplateDatabase.Retry.Deadline,
+		},
+		func(ctx context.Context) error {
+			var err error
+			db, err = fn(ctx, driverName, dataSourceName)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func connectToBoilerplateDatabase(cfg *configs.Config, fn sqlConnector) (*BoilerplateDatabase, error) {
+	var (
+		connection *BoilerplateDatabase
+		err        error
+	)
+
+	connection = &BoilerplateDatabase{
 		MasterMaxQueryDurationWarning: cfg.Datasource.BoilerplateDatabase.Master.MaximumQueryDurationWarning,
-		Slave: otelsqlx.MustConnect(
-			cfg.Datasource.BoilerplateDatabase.Slave.DriverName,
-			cfg.Datasource.BoilerplateDatabase.Slave.DataSourceName,
-		),
-		SlaveMaxQueryDurationWarning: cfg.Datasource.BoilerplateDatabase.Slave.MaximumQueryDurationWarning,
+		SlaveMaxQueryDurationWarning:  cfg.Datasource.BoilerplateDatabase.Slave.MaximumQueryDurationWarning,
+	}
+
+	connection.Master, err = connectWithRetry(
+		cfg,
+		"boilerplate_database_master",
+		cfg.Datasource.BoilerplateDatabase.Master.DriverName,
+		cfg.Datasource.BoilerplateDatabase.Master.DataSourceName,
+		fn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	connection.Slave, err = connectWithRetry(
+		cfg,
+		"boilerplate_database_slave",
+		cfg.Datasource.BoilerplateDatabase.Slave.DriverName,
+		cfg.Datasource.BoilerplateDatabase.Slave.DataSourceName,
+		fn,
+	)
+	if err != nil {
+		connection.Master.Close()
+		return nil, err
 	}
 
 	connection.Master.SetMaxOpenConns(cfg.Datasource.BoilerplateDatabase.Master.MaximumOpenConnections)
@@ -41,7 +97,31 @@ func Connect(cfg *configs.Config) *BoilerplateDatabase {
 	connection.Slave.SetConnMaxIdleTime(cfg.Datasource.BoilerplateDatabase.Slave.ConnectionMaximumIdleTime)
 	connection.Slave.SetConnMaxLifetime(cfg.Datasource.BoilerplateDatabase.Slave.ConnectionMaximumLifeTime)
 
-	return connection
+	err = metrics.RegisterDBStats("boilerplate_database_master", connection.Master.DB)
+	if err != nil {
+		connection.Disconnect()
+		return nil, err
+	}
+
+	err = metrics.RegisterDBStats("boilerplate_database_slave", connection.Slave.DB)
+	if err != nil {
+		connection.Disconnect()
+		return nil, err
+	}
+
+	return connection, nil
+}
+
+func Connect(cfg *configs.Config) (*BoilerplateDatabase, error) {
+	return connectToBoilerplateDatabase(cfg, defaultSQLConnector)
+}
+
+func (db *BoilerplateDatabase) PingMaster(ctx context.Context) error {
+	return db.Master.PingContext(ctx)
+}
+
+func (db *BoilerplateDatabase) PingSlave(ctx context.Context) error {
+	return db.Slave.PingContext(ctx)
 }
 
 func (db *BoilerplateDatabase) Disconnect() error {
@@ -0,0 +1,26 @@
+//go:build wireinject
+// +build wireinject
+
+package datasources
+
+import (
+	"go-boilerplate/configs"
+	"go-boilerplate/datasources/boilerplate_database"
+	"go-boilerplate/datasources/event_producer"
+	"go-boilerplate/datasources/in_memory_database"
+
+	"github.com/google/wire"
+)
+
+func BuildDatasources(cfg *configs.Config) (*Datasources, error) {
+	wire.Build(
+		boilerplate_database.Connect,
+		in_memory_database.Connect,
+		event_producer.Connect,
+		NewHealthChecker,
+		NewFeatureFlagFileStore,
+		wire.Struct(new(Datasources), "*"),
+	)
+
+	return nil, nil
+}
@@ -1,10 +1,14 @@
 package datasources
 
+//go:generate go run github.com/google/wire/cmd/wire
+
 import (
 	"context"
 	"go-boilerplate/datasources/boilerplate_database"
 	"go-boilerplate/datasources/event_producer"
 	"go-boilerplate/datasources/in_memory_database"
+	"go-boilerplate/pkg/feature_flag"
+	"go-boilerplate/pkg/health"
 
 	"github.com/fikri240794/gotask"
 )
@@ -13,6 +17,8 @@ type Datasources struct {
 	BoilerplateDatabase *boilerplate_database.BoilerplateDatabase
 	InMemoryDatabase    *in_memory_database.InMemoryDatabase
 	EventProducer       *event_producer.EventProducer
+	HealthChecker       *health.Checker
+	FeatureFlagStore    *feature_flag.FileStore
 }
 
 func (ds *Datasources) Disconnect() error {
@@ -25,6 +25,7 @@ type mockRedisClient struct {
 	keysError   error
 	incrError   error
 	expireError error
+	pingError   error
 }
 
 func (m *mockRedisClient) Close() error {
@@ -56,6 +57,10 @@ func (m *mockRedisClient) Set(ctx context.Context, key string, value interface{}
 	return cmd
 }
 
+func (m *mockRedisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	return redis.NewBoolResult(true, nil)
+}
+
 func (m *mockRedisClient) Keys(ctx context.Context, pattern string) *redis.StringSliceCmd {
 	cmd := redis.NewStringSliceCmd(ctx)
 	if m.keysError != nil {
@@ -64,6 +69,10 @@ func (m *mockRedisClient) Keys(ctx context.Context, pattern string) *redis.Strin
 	return cmd
 }
 
+func (m *mockRedisClient) Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return redis.NewScanCmdResult(nil, 0, m.keysError)
+}
+
 func (m *mockRedisClient) Incr(ctx context.Context, key string) *redis.IntCmd {
 	cmd := redis.NewIntCmd(ctx)
 	if m.incrError != nil {
@@ -80,6 +89,18 @@ func (m *mockRedisClient) Expire(ctx context.Context, key string, expiration tim
 	return cmd
 }
 
+func (m *mockRedisClient) PTTL(ctx context.Context, key string) *redis.DurationCmd {
+	return redis.NewDurationCmd(ctx, time.Millisecond)
+}
+
+func (m *mockRedisClient) Ping(ctx context.Context) *redis.StatusCmd {
+	cmd := redis.NewStatusCmd(ctx)
+	if m.pingError != nil {
+		cmd.SetErr(m.pingError)
+	}
+	return cmd
+}
+
 type mockNSQProducer struct {
 	pingError            error
 	stopped              bool
@@ -1,7 +1,9 @@
 package event_producer
 
 import (
+	"context"
 	"go-boilerplate/configs"
+	"go-boilerplate/pkg/retry"
 	"time"
 
 	"github.com/nsqio/go-nsq"
@@ -28,7 +30,7 @@ var defaultNSQProducer nsqProducer = func(addr string, config *nsq.Config) (INSQ
 	return nsq.NewProducer(addr, config)
 }
 
-func connectToNSQProducer(cfg *configs.Config, fn nsqProducer) *EventProducer {
+func connectToNSQProducer(cfg *configs.Config, fn nsqProducer) (*EventProducer, error) {
 	var (
 		nsqProducer INSQProducer
 		err         error
@@ -36,23 +38,41 @@ func connectToNSQProducer(cfg *configs.Config, fn nsqProducer) *EventProducer {
 
 	nsqProducer, err = fn(cfg.Datasource.EventProducer.DataSourceName, nsq.NewConfig())
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	err = nsqProducer.Ping()
+	err = retry.Do(
+		context.Background(),
+		"event_producer",
+		&retry.Policy{
+			Attempts:       cfg.Datasource.EventProducer.Retry.Attempts,
+			InitialBackoff: cfg.Datasource.EventProducer.Retry.InitialBackoff,
+			MaxBackoff:     cfg.Datasource.EventProducer.Retry.MaxBackoff,
+			Deadline:       cfg.Datasource.EventProducer.Retry.Deadline,
+		},
+		func(ctx context.Context) error {
+			return nsqProducer.Ping()
+		},
+	)
 	if err != nil {
-		panic(err)
+		nsqProducer.Stop()
+		return nil, err
 	}
 
 	return &EventProducer{
 		NSQProducer: nsqProducer,
-	}
+	}, nil
 }
 
-func Connect(cfg *configs.Config) *EventProducer {
+func Connect(cfg *configs.Config) (*EventProducer, error) {
 	return connectToNSQProducer(cfg, defaultNSQProducer)
 }
 
+// Ping ignores ctx, the NSQ client has no context aware ping.
+func (p *EventProducer) Ping(ctx context.Context) error {
+	return p.NSQProducer.Ping()
+}
+
 func (p *EventProducer) Disconnect() error {
 	p.NSQProducer.Stop()
 	return nil
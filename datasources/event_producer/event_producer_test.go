@@ -5,6 +5,7 @@ import (
 	"go-boilerplate/configs"
 	"go-boilerplate/datasources/event_producer/mocks"
 	"testing"
+	"time"
 
 	"github.com/nsqio/go-nsq"
 	"github.com/stretchr/testify/assert"
@@ -15,11 +16,12 @@ func Test_connectToNSQProducer(t *testing.T) {
 		name        string
 		setupConfig func() *configs.Config
 		fn          nsqProducer
-		expectPanic bool
+		attempts    int
+		expectError bool
 		validate    func(t *testing.T, producer *EventProducer)
 	}{
 		{
-			name: "connect with fn returns error should panic",
+			name: "connect with fn returns error should return error",
 			setupConfig: func() *configs.Config {
 				cfg := &configs.Config{}
 				cfg.Datasource.EventProducer.DataSourceName = "test-host:4150"
@@ -28,10 +30,11 @@ func Test_connectToNSQProducer(t *testing.T) {
 			fn: func(addr string, config *nsq.Config) (INSQProducer, error) {
 				return nil, errors.New("producer error")
 			},
-			expectPanic: true,
+			attempts:    1,
+			expectError: true,
 		},
 		{
-			name: "connect with ping error should panic",
+			name: "connect with ping error should stop the producer and return error",
 			setupConfig: func() *configs.Config {
 				cfg := &configs.Config{}
 				cfg.Datasource.EventProducer.DataSourceName = "test-host:4150"
@@ -39,10 +42,29 @@ func Test_connectToNSQProducer(t *testing.T) {
 			},
 			fn: func(addr string, config *nsq.Config) (INSQProducer, error) {
 				mockProducer := mocks.NewNSQProducerMock(t)
-				mockProducer.On("Ping").Return(errors.New("ping failed"))
+				mockProducer.On("Ping").Return(errors.New("ping failed")).Twice()
+				mockProducer.On("Stop").Return().Once()
 				return mockProducer, nil
 			},
-			expectPanic: true,
+			attempts:    2,
+			expectError: true,
+		},
+		{
+			name: "connect with ping failing once should be retried",
+			setupConfig: func() *configs.Config {
+				cfg := &configs.Config{}
+				cfg.Datasource.EventProducer.DataSourceName = "test-host:4150"
+				return cfg
+			},
+			fn: func(addr string, config *nsq.Config) (INSQProducer, error) {
+				mockProducer := mocks.NewNSQProducerMock(t)
+				mockProducer.On("Ping").Return(errors.New("ping failed")).Once()
+				mockProducer.On("Ping").Return(nil).Once()
+				mockProducer.On("Stop").Return()
+				return mockProducer, nil
+			},
+			attempts:    2,
+			expectError: false,
 		},
 		{
 			name: "connect successfully with mock producer",
@@ -57,7 +79,8 @@ func Test_connectToNSQProducer(t *testing.T) {
 				mockProducer.On("Stop").Return()
 				return mockProducer, nil
 			},
-			expectPanic: false,
+			attempts:    1,
+			expectError: false,
 			validate: func(t *testing.T, producer *EventProducer) {
 				assert.NotNil(t, producer)
 				assert.NotNil(t, producer.NSQProducer)
@@ -69,19 +92,22 @@ func Test_connectToNSQProducer(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := tt.setupConfig()
+			cfg.Datasource.EventProducer.Retry.Attempts = tt.attempts
+			cfg.Datasource.EventProducer.Retry.InitialBackoff = time.Millisecond
 
-			if tt.expectPanic {
-				assert.Panics(t, func() {
-					connectToNSQProducer(cfg, tt.fn)
-				})
+			producer, err := connectToNSQProducer(cfg, tt.fn)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, producer)
 			} else {
-				producer := connectToNSQProducer(cfg, tt.fn)
+				assert.NoError(t, err)
 
 				if tt.validate != nil {
 					tt.validate(t, producer)
 				}
 
-				err := producer.Disconnect()
+				err = producer.Disconnect()
 				assert.NoError(t, err)
 			}
 		})
@@ -92,34 +118,34 @@ func TestConnect(t *testing.T) {
 	tests := []struct {
 		name        string
 		setupConfig func() *configs.Config
-		expectPanic bool
+		expectError bool
 	}{
 		{
-			name: "connect with empty data source name should panic",
+			name: "connect with empty data source name should return error",
 			setupConfig: func() *configs.Config {
 				cfg := &configs.Config{}
 				cfg.Datasource.EventProducer.DataSourceName = ""
 				return cfg
 			},
-			expectPanic: true,
+			expectError: true,
 		},
 		{
-			name: "connect with invalid data source name should panic",
+			name: "connect with invalid data source name should return error",
 			setupConfig: func() *configs.Config {
 				cfg := &configs.Config{}
 				cfg.Datasource.EventProducer.DataSourceName = "invalid-host:0"
 				return cfg
 			},
-			expectPanic: true,
+			expectError: true,
 		},
 		{
-			name: "connect with unreachable host should panic on ping",
+			name: "connect with unreachable host should return ping error",
 			setupConfig: func() *configs.Config {
 				cfg := &configs.Config{}
 				cfg.Datasource.EventProducer.DataSourceName = "localhost:99999"
 				return cfg
 			},
-			expectPanic: true,
+			expectError: true,
 		},
 	}
 
@@ -127,16 +153,17 @@ func TestConnect(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := tt.setupConfig()
 
-			if tt.expectPanic {
-				assert.Panics(t, func() {
-					Connect(cfg)
-				})
+			producer, err := Connect(cfg)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, producer)
 			} else {
-				producer := Connect(cfg)
+				assert.NoError(t, err)
 				assert.NotNil(t, producer)
 				assert.NotNil(t, producer.NSQProducer)
 
-				err := producer.Disconnect()
+				err = producer.Disconnect()
 				assert.NoError(t, err)
 			}
 		})
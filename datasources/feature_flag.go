@@ -0,0 +1,28 @@
+package datasources
+
+import (
+	"fmt"
+	"go-boilerplate/configs"
+	"go-boilerplate/pkg/feature_flag"
+)
+
+// NewFeatureFlagFileStore is kept in Datasources like the other stores, so the
+// transports sharing them change and evaluate the same flags. It is nil unless
+// the file backend is configured.
+func NewFeatureFlagFileStore(cfg *configs.Config) (*feature_flag.FileStore, error) {
+	var (
+		store *feature_flag.FileStore
+		err   error
+	)
+
+	if cfg.FeatureFlag.Backend != feature_flag.BackendFile {
+		return nil, nil
+	}
+
+	store, err = feature_flag.NewFileStore(cfg.FeatureFlag.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feature flags %s: %w", cfg.FeatureFlag.FilePath, err)
+	}
+
+	return store, nil
+}
@@ -0,0 +1,71 @@
+package datasources
+
+import (
+	"go-boilerplate/configs"
+	"go-boilerplate/pkg/feature_flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFeatureFlagFileStore(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupConfig   func(t *testing.T) *configs.Config
+		expectStore   bool
+		errorContains string
+	}{
+		{
+			name: "no backend has no file store",
+			setupConfig: func(t *testing.T) *configs.Config {
+				return &configs.Config{}
+			},
+		},
+		{
+			name: "redis backend has no file store",
+			setupConfig: func(t *testing.T) *configs.Config {
+				cfg := &configs.Config{}
+				cfg.FeatureFlag.Backend = feature_flag.BackendRedis
+				return cfg
+			},
+		},
+		{
+			name: "file backend loads the file store",
+			setupConfig: func(t *testing.T) *configs.Config {
+				cfg := &configs.Config{}
+				cfg.FeatureFlag.Backend = feature_flag.BackendFile
+				cfg.FeatureFlag.FilePath = filepath.Join(t.TempDir(), "feature_flags.json")
+				return cfg
+			},
+			expectStore: true,
+		},
+		{
+			name: "invalid file returns error",
+			setupConfig: func(t *testing.T) *configs.Config {
+				cfg := &configs.Config{}
+				cfg.FeatureFlag.Backend = feature_flag.BackendFile
+				cfg.FeatureFlag.FilePath = filepath.Join(t.TempDir(), "feature_flags.json")
+				assert.NoError(t, os.WriteFile(cfg.FeatureFlag.FilePath, []byte(`{`), 0644))
+				return cfg
+			},
+			errorContains: "failed to load feature flags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := NewFeatureFlagFileStore(tt.setupConfig(t))
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.Nil(t, store)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectStore, store != nil)
+		})
+	}
+}
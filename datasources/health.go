@@ -0,0 +1,30 @@
+package datasources
+
+import (
+	"go-boilerplate/configs"
+	"go-boilerplate/datasources/boilerplate_database"
+	"go-boilerplate/datasources/event_producer"
+	"go-boilerplate/datasources/in_memory_database"
+	"go-boilerplate/pkg/health"
+)
+
+// NewHealthChecker is kept in Datasources, so the transports sharing them
+// register their checks on the same checker and every probe reports them all.
+func NewHealthChecker(
+	cfg *configs.Config,
+	boilerplateDatabase *boilerplate_database.BoilerplateDatabase,
+	inMemoryDatabase *in_memory_database.InMemoryDatabase,
+	eventProducer *event_producer.EventProducer,
+) *health.Checker {
+	var checker *health.Checker = health.NewChecker(&health.Config{
+		Timeout:       cfg.Server.Health.Timeout,
+		CacheDuration: cfg.Server.Health.CacheDuration,
+	})
+
+	checker.Register("boilerplate_database_master", boilerplateDatabase.PingMaster)
+	checker.Register("boilerplate_database_slave", boilerplateDatabase.PingSlave)
+	checker.Register("in_memory_database", inMemoryDatabase.Ping)
+	checker.Register("event_producer", eventProducer.Ping)
+
+	return checker
+}
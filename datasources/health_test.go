@@ -0,0 +1,95 @@
+package datasources
+
+import (
+	"context"
+	"errors"
+	"go-boilerplate/configs"
+	"go-boilerplate/datasources/boilerplate_database"
+	"go-boilerplate/datasources/event_producer"
+	"go-boilerplate/datasources/in_memory_database"
+	"go-boilerplate/pkg/health"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHealthChecker(t *testing.T) {
+	tests := []struct {
+		name           string
+		redisClient    *mockRedisClient
+		nsqProducer    *mockNSQProducer
+		expectedStatus string
+		validate       func(t *testing.T, report *health.Report)
+	}{
+		{
+			name:           "all datasources reachable should be up",
+			redisClient:    &mockRedisClient{},
+			nsqProducer:    &mockNSQProducer{},
+			expectedStatus: health.StatusUp,
+			validate: func(t *testing.T, report *health.Report) {
+				assert.Len(t, report.Checks, 4)
+			},
+		},
+		{
+			name:           "redis ping error should be down",
+			redisClient:    &mockRedisClient{pingError: errors.New("redis down")},
+			nsqProducer:    &mockNSQProducer{},
+			expectedStatus: health.StatusDown,
+			validate: func(t *testing.T, report *health.Report) {
+				assert.Equal(t, "redis down", report.Checks["in_memory_database"].Error)
+				assert.Equal(t, health.StatusUp, report.Checks["boilerplate_database_master"].Status)
+			},
+		},
+		{
+			name:           "nsq ping error should be down",
+			redisClient:    &mockRedisClient{},
+			nsqProducer:    &mockNSQProducer{pingError: errors.New("nsq down")},
+			expectedStatus: health.StatusDown,
+			validate: func(t *testing.T, report *health.Report) {
+				assert.Equal(t, "nsq down", report.Checks["event_producer"].Error)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			masterDB, masterMock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			assert.NoError(t, err)
+			defer masterDB.Close()
+			slaveDB, slaveMock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			assert.NoError(t, err)
+			defer slaveDB.Close()
+			masterMock.ExpectPing()
+			slaveMock.ExpectPing()
+
+			cfg := &configs.Config{}
+			cfg.Server.Health.Timeout = time.Second
+
+			checker := NewHealthChecker(
+				cfg,
+				&boilerplate_database.BoilerplateDatabase{
+					Master: sqlx.NewDb(masterDB, "sqlmock"),
+					Slave:  sqlx.NewDb(slaveDB, "sqlmock"),
+				},
+				&in_memory_database.InMemoryDatabase{
+					RedisClient: tt.redisClient,
+				},
+				&event_producer.EventProducer{
+					NSQProducer: tt.nsqProducer,
+				},
+			)
+
+			report := checker.Ready(context.Background())
+
+			assert.Equal(t, tt.expectedStatus, report.Status)
+			assert.NoError(t, masterMock.ExpectationsWereMet())
+			assert.NoError(t, slaveMock.ExpectationsWereMet())
+			if tt.validate != nil {
+				tt.validate(t, report)
+			}
+		})
+	}
+}
@@ -2,24 +2,34 @@ package in_memory_database
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"go-boilerplate/configs"
+	"go-boilerplate/pkg/retry"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	ModeStandalone string = "standalone"
+	ModeSentinel   string = "sentinel"
+	ModeCluster    string = "cluster"
+)
+
 //mockery:generate: true
 //mockery:structname: RedisTracerMock
 //mockery:filename: redis_tracer_mock.go
 //mockery:output: datasources/in_memory_database/mocks/
 type IRedisTracer interface {
-	InstrumentTracing(client *redis.Client) error
+	InstrumentTracing(client redis.UniversalClient) error
 }
 
-type redisTracer func(client *redis.Client) error
+type redisTracer func(client redis.UniversalClient) error
 
-var defaultRedisTracer redisTracer = func(client *redis.Client) error {
+var defaultRedisTracer redisTracer = func(client redis.UniversalClient) error {
 	return redisotel.InstrumentTracing(client)
 }
 
@@ -32,63 +42,248 @@ type IRedisClient interface {
 	Del(ctx context.Context, keys ...string) *redis.IntCmd
 	Get(ctx context.Context, key string) *redis.StringCmd
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd
 	Keys(ctx context.Context, pattern string) *redis.StringSliceCmd
+	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
 	Incr(ctx context.Context, key string) *redis.IntCmd
 	Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd
+	PTTL(ctx context.Context, key string) *redis.DurationCmd
+	Ping(ctx context.Context) *redis.StatusCmd
+}
+
+// IRedisPubSubClient is implemented by the clients that can broadcast to the
+// other replicas, callers type assert IRedisClient against it.
+//
+//mockery:generate: true
+//mockery:structname: RedisPubSubClientMock
+//mockery:filename: redis_pub_sub_client_mock.go
+//mockery:output: datasources/in_memory_database/mocks/
+type IRedisPubSubClient interface {
+	Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
+}
+
+// IRedisScriptClient is implemented by the clients that run Lua scripts,
+// callers type assert IRedisClient against it.
+//
+//mockery:generate: true
+//mockery:structname: RedisScriptClientMock
+//mockery:filename: redis_script_client_mock.go
+//mockery:output: datasources/in_memory_database/mocks/
+type IRedisScriptClient interface {
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd
+}
+
+// IRedisMemoryClient is implemented by the clients that report the memory a
+// key takes, callers type assert IRedisClient against it.
+//
+//mockery:generate: true
+//mockery:structname: RedisMemoryClientMock
+//mockery:filename: redis_memory_client_mock.go
+//mockery:output: datasources/in_memory_database/mocks/
+type IRedisMemoryClient interface {
+	MemoryUsage(ctx context.Context, key string, samples ...int) *redis.IntCmd
+}
+
+// IRedisShardedClient is implemented by the clients that spread the keys over
+// shards, commands such as KEYS and SCAN only see the keys of one shard. It is
+// not mocked, a mock would import this package from its own tests.
+type IRedisShardedClient interface {
+	ForEachShard(ctx context.Context, fn func(ctx context.Context, shard IRedisClient) error) error
+}
+
+type clusterClient struct {
+	*redis.ClusterClient
+}
+
+// ForEachShard calls fn concurrently for every master.
+func (c *clusterClient) ForEachShard(ctx context.Context, fn func(ctx context.Context, shard IRedisClient) error) error {
+	return c.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+		return fn(ctx, client)
+	})
 }
 
-type redisClient func(dsn string) (IRedisClient, error)
+type redisClient func(mode string, dsn string) (IRedisClient, error)
 
-func connectToRedisTracer(dsn string, fn redisTracer) (IRedisClient, error) {
+// newUniversalClient reads the data source name the way the mode expects it,
+// see redis.ParseURL, redis.ParseFailoverURL and redis.ParseClusterURL.
+func newUniversalClient(mode string, dsn string) (redis.UniversalClient, error) {
 	var (
-		redisOpts   *redis.Options
-		redisClient *redis.Client
-		err         error
+		redisOpts    *redis.Options
+		failoverOpts *redis.FailoverOptions
+		clusterOpts  *redis.ClusterOptions
+		err          error
 	)
 
-	redisOpts, err = redis.ParseURL(dsn)
+	switch mode {
+	case ModeSentinel:
+		failoverOpts, err = redis.ParseFailoverURL(dsn)
+		if err != nil {
+			return nil, err
+		}
+
+		if failoverOpts.MasterName == "" {
+			return nil, errors.New("redis: sentinel data source name requires master_name")
+		}
+
+		return redis.NewFailoverClient(failoverOpts), nil
+	case ModeCluster:
+		clusterOpts, err = redis.ParseClusterURL(dsn)
+		if err != nil {
+			return nil, err
+		}
+
+		return redis.NewClusterClient(clusterOpts), nil
+	case "", ModeStandalone:
+		redisOpts, err = redis.ParseURL(dsn)
+		if err != nil {
+			return nil, err
+		}
+
+		return redis.NewClient(redisOpts), nil
+	default:
+		return nil, fmt.Errorf("redis: unknown mode %q", mode)
+	}
+}
+
+func connectToRedisTracer(mode string, dsn string, fn redisTracer) (IRedisClient, error) {
+	var (
+		client  redis.UniversalClient
+		cluster *redis.ClusterClient
+		ok      bool
+		err     error
+	)
+
+	client, err = newUniversalClient(mode, dsn)
 	if err != nil {
 		return nil, err
 	}
 
-	redisClient = redis.NewClient(redisOpts)
-
-	err = fn(redisClient)
+	err = fn(client)
 	if err != nil {
+		client.Close()
 		return nil, err
 	}
 
-	return redisClient, nil
+	cluster, ok = client.(*redis.ClusterClient)
+	if ok {
+		return &clusterClient{ClusterClient: cluster}, nil
+	}
+
+	return client, nil
 }
 
-var defaultRedisClient redisClient = func(dsn string) (IRedisClient, error) {
-	return connectToRedisTracer(dsn, defaultRedisTracer)
+var defaultRedisClient redisClient = func(mode string, dsn string) (IRedisClient, error) {
+	return connectToRedisTracer(mode, dsn, defaultRedisTracer)
 }
 
 type InMemoryDatabase struct {
 	RedisClient IRedisClient
+
+	mutex   sync.Mutex
+	closers []func() error
 }
 
-func connectToRedis(cfg *configs.Config, fn redisClient) *InMemoryDatabase {
+func connectToRedis(cfg *configs.Config, fn redisClient) (*InMemoryDatabase, error) {
 	var (
 		redisClient IRedisClient
 		err         error
 	)
 
-	redisClient, err = fn(cfg.Datasource.InMemoryDatabase.DataSourceName)
+	redisClient, err = fn(cfg.Datasource.InMemoryDatabase.Mode, cfg.Datasource.InMemoryDatabase.DataSourceName)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	// the client connects lazily, so the ping is what is retried
+	err = retry.Do(
+		context.Background(),
+		"in_memory_database",
+		&retry.Policy{
+			Attempts:       cfg.Datasource.InMemoryDatabase.Retry.Attempts,
+			InitialBackoff: cfg.Datasource.InMemoryDatabase.Retry.InitialBackoff,
+			MaxBackoff:     cfg.Datasource.InMemoryDatabase.Retry.MaxBackoff,
+			Deadline:       cfg.Datasource.InMemoryDatabase.Retry.Deadline,
+		},
+		func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		},
+	)
+	if err != nil {
+		redisClient.Close()
+		return nil, err
 	}
 
 	return &InMemoryDatabase{
 		RedisClient: redisClient,
-	}
+	}, nil
 }
 
-func Connect(cfg *configs.Config) *InMemoryDatabase {
+func Connect(cfg *configs.Config) (*InMemoryDatabase, error) {
+	if cfg.Datasource.InMemoryDatabase.Driver == DriverMemory {
+		return &InMemoryDatabase{
+			RedisClient: NewMemoryClient(
+				cfg.Datasource.InMemoryDatabase.Memory.MaxBytes,
+				cfg.Datasource.InMemoryDatabase.Memory.CleanupInterval,
+			),
+		}, nil
+	}
+
 	return connectToRedis(cfg, defaultRedisClient)
 }
 
+func (r *InMemoryDatabase) IsSharded() bool {
+	var ok bool
+
+	_, ok = r.RedisClient.(IRedisShardedClient)
+
+	return ok
+}
+
+// ForEachShard calls fn once with the client itself, or for every shard of a
+// sharded client, possibly concurrently.
+func (r *InMemoryDatabase) ForEachShard(ctx context.Context, fn func(ctx context.Context, shard IRedisClient) error) error {
+	var (
+		sharded IRedisShardedClient
+		ok      bool
+	)
+
+	sharded, ok = r.RedisClient.(IRedisShardedClient)
+	if !ok {
+		return fn(ctx, r.RedisClient)
+	}
+
+	return sharded.ForEachShard(ctx, fn)
+}
+
+func (r *InMemoryDatabase) Ping(ctx context.Context) error {
+	return r.RedisClient.Ping(ctx).Err()
+}
+
+// OnDisconnect registers fn to be called by Disconnect before the client is
+// closed, such as closing a subscription made on it.
+func (r *InMemoryDatabase) OnDisconnect(fn func() error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.closers = append(r.closers, fn)
+}
+
 func (r *InMemoryDatabase) Disconnect() error {
-	return r.RedisClient.Close()
+	var (
+		closers []func() error
+		errs    []error
+	)
+
+	r.mutex.Lock()
+	closers, r.closers = r.closers, nil
+	r.mutex.Unlock()
+
+	for i := range closers {
+		errs = append(errs, closers[i]())
+	}
+
+	errs = append(errs, r.RedisClient.Close())
+
+	return errors.Join(errs...)
 }
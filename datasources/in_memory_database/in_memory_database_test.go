@@ -1,13 +1,16 @@
 package in_memory_database
 
 import (
+	"context"
 	"errors"
 	"go-boilerplate/configs"
 	"go-boilerplate/datasources/in_memory_database/mocks"
 	"testing"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func Test_defaultRedisTracer(t *testing.T) {
@@ -42,9 +45,98 @@ func Test_defaultRedisTracer(t *testing.T) {
 	}
 }
 
+func Test_newUniversalClient(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          string
+		dsn           string
+		expectError   bool
+		errorContains string
+		validate      func(t *testing.T, client redis.UniversalClient)
+	}{
+		{
+			name: "empty mode creates a single node client",
+			dsn:  "redis://localhost:6379/0",
+			validate: func(t *testing.T, client redis.UniversalClient) {
+				assert.Equal(t, "localhost:6379", client.(*redis.Client).Options().Addr)
+			},
+		},
+		{
+			name: "standalone mode creates a single node client",
+			mode: ModeStandalone,
+			dsn:  "redis://localhost:6379/2",
+			validate: func(t *testing.T, client redis.UniversalClient) {
+				assert.Equal(t, 2, client.(*redis.Client).Options().DB)
+			},
+		},
+		{
+			name: "sentinel mode creates a failover client",
+			mode: ModeSentinel,
+			dsn:  "redis://localhost:26379/0?master_name=mymaster&addr=localhost:26380",
+			validate: func(t *testing.T, client redis.UniversalClient) {
+				assert.IsType(t, &redis.Client{}, client)
+			},
+		},
+		{
+			name:          "sentinel mode without master name should return error",
+			mode:          ModeSentinel,
+			dsn:           "redis://localhost:26379/0",
+			expectError:   true,
+			errorContains: "master_name",
+		},
+		{
+			name: "cluster mode creates a cluster client",
+			mode: ModeCluster,
+			dsn:  "redis://localhost:7000?addr=localhost:7001&addr=localhost:7002",
+			validate: func(t *testing.T, client redis.UniversalClient) {
+				assert.Equal(t, []string{"localhost:7000", "localhost:7001", "localhost:7002"}, client.(*redis.ClusterClient).Options().Addrs)
+			},
+		},
+		{
+			name:          "cluster mode with invalid URL should return error",
+			mode:          ModeCluster,
+			dsn:           "invalid://localhost:7000",
+			expectError:   true,
+			errorContains: "invalid URL scheme",
+		},
+		{
+			name:          "standalone mode with invalid URL should return error",
+			dsn:           "invalid-dsn",
+			expectError:   true,
+			errorContains: "invalid URL scheme",
+		},
+		{
+			name:          "unknown mode should return error",
+			mode:          "ring",
+			dsn:           "redis://localhost:6379/0",
+			expectError:   true,
+			errorContains: "unknown mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := newUniversalClient(tt.mode, tt.dsn)
+
+			if tt.expectError {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.Nil(t, client)
+				return
+			}
+
+			assert.NoError(t, err)
+			if tt.validate != nil {
+				tt.validate(t, client)
+			}
+			client.Close()
+		})
+	}
+}
+
 func Test_connectToRedisTracer(t *testing.T) {
 	tests := []struct {
 		name        string
+		mode        string
 		dsn         string
 		fn          redisTracer
 		expectError bool
@@ -53,7 +145,7 @@ func Test_connectToRedisTracer(t *testing.T) {
 		{
 			name: "parse URL error should return error",
 			dsn:  "invalid-dsn",
-			fn: func(client *redis.Client) error {
+			fn: func(client redis.UniversalClient) error {
 				return nil
 			},
 			expectError: true,
@@ -61,7 +153,7 @@ func Test_connectToRedisTracer(t *testing.T) {
 		{
 			name: "tracer returns error should return error",
 			dsn:  "redis://localhost:6379/0",
-			fn: func(client *redis.Client) error {
+			fn: func(client redis.UniversalClient) error {
 				return errors.New("tracer error")
 			},
 			expectError: true,
@@ -69,19 +161,36 @@ func Test_connectToRedisTracer(t *testing.T) {
 		{
 			name: "connect successfully with tracer",
 			dsn:  "redis://localhost:6379/0",
-			fn: func(client *redis.Client) error {
+			fn: func(client redis.UniversalClient) error {
 				return nil
 			},
 			expectError: false,
 			validate: func(t *testing.T, client IRedisClient) {
 				assert.NotNil(t, client)
+				assert.Implements(t, (*IRedisPubSubClient)(nil), client)
+				assert.Implements(t, (*IRedisScriptClient)(nil), client)
+				assert.NotImplements(t, (*IRedisShardedClient)(nil), client)
+			},
+		},
+		{
+			name: "connect to cluster should return a sharded client",
+			mode: ModeCluster,
+			dsn:  "redis://localhost:7000?addr=localhost:7001",
+			fn: func(client redis.UniversalClient) error {
+				return nil
+			},
+			expectError: false,
+			validate: func(t *testing.T, client IRedisClient) {
+				assert.Implements(t, (*IRedisPubSubClient)(nil), client)
+				assert.Implements(t, (*IRedisScriptClient)(nil), client)
+				assert.Implements(t, (*IRedisShardedClient)(nil), client)
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client, err := connectToRedisTracer(tt.dsn, tt.fn)
+			client, err := connectToRedisTracer(tt.mode, tt.dsn, tt.fn)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -121,7 +230,7 @@ func Test_defaultRedisClient(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client, err := defaultRedisClient(tt.dsn)
+			client, err := defaultRedisClient(ModeStandalone, tt.dsn)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -136,49 +245,41 @@ func Test_defaultRedisClient(t *testing.T) {
 
 func Test_connectToRedis(t *testing.T) {
 	tests := []struct {
-		name        string
-		setupConfig func() *configs.Config
-		fn          func(t *testing.T, dsn string) (IRedisClient, error)
-		expectPanic bool
-		validate    func(t *testing.T, db *InMemoryDatabase)
+		name          string
+		attempts      int
+		fn            func(t *testing.T, mode string, dsn string) (IRedisClient, error)
+		expectError   bool
+		errorContains string
+		validate      func(t *testing.T, db *InMemoryDatabase)
 	}{
 		{
-			name: "connect with fn returns error should panic",
-			setupConfig: func() *configs.Config {
-				cfg := &configs.Config{}
-				cfg.Datasource.InMemoryDatabase.DataSourceName = "redis://localhost:6379"
-				return cfg
-			},
-			fn: func(t *testing.T, dsn string) (IRedisClient, error) {
+			name:     "connect with fn returns error should return error",
+			attempts: 1,
+			fn: func(t *testing.T, mode string, dsn string) (IRedisClient, error) {
 				return nil, errors.New("factory error")
 			},
-			expectPanic: true,
+			expectError:   true,
+			errorContains: "factory error",
 		},
 		{
-			name: "connect with fn returns parse error should panic",
-			setupConfig: func() *configs.Config {
-				cfg := &configs.Config{}
-				cfg.Datasource.InMemoryDatabase.DataSourceName = "redis://localhost:6379"
-				return cfg
-			},
-			fn: func(t *testing.T, dsn string) (IRedisClient, error) {
-				return nil, errors.New("parse URL error")
+			name:     "connect with instrumentation error should return error",
+			attempts: 1,
+			fn: func(t *testing.T, mode string, dsn string) (IRedisClient, error) {
+				return nil, errors.New("instrumentation error")
 			},
-			expectPanic: true,
+			expectError:   true,
+			errorContains: "instrumentation error",
 		},
 		{
-			name: "connect successfully with mock client",
-			setupConfig: func() *configs.Config {
-				cfg := &configs.Config{}
-				cfg.Datasource.InMemoryDatabase.DataSourceName = "redis://localhost:6379"
-				return cfg
-			},
-			fn: func(t *testing.T, dsn string) (IRedisClient, error) {
+			name:     "connect successfully with mock client",
+			attempts: 1,
+			fn: func(t *testing.T, mode string, dsn string) (IRedisClient, error) {
 				mockClient := mocks.NewRedisClientMock(t)
+				mockClient.On("Ping", mock.Anything).Return(redis.NewStatusResult("PONG", nil))
 				mockClient.On("Close").Return(nil)
 				return mockClient, nil
 			},
-			expectPanic: false,
+			expectError: false,
 			validate: func(t *testing.T, db *InMemoryDatabase) {
 				assert.NotNil(t, db)
 				assert.NotNil(t, db.RedisClient)
@@ -186,39 +287,53 @@ func Test_connectToRedis(t *testing.T) {
 			},
 		},
 		{
-			name: "connect with instrumentation error should panic",
-			setupConfig: func() *configs.Config {
-				cfg := &configs.Config{}
-				cfg.Datasource.InMemoryDatabase.DataSourceName = "redis://localhost:6379"
-				return cfg
+			name:     "ping failing once should be retried",
+			attempts: 2,
+			fn: func(t *testing.T, mode string, dsn string) (IRedisClient, error) {
+				mockClient := mocks.NewRedisClientMock(t)
+				mockClient.On("Ping", mock.Anything).Return(redis.NewStatusResult("", errors.New("connection refused"))).Once()
+				mockClient.On("Ping", mock.Anything).Return(redis.NewStatusResult("PONG", nil)).Once()
+				mockClient.On("Close").Return(nil)
+				return mockClient, nil
 			},
-			fn: func(t *testing.T, dsn string) (IRedisClient, error) {
-				return nil, errors.New("instrumentation error")
+			expectError: false,
+		},
+		{
+			name:     "ping staying unavailable should close the client and return error",
+			attempts: 2,
+			fn: func(t *testing.T, mode string, dsn string) (IRedisClient, error) {
+				mockClient := mocks.NewRedisClientMock(t)
+				mockClient.On("Ping", mock.Anything).Return(redis.NewStatusResult("", errors.New("connection refused"))).Twice()
+				mockClient.On("Close").Return(nil).Once()
+				return mockClient, nil
 			},
-			expectPanic: true,
+			expectError:   true,
+			errorContains: "in_memory_database is unavailable after 2 attempt(s): connection refused",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cfg := tt.setupConfig()
+			cfg := &configs.Config{}
+			cfg.Datasource.InMemoryDatabase.DataSourceName = "redis://localhost:6379"
+			cfg.Datasource.InMemoryDatabase.Retry.Attempts = tt.attempts
+			cfg.Datasource.InMemoryDatabase.Retry.InitialBackoff = time.Millisecond
 
-			if tt.expectPanic {
-				assert.Panics(t, func() {
-					connectToRedis(cfg, func(dsn string) (IRedisClient, error) {
-						return tt.fn(t, dsn)
-					})
-				})
+			db, err := connectToRedis(cfg, func(mode string, dsn string) (IRedisClient, error) {
+				return tt.fn(t, mode, dsn)
+			})
+
+			if tt.expectError {
+				assert.EqualError(t, err, tt.errorContains)
+				assert.Nil(t, db)
 			} else {
-				db := connectToRedis(cfg, func(dsn string) (IRedisClient, error) {
-					return tt.fn(t, dsn)
-				})
+				assert.NoError(t, err)
 
 				if tt.validate != nil {
 					tt.validate(t, db)
 				}
 
-				err := db.Disconnect()
+				err = db.Disconnect()
 				assert.NoError(t, err)
 			}
 		})
@@ -229,43 +344,53 @@ func TestConnect(t *testing.T) {
 	tests := []struct {
 		name        string
 		setupConfig func() *configs.Config
-		expectPanic bool
+		expectError bool
 	}{
 		{
-			name: "connect with invalid DSN should panic",
+			name: "connect with invalid DSN should return error",
 			setupConfig: func() *configs.Config {
 				cfg := &configs.Config{}
 				cfg.Datasource.InMemoryDatabase.DataSourceName = "invalid-dsn-format"
 				return cfg
 			},
-			expectPanic: true,
+			expectError: true,
 		},
 		{
-			name: "connect with empty DSN should panic",
+			name: "connect with empty DSN should return error",
 			setupConfig: func() *configs.Config {
 				cfg := &configs.Config{}
 				cfg.Datasource.InMemoryDatabase.DataSourceName = ""
 				return cfg
 			},
-			expectPanic: true,
+			expectError: true,
 		},
 		{
-			name: "connect with malformed URL should panic",
+			name: "connect with malformed URL should return error",
 			setupConfig: func() *configs.Config {
 				cfg := &configs.Config{}
 				cfg.Datasource.InMemoryDatabase.DataSourceName = "://invalid"
 				return cfg
 			},
-			expectPanic: true,
+			expectError: true,
 		},
 		{
-			name: "connect with valid DSN should succeed and cover default factory",
+			name: "connect with memory driver should not need a server",
 			setupConfig: func() *configs.Config {
 				cfg := &configs.Config{}
-				cfg.Datasource.InMemoryDatabase.DataSourceName = "redis://localhost:6379/0"
+				cfg.Datasource.InMemoryDatabase.Driver = DriverMemory
+				cfg.Datasource.InMemoryDatabase.DataSourceName = "redis://localhost:1/0"
 				return cfg
 			},
-			expectPanic: false,
+			expectError: false,
+		},
+		{
+			name: "connect with unreachable server should return error",
+			setupConfig: func() *configs.Config {
+				cfg := &configs.Config{}
+				cfg.Datasource.InMemoryDatabase.DataSourceName = "redis://localhost:1/0"
+				return cfg
+			},
+			expectError: true,
 		},
 	}
 
@@ -273,17 +398,15 @@ func TestConnect(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := tt.setupConfig()
 
-			if tt.expectPanic {
-				assert.Panics(t, func() {
-					Connect(cfg)
-				})
-			} else {
-				db := Connect(cfg)
-				assert.NotNil(t, db)
-				assert.NotNil(t, db.RedisClient)
+			db, err := Connect(cfg)
 
-				err := db.Disconnect()
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, db)
+			} else {
 				assert.NoError(t, err)
+				assert.NotNil(t, db)
+				assert.NoError(t, db.Disconnect())
 			}
 		})
 	}
@@ -338,6 +461,48 @@ func TestDisconnect(t *testing.T) {
 				assert.Equal(t, "close error", err.Error())
 			},
 		},
+		{
+			name: "disconnect should call the registered closers",
+			setupDB: func(t *testing.T) *InMemoryDatabase {
+				var calls int
+				mockClient := mocks.NewRedisClientMock(t)
+				mockClient.On("Close").Return(nil)
+				db := &InMemoryDatabase{
+					RedisClient: mockClient,
+				}
+				for i := 0; i < 2; i++ {
+					db.OnDisconnect(func() error {
+						calls++
+						return nil
+					})
+				}
+				t.Cleanup(func() {
+					assert.Equal(t, 2, calls)
+				})
+				return db
+			},
+			expectError: false,
+			expectPanic: false,
+		},
+		{
+			name: "disconnect should close the client when a closer fails",
+			setupDB: func(t *testing.T) *InMemoryDatabase {
+				mockClient := mocks.NewRedisClientMock(t)
+				mockClient.On("Close").Return(nil)
+				db := &InMemoryDatabase{
+					RedisClient: mockClient,
+				}
+				db.OnDisconnect(func() error {
+					return errors.New("closer error")
+				})
+				return db
+			},
+			expectError: true,
+			expectPanic: false,
+			validate: func(t *testing.T, err error) {
+				assert.EqualError(t, err, "closer error")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -364,3 +529,136 @@ func TestDisconnect(t *testing.T) {
 		})
 	}
 }
+
+// shardedRedisClient is written by hand, the generated mock imports this
+// package.
+type shardedRedisClient struct {
+	*mocks.RedisClientMock
+	shards int
+	err    error
+}
+
+func (c *shardedRedisClient) ForEachShard(ctx context.Context, fn func(ctx context.Context, shard IRedisClient) error) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	for i := 0; i < c.shards; i++ {
+		if err := fn(ctx, c.RedisClientMock); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestIsSharded(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   IRedisClient
+		expected bool
+	}{
+		{
+			name:     "single node client",
+			client:   mocks.NewRedisClientMock(t),
+			expected: false,
+		},
+		{
+			name:     "sharded client",
+			client:   &shardedRedisClient{RedisClientMock: mocks.NewRedisClientMock(t)},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &InMemoryDatabase{RedisClient: tt.client}
+
+			assert.Equal(t, tt.expected, db.IsSharded())
+		})
+	}
+}
+
+func TestForEachShard(t *testing.T) {
+	tests := []struct {
+		name           string
+		client         func(t *testing.T) IRedisClient
+		expectedShards int
+		expectError    bool
+	}{
+		{
+			name: "single node client is its only shard",
+			client: func(t *testing.T) IRedisClient {
+				return mocks.NewRedisClientMock(t)
+			},
+			expectedShards: 1,
+		},
+		{
+			name: "sharded client visits every shard",
+			client: func(t *testing.T) IRedisClient {
+				return &shardedRedisClient{RedisClientMock: mocks.NewRedisClientMock(t), shards: 3}
+			},
+			expectedShards: 3,
+		},
+		{
+			name: "sharded client error is returned",
+			client: func(t *testing.T) IRedisClient {
+				return &shardedRedisClient{RedisClientMock: mocks.NewRedisClientMock(t), err: errors.New("cluster down")}
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &InMemoryDatabase{RedisClient: tt.client(t)}
+			shards := 0
+
+			err := db.ForEachShard(context.Background(), func(ctx context.Context, shard IRedisClient) error {
+				shards++
+				return nil
+			})
+
+			assert.Equal(t, tt.expectError, err != nil)
+			assert.Equal(t, tt.expectedShards, shards)
+		})
+	}
+}
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name        string
+		pingError   error
+		expectError bool
+	}{
+		{
+			name:        "ping successfully",
+			pingError:   nil,
+			expectError: false,
+		},
+		{
+			name:        "ping with connection error",
+			pingError:   errors.New("connection refused"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := mocks.NewRedisClientMock(t)
+			mockClient.On("Ping", context.Background()).Return(redis.NewStatusResult("PONG", tt.pingError))
+
+			db := &InMemoryDatabase{
+				RedisClient: mockClient,
+			}
+
+			err := db.Ping(context.Background())
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
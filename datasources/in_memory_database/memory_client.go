@@ -0,0 +1,832 @@
+package in_memory_database
+
+import (
+	"cmp"
+	"container/list"
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	DriverRedis  string = "redis"
+	DriverMemory string = "memory"
+)
+
+const (
+	defaultCleanupInterval time.Duration = time.Second
+	defaultScanCount       int64         = 10
+)
+
+var (
+	// ErrMemoryLimit is returned for a write that does not fit in the memory
+	// limit even after evicting every other key with an expiry, keys without
+	// expiry are never evicted.
+	ErrMemoryLimit error = errors.New("OOM command not allowed when used memory > 'maxmemory'")
+	errNotInteger  error = errors.New("ERR value is not an integer or out of range")
+)
+
+type memoryEntry struct {
+	key   string
+	value string
+	// expiresAt is zero for a key without expiry
+	expiresAt time.Time
+	// sequence orders the keys by creation for SCAN, it is kept when the key
+	// is overwritten
+	sequence uint64
+	// element is the place in the eviction order, nil for a key without
+	// expiry
+	element *list.Element
+	removed bool
+}
+
+func (e *memoryEntry) size() int64 {
+	return int64(len(e.key) + len(e.value))
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// MemoryClient keeps the keys in the process, for local development, single
+// replica deployments and tests. It serves the IRedisClient commands and
+// MEMORY USAGE, not pub/sub nor scripts. Expired keys are removed when read
+// and by a background sweep.
+type MemoryClient struct {
+	maxBytes int64
+	now      func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*memoryEntry
+	// recency holds the keys with an expiry, front is the most recently used
+	recency *list.List
+	// ordered holds the keys by sequence for SCAN, removed keys stay until
+	// they are half of it
+	ordered  []*memoryEntry
+	removed  int
+	bytes    int64
+	sequence uint64
+	closed   bool
+	stop     chan struct{}
+}
+
+// NewMemoryClient evicts the least recently used keys with an expiry once the
+// keys and values take more than maxBytes, like Redis volatile-lru, 0 is no
+// limit. Expired keys are swept every cleanupInterval.
+func NewMemoryClient(maxBytes int64, cleanupInterval time.Duration) *MemoryClient {
+	var client *MemoryClient = &MemoryClient{
+		maxBytes: maxBytes,
+		now:      time.Now,
+		entries:  map[string]*memoryEntry{},
+		recency:  list.New(),
+		stop:     make(chan struct{}),
+	}
+
+	if cleanupInterval <= 0 {
+		cleanupInterval = defaultCleanupInterval
+	}
+
+	go client.sweep(cleanupInterval)
+
+	return client
+}
+
+func (c *MemoryClient) sweep(interval time.Duration) {
+	var ticker *time.Ticker = time.NewTicker(interval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.removeExpired()
+		}
+	}
+}
+
+func (c *MemoryClient) removeExpired() {
+	var (
+		now   time.Time
+		entry *memoryEntry
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now = c.now()
+
+	for _, entry = range c.entries {
+		if entry.expired(now) {
+			c.remove(entry)
+		}
+	}
+}
+
+// check has to be called with the mutex held.
+func (c *MemoryClient) check(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if c.closed {
+		return redis.ErrClosed
+	}
+
+	return nil
+}
+
+// lookup returns nil for a missing or expired key, it has to be called with
+// the mutex held.
+func (c *MemoryClient) lookup(key string, now time.Time) *memoryEntry {
+	var (
+		entry *memoryEntry
+		ok    bool
+	)
+
+	entry, ok = c.entries[key]
+	if !ok {
+		return nil
+	}
+
+	if entry.expired(now) {
+		c.remove(entry)
+		return nil
+	}
+
+	return entry
+}
+
+func (c *MemoryClient) remove(entry *memoryEntry) {
+	delete(c.entries, entry.key)
+	if entry.element != nil {
+		c.recency.Remove(entry.element)
+		entry.element = nil
+	}
+	c.bytes -= entry.size()
+
+	entry.removed = true
+	c.removed++
+	if c.removed > len(c.ordered)/2 {
+		c.ordered = slices.DeleteFunc(c.ordered, func(entry *memoryEntry) bool {
+			return entry.removed
+		})
+		c.removed = 0
+	}
+}
+
+// touch marks entry as the most recently used, it only takes part in the
+// eviction while it has an expiry.
+func (c *MemoryClient) touch(entry *memoryEntry) {
+	switch {
+	case entry.expiresAt.IsZero():
+		if entry.element != nil {
+			c.recency.Remove(entry.element)
+			entry.element = nil
+		}
+	case entry.element == nil:
+		entry.element = c.recency.PushFront(entry)
+	default:
+		c.recency.MoveToFront(entry.element)
+	}
+}
+
+// reserve evicts the least recently used keys with an expiry, other than
+// keep, until bytes fit in the limit. Nothing is evicted when they would not
+// fit anyway.
+func (c *MemoryClient) reserve(bytes int64, keep *memoryEntry) error {
+	var (
+		element *list.Element
+		entry   *memoryEntry
+		evicted []*memoryEntry
+	)
+
+	for element = c.recency.Back(); element != nil && bytes > c.maxBytes; element = element.Prev() {
+		entry = element.Value.(*memoryEntry)
+		if entry == keep {
+			continue
+		}
+
+		evicted = append(evicted, entry)
+		bytes -= entry.size()
+	}
+
+	if bytes > c.maxBytes {
+		return ErrMemoryLimit
+	}
+
+	for _, entry = range evicted {
+		c.remove(entry)
+	}
+
+	return nil
+}
+
+// write has to be called with the mutex held.
+func (c *MemoryClient) write(key string, value string, expiresAt time.Time, now time.Time) error {
+	var (
+		entry *memoryEntry
+		bytes int64 = c.bytes + int64(len(key)+len(value))
+		err   error
+	)
+
+	entry = c.lookup(key, now)
+	if entry != nil {
+		bytes -= entry.size()
+	}
+
+	if c.maxBytes > 0 && bytes > c.maxBytes {
+		err = c.reserve(bytes, entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	if entry == nil {
+		c.sequence++
+		entry = &memoryEntry{
+			key:      key,
+			sequence: c.sequence,
+		}
+		c.entries[key] = entry
+		c.ordered = append(c.ordered, entry)
+	} else {
+		c.bytes -= entry.size()
+	}
+
+	entry.value = value
+	entry.expiresAt = expiresAt
+	c.bytes += entry.size()
+	c.touch(entry)
+
+	return nil
+}
+
+// toString stores the values the way go-redis writes them as arguments.
+func toString(value interface{}) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch typedValue := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return typedValue, nil
+	case []byte:
+		return string(typedValue), nil
+	case int:
+		return strconv.FormatInt(int64(typedValue), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(typedValue), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(typedValue), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(typedValue), 10), nil
+	case int64:
+		return strconv.FormatInt(typedValue, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(typedValue), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(typedValue), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(typedValue), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(typedValue), 10), nil
+	case uint64:
+		return strconv.FormatUint(typedValue, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(typedValue), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64), nil
+	case bool:
+		if typedValue {
+			return "1", nil
+		}
+		return "0", nil
+	case time.Time:
+		return typedValue.Format(time.RFC3339Nano), nil
+	case time.Duration:
+		return strconv.FormatInt(typedValue.Nanoseconds(), 10), nil
+	case encoding.BinaryMarshaler:
+		data, err = typedValue.MarshalBinary()
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	default:
+		return "", fmt.Errorf("redis: can't marshal %T (implement encoding.BinaryMarshaler)", value)
+	}
+}
+
+func (c *MemoryClient) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return redis.ErrClosed
+	}
+
+	c.closed = true
+	close(c.stop)
+	c.entries = map[string]*memoryEntry{}
+	c.recency.Init()
+	c.ordered = nil
+	c.removed = 0
+	c.bytes = 0
+
+	return nil
+}
+
+func (c *MemoryClient) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	var (
+		now     time.Time
+		key     string
+		entry   *memoryEntry
+		deleted int64
+		err     error
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = c.check(ctx)
+	if err != nil {
+		return redis.NewIntResult(0, err)
+	}
+
+	now = c.now()
+
+	for _, key = range keys {
+		entry = c.lookup(key, now)
+		if entry == nil {
+			continue
+		}
+
+		c.remove(entry)
+		deleted++
+	}
+
+	return redis.NewIntResult(deleted, nil)
+}
+
+func (c *MemoryClient) Get(ctx context.Context, key string) *redis.StringCmd {
+	var (
+		entry *memoryEntry
+		err   error
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = c.check(ctx)
+	if err != nil {
+		return redis.NewStringResult("", err)
+	}
+
+	entry = c.lookup(key, c.now())
+	if entry == nil {
+		return redis.NewStringResult("", redis.Nil)
+	}
+
+	c.touch(entry)
+
+	return redis.NewStringResult(entry.value, nil)
+}
+
+// Set follows SET with PX, a zero expiration never expires and redis.KeepTTL
+// keeps the expiry of the existing key.
+func (c *MemoryClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	var (
+		data      string
+		now       time.Time
+		expiresAt time.Time
+		entry     *memoryEntry
+		err       error
+	)
+
+	data, err = toString(value)
+	if err != nil {
+		return redis.NewStatusResult("", err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = c.check(ctx)
+	if err != nil {
+		return redis.NewStatusResult("", err)
+	}
+
+	now = c.now()
+
+	switch {
+	case expiration > 0:
+		expiresAt = now.Add(expiration)
+	case expiration == redis.KeepTTL:
+		entry = c.lookup(key, now)
+		if entry != nil {
+			expiresAt = entry.expiresAt
+		}
+	}
+
+	err = c.write(key, data, expiresAt, now)
+	if err != nil {
+		return redis.NewStatusResult("", err)
+	}
+
+	return redis.NewStatusResult("OK", nil)
+}
+
+func (c *MemoryClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	var (
+		data      string
+		now       time.Time
+		expiresAt time.Time
+		err       error
+	)
+
+	data, err = toString(value)
+	if err != nil {
+		return redis.NewBoolResult(false, err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = c.check(ctx)
+	if err != nil {
+		return redis.NewBoolResult(false, err)
+	}
+
+	now = c.now()
+
+	if c.lookup(key, now) != nil {
+		return redis.NewBoolResult(false, nil)
+	}
+
+	if expiration > 0 {
+		expiresAt = now.Add(expiration)
+	}
+
+	err = c.write(key, data, expiresAt, now)
+	if err != nil {
+		return redis.NewBoolResult(false, err)
+	}
+
+	return redis.NewBoolResult(true, nil)
+}
+
+// Keys returns the matching keys sorted.
+func (c *MemoryClient) Keys(ctx context.Context, pattern string) *redis.StringSliceCmd {
+	var (
+		now   time.Time
+		entry *memoryEntry
+		keys  []string
+		err   error
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = c.check(ctx)
+	if err != nil {
+		return redis.NewStringSliceResult(nil, err)
+	}
+
+	now = c.now()
+	keys = []string{}
+
+	for _, entry = range c.entries {
+		if entry.expired(now) {
+			c.remove(entry)
+			continue
+		}
+
+		if matchGlob(pattern, entry.key) {
+			keys = append(keys, entry.key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return redis.NewStringSliceResult(keys, nil)
+}
+
+// Scan walks the keys in creation order and the cursor is the next sequence
+// to look at, so a key that exists for the whole iteration is returned
+// exactly once, even while the returned keys are deleted. The keys are kept
+// ordered, a call costs a binary search plus the keys it returns.
+func (c *MemoryClient) Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+	var (
+		now     time.Time
+		index   int
+		entry   *memoryEntry
+		scanned int64
+		expired []*memoryEntry
+		keys    []string
+		next    uint64
+		err     error
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = c.check(ctx)
+	if err != nil {
+		return redis.NewScanCmdResult(nil, 0, err)
+	}
+
+	if count <= 0 {
+		count = defaultScanCount
+	}
+
+	now = c.now()
+	keys = []string{}
+
+	index, _ = slices.BinarySearchFunc(c.ordered, cursor, func(entry *memoryEntry, cursor uint64) int {
+		return cmp.Compare(entry.sequence, cursor)
+	})
+
+	for ; index < len(c.ordered) && scanned < count; index++ {
+		entry = c.ordered[index]
+		if entry.removed {
+			continue
+		}
+		scanned++
+
+		// removing now could compact c.ordered under the loop
+		if entry.expired(now) {
+			expired = append(expired, entry)
+			continue
+		}
+
+		if match == "" || matchGlob(match, entry.key) {
+			keys = append(keys, entry.key)
+		}
+	}
+
+	for ; index < len(c.ordered); index++ {
+		if !c.ordered[index].removed {
+			next = c.ordered[index].sequence
+			break
+		}
+	}
+
+	for _, entry = range expired {
+		c.remove(entry)
+	}
+
+	return redis.NewScanCmdResult(keys, next, nil)
+}
+
+// Incr creates a missing key without expiry, like INCR.
+func (c *MemoryClient) Incr(ctx context.Context, key string) *redis.IntCmd {
+	var (
+		now       time.Time
+		entry     *memoryEntry
+		value     int64
+		expiresAt time.Time
+		err       error
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = c.check(ctx)
+	if err != nil {
+		return redis.NewIntResult(0, err)
+	}
+
+	now = c.now()
+
+	entry = c.lookup(key, now)
+	if entry != nil {
+		value, err = strconv.ParseInt(entry.value, 10, 64)
+		if err != nil || value == math.MaxInt64 {
+			return redis.NewIntResult(0, errNotInteger)
+		}
+
+		expiresAt = entry.expiresAt
+	}
+
+	value++
+
+	err = c.write(key, strconv.FormatInt(value, 10), expiresAt, now)
+	if err != nil {
+		return redis.NewIntResult(0, err)
+	}
+
+	return redis.NewIntResult(value, nil)
+}
+
+// Expire deletes the key for a non positive expiration, like EXPIRE.
+func (c *MemoryClient) Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	var (
+		now   time.Time
+		entry *memoryEntry
+		err   error
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = c.check(ctx)
+	if err != nil {
+		return redis.NewBoolResult(false, err)
+	}
+
+	now = c.now()
+
+	entry = c.lookup(key, now)
+	if entry == nil {
+		return redis.NewBoolResult(false, nil)
+	}
+
+	if expiration <= 0 {
+		c.remove(entry)
+		return redis.NewBoolResult(true, nil)
+	}
+
+	entry.expiresAt = now.Add(expiration)
+	c.touch(entry)
+
+	return redis.NewBoolResult(true, nil)
+}
+
+// PTTL replies -2 for a missing key and -1 for a key without expiry, like
+// go-redis does.
+func (c *MemoryClient) PTTL(ctx context.Context, key string) *redis.DurationCmd {
+	var (
+		now   time.Time
+		entry *memoryEntry
+		err   error
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = c.check(ctx)
+	if err != nil {
+		return redis.NewDurationResult(0, err)
+	}
+
+	now = c.now()
+
+	entry = c.lookup(key, now)
+	if entry == nil {
+		return redis.NewDurationResult(-2, nil)
+	}
+
+	if entry.expiresAt.IsZero() {
+		return redis.NewDurationResult(-1, nil)
+	}
+
+	return redis.NewDurationResult(entry.expiresAt.Sub(now).Truncate(time.Millisecond), nil)
+}
+
+func (c *MemoryClient) Ping(ctx context.Context) *redis.StatusCmd {
+	var err error
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = c.check(ctx)
+	if err != nil {
+		return redis.NewStatusResult("", err)
+	}
+
+	return redis.NewStatusResult("PONG", nil)
+}
+
+// MemoryUsage counts the bytes of the key and its value.
+func (c *MemoryClient) MemoryUsage(ctx context.Context, key string, samples ...int) *redis.IntCmd {
+	var (
+		entry *memoryEntry
+		err   error
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = c.check(ctx)
+	if err != nil {
+		return redis.NewIntResult(0, err)
+	}
+
+	entry = c.lookup(key, c.now())
+	if entry == nil {
+		return redis.NewIntResult(0, redis.Nil)
+	}
+
+	return redis.NewIntResult(entry.size(), nil)
+}
+
+// matchGlob matches value against a Redis glob pattern, byte by byte: *, ?,
+// [abc], [^abc], [a-z] and \ escapes.
+func matchGlob(pattern string, value string) bool {
+	var (
+		p       int
+		v       int
+		starP   int = -1
+		starV   int
+		matched bool
+		next    int
+	)
+
+	for v < len(value) {
+		if p < len(pattern) && pattern[p] == '*' {
+			starP, starV = p, v
+			p++
+			continue
+		}
+
+		if p < len(pattern) {
+			matched, next = matchToken(pattern, p, value[v])
+			if matched {
+				p, v = next, v+1
+				continue
+			}
+		}
+
+		// retry from the last star with one more byte consumed by it
+		if starP < 0 {
+			return false
+		}
+
+		starV++
+		p, v = starP+1, starV
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+
+	return p == len(pattern)
+}
+
+// matchToken matches b against the token, other than a star, that starts at
+// pattern[p] and returns the index past the token.
+func matchToken(pattern string, p int, b byte) (bool, int) {
+	var (
+		negate  bool
+		matched bool
+		low     byte
+		high    byte
+	)
+
+	switch pattern[p] {
+	case '?':
+		return true, p + 1
+	case '\\':
+		if p+1 < len(pattern) {
+			return pattern[p+1] == b, p + 2
+		}
+		return b == '\\', p + 1
+	case '[':
+		p++
+	default:
+		return pattern[p] == b, p + 1
+	}
+
+	if p < len(pattern) && pattern[p] == '^' {
+		negate = true
+		p++
+	}
+
+	for p < len(pattern) && pattern[p] != ']' {
+		switch {
+		case pattern[p] == '\\' && p+1 < len(pattern):
+			matched = matched || pattern[p+1] == b
+			p += 2
+		case p+2 < len(pattern) && pattern[p+1] == '-' && pattern[p+2] != ']':
+			low, high = pattern[p], pattern[p+2]
+			if low > high {
+				low, high = high, low
+			}
+			matched = matched || (low <= b && b <= high)
+			p += 3
+		default:
+			matched = matched || pattern[p] == b
+			p++
+		}
+	}
+
+	// an unterminated class ends with the pattern, as in Redis
+	if p < len(pattern) {
+		p++
+	}
+
+	return matched != negate, p
+}
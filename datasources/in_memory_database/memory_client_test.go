@@ -0,0 +1,717 @@
+package in_memory_database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestMemoryClient returns a client whose clock only moves with the
+// returned function.
+func newTestMemoryClient(t *testing.T, maxBytes int64) (*MemoryClient, func(time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := NewMemoryClient(maxBytes, time.Hour)
+	client.now = func() time.Time {
+		return now
+	}
+
+	t.Cleanup(func() {
+		client.Close()
+	})
+
+	return client, func(d time.Duration) {
+		client.mutex.Lock()
+		defer client.mutex.Unlock()
+		now = now.Add(d)
+	}
+}
+
+func TestNewMemoryClient(t *testing.T) {
+	client := NewMemoryClient(0, 0)
+
+	assert.NotNil(t, client)
+	assert.Implements(t, (*IRedisClient)(nil), client)
+	assert.Implements(t, (*IRedisMemoryClient)(nil), client)
+	assert.NoError(t, client.Close())
+}
+
+func TestMemoryClient_SetGet(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T, client *MemoryClient, advance func(time.Duration))
+		key      string
+		expected string
+		err      error
+	}{
+		{
+			name: "should_get_value_that_was_set",
+			setup: func(t *testing.T, client *MemoryClient, advance func(time.Duration)) {
+				assert.NoError(t, client.Set(context.Background(), "key", []byte("value"), 0).Err())
+			},
+			key:      "key",
+			expected: "value",
+		},
+		{
+			name: "should_return_nil_for_missing_key",
+			setup: func(t *testing.T, client *MemoryClient, advance func(time.Duration)) {
+			},
+			key: "missing",
+			err: redis.Nil,
+		},
+		{
+			name: "should_return_nil_once_expired",
+			setup: func(t *testing.T, client *MemoryClient, advance func(time.Duration)) {
+				assert.NoError(t, client.Set(context.Background(), "key", "value", time.Second).Err())
+				advance(time.Second)
+			},
+			key: "key",
+			err: redis.Nil,
+		},
+		{
+			name: "should_get_value_before_expiry",
+			setup: func(t *testing.T, client *MemoryClient, advance func(time.Duration)) {
+				assert.NoError(t, client.Set(context.Background(), "key", "value", time.Second).Err())
+				advance(999 * time.Millisecond)
+			},
+			key:      "key",
+			expected: "value",
+		},
+		{
+			name: "should_keep_expiry_with_keep_ttl",
+			setup: func(t *testing.T, client *MemoryClient, advance func(time.Duration)) {
+				assert.NoError(t, client.Set(context.Background(), "key", "old", time.Second).Err())
+				assert.NoError(t, client.Set(context.Background(), "key", "new", redis.KeepTTL).Err())
+				advance(time.Second)
+			},
+			key: "key",
+			err: redis.Nil,
+		},
+		{
+			name: "should_clear_expiry_on_overwrite",
+			setup: func(t *testing.T, client *MemoryClient, advance func(time.Duration)) {
+				assert.NoError(t, client.Set(context.Background(), "key", "old", time.Second).Err())
+				assert.NoError(t, client.Set(context.Background(), "key", "new", 0).Err())
+				advance(time.Hour)
+			},
+			key:      "key",
+			expected: "new",
+		},
+		{
+			name: "should_store_numbers_as_redis_does",
+			setup: func(t *testing.T, client *MemoryClient, advance func(time.Duration)) {
+				assert.NoError(t, client.Set(context.Background(), "key", 42, 0).Err())
+			},
+			key:      "key",
+			expected: "42",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, advance := newTestMemoryClient(t, 0)
+			tt.setup(t, client, advance)
+
+			value, err := client.Get(context.Background(), tt.key).Result()
+
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestMemoryClient_Set_UnsupportedValue(t *testing.T) {
+	client, _ := newTestMemoryClient(t, 0)
+
+	err := client.Set(context.Background(), "key", struct{}{}, 0).Err()
+
+	assert.EqualError(t, err, "redis: can't marshal struct {} (implement encoding.BinaryMarshaler)")
+}
+
+func TestMemoryClient_SetNX(t *testing.T) {
+	client, advance := newTestMemoryClient(t, 0)
+	ctx := context.Background()
+
+	acquired, err := client.SetNX(ctx, "lock", 1, time.Second).Result()
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = client.SetNX(ctx, "lock", 1, time.Second).Result()
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+
+	advance(time.Second)
+
+	acquired, err = client.SetNX(ctx, "lock", 1, time.Second).Result()
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+}
+
+func TestMemoryClient_Del(t *testing.T) {
+	client, advance := newTestMemoryClient(t, 0)
+	ctx := context.Background()
+
+	client.Set(ctx, "a", "1", 0)
+	client.Set(ctx, "b", "2", 0)
+	client.Set(ctx, "expired", "3", time.Second)
+	advance(time.Second)
+
+	deleted, err := client.Del(ctx, "a", "b", "expired", "missing").Result()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.Equal(t, redis.Nil, client.Get(ctx, "a").Err())
+	assert.Zero(t, client.bytes)
+}
+
+func TestMemoryClient_Keys(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		expected []string
+	}{
+		{
+			name:     "should_match_everything_with_star",
+			pattern:  "*",
+			expected: []string{"caches:guests:1", "caches:guests:2", "caches:guests:list:1", "locks:guests"},
+		},
+		{
+			name:     "should_match_prefix",
+			pattern:  "caches:guests:*",
+			expected: []string{"caches:guests:1", "caches:guests:2", "caches:guests:list:1"},
+		},
+		{
+			name:     "should_match_single_byte",
+			pattern:  "caches:guests:?",
+			expected: []string{"caches:guests:1", "caches:guests:2"},
+		},
+		{
+			name:     "should_return_empty_list_without_match",
+			pattern:  "sessions:*",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, advance := newTestMemoryClient(t, 0)
+			ctx := context.Background()
+			client.Set(ctx, "caches:guests:2", "b", 0)
+			client.Set(ctx, "caches:guests:1", "a", 0)
+			client.Set(ctx, "caches:guests:list:1", "c", 0)
+			client.Set(ctx, "locks:guests", "d", 0)
+			client.Set(ctx, "caches:guests:expired", "e", time.Second)
+			advance(time.Second)
+
+			keys, err := client.Keys(ctx, tt.pattern).Result()
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, keys)
+		})
+	}
+}
+
+func TestMemoryClient_Scan(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int64
+		match    string
+		deleting bool
+		expected []string
+	}{
+		{
+			name:     "should_return_every_key_over_several_calls",
+			count:    3,
+			match:    "*",
+			expected: []string{"key:0", "key:1", "key:2", "key:3", "key:4", "key:5", "key:6", "key:7", "key:8", "key:9"},
+		},
+		{
+			name:     "should_filter_by_match",
+			count:    4,
+			match:    "key:[0-2]",
+			expected: []string{"key:0", "key:1", "key:2"},
+		},
+		{
+			name:     "should_not_skip_keys_while_deleting_them",
+			count:    3,
+			match:    "*",
+			deleting: true,
+			expected: []string{"key:0", "key:1", "key:2", "key:3", "key:4", "key:5", "key:6", "key:7", "key:8", "key:9"},
+		},
+		{
+			name:     "should_use_default_count",
+			match:    "*",
+			expected: []string{"key:0", "key:1", "key:2", "key:3", "key:4", "key:5", "key:6", "key:7", "key:8", "key:9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestMemoryClient(t, 0)
+			ctx := context.Background()
+			for i := 0; i < 10; i++ {
+				client.Set(ctx, fmt.Sprintf("key:%d", i), "value", 0)
+			}
+
+			var (
+				scanned []string
+				cursor  uint64
+				calls   int
+			)
+			for {
+				keys, next, err := client.Scan(ctx, cursor, tt.match, tt.count).Result()
+				assert.NoError(t, err)
+				scanned = append(scanned, keys...)
+				calls++
+
+				if tt.deleting && len(keys) > 0 {
+					client.Del(ctx, keys...)
+				}
+
+				if next == 0 {
+					break
+				}
+				cursor = next
+			}
+
+			assert.Equal(t, tt.expected, scanned)
+			if tt.count > 0 {
+				assert.Equal(t, int((10+tt.count-1)/tt.count), calls)
+			}
+		})
+	}
+}
+
+func TestMemoryClient_Scan_CompactsRemovedKeys(t *testing.T) {
+	client, advance := newTestMemoryClient(t, 0)
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		client.Set(ctx, fmt.Sprintf("key:%d", i), "value", time.Minute)
+	}
+	client.Set(ctx, "kept", "value", 0)
+
+	for i := 0; i < 90; i++ {
+		client.Del(ctx, fmt.Sprintf("key:%d", i))
+	}
+	assert.LessOrEqual(t, len(client.ordered), 2*len(client.entries), "removed keys should be compacted away")
+
+	advance(time.Hour)
+	keys, next, err := client.Scan(ctx, 0, "*", 100).Result()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kept"}, keys, "expired keys should be skipped")
+	assert.Equal(t, uint64(0), next)
+	assert.Len(t, client.entries, 1)
+}
+
+func TestMemoryClient_Incr(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(client *MemoryClient)
+		expected  int64
+		expectErr error
+	}{
+		{
+			name:     "should_create_missing_key",
+			setup:    func(client *MemoryClient) {},
+			expected: 1,
+		},
+		{
+			name: "should_increment_existing_key",
+			setup: func(client *MemoryClient) {
+				client.Set(context.Background(), "counter", "41", 0)
+			},
+			expected: 42,
+		},
+		{
+			name: "should_fail_for_non_integer_value",
+			setup: func(client *MemoryClient) {
+				client.Set(context.Background(), "counter", "abc", 0)
+			},
+			expectErr: errNotInteger,
+		},
+		{
+			name: "should_fail_on_overflow",
+			setup: func(client *MemoryClient) {
+				client.Set(context.Background(), "counter", "9223372036854775807", 0)
+			},
+			expectErr: errNotInteger,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestMemoryClient(t, 0)
+			tt.setup(client)
+
+			value, err := client.Incr(context.Background(), "counter").Result()
+
+			assert.Equal(t, tt.expectErr, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestMemoryClient_Incr_KeepsExpiry(t *testing.T) {
+	client, advance := newTestMemoryClient(t, 0)
+	ctx := context.Background()
+
+	client.Incr(ctx, "counter")
+	client.Expire(ctx, "counter", time.Second)
+	client.Incr(ctx, "counter")
+
+	assert.Equal(t, time.Second, client.PTTL(ctx, "counter").Val())
+
+	advance(time.Second)
+
+	assert.Equal(t, redis.Nil, client.Get(ctx, "counter").Err())
+}
+
+func TestMemoryClient_ExpirePTTL(t *testing.T) {
+	tests := []struct {
+		name           string
+		setup          func(client *MemoryClient)
+		expiration     time.Duration
+		expectedExpire bool
+		expectedPTTL   time.Duration
+	}{
+		{
+			name:           "should_not_expire_missing_key",
+			setup:          func(client *MemoryClient) {},
+			expiration:     time.Second,
+			expectedExpire: false,
+			expectedPTTL:   -2,
+		},
+		{
+			name: "should_set_expiry",
+			setup: func(client *MemoryClient) {
+				client.Set(context.Background(), "key", "value", 0)
+			},
+			expiration:     1500 * time.Millisecond,
+			expectedExpire: true,
+			expectedPTTL:   1500 * time.Millisecond,
+		},
+		{
+			name: "should_delete_key_for_non_positive_expiration",
+			setup: func(client *MemoryClient) {
+				client.Set(context.Background(), "key", "value", 0)
+			},
+			expiration:     0,
+			expectedExpire: true,
+			expectedPTTL:   -2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestMemoryClient(t, 0)
+			ctx := context.Background()
+			tt.setup(client)
+
+			expired, err := client.Expire(ctx, "key", tt.expiration).Result()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedExpire, expired)
+
+			ttl, err := client.PTTL(ctx, "key").Result()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPTTL, ttl)
+		})
+	}
+}
+
+func TestMemoryClient_PTTL_WithoutExpiry(t *testing.T) {
+	client, _ := newTestMemoryClient(t, 0)
+	ctx := context.Background()
+
+	client.Set(ctx, "key", "value", 0)
+
+	assert.Equal(t, time.Duration(-1), client.PTTL(ctx, "key").Val())
+}
+
+func TestMemoryClient_MemoryUsage(t *testing.T) {
+	client, _ := newTestMemoryClient(t, 0)
+	ctx := context.Background()
+
+	client.Set(ctx, "key", "value", 0)
+
+	assert.Equal(t, int64(8), client.MemoryUsage(ctx, "key").Val())
+	assert.Equal(t, redis.Nil, client.MemoryUsage(ctx, "missing").Err())
+}
+
+func TestMemoryClient_MemoryLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxBytes     int64
+		writes       func(client *MemoryClient) error
+		expectErr    error
+		expectedKeys []string
+	}{
+		{
+			name:     "should_evict_least_recently_used_keys",
+			maxBytes: 12,
+			writes: func(client *MemoryClient) error {
+				ctx := context.Background()
+				client.Set(ctx, "a", "11111", time.Minute)
+				client.Set(ctx, "b", "22222", time.Minute)
+				// reading a makes b the least recently used
+				client.Get(ctx, "a")
+				return client.Set(ctx, "c", "33333", time.Minute).Err()
+			},
+			expectedKeys: []string{"a", "c"},
+		},
+		{
+			name:     "should_never_evict_keys_without_expiry",
+			maxBytes: 12,
+			writes: func(client *MemoryClient) error {
+				ctx := context.Background()
+				client.Set(ctx, "a", "11111", 0)
+				client.Set(ctx, "b", "22222", time.Minute)
+				client.Get(ctx, "b")
+				return client.Set(ctx, "c", "33333", 0).Err()
+			},
+			expectedKeys: []string{"a", "c"},
+		},
+		{
+			name:     "should_reject_write_when_only_keys_without_expiry_are_left",
+			maxBytes: 12,
+			writes: func(client *MemoryClient) error {
+				ctx := context.Background()
+				client.Set(ctx, "a", "11111", 0)
+				client.Set(ctx, "b", "22222", 0)
+				return client.Set(ctx, "c", "33333", time.Minute).Err()
+			},
+			expectErr:    ErrMemoryLimit,
+			expectedKeys: []string{"a", "b"},
+		},
+		{
+			name:     "should_not_evict_when_the_write_cannot_fit_anyway",
+			maxBytes: 12,
+			writes: func(client *MemoryClient) error {
+				ctx := context.Background()
+				client.Set(ctx, "a", "11111", 0)
+				client.Set(ctx, "b", "2", time.Minute)
+				return client.Set(ctx, "c", "333333", time.Minute).Err()
+			},
+			expectErr:    ErrMemoryLimit,
+			expectedKeys: []string{"a", "b"},
+		},
+		{
+			name:     "should_grow_an_existing_key_by_evicting_others",
+			maxBytes: 12,
+			writes: func(client *MemoryClient) error {
+				ctx := context.Background()
+				client.Set(ctx, "a", "1", 0)
+				client.Set(ctx, "b", "22222", time.Minute)
+				return client.Set(ctx, "a", "1111111111", 0).Err()
+			},
+			expectedKeys: []string{"a"},
+		},
+		{
+			name:     "should_reject_value_larger_than_limit",
+			maxBytes: 4,
+			writes: func(client *MemoryClient) error {
+				ctx := context.Background()
+				client.Set(ctx, "a", "1", 0)
+				return client.Set(ctx, "b", "22222", 0).Err()
+			},
+			expectErr:    ErrMemoryLimit,
+			expectedKeys: []string{"a"},
+		},
+		{
+			name:     "should_not_limit_without_max_bytes",
+			maxBytes: 0,
+			writes: func(client *MemoryClient) error {
+				ctx := context.Background()
+				client.Set(ctx, "a", "11111", 0)
+				return client.Set(ctx, "b", "22222", 0).Err()
+			},
+			expectedKeys: []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestMemoryClient(t, tt.maxBytes)
+
+			err := tt.writes(client)
+
+			assert.Equal(t, tt.expectErr, err)
+			assert.Equal(t, tt.expectedKeys, client.Keys(context.Background(), "*").Val())
+			if tt.maxBytes > 0 {
+				assert.LessOrEqual(t, client.bytes, tt.maxBytes)
+			}
+		})
+	}
+}
+
+func TestMemoryClient_Sweep(t *testing.T) {
+	client := NewMemoryClient(0, 10*time.Millisecond)
+	defer client.Close()
+	ctx := context.Background()
+
+	client.Set(ctx, "short", "value", 20*time.Millisecond)
+	client.Set(ctx, "long", "value", 0)
+
+	assert.Eventually(t, func() bool {
+		client.mutex.Lock()
+		defer client.mutex.Unlock()
+		_, ok := client.entries["short"]
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "value", client.Get(ctx, "long").Val())
+}
+
+func TestMemoryClient_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(client *MemoryClient) context.Context
+		expectErr error
+	}{
+		{
+			name: "should_fail_with_cancelled_context",
+			setup: func(client *MemoryClient) context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			expectErr: context.Canceled,
+		},
+		{
+			name: "should_fail_once_closed",
+			setup: func(client *MemoryClient) context.Context {
+				client.Close()
+				return context.Background()
+			},
+			expectErr: redis.ErrClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewMemoryClient(0, time.Hour)
+			ctx := tt.setup(client)
+
+			assert.Equal(t, tt.expectErr, client.Ping(ctx).Err())
+			assert.Equal(t, tt.expectErr, client.Get(ctx, "key").Err())
+			assert.Equal(t, tt.expectErr, client.Set(ctx, "key", "value", 0).Err())
+			assert.Equal(t, tt.expectErr, client.SetNX(ctx, "key", "value", 0).Err())
+			assert.Equal(t, tt.expectErr, client.Del(ctx, "key").Err())
+			assert.Equal(t, tt.expectErr, client.Keys(ctx, "*").Err())
+			assert.Equal(t, tt.expectErr, client.Scan(ctx, 0, "*", 10).Err())
+			assert.Equal(t, tt.expectErr, client.Incr(ctx, "key").Err())
+			assert.Equal(t, tt.expectErr, client.Expire(ctx, "key", time.Second).Err())
+			assert.Equal(t, tt.expectErr, client.PTTL(ctx, "key").Err())
+			assert.Equal(t, tt.expectErr, client.MemoryUsage(ctx, "key").Err())
+
+			client.Close()
+		})
+	}
+}
+
+func TestMemoryClient_Close(t *testing.T) {
+	client := NewMemoryClient(0, time.Hour)
+
+	assert.NoError(t, client.Close())
+	assert.Equal(t, redis.ErrClosed, client.Close())
+}
+
+type binaryValue struct{}
+
+func (binaryValue) MarshalBinary() ([]byte, error) {
+	return []byte("binary"), nil
+}
+
+type failingBinaryValue struct{}
+
+func (failingBinaryValue) MarshalBinary() ([]byte, error) {
+	return nil, errors.New("marshal error")
+}
+
+func Test_toString(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		expected  string
+		expectErr bool
+	}{
+		{name: "nil", value: nil, expected: ""},
+		{name: "string", value: "value", expected: "value"},
+		{name: "bytes", value: []byte("value"), expected: "value"},
+		{name: "int", value: -1, expected: "-1"},
+		{name: "int8", value: int8(8), expected: "8"},
+		{name: "int16", value: int16(16), expected: "16"},
+		{name: "int32", value: int32(32), expected: "32"},
+		{name: "int64", value: int64(64), expected: "64"},
+		{name: "uint", value: uint(1), expected: "1"},
+		{name: "uint8", value: uint8(8), expected: "8"},
+		{name: "uint16", value: uint16(16), expected: "16"},
+		{name: "uint32", value: uint32(32), expected: "32"},
+		{name: "uint64", value: uint64(64), expected: "64"},
+		{name: "float32", value: float32(1.5), expected: "1.5"},
+		{name: "float64", value: 2.25, expected: "2.25"},
+		{name: "true", value: true, expected: "1"},
+		{name: "false", value: false, expected: "0"},
+		{name: "time", value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), expected: "2024-01-01T00:00:00Z"},
+		{name: "duration", value: time.Second, expected: "1000000000"},
+		{name: "binary marshaler", value: binaryValue{}, expected: "binary"},
+		{name: "failing binary marshaler", value: failingBinaryValue{}, expectErr: true},
+		{name: "unsupported", value: map[string]int{}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := toString(tt.value)
+
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, value)
+			}
+		})
+	}
+}
+
+func Test_matchGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		value    string
+		expected bool
+	}{
+		{pattern: "*", value: "", expected: true},
+		{pattern: "*", value: "anything", expected: true},
+		{pattern: "caches:*", value: "caches:guests:1", expected: true},
+		{pattern: "caches:*", value: "locks:guests", expected: false},
+		{pattern: "*:list:*", value: "caches:guests:list:page:1", expected: true},
+		{pattern: "*:list", value: "caches:list:guests", expected: false},
+		{pattern: "a*b*c", value: "axxbyyc", expected: true},
+		{pattern: "a*b*c", value: "axxbyy", expected: false},
+		{pattern: "h?llo", value: "hello", expected: true},
+		{pattern: "h?llo", value: "hllo", expected: false},
+		{pattern: "h[ae]llo", value: "hallo", expected: true},
+		{pattern: "h[ae]llo", value: "hillo", expected: false},
+		{pattern: "h[^e]llo", value: "hallo", expected: true},
+		{pattern: "h[^e]llo", value: "hello", expected: false},
+		{pattern: "h[a-c]llo", value: "hbllo", expected: true},
+		{pattern: "h[c-a]llo", value: "hbllo", expected: true},
+		{pattern: "h[a-c]llo", value: "hdllo", expected: false},
+		{pattern: `h\*llo`, value: "h*llo", expected: true},
+		{pattern: `h\*llo`, value: "hello", expected: false},
+		{pattern: `h[\]]llo`, value: "h]llo", expected: true},
+		{pattern: "h[ab", value: "ha", expected: true},
+		{pattern: `a\`, value: `a\`, expected: true},
+		{pattern: "caches:guests:1", value: "caches:guests:1", expected: true},
+		{pattern: "caches:guests:1", value: "caches:guests:10", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s_%s", tt.pattern, tt.value), func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchGlob(tt.pattern, tt.value))
+		})
+	}
+}
@@ -16,5 +16,13 @@ var Provider wire.ProviderSet = wire.NewSet(
 	boilerplate_database.Connect,
 	in_memory_database.Connect,
 	event_producer.Connect,
+	NewHealthChecker,
+	NewFeatureFlagFileStore,
+	webhook_site_http_client.NewWebhookSiteHTTPClient,
+)
+
+// SharedProvider provides the datasources of an already connected Datasources.
+var SharedProvider wire.ProviderSet = wire.NewSet(
+	wire.FieldsOf(new(*Datasources), "BoilerplateDatabase", "InMemoryDatabase", "EventProducer", "HealthChecker", "FeatureFlagStore"),
 	webhook_site_http_client.NewWebhookSiteHTTPClient,
 )
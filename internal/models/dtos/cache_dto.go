@@ -0,0 +1,74 @@
+package dtos
+
+import (
+	"go-boilerplate/pkg/validator"
+	"sort"
+	"strings"
+)
+
+type WarmCacheResponseDTO struct {
+	Pages  uint64
+	IDs    uint64
+	Failed uint64
+}
+
+type CacheStatsRequestDTO struct {
+	Pattern string `json:"pattern" validate:"required"`
+	// Depth is the number of key segments a prefix keeps.
+	Depth int `json:"depth" validate:"min=1"`
+}
+
+func (dto *CacheStatsRequestDTO) Validate() error {
+	return validator.ValidateStruct(dto)
+}
+
+// Prefix is key cut to Depth segments, the last segment is always cut, so
+// the keys of one entity type share a prefix instead of one each.
+func (dto *CacheStatsRequestDTO) Prefix(key string) string {
+	var segments []string = strings.Split(key, ":")
+
+	return strings.Join(segments[:max(1, min(dto.Depth, len(segments)-1))], ":")
+}
+
+type CachePrefixStatsDTO struct {
+	Prefix      string
+	Keys        uint64
+	MemoryBytes int64
+}
+
+type CacheStatsResponseDTO struct {
+	Prefixes    []CachePrefixStatsDTO
+	Keys        uint64
+	MemoryBytes int64
+}
+
+func NewCacheStatsResponseDTO(prefixes map[string]*CachePrefixStatsDTO) *CacheStatsResponseDTO {
+	var (
+		dto    *CacheStatsResponseDTO = &CacheStatsResponseDTO{Prefixes: []CachePrefixStatsDTO{}}
+		prefix *CachePrefixStatsDTO
+	)
+
+	for _, prefix = range prefixes {
+		dto.Prefixes = append(dto.Prefixes, *prefix)
+		dto.Keys += prefix.Keys
+		dto.MemoryBytes += prefix.MemoryBytes
+	}
+
+	sort.Slice(dto.Prefixes, func(i, j int) bool {
+		return dto.Prefixes[i].Prefix < dto.Prefixes[j].Prefix
+	})
+
+	return dto
+}
+
+type FlushCacheRequestDTO struct {
+	Pattern string `json:"pattern" validate:"required"`
+}
+
+func (dto *FlushCacheRequestDTO) Validate() error {
+	return validator.ValidateStruct(dto)
+}
+
+type FlushCacheResponseDTO struct {
+	Deleted uint64
+}
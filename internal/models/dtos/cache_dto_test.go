@@ -0,0 +1,127 @@
+package dtos
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheStatsRequestDTO_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		dto         *CacheStatsRequestDTO
+		expectError bool
+	}{
+		{
+			name:        "valid stats request",
+			dto:         &CacheStatsRequestDTO{Pattern: "caches:*", Depth: 2},
+			expectError: false,
+		},
+		{
+			name:        "invalid stats request missing pattern",
+			dto:         &CacheStatsRequestDTO{Depth: 2},
+			expectError: true,
+		},
+		{
+			name:        "invalid stats request with negative depth",
+			dto:         &CacheStatsRequestDTO{Pattern: "caches:*", Depth: -1},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dto.Validate()
+
+			if tt.expectError {
+				assert.Equal(t, http.StatusBadRequest, gocerr.GetErrorCode(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCacheStatsRequestDTO_Prefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		depth    int
+		key      string
+		expected string
+	}{
+		{
+			name:     "cut to depth",
+			depth:    4,
+			key:      "caches:entities:guests:list:3:take=10&skip=0",
+			expected: "caches:entities:guests:list",
+		},
+		{
+			name:     "always cut the last segment",
+			depth:    4,
+			key:      "caches:entities:guests:01932293-d710-7f55-a9f6-66e6248ae72f",
+			expected: "caches:entities:guests",
+		},
+		{
+			name:     "keep a key without segments",
+			depth:    4,
+			key:      "counter",
+			expected: "counter",
+		},
+		{
+			name:     "keep at least one segment",
+			depth:    1,
+			key:      "lock:{job}:fence",
+			expected: "lock",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dto := &CacheStatsRequestDTO{Depth: tt.depth}
+
+			assert.Equal(t, tt.expected, dto.Prefix(tt.key))
+		})
+	}
+}
+
+func TestNewCacheStatsResponseDTO(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefixes map[string]*CachePrefixStatsDTO
+		expected *CacheStatsResponseDTO
+	}{
+		{
+			name: "sort prefixes and sum totals",
+			prefixes: map[string]*CachePrefixStatsDTO{
+				"b": {Prefix: "b", Keys: 2, MemoryBytes: 100},
+				"a": {Prefix: "a", Keys: 1, MemoryBytes: 50},
+			},
+			expected: &CacheStatsResponseDTO{
+				Prefixes: []CachePrefixStatsDTO{
+					{Prefix: "a", Keys: 1, MemoryBytes: 50},
+					{Prefix: "b", Keys: 2, MemoryBytes: 100},
+				},
+				Keys:        3,
+				MemoryBytes: 150,
+			},
+		},
+		{
+			name:     "no keys",
+			prefixes: map[string]*CachePrefixStatsDTO{},
+			expected: &CacheStatsResponseDTO{Prefixes: []CachePrefixStatsDTO{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewCacheStatsResponseDTO(tt.prefixes))
+		})
+	}
+}
+
+func TestFlushCacheRequestDTO_Validate(t *testing.T) {
+	assert.NoError(t, (&FlushCacheRequestDTO{Pattern: "caches:*"}).Validate())
+	assert.Equal(t, http.StatusBadRequest, gocerr.GetErrorCode((&FlushCacheRequestDTO{}).Validate()))
+}
@@ -44,6 +44,72 @@ func (dto *DeleteGuestByIDRequestDTO) Validate() error {
 	return validator.ValidateStruct(dto)
 }
 
+type EraseGuestByIDRequestDTO struct {
+	ID       string `json:"id" validate:"uuid_rfc4122"`
+	ErasedBy string `json:"erased_by" validate:"required"`
+}
+
+func (dto *EraseGuestByIDRequestDTO) Validate() error {
+	return validator.ValidateStruct(dto)
+}
+
+type ExportGuestByIDRequestDTO struct {
+	ID string `json:"id" validate:"uuid_rfc4122"`
+}
+
+func (dto *ExportGuestByIDRequestDTO) Validate() error {
+	return validator.ValidateStruct(dto)
+}
+
+type GuestRecordDTO struct {
+	ID        string
+	Name      string
+	Address   string
+	CreatedAt int64
+	CreatedBy string
+	UpdatedAt int64
+	UpdatedBy string
+	DeletedAt int64
+	DeletedBy string
+}
+
+func NewGuestRecordDTO(entity *entities.GuestEntity) *GuestRecordDTO {
+	return &GuestRecordDTO{
+		ID:        entity.ID.String(),
+		Name:      entity.Name,
+		Address:   entity.Address.ValueOrZero(),
+		CreatedAt: entity.CreatedAt,
+		CreatedBy: entity.CreatedBy,
+		UpdatedAt: entity.UpdatedAt.ValueOrZero(),
+		UpdatedBy: entity.UpdatedBy.ValueOrZero(),
+		DeletedAt: entity.DeletedAt.ValueOrZero(),
+		DeletedBy: entity.DeletedBy.ValueOrZero(),
+	}
+}
+
+// ExportGuestResponseDTO bundles everything stored about a guest. Published
+// events and webhook deliveries are not part of it because they are not
+// stored, NSQ drops a message once it is consumed and webhooks are sent
+// without keeping a delivery record.
+type ExportGuestResponseDTO struct {
+	Database   *GuestRecordDTO
+	Cache      *GuestRecordDTO
+	ExportedAt int64
+}
+
+func NewExportGuestResponseDTO(databaseEntity *entities.GuestEntity, cacheEntity *entities.GuestEntity) *ExportGuestResponseDTO {
+	var responseDTO *ExportGuestResponseDTO = &ExportGuestResponseDTO{
+		Database:   NewGuestRecordDTO(databaseEntity),
+		ExportedAt: time.Now().UnixMilli(),
+	}
+
+	if cacheEntity != nil {
+		responseDTO.Cache = NewGuestRecordDTO(cacheEntity)
+	}
+
+	return responseDTO
+}
+
 type FindAllGuestRequestDTO struct {
 	Keyword string `json:"keyword,omitempty"`
 	Sorts   string `json:"sorts,omitempty"`
@@ -76,21 +142,12 @@ func (dto *FindAllGuestRequestDTO) ToFilterAndSorts() (*goqube.Filter, []goqube.
 		},
 	}
 
+	// address is stored encrypted, so it can be neither searched nor sorted
 	if dto.Keyword != "" {
 		filter.Filters = append(filter.Filters, goqube.Filter{
-			Logic: goqube.LogicOr,
-			Filters: []goqube.Filter{
-				{
-					Field:    goqube.Field{Column: entities.GuestEntityDatabaseFieldName},
-					Operator: goqube.OperatorLike,
-					Value:    goqube.FilterValue{Value: dto.Keyword},
-				},
-				{
-					Field:    goqube.Field{Column: entities.GuestEntityDatabaseFieldAddress},
-					Operator: goqube.OperatorLike,
-					Value:    goqube.FilterValue{Value: dto.Keyword},
-				},
-			},
+			Field:    goqube.Field{Column: entities.GuestEntityDatabaseFieldName},
+			Operator: goqube.OperatorLike,
+			Value:    goqube.FilterValue{Value: dto.Keyword},
 		})
 	}
 
@@ -102,8 +159,7 @@ func (dto *FindAllGuestRequestDTO) ToFilterAndSorts() (*goqube.Filter, []goqube.
 		var sortFieldAndDirection []string = strings.Split(splittedString[i], ".")
 
 		if len(sortFieldAndDirection) == 1 {
-			if sortFieldAndDirection[0] != entities.GuestEntityDatabaseFieldName &&
-				sortFieldAndDirection[0] != entities.GuestEntityDatabaseFieldAddress {
+			if sortFieldAndDirection[0] != entities.GuestEntityDatabaseFieldName {
 				err = gocerr.New(
 					http.StatusBadRequest,
 					http.StatusText(http.StatusBadRequest),
@@ -119,8 +175,7 @@ func (dto *FindAllGuestRequestDTO) ToFilterAndSorts() (*goqube.Filter, []goqube.
 		}
 
 		if len(sortFieldAndDirection) >= 2 {
-			if sortFieldAndDirection[0] != entities.GuestEntityDatabaseFieldName &&
-				sortFieldAndDirection[0] != entities.GuestEntityDatabaseFieldAddress {
+			if sortFieldAndDirection[0] != entities.GuestEntityDatabaseFieldName {
 				err = gocerr.New(
 					http.StatusBadRequest,
 					http.StatusText(http.StatusBadRequest),
@@ -202,6 +202,162 @@ func TestDeleteGuestByIDRequestDTO_Validate(t *testing.T) {
 	}
 }
 
+func TestEraseGuestByIDRequestDTO_Validate(t *testing.T) {
+	validUUID := uuid.Must(uuid.NewV4()).String()
+
+	tests := []struct {
+		name     string
+		dto      *EraseGuestByIDRequestDTO
+		validate func(t *testing.T, err error)
+	}{
+		{
+			name: "valid erase guest request",
+			dto: &EraseGuestByIDRequestDTO{
+				ID:       validUUID,
+				ErasedBy: "dpo",
+			},
+			validate: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "invalid erase guest request with invalid UUID",
+			dto: &EraseGuestByIDRequestDTO{
+				ID:       "invalid-uuid",
+				ErasedBy: "dpo",
+			},
+			validate: func(t *testing.T, err error) {
+				assert.Error(t, err, "Expected validation error for invalid UUID")
+			},
+		},
+		{
+			name: "invalid erase guest request missing erased_by",
+			dto: &EraseGuestByIDRequestDTO{
+				ID: validUUID,
+			},
+			validate: func(t *testing.T, err error) {
+				assert.Error(t, err, "Expected validation error for missing erased_by")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dto.Validate()
+
+			tt.validate(t, err)
+		})
+	}
+}
+
+func TestExportGuestByIDRequestDTO_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		dto      *ExportGuestByIDRequestDTO
+		validate func(t *testing.T, err error)
+	}{
+		{
+			name: "valid export guest request",
+			dto: &ExportGuestByIDRequestDTO{
+				ID: uuid.Must(uuid.NewV4()).String(),
+			},
+			validate: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "invalid export guest request with invalid UUID",
+			dto: &ExportGuestByIDRequestDTO{
+				ID: "invalid-uuid",
+			},
+			validate: func(t *testing.T, err error) {
+				assert.Error(t, err, "Expected validation error for invalid UUID")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dto.Validate()
+
+			tt.validate(t, err)
+		})
+	}
+}
+
+func TestNewGuestRecordDTO(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+	entity := &entities.GuestEntity{
+		ID:        id,
+		Name:      "John Doe",
+		Address:   null.StringFrom("123 Main St"),
+		CreatedAt: 1000,
+		CreatedBy: "admin",
+		UpdatedAt: null.IntFrom(2000),
+		UpdatedBy: null.StringFrom("editor"),
+		DeletedAt: null.IntFrom(3000),
+		DeletedBy: null.StringFrom("remover"),
+	}
+
+	result := NewGuestRecordDTO(entity)
+
+	assert.Equal(t, &GuestRecordDTO{
+		ID:        id.String(),
+		Name:      "John Doe",
+		Address:   "123 Main St",
+		CreatedAt: 1000,
+		CreatedBy: "admin",
+		UpdatedAt: 2000,
+		UpdatedBy: "editor",
+		DeletedAt: 3000,
+		DeletedBy: "remover",
+	}, result)
+}
+
+func TestNewExportGuestResponseDTO(t *testing.T) {
+	entity := &entities.GuestEntity{
+		ID:        uuid.Must(uuid.NewV4()),
+		Name:      "John Doe",
+		CreatedAt: 1000,
+		CreatedBy: "admin",
+	}
+
+	tests := []struct {
+		name           string
+		databaseEntity *entities.GuestEntity
+		cacheEntity    *entities.GuestEntity
+		validate       func(t *testing.T, result *ExportGuestResponseDTO)
+	}{
+		{
+			name:           "export with database and cache records",
+			databaseEntity: entity,
+			cacheEntity:    entity,
+			validate: func(t *testing.T, result *ExportGuestResponseDTO) {
+				assert.Equal(t, NewGuestRecordDTO(entity), result.Database)
+				assert.Equal(t, NewGuestRecordDTO(entity), result.Cache)
+				assert.Greater(t, result.ExportedAt, int64(0))
+			},
+		},
+		{
+			name:           "export without cache record",
+			databaseEntity: entity,
+			cacheEntity:    nil,
+			validate: func(t *testing.T, result *ExportGuestResponseDTO) {
+				assert.Equal(t, NewGuestRecordDTO(entity), result.Database)
+				assert.Nil(t, result.Cache)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewExportGuestResponseDTO(tt.databaseEntity, tt.cacheEntity)
+
+			tt.validate(t, result)
+		})
+	}
+}
+
 func TestNewFindAllGuestRequestDTO(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -268,20 +424,14 @@ func TestFindAllGuestRequestDTO_ToFilterAndSorts(t *testing.T) {
 
 				assert.Len(t, filter.Filters, 2)
 
-				keywordFilter := filter.Filters[1]
-				assert.Equal(t, goqube.LogicOr, keywordFilter.Logic)
-
-				assert.Len(t, keywordFilter.Filters, 2)
-
-				nameFilter := keywordFilter.Filters[0]
+				nameFilter := filter.Filters[1]
 				assert.Equal(t, entities.GuestEntityDatabaseFieldName, nameFilter.Field.Column)
 
 				assert.Equal(t, goqube.OperatorLike, nameFilter.Operator)
 
 				assert.Equal(t, "john", nameFilter.Value.Value)
 
-				addressFilter := keywordFilter.Filters[1]
-				assert.Equal(t, entities.GuestEntityDatabaseFieldAddress, addressFilter.Field.Column)
+				assert.Empty(t, nameFilter.Filters, "encrypted address should not be searched")
 			},
 		},
 		{
@@ -330,7 +480,7 @@ func TestFindAllGuestRequestDTO_ToFilterAndSorts(t *testing.T) {
 			name: "convert DTO with multiple sorts",
 			dto: &FindAllGuestRequestDTO{
 				Keyword: "",
-				Sorts:   "name.ASC,address.DESC",
+				Sorts:   "name.ASC,name.DESC",
 				Take:    10,
 				Skip:    0,
 			},
@@ -346,7 +496,7 @@ func TestFindAllGuestRequestDTO_ToFilterAndSorts(t *testing.T) {
 					assert.Equal(t, goqube.SortDirectionAscending, sort1.Direction)
 
 					sort2 := sorts[1]
-					assert.Equal(t, entities.GuestEntityDatabaseFieldAddress, sort2.Field.Column)
+					assert.Equal(t, entities.GuestEntityDatabaseFieldName, sort2.Field.Column)
 
 					assert.Equal(t, goqube.SortDirectionDescending, sort2.Direction)
 				}
@@ -368,11 +518,8 @@ func TestFindAllGuestRequestDTO_ToFilterAndSorts(t *testing.T) {
 				assert.Len(t, sorts, 1)
 
 				if len(filter.Filters) >= 2 {
-					keywordFilter := filter.Filters[1]
-					if len(keywordFilter.Filters) >= 1 {
-						nameFilter := keywordFilter.Filters[0]
-						assert.Equal(t, "test search", nameFilter.Value.Value)
-					}
+					nameFilter := filter.Filters[1]
+					assert.Equal(t, "test search", nameFilter.Value.Value)
 				}
 			},
 		},
@@ -417,7 +564,7 @@ func TestFindAllGuestRequestDTO_ToFilterAndSorts(t *testing.T) {
 			},
 		},
 		{
-			name: "address field with ascending direction",
+			name: "encrypted address field should not be sortable",
 			dto: &FindAllGuestRequestDTO{
 				Keyword: "",
 				Sorts:   "address.ASC",
@@ -425,16 +572,21 @@ func TestFindAllGuestRequestDTO_ToFilterAndSorts(t *testing.T) {
 				Skip:    0,
 			},
 			validate: func(t *testing.T, filter *goqube.Filter, sorts []goqube.Sort, err error) {
-				assert.NoError(t, err)
-
-				assert.Len(t, sorts, 1)
-
-				if len(sorts) > 0 {
-					sort := sorts[0]
-					assert.Equal(t, entities.GuestEntityDatabaseFieldAddress, sort.Field.Column)
-
-					assert.Equal(t, goqube.SortDirectionAscending, sort.Direction)
-				}
+				assert.Error(t, err, "Expected error for sorting by encrypted address")
+				assert.Nil(t, filter, "Filter should be nil on error")
+				assert.Nil(t, sorts, "Sorts should be nil on error")
+			},
+		},
+		{
+			name: "encrypted address field without direction should not be sortable",
+			dto: &FindAllGuestRequestDTO{
+				Keyword: "",
+				Sorts:   "address",
+				Take:    10,
+				Skip:    0,
+			},
+			validate: func(t *testing.T, filter *goqube.Filter, sorts []goqube.Sort, err error) {
+				assert.Error(t, err, "Expected error for sorting by encrypted address")
 			},
 		},
 		{
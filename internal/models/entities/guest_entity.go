@@ -19,12 +19,14 @@ const (
 	GuestEntityDatabaseFieldDeletedBy string = "deleted_by"
 )
 
+const GuestEntityErasedValue string = "[erased]"
+
 type GuestEntity struct {
 	Table string `table:"guests" db:"-" json:"-"`
 
 	ID        uuid.UUID   `db:"id" json:"id" primary_key:"true" db_type:"uuid"`
 	Name      string      `db:"name" json:"name" db_type:"text"`
-	Address   null.String `db:"address" json:"address" db_type:"text"`
+	Address   null.String `db:"address" json:"address" db_type:"text" encrypted:"true"`
 	CreatedAt int64       `db:"created_at" json:"created_at" db_type:"bigint"`
 	CreatedBy string      `db:"created_by" json:"created_by" db_type:"text"`
 	UpdatedAt null.Int64  `db:"updated_at" json:"updated_at" db_type:"bigint"`
@@ -39,3 +41,19 @@ func (entity *GuestEntity) MarkAsDeleted(deletedBy string) *GuestEntity {
 
 	return entity
 }
+
+func (entity *GuestEntity) Erase(erasedBy string) *GuestEntity {
+	var now int64 = time.Now().UnixMilli()
+
+	entity.Name = GuestEntityErasedValue
+	entity.Address = null.String{}
+	entity.UpdatedAt = null.IntFrom(now)
+	entity.UpdatedBy = null.StringFrom(erasedBy)
+
+	if !entity.DeletedAt.Valid {
+		entity.DeletedAt = null.IntFrom(now)
+		entity.DeletedBy = null.StringFrom(erasedBy)
+	}
+
+	return entity
+}
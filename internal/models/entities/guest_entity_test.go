@@ -185,3 +185,63 @@ func TestGuestEntity_MarkAsDeleted(t *testing.T) {
 		})
 	}
 }
+
+func TestGuestEntity_Erase(t *testing.T) {
+	tests := []struct {
+		name     string
+		entity   *GuestEntity
+		erasedBy string
+		validate func(t *testing.T, result *GuestEntity)
+	}{
+		{
+			name: "erase active guest anonymises personal data and marks it as deleted",
+			entity: &GuestEntity{
+				ID:        uuid.Must(uuid.NewV4()),
+				Name:      "John Doe",
+				Address:   null.StringFrom("123 Main St"),
+				CreatedAt: time.Now().UnixMilli(),
+				CreatedBy: "admin",
+			},
+			erasedBy: "dpo",
+			validate: func(t *testing.T, result *GuestEntity) {
+				assert.Equal(t, GuestEntityErasedValue, result.Name)
+				assert.False(t, result.Address.Valid)
+				assert.True(t, result.UpdatedAt.Valid)
+				assert.Equal(t, "dpo", result.UpdatedBy.String)
+				assert.True(t, result.DeletedAt.Valid)
+				assert.Equal(t, "dpo", result.DeletedBy.String)
+				assert.Equal(t, result.UpdatedAt.Int64, result.DeletedAt.Int64)
+				assert.Equal(t, "admin", result.CreatedBy)
+			},
+		},
+		{
+			name: "erase already deleted guest keeps original deletion audit",
+			entity: &GuestEntity{
+				ID:        uuid.Must(uuid.NewV4()),
+				Name:      "Jane Smith",
+				Address:   null.StringFrom("456 Oak Ave"),
+				CreatedAt: time.Now().Add(-48 * time.Hour).UnixMilli(),
+				CreatedBy: "admin",
+				DeletedAt: null.IntFrom(1000),
+				DeletedBy: null.StringFrom("admin"),
+			},
+			erasedBy: "dpo",
+			validate: func(t *testing.T, result *GuestEntity) {
+				assert.Equal(t, GuestEntityErasedValue, result.Name)
+				assert.False(t, result.Address.Valid)
+				assert.Equal(t, "dpo", result.UpdatedBy.String)
+				assert.Equal(t, int64(1000), result.DeletedAt.Int64)
+				assert.Equal(t, "admin", result.DeletedBy.String)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.entity.Erase(tt.erasedBy)
+
+			assert.Same(t, tt.entity, result)
+			tt.validate(t, result)
+		})
+	}
+}
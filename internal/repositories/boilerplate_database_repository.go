@@ -5,15 +5,19 @@ import (
 	"database/sql"
 	"fmt"
 	"go-boilerplate/datasources/boilerplate_database"
+	"go-boilerplate/pkg/encryption"
+	"go-boilerplate/pkg/metrics"
 	"go-boilerplate/pkg/tracer"
 	"net/http"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/fikri240794/gocerr"
 	"github.com/fikri240794/goqube"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -58,6 +62,7 @@ func (r *boilerplateDatabaseStatement) Exec(ctx context.Context, args ...interfa
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[boilerplateDatabaseStatement][Exec][ExecContext] failed to exec statement")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -84,6 +89,7 @@ func (r *boilerplateDatabaseStatement) Get(ctx context.Context, dest interface{}
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[boilerplateDatabaseStatement][Get][GetContext] failed to get with statement")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -110,6 +116,7 @@ func (r *boilerplateDatabaseStatement) Select(ctx context.Context, dest interfac
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[boilerplateDatabaseStatement][Select][SelectContext] failed to select with statement")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -177,6 +184,7 @@ func (r *boilerplateDatabaseTransaction) Prepare(ctx context.Context, query stri
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[boilerplateDatabaseTransaction][Prepare][PreparexContext] failed to prepare statement")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -289,13 +297,15 @@ type entityMeta struct {
 	FieldValueMap map[string]interface{}
 }
 
-func (r *BoilerplateDatabaseRepository[TEntity]) getEntityMeta(entity *TEntity) entityMeta {
+func (r *BoilerplateDatabaseRepository[TEntity]) getEntityMeta(entity *TEntity) (entityMeta, error) {
 	var (
-		entityType reflect.Type
-		field      reflect.StructField
-		tagValue   string
-		dbTag      string
-		meta       entityMeta
+		entityType      reflect.Type
+		field           reflect.StructField
+		tagValue        string
+		dbTag           string
+		encryptedEntity TEntity
+		meta            entityMeta
+		err             error
 	)
 
 	meta = entityMeta{
@@ -305,6 +315,13 @@ func (r *BoilerplateDatabaseRepository[TEntity]) getEntityMeta(entity *TEntity)
 
 	entityType = reflect.TypeOf(entity).Elem()
 
+	// the copy is encrypted, the caller keeps the plain values
+	encryptedEntity = *entity
+	err = encryption.EncryptFields(&encryptedEntity)
+	if err != nil {
+		return entityMeta{}, err
+	}
+
 	for i := range entityType.NumField() {
 		field = entityType.Field(i)
 
@@ -319,7 +336,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) getEntityMeta(entity *TEntity)
 			continue
 		}
 
-		meta.FieldValueMap[dbTag] = reflect.ValueOf(entity).Elem().Field(i).Interface()
+		meta.FieldValueMap[dbTag] = reflect.ValueOf(&encryptedEntity).Elem().Field(i).Interface()
 
 		tagValue = field.Tag.Get("db_type")
 		if tagValue != "" {
@@ -332,7 +349,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) getEntityMeta(entity *TEntity)
 		}
 	}
 
-	return meta
+	return meta, nil
 }
 
 func (r *BoilerplateDatabaseRepository[TEntity]) prepareQueryStatement(
@@ -405,6 +422,31 @@ func (r *BoilerplateDatabaseRepository[TEntity]) logSlowQuery(
 	}
 }
 
+func (r *BoilerplateDatabaseRepository[TEntity]) observeQueryDuration(operation string, duration time.Duration) {
+	var tableName string
+
+	tableName, _ = r.getTableNameAndFields()
+	metrics.ObserveDatabaseQuery(tableName, operation, duration)
+}
+
+// setQueryAttributes only records the statement with its placeholders, the
+// args are never attached to the span.
+func (r *BoilerplateDatabaseRepository[TEntity]) setQueryAttributes(span trace.Span, query string) {
+	var (
+		tableName string
+		operation string
+	)
+
+	tableName, _ = r.getTableNameAndFields()
+	operation, _, _ = strings.Cut(strings.TrimSpace(query), " ")
+
+	span.SetAttributes(
+		semconv.DBSQLTable(tableName),
+		semconv.DBOperation(strings.ToUpper(operation)),
+		semconv.DBStatement(query),
+	)
+}
+
 func (r *BoilerplateDatabaseRepository[TEntity]) exec(ctx context.Context, query string, args ...interface{}) error {
 	var (
 		span               trace.Span
@@ -419,6 +461,8 @@ func (r *BoilerplateDatabaseRepository[TEntity]) exec(ctx context.Context, query
 	ctx, span = tracer.Start(ctx, "[BoilerplateDatabaseRepository][exec]")
 	defer span.End()
 
+	r.setQueryAttributes(span, query)
+
 	logFields = map[string]interface{}{
 		"query": query,
 		"args":  args,
@@ -426,6 +470,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) exec(ctx context.Context, query
 
 	stmt, err = r.prepareQueryStatement(ctx, logFields, query, true, "exec")
 	if err != nil {
+		tracer.RecordError(span, err)
 		return err
 	}
 	defer func() {
@@ -447,11 +492,13 @@ func (r *BoilerplateDatabaseRepository[TEntity]) exec(ctx context.Context, query
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][exec][Exec] failed to exec statement")
 		err = gocerr.New(http.StatusInternalServerError, "error")
+		tracer.RecordError(span, err)
 		return err
 	}
 
 	queryExecEndTime = time.Now()
 	queryExecDuration = queryExecEndTime.Sub(queryExecStartTime)
+	r.observeQueryDuration("exec", queryExecDuration)
 	logFields["duration"] = fmt.Sprintf("%.3f ms", (float64(queryExecDuration) / float64(time.Millisecond)))
 
 	if queryExecDuration > r.db.MasterMaxQueryDurationWarning {
@@ -489,6 +536,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) BeginTransaction(ctx context.Co
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][BeginTransaction][BeginTxx] failed to begin transaction")
 		err = gocerr.New(http.StatusInternalServerError, "error")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -551,13 +599,16 @@ func (r *BoilerplateDatabaseRepository[TEntity]) Count(
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][Count][ToSQLWithArgsWithAlias] failed to build select query")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return 0, err
 	}
 	logFields["query"] = query
 	logFields["args"] = args
+	r.setQueryAttributes(span, query)
 
 	stmt, err = r.prepareQueryStatement(ctx, logFields, query, useMaster, "Count")
 	if err != nil {
+		tracer.RecordError(span, err)
 		return 0, err
 	}
 	defer func() {
@@ -584,12 +635,14 @@ func (r *BoilerplateDatabaseRepository[TEntity]) Count(
 			err = gocerr.New(http.StatusInternalServerError, "error")
 		}
 
+		tracer.RecordError(span, err)
 		return 0, err
 	}
 
 	queryExecEndTime = time.Now()
 	queryExecDuration = queryExecEndTime.Sub(queryExecStartTime)
 	r.logSlowQuery(logFields, queryExecDuration, r.db.SlaveMaxQueryDurationWarning, "Count")
+	r.observeQueryDuration("Count", queryExecDuration)
 
 	return count, nil
 }
@@ -617,7 +670,16 @@ func (r *BoilerplateDatabaseRepository[TEntity]) Create(ctx context.Context, ent
 		"entity": entity,
 	}
 
-	meta = r.getEntityMeta(entity)
+	meta, err = r.getEntityMeta(entity)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[BoilerplateDatabaseRepository][Create][getEntityMeta] failed to get entity meta")
+		err = gocerr.New(http.StatusInternalServerError, "error")
+		tracer.RecordError(span, err)
+		return err
+	}
 
 	insertQuery = &goqube.InsertQuery{
 		Table:  meta.TableName,
@@ -635,6 +697,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) Create(ctx context.Context, ent
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][Create][ToSQLWithArgs] failed to build insert query")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 	logFields["query"] = query
@@ -646,6 +709,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) Create(ctx context.Context, ent
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][Create][exec] failed to create entity")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -687,6 +751,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) Delete(ctx context.Context, fil
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][Delete][ToSQLWithArgs] failed to build delete query")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 	logFields["query"] = query
@@ -698,6 +763,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) Delete(ctx context.Context, fil
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][Delete][exec] failed to delete entity")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -771,13 +837,16 @@ func (r *BoilerplateDatabaseRepository[TEntity]) FindAll(
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][FindAll][ToSQLWithArgsWithAlias] failed to build select query")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 	logFields["query"] = query
 	logFields["args"] = args
+	r.setQueryAttributes(span, query)
 
 	stmt, err = r.prepareQueryStatement(ctx, logFields, query, useMaster, "FindAll")
 	if err != nil {
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 	defer func() {
@@ -800,12 +869,27 @@ func (r *BoilerplateDatabaseRepository[TEntity]) FindAll(
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][FindAll][Select] failed to select entities")
 		err = gocerr.New(http.StatusInternalServerError, "error")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
 	queryExecEndTime = time.Now()
 	queryExecDuration = queryExecEndTime.Sub(queryExecStartTime)
 	r.logSlowQuery(logFields, queryExecDuration, r.db.SlaveMaxQueryDurationWarning, "FindAll")
+	r.observeQueryDuration("FindAll", queryExecDuration)
+
+	for i := range entities {
+		err = encryption.DecryptFields(&entities[i])
+		if err != nil {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[BoilerplateDatabaseRepository][FindAll][DecryptFields] failed to decrypt entity")
+			err = gocerr.New(http.StatusInternalServerError, "error")
+			tracer.RecordError(span, err)
+			return nil, err
+		}
+	}
 
 	return entities, nil
 }
@@ -874,13 +958,16 @@ func (r *BoilerplateDatabaseRepository[TEntity]) FindOne(
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][FindOne][ToSQLWithArgsWithAlias] failed to build select query")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 	logFields["query"] = query
 	logFields["args"] = args
+	r.setQueryAttributes(span, query)
 
 	stmt, err = r.prepareQueryStatement(ctx, logFields, query, useMaster, "FindOne")
 	if err != nil {
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 	defer func() {
@@ -908,12 +995,25 @@ func (r *BoilerplateDatabaseRepository[TEntity]) FindOne(
 			err = gocerr.New(http.StatusInternalServerError, "error")
 		}
 
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
 	queryExecEndTime = time.Now()
 	queryExecDuration = queryExecEndTime.Sub(queryExecStartTime)
 	r.logSlowQuery(logFields, queryExecDuration, r.db.SlaveMaxQueryDurationWarning, "FindOne")
+	r.observeQueryDuration("FindOne", queryExecDuration)
+
+	err = encryption.DecryptFields(entity)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[BoilerplateDatabaseRepository][FindOne][DecryptFields] failed to decrypt entity")
+		err = gocerr.New(http.StatusInternalServerError, "error")
+		tracer.RecordError(span, err)
+		return nil, err
+	}
 
 	return entity, nil
 }
@@ -941,7 +1041,16 @@ func (r *BoilerplateDatabaseRepository[TEntity]) Update(ctx context.Context, ent
 		"entity": entity,
 	}
 
-	meta = r.getEntityMeta(entity)
+	meta, err = r.getEntityMeta(entity)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[BoilerplateDatabaseRepository][Update][getEntityMeta] failed to get entity meta")
+		err = gocerr.New(http.StatusInternalServerError, "error")
+		tracer.RecordError(span, err)
+		return err
+	}
 
 	updateQuery = &goqube.UpdateQuery{
 		Table:       meta.TableName,
@@ -960,6 +1069,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) Update(ctx context.Context, ent
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][Update][ToSQLWithArgs] failed to build update query")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 
 	}
@@ -972,6 +1082,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) Update(ctx context.Context, ent
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][Update][exec] failed to update entity")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -1004,7 +1115,16 @@ func (r *BoilerplateDatabaseRepository[TEntity]) BulkCreate(ctx context.Context,
 
 	fieldsValues = []map[string]interface{}{}
 	for i := range entities {
-		meta = r.getEntityMeta(&entities[i])
+		meta, err = r.getEntityMeta(&entities[i])
+		if err != nil {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[BoilerplateDatabaseRepository][BulkCreate][getEntityMeta] failed to get entity meta")
+			err = gocerr.New(http.StatusInternalServerError, "error")
+			tracer.RecordError(span, err)
+			return err
+		}
 		fieldsValues = append(fieldsValues, meta.FieldValueMap)
 	}
 
@@ -1024,6 +1144,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) BulkCreate(ctx context.Context,
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][BulkCreate][ToSQLWithArgs] failed to build insert query")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 	logFields["query"] = query
@@ -1035,6 +1156,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) BulkCreate(ctx context.Context,
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][BulkCreate][exec] failed to bulk create entities")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -1066,7 +1188,16 @@ func (r *BoilerplateDatabaseRepository[TEntity]) BulkUpdate(ctx context.Context,
 
 	fieldsValues = []map[string]interface{}{}
 	for i := range entities {
-		meta = r.getEntityMeta(&entities[i])
+		meta, err = r.getEntityMeta(&entities[i])
+		if err != nil {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[BoilerplateDatabaseRepository][BulkUpdate][getEntityMeta] failed to get entity meta")
+			err = gocerr.New(http.StatusInternalServerError, "error")
+			tracer.RecordError(span, err)
+			return err
+		}
 		fieldsValues = append(fieldsValues, meta.FieldValueMap)
 	}
 
@@ -1088,6 +1219,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) BulkUpdate(ctx context.Context,
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][BulkUpdate][BuildBulkUpdateQuery] failed to build bulk update query")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 	logFields["query"] = query
@@ -1099,6 +1231,7 @@ func (r *BoilerplateDatabaseRepository[TEntity]) BulkUpdate(ctx context.Context,
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[BoilerplateDatabaseRepository][BulkUpdate][exec] failed to bulk update entities")
+		tracer.RecordError(span, err)
 		return err
 	}
 
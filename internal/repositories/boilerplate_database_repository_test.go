@@ -3,9 +3,13 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"go-boilerplate/datasources/boilerplate_database"
+	"go-boilerplate/pkg/encryption"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -13,6 +17,9 @@ import (
 	"github.com/fikri240794/goqube"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+	sdk_trace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 )
 
 type testDBEntity struct {
@@ -74,6 +81,29 @@ type testEntityWithNilPointerValues struct {
 	Name string `db:"name"`
 }
 
+type testEntityWithEncryptedField struct {
+	ID      int    `db:"id" table:"encrypted_table" primary_key:"true"`
+	Name    string `db:"name"`
+	Address string `db:"address" encrypted:"true"`
+}
+
+func setupTestKeyring(t *testing.T) {
+	keyringFilePath := filepath.Join(t.TempDir(), "keyring.json")
+	key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	err := os.WriteFile(keyringFilePath, []byte(`{"active_key_id":"test","keys":{"test":"`+key+`"}}`), 0600)
+	if err != nil {
+		t.Fatalf("failed to write keyring file: %v", err)
+	}
+
+	err = encryption.NewKeyring(&encryption.Config{Enable: true, KeyringFilePath: keyringFilePath})
+	if err != nil {
+		t.Fatalf("failed to load keyring: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = encryption.NewKeyring(nil)
+	})
+}
+
 type testEntityForExec struct {
 	ID   int    `db:"id" table:"exec_test_table"`
 	Name string `db:"name"`
@@ -939,7 +969,8 @@ func Test_BoilerplateDatabaseRepository_getEntityMeta(t *testing.T) {
 					Age:       30,
 					CreatedAt: now,
 				}
-				return repo.getEntityMeta(entity)
+				meta, _ := repo.getEntityMeta(entity)
+				return meta
 			},
 			expectedTable:  "test_values_table",
 			expectedMapLen: 4,
@@ -979,7 +1010,8 @@ func Test_BoilerplateDatabaseRepository_getEntityMeta(t *testing.T) {
 					Password: "secret123",
 					Internal: "internal_data",
 				}
-				return repo.getEntityMeta(entity)
+				meta, _ := repo.getEntityMeta(entity)
+				return meta
 			},
 			expectedTable:        "users_values",
 			expectedMapLen:       1,
@@ -1014,7 +1046,8 @@ func Test_BoilerplateDatabaseRepository_getEntityMeta(t *testing.T) {
 					Ignored1: "should_be_ignored",
 					Ignored2: "also_ignored",
 				}
-				return repo.getEntityMeta(entity)
+				meta, _ := repo.getEntityMeta(entity)
+				return meta
 			},
 			expectedTable:        "products_values",
 			expectedMapLen:       2,
@@ -1047,7 +1080,8 @@ func Test_BoilerplateDatabaseRepository_getEntityMeta(t *testing.T) {
 					ID:   0,
 					Name: "",
 				}
-				return repo.getEntityMeta(entity)
+				meta, _ := repo.getEntityMeta(entity)
+				return meta
 			},
 			expectedTable:  "nullable_table",
 			expectedMapLen: 1,
@@ -1070,6 +1104,42 @@ func Test_BoilerplateDatabaseRepository_getEntityMeta(t *testing.T) {
 	}
 }
 
+func Test_BoilerplateDatabaseRepository_setQueryAttributes(t *testing.T) {
+	tests := []struct {
+		name              string
+		query             string
+		expectedOperation string
+	}{
+		{
+			name:              "select query should set select operation",
+			query:             "SELECT id, name FROM orders WHERE id = ?",
+			expectedOperation: "SELECT",
+		},
+		{
+			name:              "lowercase query with leading spaces should set uppercase operation",
+			query:             "  insert into orders (code) values (?)",
+			expectedOperation: "INSERT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdk_trace.NewTracerProvider(sdk_trace.WithSpanProcessor(recorder))
+			repo := NewBoilerplateDatabaseRepository[testEntityWithTableTag](&boilerplate_database.BoilerplateDatabase{})
+
+			_, span := provider.Tracer("test").Start(context.Background(), "test")
+			repo.setQueryAttributes(span, tt.query)
+			span.End()
+
+			attributes := recorder.Ended()[0].Attributes()
+			assert.Contains(t, attributes, semconv.DBSQLTable("orders"))
+			assert.Contains(t, attributes, semconv.DBOperation(tt.expectedOperation))
+			assert.Contains(t, attributes, semconv.DBStatement(tt.query))
+		})
+	}
+}
+
 func Test_BoilerplateDatabaseRepository_exec(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -3178,3 +3248,126 @@ func Test_BoilerplateDatabaseRepository_BulkUpdate(t *testing.T) {
 		})
 	}
 }
+
+func Test_BoilerplateDatabaseRepository_EncryptedFields(t *testing.T) {
+	setupTestKeyring(t)
+
+	newRepo := func() (*BoilerplateDatabaseRepository[testEntityWithEncryptedField], sqlmock.Sqlmock) {
+		mockMasterDB, _, _ := sqlmock.New()
+		mockSlaveDB, mockSlave, _ := sqlmock.New()
+
+		boilerplateDB := &boilerplate_database.BoilerplateDatabase{
+			Master:                        sqlx.NewDb(mockMasterDB, "mysql"),
+			MasterMaxQueryDurationWarning: 100 * time.Millisecond,
+			Slave:                         sqlx.NewDb(mockSlaveDB, "mysql"),
+			SlaveMaxQueryDurationWarning:  100 * time.Millisecond,
+		}
+
+		return NewBoilerplateDatabaseRepository[testEntityWithEncryptedField](boilerplateDB), mockSlave
+	}
+
+	t.Run("getEntityMeta encrypts tagged fields only", func(t *testing.T) {
+		repo, _ := newRepo()
+		entity := &testEntityWithEncryptedField{Name: "John Doe", Address: "123 Main St"}
+
+		meta, err := repo.getEntityMeta(entity)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "John Doe", meta.FieldValueMap["name"])
+		assert.True(t, encryption.IsEncrypted(meta.FieldValueMap["address"].(string)))
+		assert.Equal(t, "123 Main St", entity.Address, "expected entity to be left untouched")
+	})
+
+	t.Run("FindOne decrypts tagged fields", func(t *testing.T) {
+		repo, mockSlave := newRepo()
+		ciphertext, err := encryption.Encrypt("123 Main St", encryption.Binding{Table: "encrypted_table", Column: "address", RowID: "1"})
+		assert.NoError(t, err)
+
+		mockSlave.ExpectPrepare("SELECT (.+) FROM encrypted_table").
+			WillBeClosed().
+			ExpectQuery().
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "address"}).AddRow(1, "John Doe", ciphertext))
+
+		entity, err := repo.FindOne(context.Background(), nil, nil, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "123 Main St", entity.Address)
+	})
+
+	t.Run("FindOne reads legacy plaintext rows", func(t *testing.T) {
+		repo, mockSlave := newRepo()
+
+		mockSlave.ExpectPrepare("SELECT (.+) FROM encrypted_table").
+			WillBeClosed().
+			ExpectQuery().
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "address"}).AddRow(1, "John Doe", "123 Main St"))
+
+		entity, err := repo.FindOne(context.Background(), nil, nil, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "123 Main St", entity.Address)
+	})
+
+	t.Run("FindOne fails on undecryptable value", func(t *testing.T) {
+		repo, mockSlave := newRepo()
+
+		mockSlave.ExpectPrepare("SELECT (.+) FROM encrypted_table").
+			WillBeClosed().
+			ExpectQuery().
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "address"}).AddRow(1, "John Doe", "enc:v2:test:malformed"))
+
+		entity, err := repo.FindOne(context.Background(), nil, nil, false)
+
+		assert.Error(t, err)
+		assert.Nil(t, entity)
+	})
+
+	t.Run("FindAll decrypts tagged fields", func(t *testing.T) {
+		repo, mockSlave := newRepo()
+		firstCiphertext, _ := encryption.Encrypt("123 Main St", encryption.Binding{Table: "encrypted_table", Column: "address", RowID: "1"})
+		secondCiphertext, _ := encryption.Encrypt("456 Oak Ave", encryption.Binding{Table: "encrypted_table", Column: "address", RowID: "2"})
+
+		mockSlave.ExpectPrepare("SELECT (.+) FROM encrypted_table").
+			WillBeClosed().
+			ExpectQuery().
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "address"}).
+				AddRow(1, "John Doe", firstCiphertext).
+				AddRow(2, "Jane Doe", secondCiphertext))
+
+		entities, err := repo.FindAll(context.Background(), nil, nil, 0, 0, false)
+
+		assert.NoError(t, err)
+		assert.Len(t, entities, 2)
+		assert.Equal(t, "123 Main St", entities[0].Address)
+		assert.Equal(t, "456 Oak Ave", entities[1].Address)
+	})
+
+	t.Run("FindAll fails on a value copied from another row", func(t *testing.T) {
+		repo, mockSlave := newRepo()
+		ciphertext, _ := encryption.Encrypt("123 Main St", encryption.Binding{Table: "encrypted_table", Column: "address", RowID: "1"})
+
+		mockSlave.ExpectPrepare("SELECT (.+) FROM encrypted_table").
+			WillBeClosed().
+			ExpectQuery().
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "address"}).AddRow(2, "Jane Doe", ciphertext))
+
+		entities, err := repo.FindAll(context.Background(), nil, nil, 0, 0, false)
+
+		assert.Error(t, err)
+		assert.Nil(t, entities)
+	})
+
+	t.Run("FindAll fails on undecryptable value", func(t *testing.T) {
+		repo, mockSlave := newRepo()
+
+		mockSlave.ExpectPrepare("SELECT (.+) FROM encrypted_table").
+			WillBeClosed().
+			ExpectQuery().
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "address"}).AddRow(1, "John Doe", "enc:v2:unknown:a:b"))
+
+		entities, err := repo.FindAll(context.Background(), nil, nil, 0, 0, false)
+
+		assert.Error(t, err)
+		assert.Nil(t, entities)
+	})
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/fikri240794/gocerr"
 	"github.com/goccy/go-json"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -56,6 +58,14 @@ func NewEventProducerRepository[TEntity interface{}](eventProducer *event_produc
 	}
 }
 
+func publishSpanAttributes(topic string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.MessagingSystemKey.String("nsq"),
+		semconv.MessagingDestinationName(topic),
+		semconv.MessagingOperationPublish,
+	}
+}
+
 func (r *EventProducerRepository[TEntity]) Publish(
 	ctx context.Context,
 	topic string,
@@ -68,7 +78,12 @@ func (r *EventProducerRepository[TEntity]) Publish(
 		err       error
 	)
 
-	ctx, span = tracer.Start(ctx, "[EventProducerRepository][Publish]")
+	ctx, span = tracer.Start(
+		ctx,
+		"[EventProducerRepository][Publish]",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(publishSpanAttributes(topic)...),
+	)
 	defer span.End()
 
 	ctx, message = message.InjectTracerPropagator(ctx)
@@ -85,9 +100,12 @@ func (r *EventProducerRepository[TEntity]) Publish(
 			Fields(logFields).
 			Msg("[EventProducerRepository][Publish][Marshal] failed to marshal message")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
+	span.SetAttributes(semconv.MessagingMessageBodySize(len(bMessage)))
+
 	err = r.eventProducer.NSQProducer.Publish(topic, bMessage)
 	if err != nil {
 		log.Err(err).
@@ -95,6 +113,7 @@ func (r *EventProducerRepository[TEntity]) Publish(
 			Fields(logFields).
 			Msg("[EventProducerRepository][Publish][Publish] failed to publish message")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -114,7 +133,12 @@ func (r *EventProducerRepository[TEntity]) PublishWithDelay(
 		err       error
 	)
 
-	ctx, span = tracer.Start(ctx, "[EventProducerRepository][PublishWithDelay]")
+	ctx, span = tracer.Start(
+		ctx,
+		"[EventProducerRepository][PublishWithDelay]",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(publishSpanAttributes(topic)...),
+	)
 	defer span.End()
 
 	ctx, message = message.InjectTracerPropagator(ctx)
@@ -132,9 +156,12 @@ func (r *EventProducerRepository[TEntity]) PublishWithDelay(
 			Fields(logFields).
 			Msg("[EventProducerRepository][PublishWithDelay][Marshal] failed to marshal message")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
+	span.SetAttributes(semconv.MessagingMessageBodySize(len(bMessage)))
+
 	err = r.eventProducer.NSQProducer.DeferredPublish(topic, delay, bMessage)
 	if err != nil {
 		log.Err(err).
@@ -142,6 +169,7 @@ func (r *EventProducerRepository[TEntity]) PublishWithDelay(
 			Fields(logFields).
 			Msg("[EventProducerRepository][PublishWithDelay][DeferredPublish] failed to publish message")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -160,7 +188,12 @@ func (r *EventProducerRepository[TEntity]) PublishBulk(
 		err       error
 	)
 
-	ctx, span = tracer.Start(ctx, "[EventProducerRepository][PublishBulk]")
+	ctx, span = tracer.Start(
+		ctx,
+		"[EventProducerRepository][PublishBulk]",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(publishSpanAttributes(topic)...),
+	)
 	defer span.End()
 
 	ctx, message = message.InjectTracerPropagator(ctx)
@@ -177,9 +210,12 @@ func (r *EventProducerRepository[TEntity]) PublishBulk(
 			Fields(logFields).
 			Msg("[EventProducerRepository][PublishBulk][Marshal] failed to marshal message")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
+	span.SetAttributes(semconv.MessagingMessageBodySize(len(bMessage)))
+
 	err = r.eventProducer.NSQProducer.Publish(topic, bMessage)
 	if err != nil {
 		log.Err(err).
@@ -187,6 +223,7 @@ func (r *EventProducerRepository[TEntity]) PublishBulk(
 			Fields(logFields).
 			Msg("[EventProducerRepository][PublishBulk][Publish] failed to publish message")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -206,7 +243,12 @@ func (r *EventProducerRepository[TEntity]) PublishBulkWithDelay(
 		err       error
 	)
 
-	ctx, span = tracer.Start(ctx, "[EventProducerRepository][PublishBulkWithDelay]")
+	ctx, span = tracer.Start(
+		ctx,
+		"[EventProducerRepository][PublishBulkWithDelay]",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(publishSpanAttributes(topic)...),
+	)
 	defer span.End()
 
 	ctx, message = message.InjectTracerPropagator(ctx)
@@ -224,9 +266,12 @@ func (r *EventProducerRepository[TEntity]) PublishBulkWithDelay(
 			Fields(logFields).
 			Msg("[EventProducerRepository][PublishBulkWithDelay][Marshal] failed to marshal message")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
+	span.SetAttributes(semconv.MessagingMessageBodySize(len(bMessage)))
+
 	err = r.eventProducer.NSQProducer.DeferredPublish(topic, delay, bMessage)
 	if err != nil {
 		log.Err(err).
@@ -234,6 +279,7 @@ func (r *EventProducerRepository[TEntity]) PublishBulkWithDelay(
 			Fields(logFields).
 			Msg("[EventProducerRepository][PublishBulkWithDelay][DeferredPublish] failed to publish message")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
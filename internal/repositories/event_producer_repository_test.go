@@ -11,6 +11,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 )
 
 type testEntity struct {
@@ -62,6 +64,16 @@ func Test_NewEventProducerRepository(t *testing.T) {
 	}
 }
 
+func Test_publishSpanAttributes(t *testing.T) {
+	attributes := publishSpanAttributes("test.topic")
+
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.MessagingSystemKey.String("nsq"),
+		semconv.MessagingDestinationName("test.topic"),
+		semconv.MessagingOperationPublish,
+	}, attributes)
+}
+
 func Test_EventProducerRepository_Publish(t *testing.T) {
 	tests := []struct {
 		name        string
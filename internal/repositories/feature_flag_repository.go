@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-boilerplate/configs"
+	"go-boilerplate/datasources/in_memory_database"
+	"go-boilerplate/pkg/feature_flag"
+	"go-boilerplate/pkg/tracer"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/fikri240794/gocerr"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//mockery:generate: true
+//mockery:structname: FeatureFlagRepositoryMock
+//mockery:filename: feature_flag_repository_mock.go
+//mockery:output: internal/repositories/mocks/
+type IFeatureFlagRepository interface {
+	feature_flag.Store
+}
+
+// FeatureFlagRepository keeps the feature flags in the in memory database, so
+// every instance sees the same flags.
+type FeatureFlagRepository struct {
+	cfg                        *configs.Config
+	inMemoryDatabaseRepository IInMemoryDatabaseRepository[feature_flag.Flag]
+}
+
+func NewFeatureFlagRepository(cfg *configs.Config, inMemoryDatabase *in_memory_database.InMemoryDatabase) *FeatureFlagRepository {
+	return &FeatureFlagRepository{
+		cfg:                        cfg,
+		inMemoryDatabaseRepository: NewInMemoryDatabaseRepository[feature_flag.Flag](inMemoryDatabase),
+	}
+}
+
+func (r *FeatureFlagRepository) key(name string) string {
+	return fmt.Sprintf(r.cfg.FeatureFlag.Keyf, name)
+}
+
+func (r *FeatureFlagRepository) Get(ctx context.Context, name string) (*feature_flag.Flag, error) {
+	var (
+		span trace.Span
+		flag *feature_flag.Flag
+		err  error
+	)
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagRepository][Get]")
+	defer span.End()
+
+	flag, err = r.inMemoryDatabaseRepository.Get(ctx, r.key(name))
+	if err != nil {
+		if gocerr.GetErrorCode(err) == http.StatusNotFound {
+			return nil, feature_flag.ErrFlagNotFound
+		}
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	return flag, nil
+}
+
+func (r *FeatureFlagRepository) List(ctx context.Context) ([]feature_flag.Flag, error) {
+	var (
+		span  trace.Span
+		keys  []string
+		flag  *feature_flag.Flag
+		flags []feature_flag.Flag
+		err   error
+	)
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagRepository][List]")
+	defer span.End()
+
+	keys, err = r.inMemoryDatabaseRepository.Keys(ctx, r.key("*"))
+	if err != nil {
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	flags = make([]feature_flag.Flag, 0, len(keys))
+	for i := range keys {
+		flag, err = r.inMemoryDatabaseRepository.Get(ctx, keys[i])
+		if err != nil {
+			// deleted since the keys were listed
+			if gocerr.GetErrorCode(err) == http.StatusNotFound {
+				continue
+			}
+			tracer.RecordError(span, err)
+			return nil, err
+		}
+		flags = append(flags, *flag)
+	}
+
+	slices.SortFunc(flags, func(a, b feature_flag.Flag) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return flags, nil
+}
+
+func (r *FeatureFlagRepository) Set(ctx context.Context, flag *feature_flag.Flag) error {
+	var (
+		span trace.Span
+		err  error
+	)
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagRepository][Set]")
+	defer span.End()
+
+	err = r.inMemoryDatabaseRepository.Set(ctx, r.key(flag.Name), flag, 0)
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *FeatureFlagRepository) Delete(ctx context.Context, name string) error {
+	var (
+		span trace.Span
+		err  error
+	)
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagRepository][Delete]")
+	defer span.End()
+
+	_, err = r.Get(ctx, name)
+	if err != nil {
+		if !errors.Is(err, feature_flag.ErrFlagNotFound) {
+			tracer.RecordError(span, err)
+		}
+		return err
+	}
+
+	err = r.inMemoryDatabaseRepository.Delete(ctx, r.key(name))
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
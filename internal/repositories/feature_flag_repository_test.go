@@ -0,0 +1,282 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"go-boilerplate/configs"
+	"go-boilerplate/datasources/in_memory_database"
+	in_memory_database_mocks "go-boilerplate/datasources/in_memory_database/mocks"
+	"go-boilerplate/pkg/feature_flag"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newFeatureFlagTestConfig() *configs.Config {
+	cfg := &configs.Config{}
+	cfg.FeatureFlag.Keyf = "feature_flags:%s"
+	return cfg
+}
+
+func newStringCmd(value string, err error) *redis.StringCmd {
+	cmd := redis.NewStringCmd(context.Background())
+	cmd.SetVal(value)
+	cmd.SetErr(err)
+	return cmd
+}
+
+func Test_NewFeatureFlagRepository(t *testing.T) {
+	cfg := newFeatureFlagTestConfig()
+	inMemoryDatabase := &in_memory_database.InMemoryDatabase{
+		RedisClient: in_memory_database_mocks.NewRedisClientMock(t),
+	}
+
+	repo := NewFeatureFlagRepository(cfg, inMemoryDatabase)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, cfg, repo.cfg)
+	assert.Equal(t, inMemoryDatabase, repo.inMemoryDatabaseRepository.(*InMemoryDatabaseRepository[feature_flag.Flag]).inMemoryDatabase)
+}
+
+func Test_FeatureFlagRepository_Get(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(m *in_memory_database_mocks.RedisClientMock)
+		expected      *feature_flag.Flag
+		expectedError error
+		expectedCode  int
+	}{
+		{
+			name: "existing flag",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Get", mock.Anything, "feature_flags:guest.cache").
+					Return(newStringCmd(`{"name":"guest.cache","enabled":true,"percentage":100}`, nil))
+			},
+			expected: &feature_flag.Flag{Name: "guest.cache", Enabled: true, Percentage: 100},
+		},
+		{
+			name: "missing flag returns not found",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Get", mock.Anything, "feature_flags:guest.cache").
+					Return(newStringCmd("", redis.Nil))
+			},
+			expectedError: feature_flag.ErrFlagNotFound,
+		},
+		{
+			name: "redis error is returned",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Get", mock.Anything, "feature_flags:guest.cache").
+					Return(newStringCmd("", errors.New("connection refused")))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			tt.setupMock(mockRedis)
+			repo := NewFeatureFlagRepository(newFeatureFlagTestConfig(), &in_memory_database.InMemoryDatabase{
+				RedisClient: mockRedis,
+			})
+
+			flag, err := repo.Get(context.Background(), "guest.cache")
+
+			if tt.expectedCode != 0 {
+				assert.Equal(t, tt.expectedCode, gocerr.GetErrorCode(err))
+			} else {
+				assert.Equal(t, tt.expectedError, err)
+			}
+			assert.Equal(t, tt.expected, flag)
+		})
+	}
+}
+
+func Test_FeatureFlagRepository_List(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMock    func(m *in_memory_database_mocks.RedisClientMock)
+		expected     []feature_flag.Flag
+		expectedCode int
+	}{
+		{
+			name: "flags are sorted by name",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Scan", mock.Anything, uint64(0), "feature_flags:*", scanCount).
+					Return(redis.NewScanCmdResult([]string{"feature_flags:guest.event.created", "feature_flags:guest.cache"}, 0, nil))
+				m.On("Get", mock.Anything, "feature_flags:guest.event.created").
+					Return(newStringCmd(`{"name":"guest.event.created"}`, nil))
+				m.On("Get", mock.Anything, "feature_flags:guest.cache").
+					Return(newStringCmd(`{"name":"guest.cache"}`, nil))
+			},
+			expected: []feature_flag.Flag{{Name: "guest.cache"}, {Name: "guest.event.created"}},
+		},
+		{
+			name: "flags deleted while listing are skipped",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Scan", mock.Anything, uint64(0), "feature_flags:*", scanCount).
+					Return(redis.NewScanCmdResult([]string{"feature_flags:guest.cache", "feature_flags:guest.event.created"}, 0, nil))
+				m.On("Get", mock.Anything, "feature_flags:guest.cache").
+					Return(newStringCmd("", redis.Nil))
+				m.On("Get", mock.Anything, "feature_flags:guest.event.created").
+					Return(newStringCmd(`{"name":"guest.event.created"}`, nil))
+			},
+			expected: []feature_flag.Flag{{Name: "guest.event.created"}},
+		},
+		{
+			name: "no flags",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Scan", mock.Anything, uint64(0), "feature_flags:*", scanCount).
+					Return(redis.NewScanCmdResult([]string{}, 0, nil))
+			},
+			expected: []feature_flag.Flag{},
+		},
+		{
+			name: "scan error is returned",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Scan", mock.Anything, uint64(0), "feature_flags:*", scanCount).
+					Return(redis.NewScanCmdResult(nil, 0, errors.New("connection refused")))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name: "get error is returned",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Scan", mock.Anything, uint64(0), "feature_flags:*", scanCount).
+					Return(redis.NewScanCmdResult([]string{"feature_flags:guest.cache"}, 0, nil))
+				m.On("Get", mock.Anything, "feature_flags:guest.cache").
+					Return(newStringCmd("", errors.New("connection refused")))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			tt.setupMock(mockRedis)
+			repo := NewFeatureFlagRepository(newFeatureFlagTestConfig(), &in_memory_database.InMemoryDatabase{
+				RedisClient: mockRedis,
+			})
+
+			flags, err := repo.List(context.Background())
+
+			if tt.expectedCode != 0 {
+				assert.Equal(t, tt.expectedCode, gocerr.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, flags)
+		})
+	}
+}
+
+func Test_FeatureFlagRepository_Set(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMock    func(m *in_memory_database_mocks.RedisClientMock)
+		expectedCode int
+	}{
+		{
+			name: "flag is set without expiration",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Set", mock.Anything, "feature_flags:guest.cache", `{"name":"guest.cache","enabled":true,"percentage":0}`, time.Duration(0)).
+					Return(redis.NewStatusResult("OK", nil))
+			},
+		},
+		{
+			name: "set error is returned",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Set", mock.Anything, "feature_flags:guest.cache", mock.Anything, time.Duration(0)).
+					Return(redis.NewStatusResult("", errors.New("connection refused")))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			tt.setupMock(mockRedis)
+			repo := NewFeatureFlagRepository(newFeatureFlagTestConfig(), &in_memory_database.InMemoryDatabase{
+				RedisClient: mockRedis,
+			})
+
+			err := repo.Set(context.Background(), &feature_flag.Flag{Name: "guest.cache", Enabled: true})
+
+			if tt.expectedCode != 0 {
+				assert.Equal(t, tt.expectedCode, gocerr.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_FeatureFlagRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(m *in_memory_database_mocks.RedisClientMock)
+		expectedError error
+		expectedCode  int
+	}{
+		{
+			name: "existing flag is deleted",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Get", mock.Anything, "feature_flags:guest.cache").
+					Return(newStringCmd(`{"name":"guest.cache"}`, nil))
+				m.On("Del", mock.Anything, []string{"feature_flags:guest.cache"}).
+					Return(redis.NewIntResult(1, nil))
+			},
+		},
+		{
+			name: "missing flag returns not found",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Get", mock.Anything, "feature_flags:guest.cache").
+					Return(newStringCmd("", redis.Nil))
+			},
+			expectedError: feature_flag.ErrFlagNotFound,
+		},
+		{
+			name: "get error is returned",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Get", mock.Anything, "feature_flags:guest.cache").
+					Return(newStringCmd("", errors.New("connection refused")))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name: "delete error is returned",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Get", mock.Anything, "feature_flags:guest.cache").
+					Return(newStringCmd(`{"name":"guest.cache"}`, nil))
+				m.On("Del", mock.Anything, []string{"feature_flags:guest.cache"}).
+					Return(redis.NewIntResult(0, errors.New("connection refused")))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			tt.setupMock(mockRedis)
+			repo := NewFeatureFlagRepository(newFeatureFlagTestConfig(), &in_memory_database.InMemoryDatabase{
+				RedisClient: mockRedis,
+			})
+
+			err := repo.Delete(context.Background(), "guest.cache")
+
+			if tt.expectedCode != 0 {
+				assert.Equal(t, tt.expectedCode, gocerr.GetErrorCode(err))
+			} else {
+				assert.Equal(t, tt.expectedError, err)
+			}
+		})
+	}
+}
@@ -1,10 +1,19 @@
 package repositories
 
 import (
+	"go-boilerplate/configs"
 	"go-boilerplate/datasources/in_memory_database"
 	"go-boilerplate/internal/models/entities"
+	"go-boilerplate/pkg/codec"
+	"go-boilerplate/pkg/protobuf_boilerplate"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
 )
 
+// guestL1CacheName labels the GUEST.CACHE.L1 hit and miss metrics
+const guestL1CacheName string = "guest_l1"
+
 //mockery:generate: true
 //mockery:structname: GuestCacheRepositoryMock
 //mockery:filename: guest_cache_repository_mock.go
@@ -14,13 +23,100 @@ type IGuestCacheRepository interface {
 }
 
 type GuestCacheRepository struct {
-	InMemoryDatabaseRepository[entities.GuestEntity]
+	IInMemoryDatabaseRepository[entities.GuestEntity]
+}
+
+// guestEntityToRecordVM and guestEntityFromRecordVM back the protobuf codec,
+// GuestRecordVM has no nulls, so empty values read back as null.
+func guestEntityToRecordVM(entity *entities.GuestEntity) *protobuf_boilerplate.GuestRecordVM {
+	return &protobuf_boilerplate.GuestRecordVM{
+		Id:        entity.ID.String(),
+		Name:      entity.Name,
+		Address:   entity.Address.String,
+		CreatedAt: entity.CreatedAt,
+		CreatedBy: entity.CreatedBy,
+		UpdatedAt: entity.UpdatedAt.Int64,
+		UpdatedBy: entity.UpdatedBy.String,
+		DeletedAt: entity.DeletedAt.Int64,
+		DeletedBy: entity.DeletedBy.String,
+	}
+}
+
+func guestEntityFromRecordVM(vm *protobuf_boilerplate.GuestRecordVM) (entities.GuestEntity, error) {
+	var (
+		id  uuid.UUID
+		err error
+	)
+
+	id, err = uuid.FromString(vm.GetId())
+	if err != nil {
+		return entities.GuestEntity{}, err
+	}
+
+	return entities.GuestEntity{
+		ID:        id,
+		Name:      vm.GetName(),
+		Address:   null.NewString(vm.GetAddress(), vm.GetAddress() != ""),
+		CreatedAt: vm.GetCreatedAt(),
+		CreatedBy: vm.GetCreatedBy(),
+		UpdatedAt: null.NewInt(vm.GetUpdatedAt(), vm.GetUpdatedAt() != 0),
+		UpdatedBy: null.NewString(vm.GetUpdatedBy(), vm.GetUpdatedBy() != ""),
+		DeletedAt: null.NewInt(vm.GetDeletedAt(), vm.GetDeletedAt() != 0),
+		DeletedBy: null.NewString(vm.GetDeletedBy(), vm.GetDeletedBy() != ""),
+	}, nil
+}
+
+// newGuestCacheSerializer always reads protobuf, so GUEST.CACHE.CODEC can be
+// switched away from it while protobuf values are still cached.
+func newGuestCacheSerializer(cfg *configs.Config) *codec.Serializer {
+	var (
+		protobufCodec codec.Codec = codec.NewProtobuf(
+			guestEntityToRecordVM,
+			guestEntityFromRecordVM,
+			func() *protobuf_boilerplate.GuestRecordVM {
+				return &protobuf_boilerplate.GuestRecordVM{}
+			},
+		)
+		serializerCfg *codec.Config = &codec.Config{
+			Codec:                codec.JSON,
+			CompressionThreshold: cfg.Guest.Cache.Compression.Threshold,
+		}
+	)
+
+	switch cfg.Guest.Cache.Codec {
+	case codec.NameMessagePack:
+		serializerCfg.Codec = codec.MessagePack
+	case codec.NameProtobuf:
+		serializerCfg.Codec = protobufCodec
+	}
+
+	// the algorithm is validated with the config
+	serializerCfg.Compression, _ = codec.ParseCompression(cfg.Guest.Cache.Compression.Algorithm)
+
+	return codec.NewSerializer(serializerCfg, protobufCodec)
 }
 
-func NewGuestCacheRepository(inMemoryDatabase *in_memory_database.InMemoryDatabase) *GuestCacheRepository {
+func NewGuestCacheRepository(cfg *configs.Config, inMemoryDatabase *in_memory_database.InMemoryDatabase) *GuestCacheRepository {
+	var repository IInMemoryDatabaseRepository[entities.GuestEntity] = NewInMemoryDatabaseRepositoryWithSerializer[entities.GuestEntity](
+		inMemoryDatabase,
+		newGuestCacheSerializer(cfg),
+	)
+
+	if cfg.Guest.Cache.L1.Enable {
+		repository = NewTieredInMemoryDatabaseRepository(
+			repository,
+			inMemoryDatabase,
+			&TieredCacheConfig{
+				Name:     guestL1CacheName,
+				Capacity: cfg.Guest.Cache.L1.Capacity,
+				TTL:      cfg.Guest.Cache.L1.Duration,
+				Channel:  cfg.Guest.Cache.L1.Channel,
+				Stats:    cfg.Guest.Cache.L1.Stats,
+			},
+		)
+	}
+
 	return &GuestCacheRepository{
-		InMemoryDatabaseRepository: InMemoryDatabaseRepository[entities.GuestEntity]{
-			inMemoryDatabase: inMemoryDatabase,
-		},
+		IInMemoryDatabaseRepository: repository,
 	}
 }
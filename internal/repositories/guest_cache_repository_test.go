@@ -1,43 +1,159 @@
 package repositories
 
 import (
+	"go-boilerplate/configs"
 	"go-boilerplate/datasources/in_memory_database"
 	in_memory_database_mocks "go-boilerplate/datasources/in_memory_database/mocks"
+	"go-boilerplate/internal/models/entities"
+	"go-boilerplate/pkg/codec"
+	"go-boilerplate/pkg/protobuf_boilerplate"
 	"testing"
+	"time"
 
+	"github.com/gofrs/uuid/v5"
+	"github.com/guregu/null/v5"
 	"github.com/stretchr/testify/assert"
 )
 
 func Test_NewGuestCacheRepository(t *testing.T) {
 	tests := []struct {
 		name             string
+		setupConfig      func(cfg *configs.Config)
 		inMemoryDatabase *in_memory_database.InMemoryDatabase
-		expectNil        bool
+		validate         func(t *testing.T, repo *GuestCacheRepository, inMemoryDatabase *in_memory_database.InMemoryDatabase)
 	}{
 		{
-			name: "create guest cache repository with in memory database",
+			name:        "create guest cache repository with in memory database",
+			setupConfig: func(cfg *configs.Config) {},
 			inMemoryDatabase: &in_memory_database.InMemoryDatabase{
 				RedisClient: in_memory_database_mocks.NewRedisClientMock(t),
 			},
-			expectNil: false,
+			validate: func(t *testing.T, repo *GuestCacheRepository, inMemoryDatabase *in_memory_database.InMemoryDatabase) {
+				redisRepo, ok := repo.IInMemoryDatabaseRepository.(*InMemoryDatabaseRepository[entities.GuestEntity])
+				assert.True(t, ok, "NewGuestCacheRepository() expected the redis repository")
+				assert.Equal(t, inMemoryDatabase, redisRepo.inMemoryDatabase, "NewGuestCacheRepository() inMemoryDatabase mismatch")
+			},
 		},
 		{
 			name:             "create guest cache repository without in memory database",
+			setupConfig:      func(cfg *configs.Config) {},
 			inMemoryDatabase: nil,
-			expectNil:        false,
+			validate: func(t *testing.T, repo *GuestCacheRepository, inMemoryDatabase *in_memory_database.InMemoryDatabase) {
+				redisRepo, ok := repo.IInMemoryDatabaseRepository.(*InMemoryDatabaseRepository[entities.GuestEntity])
+				assert.True(t, ok, "NewGuestCacheRepository() expected the redis repository")
+				assert.Nil(t, redisRepo.inMemoryDatabase, "NewGuestCacheRepository() inMemoryDatabase mismatch")
+			},
+		},
+		{
+			name: "create tiered guest cache repository when l1 is enabled",
+			setupConfig: func(cfg *configs.Config) {
+				cfg.Guest.Cache.L1.Enable = true
+				cfg.Guest.Cache.L1.Capacity = 100
+				cfg.Guest.Cache.L1.Duration = 30 * time.Second
+				cfg.Guest.Cache.L1.Channel = "guest:invalidations"
+			},
+			inMemoryDatabase: &in_memory_database.InMemoryDatabase{
+				RedisClient: in_memory_database_mocks.NewRedisClientMock(t),
+			},
+			validate: func(t *testing.T, repo *GuestCacheRepository, inMemoryDatabase *in_memory_database.InMemoryDatabase) {
+				tieredRepo, ok := repo.IInMemoryDatabaseRepository.(*TieredInMemoryDatabaseRepository[entities.GuestEntity])
+				assert.True(t, ok, "NewGuestCacheRepository() expected the tiered repository")
+				assert.Equal(t, guestL1CacheName, tieredRepo.cfg.Name)
+				assert.Equal(t, "guest:invalidations", tieredRepo.cfg.Channel)
+				assert.Nil(t, tieredRepo.pubSub, "NewGuestCacheRepository() expected no pub/sub without a pub/sub client")
+			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			repo := NewGuestCacheRepository(tt.inMemoryDatabase)
-
-			if tt.expectNil {
-				assert.Nil(t, repo, "NewGuestCacheRepository() expected nil, got %v", repo)
-			} else {
-				assert.NotNil(t, repo, "NewGuestCacheRepository() expected non-nil repository, got nil")
-				assert.Equal(t, tt.inMemoryDatabase, repo.inMemoryDatabase, "NewGuestCacheRepository() inMemoryDatabase mismatch")
-			}
+			cfg := &configs.Config{}
+			tt.setupConfig(cfg)
+
+			repo := NewGuestCacheRepository(cfg, tt.inMemoryDatabase)
+
+			assert.NotNil(t, repo, "NewGuestCacheRepository() expected non-nil repository, got nil")
+			tt.validate(t, repo, tt.inMemoryDatabase)
 		})
 	}
 }
+
+func Test_newGuestCacheSerializer(t *testing.T) {
+	guest := entities.GuestEntity{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "John",
+		Address:   null.StringFrom("Street"),
+		CreatedAt: 1,
+		CreatedBy: "system",
+		UpdatedAt: null.IntFrom(2),
+		UpdatedBy: null.StringFrom("admin"),
+	}
+	guests := []entities.GuestEntity{guest, {ID: uuid.Must(uuid.NewV7()), Name: "Jane"}}
+
+	tests := []struct {
+		name                string
+		codec               string
+		compression         string
+		expectedCodec       codec.ID
+		expectedCompression codec.Compression
+	}{
+		{
+			name:          "default codec is json",
+			expectedCodec: codec.IDJSON,
+		},
+		{
+			name:                "message pack with zstd",
+			codec:               codec.NameMessagePack,
+			compression:         "zstd",
+			expectedCodec:       codec.IDMessagePack,
+			expectedCompression: codec.CompressionZstd,
+		},
+		{
+			name:                "protobuf with snappy",
+			codec:               codec.NameProtobuf,
+			compression:         "snappy",
+			expectedCodec:       codec.IDProtobuf,
+			expectedCompression: codec.CompressionSnappy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Guest.Cache.Codec = tt.codec
+			cfg.Guest.Cache.Compression.Algorithm = tt.compression
+
+			serializer := newGuestCacheSerializer(cfg)
+
+			payload, err := serializer.Marshal(&guest)
+			assert.NoError(t, err)
+			assert.Equal(t, byte(tt.expectedCodec), payload[2], "codec mismatch")
+			assert.Equal(t, byte(tt.expectedCompression), payload[3], "compression mismatch")
+
+			decoded := &entities.GuestEntity{}
+			assert.NoError(t, serializer.Unmarshal(payload, decoded))
+			assert.Equal(t, &guest, decoded)
+
+			payload, err = serializer.Marshal(guests)
+			assert.NoError(t, err)
+			decodedList := []entities.GuestEntity{}
+			assert.NoError(t, serializer.Unmarshal(payload, &decodedList))
+			assert.Equal(t, guests, decodedList)
+
+			// switching the codec keeps the protobuf values readable
+			protobufCfg := &configs.Config{}
+			protobufCfg.Guest.Cache.Codec = codec.NameProtobuf
+			payload, err = newGuestCacheSerializer(protobufCfg).Marshal(&guest)
+			assert.NoError(t, err)
+			decoded = &entities.GuestEntity{}
+			assert.NoError(t, serializer.Unmarshal(payload, decoded))
+			assert.Equal(t, &guest, decoded)
+		})
+	}
+}
+
+func Test_guestEntityFromRecordVM(t *testing.T) {
+	_, err := guestEntityFromRecordVM(&protobuf_boilerplate.GuestRecordVM{Id: "invalid"})
+
+	assert.Error(t, err, "guestEntityFromRecordVM() expected error for an invalid id")
+}
@@ -2,16 +2,22 @@ package repositories
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"go-boilerplate/datasources/in_memory_database"
+	"go-boilerplate/pkg/codec"
+	"go-boilerplate/pkg/encryption"
 	"go-boilerplate/pkg/tracer"
 	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fikri240794/gocerr"
 	"github.com/goccy/go-json"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -21,19 +27,45 @@ import (
 //mockery:output: internal/repositories/mocks/
 type IInMemoryDatabaseRepository[TEntity interface{}] interface {
 	Delete(ctx context.Context, keys ...string) error
+	DeleteByPattern(ctx context.Context, pattern string) (uint64, error)
 	Get(ctx context.Context, key string) (*TEntity, error)
 	GetList(ctx context.Context, key string) ([]TEntity, error)
 	GetCount(ctx context.Context, key string) (uint64, error)
 	Keys(ctx context.Context, pattern string) ([]string, error)
-	Lock(ctx context.Context, key string, expiration time.Duration) error
+	MemoryUsageByPattern(ctx context.Context, pattern string, fn func(key string, bytes int64)) error
 	Set(ctx context.Context, key string, value *TEntity, expiration time.Duration) error
 	SetList(ctx context.Context, key string, values []TEntity, expiration time.Duration) error
 	SetCount(ctx context.Context, key string, value uint64, expiration time.Duration) error
-	Unlock(ctx context.Context, key string) error
+	SetCountIfNotExists(ctx context.Context, key string, value uint64, expiration time.Duration) (bool, error)
+	SetTombstone(ctx context.Context, key string, expiration time.Duration) error
+	TTL(ctx context.Context, key string) (time.Duration, error)
+}
+
+// scanCount is the number of keys DeleteByPattern asks for per SCAN call
+const scanCount int64 = 100
+
+// tombstoneValue is neither JSON nor a serializer payload, so it never
+// collides with a cached value
+const tombstoneValue string = "tombstone"
+
+// ErrTombstone is returned by Get for a key marked with SetTombstone, it is a
+// not found that is already known. gocerr errors are not comparable, match it
+// with IsTombstone.
+var ErrTombstone error = gocerr.New(http.StatusNotFound, tombstoneValue)
+
+func IsTombstone(err error) bool {
+	var gocerrError gocerr.Error
+
+	return errors.As(err, &gocerrError) &&
+		gocerrError.Code == http.StatusNotFound &&
+		gocerrError.Message == tombstoneValue
 }
 
 type InMemoryDatabaseRepository[TEntity interface{}] struct {
 	inMemoryDatabase *in_memory_database.InMemoryDatabase
+	// serializer is nil for plain JSON without a header, which keeps the
+	// values readable and writable by hand
+	serializer *codec.Serializer
 }
 
 func NewInMemoryDatabaseRepository[TEntity interface{}](inMemoryDatabase *in_memory_database.InMemoryDatabase) *InMemoryDatabaseRepository[TEntity] {
@@ -42,6 +74,41 @@ func NewInMemoryDatabaseRepository[TEntity interface{}](inMemoryDatabase *in_mem
 	}
 }
 
+func NewInMemoryDatabaseRepositoryWithSerializer[TEntity interface{}](
+	inMemoryDatabase *in_memory_database.InMemoryDatabase,
+	serializer *codec.Serializer,
+) *InMemoryDatabaseRepository[TEntity] {
+	return &InMemoryDatabaseRepository[TEntity]{
+		inMemoryDatabase: inMemoryDatabase,
+		serializer:       serializer,
+	}
+}
+
+func (r *InMemoryDatabaseRepository[TEntity]) marshal(value interface{}) ([]byte, error) {
+	if r.serializer == nil {
+		return json.Marshal(value)
+	}
+
+	return r.serializer.Marshal(value)
+}
+
+func (r *InMemoryDatabaseRepository[TEntity]) unmarshal(data []byte, value interface{}) error {
+	if r.serializer == nil {
+		return json.Unmarshal(data, value)
+	}
+
+	return r.serializer.Unmarshal(data, value)
+}
+
+// commandSpanAttributes leaves out the keys and values, they may hold guest
+// data.
+func commandSpanAttributes(operation string) trace.SpanStartOption {
+	return trace.WithAttributes(
+		semconv.DBSystemRedis,
+		semconv.DBOperation(operation),
+	)
+}
+
 func (r *InMemoryDatabaseRepository[TEntity]) Delete(ctx context.Context, keys ...string) error {
 	var (
 		span      trace.Span
@@ -49,27 +116,116 @@ func (r *InMemoryDatabaseRepository[TEntity]) Delete(ctx context.Context, keys .
 		err       error
 	)
 
-	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][Delete]")
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][Delete]", commandSpanAttributes("DEL"))
 	defer span.End()
 
 	logFields = map[string]interface{}{
 		"keys": keys,
 	}
 
-	_, err = r.inMemoryDatabase.RedisClient.Del(ctx, keys...).
-		Result()
+	_, err = r.del(ctx, r.inMemoryDatabase.RedisClient, keys)
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
-			Msg("[InMemoryDatabaseRepository][Delete][Del][Result] failed to delete")
+			Msg("[InMemoryDatabaseRepository][Delete][del] failed to delete")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
 	return nil
 }
 
+// del deletes the keys one at a time on a sharded client, where a DEL of many
+// keys fails unless they all hash to the same slot.
+func (r *InMemoryDatabaseRepository[TEntity]) del(ctx context.Context, client in_memory_database.IRedisClient, keys []string) (int64, error) {
+	var (
+		deleted int64
+		total   int64
+		err     error
+	)
+
+	if !r.inMemoryDatabase.IsSharded() {
+		return client.Del(ctx, keys...).
+			Result()
+	}
+
+	for _, key := range keys {
+		deleted, err = client.Del(ctx, key).
+			Result()
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+	}
+
+	return total, nil
+}
+
+// DeleteByPattern walks the keyspace of every shard with SCAN and deletes the
+// matching keys a batch at a time, it is meant for admin flushes and not for
+// the request path.
+func (r *InMemoryDatabaseRepository[TEntity]) DeleteByPattern(ctx context.Context, pattern string) (uint64, error) {
+	var (
+		span      trace.Span
+		logFields map[string]interface{}
+		total     atomic.Uint64
+		err       error
+	)
+
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][DeleteByPattern]", commandSpanAttributes("SCAN"))
+	defer span.End()
+
+	logFields = map[string]interface{}{
+		"pattern": pattern,
+	}
+
+	err = r.inMemoryDatabase.ForEachShard(ctx, func(ctx context.Context, shard in_memory_database.IRedisClient) error {
+		var (
+			cursor  uint64
+			keys    []string
+			deleted int64
+			err     error
+		)
+
+		for {
+			keys, cursor, err = shard.Scan(ctx, cursor, pattern, scanCount).
+				Result()
+			if err != nil {
+				log.Err(err).
+					Ctx(ctx).
+					Fields(logFields).
+					Msg("[InMemoryDatabaseRepository][DeleteByPattern][Scan][Result] failed to scan keys")
+				return err
+			}
+
+			if len(keys) > 0 {
+				deleted, err = r.del(ctx, shard, keys)
+				if err != nil {
+					log.Err(err).
+						Ctx(ctx).
+						Fields(logFields).
+						Msg("[InMemoryDatabaseRepository][DeleteByPattern][del] failed to delete")
+					return err
+				}
+				total.Add(uint64(deleted))
+			}
+
+			if cursor == 0 {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
+		return total.Load(), err
+	}
+
+	return total.Load(), nil
+}
+
 func (r *InMemoryDatabaseRepository[TEntity]) Get(ctx context.Context, key string) (*TEntity, error) {
 	var (
 		span      trace.Span
@@ -80,7 +236,7 @@ func (r *InMemoryDatabaseRepository[TEntity]) Get(ctx context.Context, key strin
 		err       error
 	)
 
-	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][Get]")
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][Get]", commandSpanAttributes("GET"))
 	defer span.End()
 
 	logFields = map[string]interface{}{
@@ -100,19 +256,36 @@ func (r *InMemoryDatabaseRepository[TEntity]) Get(ctx context.Context, key strin
 				Msg("[InMemoryDatabaseRepository][Get][Get][Scan] failed to get")
 		}
 		err = gocerr.New(errorCode, err.Error())
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
 	logFields["rawValue"] = rawValue
 
+	if rawValue == tombstoneValue {
+		return nil, ErrTombstone
+	}
+
 	value = new(TEntity)
-	err = json.Unmarshal([]byte(rawValue), value)
+	err = r.unmarshal([]byte(rawValue), value)
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[InMemoryDatabaseRepository][Get][Unmarshal] failed to unmarshal raw value")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	err = encryption.DecryptFields(value)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[InMemoryDatabaseRepository][Get][DecryptFields] failed to decrypt value")
+		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -129,7 +302,7 @@ func (r *InMemoryDatabaseRepository[TEntity]) GetList(ctx context.Context, key s
 		err       error
 	)
 
-	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][GetList]")
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][GetList]", commandSpanAttributes("GET"))
 	defer span.End()
 
 	logFields = map[string]interface{}{
@@ -149,21 +322,36 @@ func (r *InMemoryDatabaseRepository[TEntity]) GetList(ctx context.Context, key s
 				Msg("[InMemoryDatabaseRepository][GetList][Get][Scan] failed to get")
 		}
 		err = gocerr.New(errorCode, err.Error())
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
 	logFields["rawValues"] = rawValues
 
-	err = json.Unmarshal([]byte(rawValues), &values)
+	err = r.unmarshal([]byte(rawValues), &values)
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[InMemoryDatabaseRepository][GetList][Unmarshal] failed to unmarshal raw values")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
+	for i := range values {
+		err = encryption.DecryptFields(&values[i])
+		if err != nil {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[InMemoryDatabaseRepository][GetList][DecryptFields] failed to decrypt values")
+			err = gocerr.New(http.StatusInternalServerError, err.Error())
+			tracer.RecordError(span, err)
+			return nil, err
+		}
+	}
+
 	return values, nil
 }
 
@@ -176,7 +364,7 @@ func (r *InMemoryDatabaseRepository[TEntity]) GetCount(ctx context.Context, key
 		err       error
 	)
 
-	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][GetCount]")
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][GetCount]", commandSpanAttributes("GET"))
 	defer span.End()
 
 	logFields = map[string]interface{}{
@@ -196,88 +384,144 @@ func (r *InMemoryDatabaseRepository[TEntity]) GetCount(ctx context.Context, key
 				Msg("[InMemoryDatabaseRepository][GetCount][Get][Uint64] failed to get")
 		}
 		err = gocerr.New(errorCode, err.Error())
+		tracer.RecordError(span, err)
 		return 0, err
 	}
 
 	return value, nil
 }
 
+// Keys walks the keys matching pattern with SCAN, so listing them does not
+// block the server like KEYS.
 func (r *InMemoryDatabaseRepository[TEntity]) Keys(ctx context.Context, pattern string) ([]string, error) {
 	var (
 		span      trace.Span
 		logFields map[string]interface{}
 		keys      []string
+		mutex     sync.Mutex
 		err       error
 	)
 
-	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][Keys]")
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][Keys]", commandSpanAttributes("SCAN"))
 	defer span.End()
 
 	logFields = map[string]interface{}{
 		"pattern": pattern,
 	}
 
-	keys, err = r.inMemoryDatabase.RedisClient.Keys(ctx, pattern).
-		Result()
+	err = r.inMemoryDatabase.ForEachShard(ctx, func(ctx context.Context, shard in_memory_database.IRedisClient) error {
+		var (
+			cursor    uint64
+			shardKeys []string
+			seen      map[string]bool = map[string]bool{}
+			key       string
+			err       error
+		)
+
+		for {
+			shardKeys, cursor, err = shard.Scan(ctx, cursor, pattern, scanCount).
+				Result()
+			if err != nil {
+				return err
+			}
+
+			mutex.Lock()
+			for _, key = range shardKeys {
+				// SCAN may return a key more than once
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				keys = append(keys, key)
+			}
+			mutex.Unlock()
+
+			if cursor == 0 {
+				return nil
+			}
+		}
+	})
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
-			Msg("[InMemoryDatabaseRepository][Keys][Keys][Result] failed to get keys")
+			Msg("[InMemoryDatabaseRepository][Keys][ForEachShard] failed to get keys")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
 	return keys, nil
 }
 
-func (r *InMemoryDatabaseRepository[TEntity]) Lock(ctx context.Context, key string, expiration time.Duration) error {
+func (r *InMemoryDatabaseRepository[TEntity]) MemoryUsageByPattern(ctx context.Context, pattern string, fn func(key string, bytes int64)) error {
 	var (
 		span      trace.Span
 		logFields map[string]interface{}
-		counter   int64
 		err       error
 	)
 
-	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][Lock]")
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][MemoryUsageByPattern]", commandSpanAttributes("SCAN"))
 	defer span.End()
 
 	logFields = map[string]interface{}{
-		"key":        key,
-		"expiration": expiration,
+		"pattern": pattern,
 	}
 
-	counter, err = r.inMemoryDatabase.RedisClient.Incr(ctx, key).
-		Result()
+	err = r.inMemoryDatabase.ForEachShard(ctx, func(ctx context.Context, shard in_memory_database.IRedisClient) error {
+		var (
+			memoryClient in_memory_database.IRedisMemoryClient
+			ok           bool
+			cursor       uint64
+			keys         []string
+			key          string
+			bytes        int64
+			err          error
+		)
+
+		memoryClient, ok = shard.(in_memory_database.IRedisMemoryClient)
+
+		for {
+			keys, cursor, err = shard.Scan(ctx, cursor, pattern, scanCount).
+				Result()
+			if err != nil {
+				log.Err(err).
+					Ctx(ctx).
+					Fields(logFields).
+					Msg("[InMemoryDatabaseRepository][MemoryUsageByPattern][Scan][Result] failed to scan keys")
+				return err
+			}
+
+			for _, key = range keys {
+				bytes = 0
+
+				if ok {
+					bytes, err = memoryClient.MemoryUsage(ctx, key).
+						Result()
+					// the key expired since the scan
+					if err == redis.Nil {
+						continue
+					}
+					if err != nil {
+						log.Err(err).
+							Ctx(ctx).
+							Fields(logFields).
+							Msg("[InMemoryDatabaseRepository][MemoryUsageByPattern][MemoryUsage][Result] failed to get memory usage")
+						return err
+					}
+				}
+
+				fn(key, bytes)
+			}
+
+			if cursor == 0 {
+				return nil
+			}
+		}
+	})
 	if err != nil {
-		log.Err(err).
-			Ctx(ctx).
-			Fields(logFields).
-			Msg("[InMemoryDatabaseRepository][Lock][Incr][Result] failed to increment")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
-		return err
-	}
-	logFields["counter"] = counter
-
-	if expiration > 0 {
-		_, err = r.inMemoryDatabase.RedisClient.Expire(
-			ctx,
-			key,
-			expiration,
-		).
-			Result()
-		if err != nil {
-			log.Err(err).
-				Ctx(ctx).
-				Fields(logFields).
-				Msg("[InMemoryDatabaseRepository][Lock][Expire][Result] failed to set expire")
-			err = gocerr.New(http.StatusInternalServerError, err.Error())
-			return err
-		}
-	}
-
-	if counter > 1 {
-		err = gocerr.New(http.StatusConflict, fmt.Sprintf("%s is already locked", key))
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -286,13 +530,14 @@ func (r *InMemoryDatabaseRepository[TEntity]) Lock(ctx context.Context, key stri
 
 func (r *InMemoryDatabaseRepository[TEntity]) Set(ctx context.Context, key string, value *TEntity, expiration time.Duration) error {
 	var (
-		span      trace.Span
-		logFields map[string]interface{}
-		rawValue  []byte
-		err       error
+		span           trace.Span
+		logFields      map[string]interface{}
+		encryptedValue *TEntity
+		rawValue       []byte
+		err            error
 	)
 
-	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][Set]")
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][Set]", commandSpanAttributes("SET"))
 	defer span.End()
 
 	logFields = map[string]interface{}{
@@ -301,13 +546,31 @@ func (r *InMemoryDatabaseRepository[TEntity]) Set(ctx context.Context, key strin
 		"expiration": expiration,
 	}
 
-	rawValue, err = json.Marshal(value)
+	encryptedValue = value
+	if value != nil {
+		encryptedValue = new(TEntity)
+		*encryptedValue = *value
+
+		err = encryption.EncryptFields(encryptedValue)
+		if err != nil {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[InMemoryDatabaseRepository][Set][EncryptFields] failed to encrypt value")
+			err = gocerr.New(http.StatusInternalServerError, err.Error())
+			tracer.RecordError(span, err)
+			return err
+		}
+	}
+
+	rawValue, err = r.marshal(encryptedValue)
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[InMemoryDatabaseRepository][Set][Marshal] failed to marshal value")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -324,6 +587,7 @@ func (r *InMemoryDatabaseRepository[TEntity]) Set(ctx context.Context, key strin
 			Fields(logFields).
 			Msg("[InMemoryDatabaseRepository][Set][Set][Result] failed to set")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -332,13 +596,14 @@ func (r *InMemoryDatabaseRepository[TEntity]) Set(ctx context.Context, key strin
 
 func (r *InMemoryDatabaseRepository[TEntity]) SetList(ctx context.Context, key string, values []TEntity, expiration time.Duration) error {
 	var (
-		span      trace.Span
-		logFields map[string]interface{}
-		rawValues []byte
-		err       error
+		span            trace.Span
+		logFields       map[string]interface{}
+		encryptedValues []TEntity
+		rawValues       []byte
+		err             error
 	)
 
-	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][SetList]")
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][SetList]", commandSpanAttributes("SET"))
 	defer span.End()
 
 	logFields = map[string]interface{}{
@@ -347,13 +612,28 @@ func (r *InMemoryDatabaseRepository[TEntity]) SetList(ctx context.Context, key s
 		"expiration": expiration,
 	}
 
-	rawValues, err = json.Marshal(values)
+	encryptedValues = slices.Clone(values)
+	for i := range encryptedValues {
+		err = encryption.EncryptFields(&encryptedValues[i])
+		if err != nil {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[InMemoryDatabaseRepository][SetList][EncryptFields] failed to encrypt values")
+			err = gocerr.New(http.StatusInternalServerError, err.Error())
+			tracer.RecordError(span, err)
+			return err
+		}
+	}
+
+	rawValues, err = r.marshal(encryptedValues)
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[InMemoryDatabaseRepository][SetList][Marshal] failed to marshal value")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -370,6 +650,7 @@ func (r *InMemoryDatabaseRepository[TEntity]) SetList(ctx context.Context, key s
 			Fields(logFields).
 			Msg("[InMemoryDatabaseRepository][SetList][Set][Result] failed to set")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -383,7 +664,7 @@ func (r *InMemoryDatabaseRepository[TEntity]) SetCount(ctx context.Context, key
 		err       error
 	)
 
-	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][SetCount]")
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][SetCount]", commandSpanAttributes("SET"))
 	defer span.End()
 
 	logFields = map[string]interface{}{
@@ -405,36 +686,113 @@ func (r *InMemoryDatabaseRepository[TEntity]) SetCount(ctx context.Context, key
 			Fields(logFields).
 			Msg("[InMemoryDatabaseRepository][SetCount][Set][Result] failed to set")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
 	return nil
 }
 
-func (r *InMemoryDatabaseRepository[TEntity]) Unlock(ctx context.Context, key string) error {
+// SetCountIfNotExists sets the key only when it is missing and reports
+// whether it did.
+func (r *InMemoryDatabaseRepository[TEntity]) SetCountIfNotExists(ctx context.Context, key string, value uint64, expiration time.Duration) (bool, error) {
 	var (
 		span      trace.Span
 		logFields map[string]interface{}
+		created   bool
 		err       error
 	)
 
-	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][Unlock]")
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][SetCountIfNotExists]", commandSpanAttributes("SET"))
 	defer span.End()
 
 	logFields = map[string]interface{}{
-		"key": key,
+		"key":        key,
+		"value":      value,
+		"expiration": expiration,
 	}
 
-	_, err = r.inMemoryDatabase.RedisClient.Del(ctx, key).
+	created, err = r.inMemoryDatabase.RedisClient.SetNX(ctx, key, value, expiration).
 		Result()
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
-			Msg("[InMemoryDatabaseRepository][Unlock][Delete][Result] failed to delete")
+			Msg("[InMemoryDatabaseRepository][SetCountIfNotExists][SetNX][Result] failed to set if not exists")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
+		return false, err
+	}
+
+	return created, nil
+}
+
+// SetTombstone marks key as known to be missing, Get returns ErrTombstone
+// for it until it expires or is deleted.
+func (r *InMemoryDatabaseRepository[TEntity]) SetTombstone(ctx context.Context, key string, expiration time.Duration) error {
+	var (
+		span      trace.Span
+		logFields map[string]interface{}
+		err       error
+	)
+
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][SetTombstone]", commandSpanAttributes("SET"))
+	defer span.End()
+
+	logFields = map[string]interface{}{
+		"key":        key,
+		"expiration": expiration,
+	}
+
+	_, err = r.inMemoryDatabase.RedisClient.Set(ctx, key, tombstoneValue, expiration).
+		Result()
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[InMemoryDatabaseRepository][SetTombstone][Set][Result] failed to set tombstone")
+		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
 	return nil
 }
+
+// TTL returns the remaining time to live of key, -1 when it never expires.
+func (r *InMemoryDatabaseRepository[TEntity]) TTL(ctx context.Context, key string) (time.Duration, error) {
+	var (
+		span      trace.Span
+		logFields map[string]interface{}
+		ttl       time.Duration
+		err       error
+	)
+
+	ctx, span = tracer.Start(ctx, "[InMemoryDatabaseRepository][TTL]", commandSpanAttributes("PTTL"))
+	defer span.End()
+
+	logFields = map[string]interface{}{
+		"key": key,
+	}
+
+	ttl, err = r.inMemoryDatabase.RedisClient.PTTL(ctx, key).
+		Result()
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[InMemoryDatabaseRepository][TTL][PTTL][Result] failed to get ttl")
+		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
+		return 0, err
+	}
+
+	// PTTL replies -2 for a missing key
+	if ttl == -2 {
+		err = gocerr.New(http.StatusNotFound, redis.Nil.Error())
+		tracer.RecordError(span, err)
+		return 0, err
+	}
+
+	return ttl, nil
+}
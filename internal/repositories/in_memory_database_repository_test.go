@@ -2,11 +2,17 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"go-boilerplate/datasources/in_memory_database"
 	in_memory_database_mocks "go-boilerplate/datasources/in_memory_database/mocks"
+	"go-boilerplate/pkg/codec"
+	"go-boilerplate/pkg/encryption"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/fikri240794/gocerr"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -17,6 +23,11 @@ type testInMemoryEntity struct {
 	Name string
 }
 
+type testInMemoryEntityWithEncryptedField struct {
+	ID      int
+	Address string `encrypted:"true"`
+}
+
 type testInMemoryEntityWithChannel struct {
 	ID      int
 	Name    string
@@ -57,6 +68,102 @@ func Test_NewInMemoryDatabaseRepository(t *testing.T) {
 	}
 }
 
+// shardedRedisClient stands for a cluster client, it visits its shards in
+// order.
+type shardedRedisClient struct {
+	*in_memory_database_mocks.RedisClientMock
+	shards []*in_memory_database_mocks.RedisClientMock
+}
+
+func (c *shardedRedisClient) ForEachShard(ctx context.Context, fn func(ctx context.Context, shard in_memory_database.IRedisClient) error) error {
+	for _, shard := range c.shards {
+		if err := fn(ctx, shard); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newTestShardedRepository(t *testing.T, shards int) (*InMemoryDatabaseRepository[testInMemoryEntity], *shardedRedisClient) {
+	client := &shardedRedisClient{RedisClientMock: in_memory_database_mocks.NewRedisClientMock(t)}
+	for i := 0; i < shards; i++ {
+		client.shards = append(client.shards, in_memory_database_mocks.NewRedisClientMock(t))
+	}
+
+	return NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
+		RedisClient: client,
+	}), client
+}
+
+func Test_InMemoryDatabaseRepository_Delete_sharded(t *testing.T) {
+	repo, client := newTestShardedRepository(t, 0)
+	client.On("Del", mock.Anything, []string{"key1"}).Return(redis.NewIntResult(1, nil)).Once()
+	client.On("Del", mock.Anything, []string{"key2"}).Return(redis.NewIntResult(1, nil)).Once()
+
+	err := repo.Delete(context.Background(), "key1", "key2")
+
+	assert.NoError(t, err, "keys of different slots should be deleted one at a time")
+}
+
+func Test_InMemoryDatabaseRepository_DeleteByPattern_sharded(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(shards []*in_memory_database_mocks.RedisClientMock)
+		expected    uint64
+		expectError bool
+	}{
+		{
+			name: "delete matching keys on every shard",
+			setupMock: func(shards []*in_memory_database_mocks.RedisClientMock) {
+				shards[0].On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key1", "key2"}, 0, nil))
+				shards[0].On("Del", mock.Anything, []string{"key1"}).Return(redis.NewIntResult(1, nil))
+				shards[0].On("Del", mock.Anything, []string{"key2"}).Return(redis.NewIntResult(1, nil))
+				shards[1].On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key3"}, 0, nil))
+				shards[1].On("Del", mock.Anything, []string{"key3"}).Return(redis.NewIntResult(1, nil))
+			},
+			expected: 3,
+		},
+		{
+			name: "shard error returns deleted count so far",
+			setupMock: func(shards []*in_memory_database_mocks.RedisClientMock) {
+				shards[0].On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key1"}, 0, nil))
+				shards[0].On("Del", mock.Anything, []string{"key1"}).Return(redis.NewIntResult(1, nil))
+				shards[1].On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult(nil, 0, redis.ErrClosed))
+			},
+			expected:    1,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, client := newTestShardedRepository(t, 2)
+			tt.setupMock(client.shards)
+
+			deleted, err := repo.DeleteByPattern(context.Background(), "key*")
+
+			if tt.expectError {
+				assert.Equal(t, http.StatusInternalServerError, gocerr.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, deleted)
+		})
+	}
+}
+
+func Test_InMemoryDatabaseRepository_Keys_sharded(t *testing.T) {
+	repo, client := newTestShardedRepository(t, 2)
+	client.shards[0].On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key1"}, 0, nil))
+	client.shards[1].On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key2", "key3"}, 0, nil))
+
+	keys, err := repo.Keys(context.Background(), "key*")
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"key1", "key2", "key3"}, keys)
+}
+
 func Test_InMemoryDatabaseRepository_Delete(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -114,6 +221,179 @@ func Test_InMemoryDatabaseRepository_Delete(t *testing.T) {
 	}
 }
 
+func Test_InMemoryDatabaseRepository_DeleteByPattern(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(m *in_memory_database_mocks.RedisClientMock)
+		expected    uint64
+		expectError bool
+	}{
+		{
+			name: "delete matching keys across scan pages",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key1", "key2"}, 7, nil))
+				m.On("Del", mock.Anything, []string{"key1", "key2"}).Return(redis.NewIntResult(2, nil))
+				m.On("Scan", mock.Anything, uint64(7), "key*", scanCount).Return(redis.NewScanCmdResult([]string{}, 9, nil))
+				m.On("Scan", mock.Anything, uint64(9), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key3"}, 0, nil))
+				m.On("Del", mock.Anything, []string{"key3"}).Return(redis.NewIntResult(1, nil))
+			},
+			expected: 3,
+		},
+		{
+			name: "no matching keys",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{}, 0, nil))
+			},
+			expected: 0,
+		},
+		{
+			name: "scan error returns deleted count so far",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key1"}, 7, nil))
+				m.On("Del", mock.Anything, []string{"key1"}).Return(redis.NewIntResult(1, nil))
+				m.On("Scan", mock.Anything, uint64(7), "key*", scanCount).Return(redis.NewScanCmdResult(nil, 0, redis.TxFailedErr))
+			},
+			expected:    1,
+			expectError: true,
+		},
+		{
+			name: "delete error",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key1"}, 0, nil))
+				m.On("Del", mock.Anything, []string{"key1"}).Return(redis.NewIntResult(0, redis.TxFailedErr))
+			},
+			expected:    0,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			tt.setupMock(mockRedis)
+			repo := NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
+				RedisClient: mockRedis,
+			})
+
+			deleted, err := repo.DeleteByPattern(context.Background(), "key*")
+
+			if tt.expectError {
+				assert.Equal(t, http.StatusInternalServerError, gocerr.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, deleted)
+		})
+	}
+}
+
+func Test_InMemoryDatabaseRepository_SetCountIfNotExists(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(m *in_memory_database_mocks.RedisClientMock)
+		expected    bool
+		expectError bool
+	}{
+		{
+			name: "set missing key",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("SetNX", mock.Anything, "generation", uint64(4), time.Duration(0)).Return(redis.NewBoolResult(true, nil))
+			},
+			expected: true,
+		},
+		{
+			name: "keep existing key",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("SetNX", mock.Anything, "generation", uint64(4), time.Duration(0)).Return(redis.NewBoolResult(false, nil))
+			},
+			expected: false,
+		},
+		{
+			name: "set with redis error",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("SetNX", mock.Anything, "generation", uint64(4), time.Duration(0)).Return(redis.NewBoolResult(false, redis.TxFailedErr))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			tt.setupMock(mockRedis)
+			repo := NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
+				RedisClient: mockRedis,
+			})
+
+			created, err := repo.SetCountIfNotExists(context.Background(), "generation", 4, 0)
+
+			if tt.expectError {
+				assert.Equal(t, http.StatusInternalServerError, gocerr.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, created)
+		})
+	}
+}
+
+func Test_InMemoryDatabaseRepository_TTL(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMock    func(m *in_memory_database_mocks.RedisClientMock)
+		expected     time.Duration
+		expectedCode int
+	}{
+		{
+			name: "return remaining ttl",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("PTTL", mock.Anything, "test_key").Return(redis.NewDurationResult(90*time.Second, nil))
+			},
+			expected: 90 * time.Second,
+		},
+		{
+			name: "return -1 when key never expires",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("PTTL", mock.Anything, "test_key").Return(redis.NewDurationResult(-1, nil))
+			},
+			expected: -1,
+		},
+		{
+			name: "return not found when key is missing",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("PTTL", mock.Anything, "test_key").Return(redis.NewDurationResult(-2, nil))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "return error when pttl fails",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("PTTL", mock.Anything, "test_key").Return(redis.NewDurationResult(0, redis.TxFailedErr))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			tt.setupMock(mockRedis)
+			repo := NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
+				RedisClient: mockRedis,
+			})
+
+			ttl, err := repo.TTL(context.Background(), "test_key")
+
+			if tt.expectedCode != 0 {
+				assert.Equal(t, tt.expectedCode, gocerr.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, ttl)
+		})
+	}
+}
+
 func Test_InMemoryDatabaseRepository_Get(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -197,6 +477,23 @@ func Test_InMemoryDatabaseRepository_Get(t *testing.T) {
 				assert.Nil(t, result, "Get() expected nil result")
 			},
 		},
+		{
+			name: "get tombstone",
+			setupRepo: func(t *testing.T) *InMemoryDatabaseRepository[testInMemoryEntity] {
+				mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+				mockRedis.On("Get", mock.Anything, "tombstone_key").Return(redis.NewStringResult(tombstoneValue, nil))
+				return NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
+					RedisClient: mockRedis,
+				})
+			},
+			key:         "tombstone_key",
+			expectError: true,
+			validate: func(t *testing.T, result *testInMemoryEntity, err error) {
+				assert.True(t, IsTombstone(err), "Get() expected tombstone error, got %v", err)
+				assert.Equal(t, http.StatusNotFound, gocerr.GetErrorCode(err))
+				assert.Nil(t, result, "Get() expected nil result")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -213,6 +510,81 @@ func Test_InMemoryDatabaseRepository_Get(t *testing.T) {
 	}
 }
 
+func Test_InMemoryDatabaseRepository_SetTombstone(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(m *in_memory_database_mocks.RedisClientMock)
+		expectError bool
+	}{
+		{
+			name: "set tombstone successfully",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Set", mock.Anything, "missing_key", tombstoneValue, 10*time.Second).Return(redis.NewStatusResult("OK", nil))
+			},
+		},
+		{
+			name: "set tombstone with redis error",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Set", mock.Anything, "missing_key", tombstoneValue, 10*time.Second).Return(redis.NewStatusResult("", redis.TxFailedErr))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			tt.setupMock(mockRedis)
+			repo := NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
+				RedisClient: mockRedis,
+			})
+
+			err := repo.SetTombstone(context.Background(), "missing_key", 10*time.Second)
+
+			if tt.expectError {
+				assert.Equal(t, http.StatusInternalServerError, gocerr.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_IsTombstone(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "tombstone error",
+			err:      ErrTombstone,
+			expected: true,
+		},
+		{
+			name:     "other not found error",
+			err:      gocerr.New(http.StatusNotFound, "redis: nil"),
+			expected: false,
+		},
+		{
+			name:     "plain error",
+			err:      errors.New(tombstoneValue),
+			expected: false,
+		},
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsTombstone(tt.err))
+		})
+	}
+}
+
 func Test_InMemoryDatabaseRepository_GetList(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -405,9 +777,8 @@ func Test_InMemoryDatabaseRepository_Keys(t *testing.T) {
 			name: "get keys successfully",
 			setupRepo: func(t *testing.T) *InMemoryDatabaseRepository[testInMemoryEntity] {
 				mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
-				cmd := redis.NewStringSliceCmd(context.Background())
-				cmd.SetVal([]string{"key1", "key2", "key3"})
-				mockRedis.On("Keys", mock.Anything, "key*").Return(cmd)
+				mockRedis.On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key1", "key2"}, 7, nil))
+				mockRedis.On("Scan", mock.Anything, uint64(7), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key2", "key3"}, 0, nil))
 				return NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
 					RedisClient: mockRedis,
 				})
@@ -416,18 +787,14 @@ func Test_InMemoryDatabaseRepository_Keys(t *testing.T) {
 			expectError: false,
 			validate: func(t *testing.T, result []string, err error) {
 				assert.NoError(t, err, "Keys() unexpected error")
-				assert.NotNil(t, result, "Keys() expected non-nil result")
-				assert.Len(t, result, 3, "Keys() result length mismatch")
-				assert.Equal(t, []string{"key1", "key2", "key3"}, result, "Keys() result mismatch")
+				assert.Equal(t, []string{"key1", "key2", "key3"}, result, "Keys() should walk every page once per key")
 			},
 		},
 		{
 			name: "get keys with redis error",
 			setupRepo: func(t *testing.T) *InMemoryDatabaseRepository[testInMemoryEntity] {
 				mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
-				cmd := redis.NewStringSliceCmd(context.Background())
-				cmd.SetErr(redis.TxFailedErr)
-				mockRedis.On("Keys", mock.Anything, "error*").Return(cmd)
+				mockRedis.On("Scan", mock.Anything, uint64(0), "error*", scanCount).Return(redis.NewScanCmdResult(nil, 0, redis.TxFailedErr))
 				return NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
 					RedisClient: mockRedis,
 				})
@@ -455,126 +822,87 @@ func Test_InMemoryDatabaseRepository_Keys(t *testing.T) {
 	}
 }
 
-func Test_InMemoryDatabaseRepository_Lock(t *testing.T) {
+// memoryRedisClient stands for a client that reports the memory of a key
+type memoryRedisClient struct {
+	*in_memory_database_mocks.RedisClientMock
+	*in_memory_database_mocks.RedisMemoryClientMock
+}
+
+func Test_InMemoryDatabaseRepository_MemoryUsageByPattern(t *testing.T) {
 	tests := []struct {
 		name        string
-		setupRepo   func(t *testing.T) *InMemoryDatabaseRepository[testInMemoryEntity]
-		key         string
-		expiration  time.Duration
+		setupClient func(t *testing.T) in_memory_database.IRedisClient
+		expected    map[string]int64
 		expectError bool
-		validate    func(t *testing.T, err error)
 	}{
 		{
-			name: "lock successfully with expiration",
-			setupRepo: func(t *testing.T) *InMemoryDatabaseRepository[testInMemoryEntity] {
-				mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
-				incrCmd := redis.NewIntCmd(context.Background())
-				incrCmd.SetVal(1)
-				expireCmd := redis.NewBoolCmd(context.Background())
-				expireCmd.SetVal(true)
-				mockRedis.On("Incr", mock.Anything, "lock_key").Return(incrCmd)
-				mockRedis.On("Expire", mock.Anything, "lock_key", 5*time.Second).Return(expireCmd)
-				return NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
-					RedisClient: mockRedis,
-				})
-			},
-			key:         "lock_key",
-			expiration:  5 * time.Second,
-			expectError: false,
-			validate: func(t *testing.T, err error) {
-				assert.NoError(t, err, "Lock() unexpected error")
+			name: "report the memory of every matching key",
+			setupClient: func(t *testing.T) in_memory_database.IRedisClient {
+				client := &memoryRedisClient{
+					RedisClientMock:       in_memory_database_mocks.NewRedisClientMock(t),
+					RedisMemoryClientMock: in_memory_database_mocks.NewRedisMemoryClientMock(t),
+				}
+				client.RedisClientMock.On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key1", "key2"}, 7, nil))
+				client.RedisClientMock.On("Scan", mock.Anything, uint64(7), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key3"}, 0, nil))
+				client.RedisMemoryClientMock.On("MemoryUsage", mock.Anything, "key1", mock.Anything).Return(redis.NewIntResult(10, nil))
+				client.RedisMemoryClientMock.On("MemoryUsage", mock.Anything, "key2", mock.Anything).Return(redis.NewIntResult(0, redis.Nil))
+				client.RedisMemoryClientMock.On("MemoryUsage", mock.Anything, "key3", mock.Anything).Return(redis.NewIntResult(30, nil))
+				return client
 			},
+			expected: map[string]int64{"key1": 10, "key3": 30},
 		},
 		{
-			name: "lock successfully without expiration",
-			setupRepo: func(t *testing.T) *InMemoryDatabaseRepository[testInMemoryEntity] {
-				mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
-				incrCmd := redis.NewIntCmd(context.Background())
-				incrCmd.SetVal(1)
-				mockRedis.On("Incr", mock.Anything, "lock_key_no_expire").Return(incrCmd)
-				return NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
-					RedisClient: mockRedis,
-				})
-			},
-			key:         "lock_key_no_expire",
-			expiration:  0,
-			expectError: false,
-			validate: func(t *testing.T, err error) {
-				assert.NoError(t, err, "Lock() unexpected error")
+			name: "report zero when the client cannot tell the memory",
+			setupClient: func(t *testing.T) in_memory_database.IRedisClient {
+				client := in_memory_database_mocks.NewRedisClientMock(t)
+				client.On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key1"}, 0, nil))
+				return client
 			},
+			expected: map[string]int64{"key1": 0},
 		},
 		{
-			name: "lock with incr error",
-			setupRepo: func(t *testing.T) *InMemoryDatabaseRepository[testInMemoryEntity] {
-				mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
-				incrCmd := redis.NewIntCmd(context.Background())
-				incrCmd.SetErr(redis.TxFailedErr)
-				mockRedis.On("Incr", mock.Anything, "lock_key_incr_error").Return(incrCmd)
-				return NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
-					RedisClient: mockRedis,
-				})
+			name: "scan error",
+			setupClient: func(t *testing.T) in_memory_database.IRedisClient {
+				client := in_memory_database_mocks.NewRedisClientMock(t)
+				client.On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult(nil, 0, redis.ErrClosed))
+				return client
 			},
-			key:         "lock_key_incr_error",
-			expiration:  5 * time.Second,
+			expected:    map[string]int64{},
 			expectError: true,
-			validate: func(t *testing.T, err error) {
-				assert.Error(t, err, "Lock() expected error, got nil")
-			},
 		},
 		{
-			name: "lock with expire error",
-			setupRepo: func(t *testing.T) *InMemoryDatabaseRepository[testInMemoryEntity] {
-				mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
-				incrCmd := redis.NewIntCmd(context.Background())
-				incrCmd.SetVal(1)
-				expireCmd := redis.NewBoolCmd(context.Background())
-				expireCmd.SetErr(redis.TxFailedErr)
-				mockRedis.On("Incr", mock.Anything, "lock_key_expire_error").Return(incrCmd)
-				mockRedis.On("Expire", mock.Anything, "lock_key_expire_error", 5*time.Second).Return(expireCmd)
-				return NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
-					RedisClient: mockRedis,
-				})
-			},
-			key:         "lock_key_expire_error",
-			expiration:  5 * time.Second,
-			expectError: true,
-			validate: func(t *testing.T, err error) {
-				assert.Error(t, err, "Lock() expected error, got nil")
-			},
-		},
-		{
-			name: "lock when already locked (counter > 1)",
-			setupRepo: func(t *testing.T) *InMemoryDatabaseRepository[testInMemoryEntity] {
-				mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
-				incrCmd := redis.NewIntCmd(context.Background())
-				incrCmd.SetVal(2)
-				expireCmd := redis.NewBoolCmd(context.Background())
-				expireCmd.SetVal(true)
-				mockRedis.On("Incr", mock.Anything, "already_locked_key").Return(incrCmd)
-				mockRedis.On("Expire", mock.Anything, "already_locked_key", 5*time.Second).Return(expireCmd)
-				return NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
-					RedisClient: mockRedis,
-				})
+			name: "memory usage error",
+			setupClient: func(t *testing.T) in_memory_database.IRedisClient {
+				client := &memoryRedisClient{
+					RedisClientMock:       in_memory_database_mocks.NewRedisClientMock(t),
+					RedisMemoryClientMock: in_memory_database_mocks.NewRedisMemoryClientMock(t),
+				}
+				client.RedisClientMock.On("Scan", mock.Anything, uint64(0), "key*", scanCount).Return(redis.NewScanCmdResult([]string{"key1"}, 0, nil))
+				client.RedisMemoryClientMock.On("MemoryUsage", mock.Anything, "key1", mock.Anything).Return(redis.NewIntResult(0, redis.ErrClosed))
+				return client
 			},
-			key:         "already_locked_key",
-			expiration:  5 * time.Second,
+			expected:    map[string]int64{},
 			expectError: true,
-			validate: func(t *testing.T, err error) {
-				assert.Error(t, err, "Lock() expected error, got nil")
-			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			repo := tt.setupRepo(t)
-			ctx := context.Background()
+			repo := NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
+				RedisClient: tt.setupClient(t),
+			})
+			usage := map[string]int64{}
 
-			err := repo.Lock(ctx, tt.key, tt.expiration)
+			err := repo.MemoryUsageByPattern(context.Background(), "key*", func(key string, bytes int64) {
+				usage[key] = bytes
+			})
 
-			if tt.validate != nil {
-				tt.validate(t, err)
+			if tt.expectError {
+				assert.Equal(t, http.StatusInternalServerError, gocerr.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
 			}
+			assert.Equal(t, tt.expected, usage)
 		})
 	}
 }
@@ -821,64 +1149,235 @@ func Test_InMemoryDatabaseRepository_SetCount(t *testing.T) {
 	}
 }
 
-func Test_InMemoryDatabaseRepository_Unlock(t *testing.T) {
+func Test_InMemoryDatabaseRepository_EncryptedFields(t *testing.T) {
+	setupTestKeyring(t)
+
+	t.Run("Set stores ciphertext without mutating value", func(t *testing.T) {
+		mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+		cmd := redis.NewStatusCmd(context.Background())
+		cmd.SetVal("OK")
+		mockRedis.On("Set", mock.Anything, "test_key", mock.MatchedBy(func(rawValue string) bool {
+			return !strings.Contains(rawValue, "123 Main St") && strings.Contains(rawValue, "enc:v2:test:")
+		}), 5*time.Second).Return(cmd)
+		repo := NewInMemoryDatabaseRepository[testInMemoryEntityWithEncryptedField](&in_memory_database.InMemoryDatabase{
+			RedisClient: mockRedis,
+		})
+		value := &testInMemoryEntityWithEncryptedField{ID: 1, Address: "123 Main St"}
+
+		err := repo.Set(context.Background(), "test_key", value, 5*time.Second)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "123 Main St", value.Address)
+	})
+
+	t.Run("SetList stores ciphertext without mutating values", func(t *testing.T) {
+		mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+		cmd := redis.NewStatusCmd(context.Background())
+		cmd.SetVal("OK")
+		mockRedis.On("Set", mock.Anything, "test_key", mock.MatchedBy(func(rawValues string) bool {
+			return !strings.Contains(rawValues, "123 Main St") && strings.Count(rawValues, "enc:v2:test:") == 2
+		}), 5*time.Second).Return(cmd)
+		repo := NewInMemoryDatabaseRepository[testInMemoryEntityWithEncryptedField](&in_memory_database.InMemoryDatabase{
+			RedisClient: mockRedis,
+		})
+		values := []testInMemoryEntityWithEncryptedField{
+			{ID: 1, Address: "123 Main St"},
+			{ID: 2, Address: "456 Oak Ave"},
+		}
+
+		err := repo.SetList(context.Background(), "test_key", values, 5*time.Second)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "123 Main St", values[0].Address)
+	})
+
+	t.Run("Get decrypts ciphertext", func(t *testing.T) {
+		ciphertext, err := encryption.Encrypt("123 Main St", encryption.Binding{Column: "Address"})
+		assert.NoError(t, err)
+		mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+		cmd := redis.NewStringCmd(context.Background())
+		cmd.SetVal(`{"ID":1,"Address":"` + ciphertext + `"}`)
+		mockRedis.On("Get", mock.Anything, "test_key").Return(cmd)
+		repo := NewInMemoryDatabaseRepository[testInMemoryEntityWithEncryptedField](&in_memory_database.InMemoryDatabase{
+			RedisClient: mockRedis,
+		})
+
+		value, err := repo.Get(context.Background(), "test_key")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "123 Main St", value.Address)
+	})
+
+	t.Run("Get fails on undecryptable value", func(t *testing.T) {
+		mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+		cmd := redis.NewStringCmd(context.Background())
+		cmd.SetVal(`{"ID":1,"Address":"enc:v2:test:malformed"}`)
+		mockRedis.On("Get", mock.Anything, "test_key").Return(cmd)
+		repo := NewInMemoryDatabaseRepository[testInMemoryEntityWithEncryptedField](&in_memory_database.InMemoryDatabase{
+			RedisClient: mockRedis,
+		})
+
+		value, err := repo.Get(context.Background(), "test_key")
+
+		assert.Error(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("GetList decrypts ciphertext", func(t *testing.T) {
+		ciphertext, err := encryption.Encrypt("123 Main St", encryption.Binding{Column: "Address"})
+		assert.NoError(t, err)
+		mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+		cmd := redis.NewStringCmd(context.Background())
+		cmd.SetVal(`[{"ID":1,"Address":"` + ciphertext + `"}]`)
+		mockRedis.On("Get", mock.Anything, "test_key").Return(cmd)
+		repo := NewInMemoryDatabaseRepository[testInMemoryEntityWithEncryptedField](&in_memory_database.InMemoryDatabase{
+			RedisClient: mockRedis,
+		})
+
+		values, err := repo.GetList(context.Background(), "test_key")
+
+		assert.NoError(t, err)
+		assert.Len(t, values, 1)
+		assert.Equal(t, "123 Main St", values[0].Address)
+	})
+
+	t.Run("GetList fails on undecryptable value", func(t *testing.T) {
+		mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+		cmd := redis.NewStringCmd(context.Background())
+		cmd.SetVal(`[{"ID":1,"Address":"enc:v2:test:malformed"}]`)
+		mockRedis.On("Get", mock.Anything, "test_key").Return(cmd)
+		repo := NewInMemoryDatabaseRepository[testInMemoryEntityWithEncryptedField](&in_memory_database.InMemoryDatabase{
+			RedisClient: mockRedis,
+		})
+
+		values, err := repo.GetList(context.Background(), "test_key")
+
+		assert.Error(t, err)
+		assert.Nil(t, values)
+	})
+}
+
+func Test_InMemoryDatabaseRepository_withSerializer(t *testing.T) {
 	tests := []struct {
-		name        string
-		setupRepo   func(t *testing.T) *InMemoryDatabaseRepository[testInMemoryEntity]
-		key         string
-		expectError bool
-		validate    func(t *testing.T, err error)
+		name string
+		cfg  *codec.Config
 	}{
 		{
-			name: "unlock successfully",
-			setupRepo: func(t *testing.T) *InMemoryDatabaseRepository[testInMemoryEntity] {
-				mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
-				cmd := redis.NewIntCmd(context.Background())
-				cmd.SetVal(1)
-				mockRedis.On("Del", mock.Anything, mock.MatchedBy(func(keys []string) bool {
-					return len(keys) == 1 && keys[0] == "lock_key"
-				})).Return(cmd)
-				return NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
-					RedisClient: mockRedis,
-				})
-			},
-			key:         "lock_key",
-			expectError: false,
-			validate: func(t *testing.T, err error) {
-				assert.NoError(t, err, "Unlock() unexpected error")
-			},
+			name: "json",
+			cfg:  &codec.Config{Codec: codec.JSON},
 		},
 		{
-			name: "unlock with redis error",
-			setupRepo: func(t *testing.T) *InMemoryDatabaseRepository[testInMemoryEntity] {
-				mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
-				cmd := redis.NewIntCmd(context.Background())
-				cmd.SetErr(redis.TxFailedErr)
-				mockRedis.On("Del", mock.Anything, mock.MatchedBy(func(keys []string) bool {
-					return len(keys) == 1 && keys[0] == "error_lock_key"
-				})).Return(cmd)
-				return NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
-					RedisClient: mockRedis,
-				})
-			},
-			key:         "error_lock_key",
-			expectError: true,
-			validate: func(t *testing.T, err error) {
-				assert.Error(t, err, "Unlock() expected error, got nil")
-			},
+			name: "message pack compressed with zstd",
+			cfg:  &codec.Config{Codec: codec.MessagePack, Compression: codec.CompressionZstd},
+		},
+		{
+			name: "message pack compressed with snappy",
+			cfg:  &codec.Config{Codec: codec.MessagePack, Compression: codec.CompressionSnappy},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			repo := tt.setupRepo(t)
+			var stored string
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			mockRedis.On("Set", mock.Anything, "test_key", mock.Anything, 5*time.Second).
+				Run(func(args mock.Arguments) {
+					stored = args.String(2)
+				}).
+				Return(redis.NewStatusResult("OK", nil))
+			mockRedis.On("Get", mock.Anything, "test_key").
+				Return(func(ctx context.Context, key string) *redis.StringCmd {
+					return redis.NewStringResult(stored, nil)
+				})
+			repo := NewInMemoryDatabaseRepositoryWithSerializer[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
+				RedisClient: mockRedis,
+			}, codec.NewSerializer(tt.cfg))
 			ctx := context.Background()
 
-			err := repo.Unlock(ctx, tt.key)
+			err := repo.Set(ctx, "test_key", &testInMemoryEntity{ID: 1, Name: "test"}, 5*time.Second)
+			assert.NoError(t, err, "Set() unexpected error")
+			assert.NotEqual(t, byte('{'), stored[0], "Set() should write a serializer payload")
 
-			if tt.validate != nil {
-				tt.validate(t, err)
-			}
+			value, err := repo.Get(ctx, "test_key")
+			assert.NoError(t, err, "Get() unexpected error")
+			assert.Equal(t, &testInMemoryEntity{ID: 1, Name: "test"}, value)
 		})
 	}
+
+	t.Run("read json written without a serializer", func(t *testing.T) {
+		mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+		mockRedis.On("Get", mock.Anything, "test_key").Return(redis.NewStringResult(`{"ID":1,"Name":"legacy"}`, nil))
+		repo := NewInMemoryDatabaseRepositoryWithSerializer[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
+			RedisClient: mockRedis,
+		}, codec.NewSerializer(&codec.Config{Codec: codec.MessagePack}))
+
+		value, err := repo.Get(context.Background(), "test_key")
+
+		assert.NoError(t, err, "Get() unexpected error")
+		assert.Equal(t, &testInMemoryEntity{ID: 1, Name: "legacy"}, value)
+	})
+
+	t.Run("unknown payload codec", func(t *testing.T) {
+		payload, _ := codec.NewSerializer(&codec.Config{Codec: codec.MessagePack}).Marshal(&testInMemoryEntity{ID: 1})
+		payload[2] = 9
+		mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+		mockRedis.On("Get", mock.Anything, "test_key").Return(redis.NewStringResult(string(payload), nil))
+		repo := NewInMemoryDatabaseRepositoryWithSerializer[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
+			RedisClient: mockRedis,
+		}, codec.NewSerializer(&codec.Config{Codec: codec.JSON}))
+
+		value, err := repo.Get(context.Background(), "test_key")
+
+		assert.Nil(t, value)
+		assert.Equal(t, http.StatusInternalServerError, gocerr.GetErrorCode(err))
+	})
+}
+
+func Test_InMemoryDatabaseRepository_withMemoryClient(t *testing.T) {
+	client := in_memory_database.NewMemoryClient(0, time.Hour)
+	defer client.Close()
+	repo := NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
+		RedisClient: client,
+	})
+	ctx := context.Background()
+
+	assert.NoError(t, repo.Set(ctx, "caches:entity:1", &testInMemoryEntity{ID: 1, Name: "test"}, time.Minute))
+	value, err := repo.Get(ctx, "caches:entity:1")
+	assert.NoError(t, err)
+	assert.Equal(t, &testInMemoryEntity{ID: 1, Name: "test"}, value)
+
+	assert.NoError(t, repo.SetList(ctx, "caches:list:1", []testInMemoryEntity{{ID: 1}, {ID: 2}}, time.Minute))
+	values, err := repo.GetList(ctx, "caches:list:1")
+	assert.NoError(t, err)
+	assert.Len(t, values, 2)
+
+	assert.NoError(t, repo.SetCount(ctx, "caches:count", 2, time.Minute))
+	count, err := repo.GetCount(ctx, "caches:count")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	created, err := repo.SetCountIfNotExists(ctx, "generation", 1, 0)
+	assert.NoError(t, err)
+	assert.True(t, created)
+	created, err = repo.SetCountIfNotExists(ctx, "generation", 2, 0)
+	assert.NoError(t, err)
+	assert.False(t, created)
+
+	ttl, err := repo.TTL(ctx, "caches:entity:1")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, ttl.Round(time.Second))
+
+	_, err = repo.TTL(ctx, "missing")
+	assert.Equal(t, http.StatusNotFound, gocerr.GetErrorCode(err))
+
+	keys, err := repo.Keys(ctx, "caches:*")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"caches:entity:1", "caches:list:1", "caches:count"}, keys)
+
+	deleted, err := repo.DeleteByPattern(ctx, "caches:*")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), deleted)
+
+	_, err = repo.Get(ctx, "caches:entity:1")
+	assert.Equal(t, http.StatusNotFound, gocerr.GetErrorCode(err))
 }
@@ -14,6 +14,10 @@ var Provider wire.ProviderSet = wire.NewSet(
 	NewGuestEventProducerRepository,
 	wire.Bind(new(IGuestEventProducerRepository), new(*GuestEventProducerRepository)),
 
+	// feature flags
+	NewFeatureFlagRepository,
+	wire.Bind(new(IFeatureFlagRepository), new(*FeatureFlagRepository)),
+
 	// webhook.site
 	NewWebhookSiteRepository,
 	wire.Bind(new(IWebhookSiteRepository), new(*WebhookSiteRepository)),
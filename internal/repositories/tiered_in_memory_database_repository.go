@@ -0,0 +1,574 @@
+package repositories
+
+import (
+	"context"
+	"go-boilerplate/datasources/in_memory_database"
+	"go-boilerplate/pkg/constants"
+	custom_context "go-boilerplate/pkg/context"
+	"go-boilerplate/pkg/lru"
+	"go-boilerplate/pkg/metrics"
+	"go-boilerplate/pkg/tracer"
+	"go-boilerplate/pkg/uuid"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/goccy/go-json"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TieredCacheConfig struct {
+	// Name labels the hit and miss metrics.
+	Name     string
+	Capacity int
+	TTL      time.Duration
+	// Channel carries the evicted keys to the other replicas.
+	Channel string
+	Stats   bool
+}
+
+type invalidationMessage struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys,omitempty"`
+	Purge  bool     `json:"purge,omitempty"`
+}
+
+// tieredEntry is a value kept in l1 with the expiry of its Redis key.
+type tieredEntry struct {
+	value interface{}
+	// expiresAt is zero for a key that never expires
+	expiresAt time.Time
+	// expiryKnown is false for a value filled from a read, its expiry is read
+	// from Redis on the first TTL
+	expiryKnown bool
+}
+
+// pendingFill is a read or write of a key whose result is about to be kept in
+// l1, an eviction of the key meanwhile marks it as evicted.
+type pendingFill struct {
+	evicted bool
+}
+
+// WithCacheFill marks the writes made with ctx as fills of what the database
+// holds, the tiered repository keeps them in l1 without evicting them on the
+// other replicas.
+func WithCacheFill(ctx context.Context) context.Context {
+	return context.WithValue(ctx, constants.ContextKeyCacheFill, true)
+}
+
+func isCacheFill(ctx context.Context) bool {
+	return custom_context.GetCtxValueSafely[bool](ctx, constants.ContextKeyCacheFill)
+}
+
+// TieredInMemoryDatabaseRepository answers reads from an in-process LRU in
+// front of the Redis backed repository. Writes go to Redis first, then the
+// written keys are evicted locally and on every replica over pub/sub.
+type TieredInMemoryDatabaseRepository[TEntity interface{}] struct {
+	IInMemoryDatabaseRepository[TEntity]
+
+	cfg    *TieredCacheConfig
+	cache  *lru.Cache[tieredEntry]
+	pubSub in_memory_database.IRedisPubSubClient
+	origin string
+
+	// pending holds the fills in flight by key, an eviction marks only the
+	// fills of its keys
+	mutex   sync.Mutex
+	pending map[string][]*pendingFill
+}
+
+// NewTieredInMemoryDatabaseRepository subscribes to cfg.Channel when the
+// Redis client supports pub/sub, inMemoryDatabase closes the subscription on
+// Disconnect.
+func NewTieredInMemoryDatabaseRepository[TEntity interface{}](
+	repository IInMemoryDatabaseRepository[TEntity],
+	inMemoryDatabase *in_memory_database.InMemoryDatabase,
+	cfg *TieredCacheConfig,
+) *TieredInMemoryDatabaseRepository[TEntity] {
+	var (
+		tieredRepository *TieredInMemoryDatabaseRepository[TEntity] = &TieredInMemoryDatabaseRepository[TEntity]{
+			IInMemoryDatabaseRepository: repository,
+			cfg:                         cfg,
+			cache:                       lru.New[tieredEntry](cfg.Capacity, cfg.TTL),
+			origin:                      uuid.NewV7().String(),
+			pending:                     map[string][]*pendingFill{},
+		}
+		subscription *redis.PubSub
+	)
+
+	if inMemoryDatabase != nil {
+		tieredRepository.pubSub, _ = inMemoryDatabase.RedisClient.(in_memory_database.IRedisPubSubClient)
+	}
+
+	if tieredRepository.pubSub != nil {
+		subscription = tieredRepository.pubSub.Subscribe(context.Background(), cfg.Channel)
+		inMemoryDatabase.OnDisconnect(subscription.Close)
+		go tieredRepository.subscribe(subscription)
+	}
+
+	return tieredRepository
+}
+
+func (r *TieredInMemoryDatabaseRepository[TEntity]) subscribe(subscription *redis.PubSub) {
+	for message := range subscription.Channel() {
+		r.handleInvalidation(message.Payload)
+	}
+}
+
+func (r *TieredInMemoryDatabaseRepository[TEntity]) handleInvalidation(payload string) {
+	var (
+		message invalidationMessage
+		err     error
+	)
+
+	err = json.Unmarshal([]byte(payload), &message)
+	if err != nil {
+		log.Err(err).
+			Str("channel", r.cfg.Channel).
+			Msg("[TieredInMemoryDatabaseRepository][handleInvalidation][Unmarshal] failed to unmarshal invalidation")
+		return
+	}
+
+	// the writer already evicted its own keys
+	if message.Origin == r.origin {
+		return
+	}
+
+	r.evict(&message, nil)
+}
+
+// evict removes the keys of message, or every key for a purge, and marks the
+// fills of those keys other than own as evicted.
+func (r *TieredInMemoryDatabaseRepository[TEntity]) evict(message *invalidationMessage, own *pendingFill) {
+	var markEvicted func(fills []*pendingFill) = func(fills []*pendingFill) {
+		for _, fill := range fills {
+			if fill != own {
+				fill.evicted = true
+			}
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if message.Purge {
+		for _, fills := range r.pending {
+			markEvicted(fills)
+		}
+		r.cache.Purge()
+		return
+	}
+
+	for _, key := range message.Keys {
+		markEvicted(r.pending[key])
+	}
+	r.cache.Delete(message.Keys...)
+}
+
+// beginFill registers a fill of key, to be ended with endFill once its value
+// is known.
+func (r *TieredInMemoryDatabaseRepository[TEntity]) beginFill(key string) *pendingFill {
+	var fill *pendingFill = &pendingFill{}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.pending[key] = append(r.pending[key], fill)
+
+	return fill
+}
+
+// endFill unregisters fill and keeps entry in l1 unless the key was evicted
+// since beginFill, so a value read before its eviction is not put back. A nil
+// entry only unregisters. The entry lasts cfg.TTL and never past a known
+// expiry.
+func (r *TieredInMemoryDatabaseRepository[TEntity]) endFill(key string, fill *pendingFill, entry *tieredEntry) {
+	var (
+		ttl       time.Duration = r.cfg.TTL
+		remaining time.Duration
+	)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.pending[key] = slices.DeleteFunc(r.pending[key], func(pending *pendingFill) bool {
+		return pending == fill
+	})
+	if len(r.pending[key]) == 0 {
+		delete(r.pending, key)
+	}
+
+	if entry == nil || fill.evicted {
+		return
+	}
+
+	if entry.expiryKnown && !entry.expiresAt.IsZero() {
+		remaining = time.Until(entry.expiresAt)
+		if remaining <= 0 {
+			return
+		}
+		if ttl <= 0 || remaining < ttl {
+			ttl = remaining
+		}
+	}
+
+	r.cache.SetWithTTL(key, *entry, ttl)
+}
+
+// writtenEntry is the entry of a value written with expiration.
+func writtenEntry(value interface{}, expiration time.Duration) *tieredEntry {
+	var entry *tieredEntry = &tieredEntry{
+		value:       value,
+		expiryKnown: true,
+	}
+
+	if expiration > 0 {
+		entry.expiresAt = time.Now().Add(expiration)
+	}
+
+	return entry
+}
+
+// readEntry is the entry of a value read from Redis. Its expiry is left
+// unknown, saving a PTTL per miss, so the entry may outlive the Redis key by
+// up to cfg.TTL. Without cfg.TTL the expiry is read, and a key whose TTL
+// cannot be read is left to Redis.
+func (r *TieredInMemoryDatabaseRepository[TEntity]) readEntry(ctx context.Context, key string, value interface{}) *tieredEntry {
+	var (
+		ttl time.Duration
+		err error
+	)
+
+	if r.cfg.TTL > 0 {
+		return &tieredEntry{value: value}
+	}
+
+	ttl, err = r.IInMemoryDatabaseRepository.TTL(ctx, key)
+	if err != nil {
+		return nil
+	}
+
+	return writtenEntry(value, ttl)
+}
+
+// invalidate evicts the keys locally and publishes them, a failed publish
+// leaves the other replicas to expire them after cfg.TTL. A write made with
+// WithCacheFill is only evicted locally.
+func (r *TieredInMemoryDatabaseRepository[TEntity]) invalidate(ctx context.Context, message *invalidationMessage, own *pendingFill) {
+	var (
+		span      trace.Span
+		logFields map[string]interface{}
+		payload   []byte
+		err       error
+	)
+
+	ctx, span = tracer.Start(ctx, "[TieredInMemoryDatabaseRepository][invalidate]", commandSpanAttributes("PUBLISH"))
+	defer span.End()
+
+	r.evict(message, own)
+
+	if r.pubSub == nil || isCacheFill(ctx) {
+		return
+	}
+
+	message.Origin = r.origin
+	logFields = map[string]interface{}{
+		"channel": r.cfg.Channel,
+		"message": message,
+	}
+
+	payload, err = json.Marshal(message)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[TieredInMemoryDatabaseRepository][invalidate][Marshal] failed to marshal invalidation")
+		tracer.RecordError(span, err)
+		return
+	}
+
+	err = r.pubSub.Publish(ctx, r.cfg.Channel, payload).
+		Err()
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[TieredInMemoryDatabaseRepository][invalidate][Publish] failed to publish invalidation")
+		tracer.RecordError(span, err)
+	}
+}
+
+func (r *TieredInMemoryDatabaseRepository[TEntity]) lookup(key string) (interface{}, bool) {
+	var (
+		entry tieredEntry
+		ok    bool
+	)
+
+	entry, ok = r.cache.Get(key)
+	if r.cfg.Stats {
+		if ok {
+			metrics.ObserveCacheHit(r.cfg.Name)
+		} else {
+			metrics.ObserveCacheMiss(r.cfg.Name)
+		}
+	}
+
+	return entry.value, ok
+}
+
+func (r *TieredInMemoryDatabaseRepository[TEntity]) Stats() lru.Stats {
+	return r.cache.Stats()
+}
+
+func (r *TieredInMemoryDatabaseRepository[TEntity]) Delete(ctx context.Context, keys ...string) error {
+	var err error = r.IInMemoryDatabaseRepository.Delete(ctx, keys...)
+
+	if err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, &invalidationMessage{Keys: keys}, nil)
+
+	return nil
+}
+
+func (r *TieredInMemoryDatabaseRepository[TEntity]) DeleteByPattern(ctx context.Context, pattern string) (uint64, error) {
+	var (
+		deleted uint64
+		err     error
+	)
+
+	deleted, err = r.IInMemoryDatabaseRepository.DeleteByPattern(ctx, pattern)
+
+	// part of the keys may be gone even when the scan failed
+	r.invalidate(ctx, &invalidationMessage{Purge: true}, nil)
+
+	return deleted, err
+}
+
+// Get copies the cached entity, so callers cannot change the cached one.
+func (r *TieredInMemoryDatabaseRepository[TEntity]) Get(ctx context.Context, key string) (*TEntity, error) {
+	var (
+		value  interface{}
+		cached TEntity
+		entity *TEntity
+		fill   *pendingFill
+		ok     bool
+		err    error
+	)
+
+	value, ok = r.lookup(key)
+	if ok {
+		cached, ok = value.(TEntity)
+	}
+	if ok {
+		return &cached, nil
+	}
+
+	fill = r.beginFill(key)
+
+	entity, err = r.IInMemoryDatabaseRepository.Get(ctx, key)
+	if err != nil {
+		r.endFill(key, fill, nil)
+		return nil, err
+	}
+
+	r.endFill(key, fill, r.readEntry(ctx, key, *entity))
+
+	return entity, nil
+}
+
+func (r *TieredInMemoryDatabaseRepository[TEntity]) GetList(ctx context.Context, key string) ([]TEntity, error) {
+	var (
+		value    interface{}
+		cached   []TEntity
+		entities []TEntity
+		fill     *pendingFill
+		ok       bool
+		err      error
+	)
+
+	value, ok = r.lookup(key)
+	if ok {
+		cached, ok = value.([]TEntity)
+	}
+	if ok {
+		return slices.Clone(cached), nil
+	}
+
+	fill = r.beginFill(key)
+
+	entities, err = r.IInMemoryDatabaseRepository.GetList(ctx, key)
+	if err != nil {
+		r.endFill(key, fill, nil)
+		return nil, err
+	}
+
+	r.endFill(key, fill, r.readEntry(ctx, key, slices.Clone(entities)))
+
+	return entities, nil
+}
+
+func (r *TieredInMemoryDatabaseRepository[TEntity]) GetCount(ctx context.Context, key string) (uint64, error) {
+	var (
+		value  interface{}
+		cached uint64
+		count  uint64
+		fill   *pendingFill
+		ok     bool
+		err    error
+	)
+
+	value, ok = r.lookup(key)
+	if ok {
+		cached, ok = value.(uint64)
+	}
+	if ok {
+		return cached, nil
+	}
+
+	fill = r.beginFill(key)
+
+	count, err = r.IInMemoryDatabaseRepository.GetCount(ctx, key)
+	if err != nil {
+		r.endFill(key, fill, nil)
+		return 0, err
+	}
+
+	r.endFill(key, fill, r.readEntry(ctx, key, count))
+
+	return count, nil
+}
+
+func (r *TieredInMemoryDatabaseRepository[TEntity]) Set(ctx context.Context, key string, value *TEntity, expiration time.Duration) error {
+	var (
+		fill *pendingFill = r.beginFill(key)
+		err  error
+	)
+
+	err = r.IInMemoryDatabaseRepository.Set(ctx, key, value, expiration)
+	if err != nil {
+		r.endFill(key, fill, nil)
+		return err
+	}
+
+	r.invalidate(ctx, &invalidationMessage{Keys: []string{key}}, fill)
+	r.endFill(key, fill, writtenEntry(*value, expiration))
+
+	return nil
+}
+
+func (r *TieredInMemoryDatabaseRepository[TEntity]) SetList(ctx context.Context, key string, values []TEntity, expiration time.Duration) error {
+	var (
+		fill *pendingFill = r.beginFill(key)
+		err  error
+	)
+
+	err = r.IInMemoryDatabaseRepository.SetList(ctx, key, values, expiration)
+	if err != nil {
+		r.endFill(key, fill, nil)
+		return err
+	}
+
+	r.invalidate(ctx, &invalidationMessage{Keys: []string{key}}, fill)
+	r.endFill(key, fill, writtenEntry(slices.Clone(values), expiration))
+
+	return nil
+}
+
+func (r *TieredInMemoryDatabaseRepository[TEntity]) SetCount(ctx context.Context, key string, value uint64, expiration time.Duration) error {
+	var (
+		fill *pendingFill = r.beginFill(key)
+		err  error
+	)
+
+	err = r.IInMemoryDatabaseRepository.SetCount(ctx, key, value, expiration)
+	if err != nil {
+		r.endFill(key, fill, nil)
+		return err
+	}
+
+	r.invalidate(ctx, &invalidationMessage{Keys: []string{key}}, fill)
+	r.endFill(key, fill, writtenEntry(value, expiration))
+
+	return nil
+}
+
+func (r *TieredInMemoryDatabaseRepository[TEntity]) SetCountIfNotExists(ctx context.Context, key string, value uint64, expiration time.Duration) (bool, error) {
+	var (
+		fill    *pendingFill = r.beginFill(key)
+		created bool
+		err     error
+	)
+
+	created, err = r.IInMemoryDatabaseRepository.SetCountIfNotExists(ctx, key, value, expiration)
+	if err != nil || !created {
+		r.endFill(key, fill, nil)
+		return created, err
+	}
+
+	r.invalidate(ctx, &invalidationMessage{Keys: []string{key}}, fill)
+	r.endFill(key, fill, writtenEntry(value, expiration))
+
+	return true, nil
+}
+
+// SetTombstone leaves the tombstone to Redis, l1 only holds values.
+func (r *TieredInMemoryDatabaseRepository[TEntity]) SetTombstone(ctx context.Context, key string, expiration time.Duration) error {
+	var err error = r.IInMemoryDatabaseRepository.SetTombstone(ctx, key, expiration)
+
+	if err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, &invalidationMessage{Keys: []string{key}}, nil)
+
+	return nil
+}
+
+// TTL answers from the expiry kept in l1, an entry filled from a read learns
+// its expiry from Redis on the first call. A key gone from Redis is evicted
+// locally.
+func (r *TieredInMemoryDatabaseRepository[TEntity]) TTL(ctx context.Context, key string) (time.Duration, error) {
+	var (
+		entry     tieredEntry
+		fill      *pendingFill
+		remaining time.Duration
+		ttl       time.Duration
+		ok        bool
+		err       error
+	)
+
+	entry, ok = r.cache.Peek(key)
+	if ok && entry.expiryKnown {
+		if entry.expiresAt.IsZero() {
+			return -1, nil
+		}
+
+		remaining = time.Until(entry.expiresAt)
+		if remaining > 0 {
+			return remaining, nil
+		}
+	}
+
+	fill = r.beginFill(key)
+
+	ttl, err = r.IInMemoryDatabaseRepository.TTL(ctx, key)
+	if gocerr.GetErrorCode(err) == http.StatusNotFound {
+		r.endFill(key, fill, nil)
+		r.evict(&invalidationMessage{Keys: []string{key}}, nil)
+		return 0, err
+	}
+	if err != nil || !ok {
+		r.endFill(key, fill, nil)
+		return ttl, err
+	}
+
+	r.endFill(key, fill, writtenEntry(entry.value, ttl))
+
+	return ttl, nil
+}
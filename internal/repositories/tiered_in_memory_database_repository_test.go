@@ -0,0 +1,604 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"go-boilerplate/datasources/in_memory_database"
+	in_memory_database_mocks "go-boilerplate/datasources/in_memory_database/mocks"
+	"go-boilerplate/pkg/lru"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/goccy/go-json"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestTieredRepository(
+	redisClient in_memory_database.IRedisClient,
+	pubSub *in_memory_database_mocks.RedisPubSubClientMock,
+) *TieredInMemoryDatabaseRepository[testInMemoryEntity] {
+	repo := &TieredInMemoryDatabaseRepository[testInMemoryEntity]{
+		IInMemoryDatabaseRepository: NewInMemoryDatabaseRepository[testInMemoryEntity](&in_memory_database.InMemoryDatabase{
+			RedisClient: redisClient,
+		}),
+		cfg: &TieredCacheConfig{
+			Name:     "test_l1",
+			Capacity: 10,
+			TTL:      time.Minute,
+			Channel:  "invalidations",
+			Stats:    true,
+		},
+		cache:   lru.New[tieredEntry](10, time.Minute),
+		origin:  "self",
+		pending: map[string][]*pendingFill{},
+	}
+	if pubSub != nil {
+		repo.pubSub = pubSub
+	}
+	return repo
+}
+
+func invalidationPayload(t *testing.T, message invalidationMessage) []byte {
+	payload, err := json.Marshal(message)
+	assert.NoError(t, err)
+	return payload
+}
+
+func Test_NewTieredInMemoryDatabaseRepository(t *testing.T) {
+	cfg := &TieredCacheConfig{Name: "test_l1", Capacity: 10, TTL: time.Minute, Channel: "invalidations"}
+	inMemoryDatabase := &in_memory_database.InMemoryDatabase{
+		RedisClient: in_memory_database_mocks.NewRedisClientMock(t),
+	}
+	redisRepo := NewInMemoryDatabaseRepository[testInMemoryEntity](inMemoryDatabase)
+
+	repo := NewTieredInMemoryDatabaseRepository[testInMemoryEntity](redisRepo, inMemoryDatabase, cfg)
+
+	assert.Equal(t, redisRepo, repo.IInMemoryDatabaseRepository)
+	assert.Equal(t, cfg, repo.cfg)
+	assert.NotNil(t, repo.cache)
+	assert.NotEmpty(t, repo.origin)
+	assert.Nil(t, repo.pubSub, "a client without pub/sub should only evict locally")
+}
+
+// pubSubRedisClient is a Redis client that also supports pub/sub.
+type pubSubRedisClient struct {
+	*in_memory_database_mocks.RedisClientMock
+	*in_memory_database_mocks.RedisPubSubClientMock
+}
+
+func Test_NewTieredInMemoryDatabaseRepository_subscription(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	defer client.Close()
+	subscription := client.Subscribe(context.Background())
+
+	mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+	mockRedis.On("Close").Return(nil)
+	mockPubSub := in_memory_database_mocks.NewRedisPubSubClientMock(t)
+	mockPubSub.On("Subscribe", mock.Anything, []string{"invalidations"}).Return(subscription)
+	inMemoryDatabase := &in_memory_database.InMemoryDatabase{
+		RedisClient: &pubSubRedisClient{RedisClientMock: mockRedis, RedisPubSubClientMock: mockPubSub},
+	}
+
+	repo := NewTieredInMemoryDatabaseRepository[testInMemoryEntity](
+		NewInMemoryDatabaseRepository[testInMemoryEntity](inMemoryDatabase),
+		inMemoryDatabase,
+		&TieredCacheConfig{Name: "test_l1", Capacity: 10, TTL: time.Minute, Channel: "invalidations"},
+	)
+	assert.NotNil(t, repo.pubSub)
+
+	assert.NoError(t, inMemoryDatabase.Disconnect())
+	assert.Error(t, subscription.Close(), "disconnect should have closed the subscription")
+}
+
+func Test_TieredInMemoryDatabaseRepository_Get(t *testing.T) {
+	mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+	mockRedis.On("Get", mock.Anything, "key").Return(redis.NewStringResult(`{"ID":1,"Name":"test"}`, nil)).Once()
+	repo := newTestTieredRepository(mockRedis, nil)
+
+	first, err := repo.Get(context.Background(), "key")
+	assert.NoError(t, err)
+	first.Name = "changed"
+
+	second, err := repo.Get(context.Background(), "key")
+	assert.NoError(t, err)
+	assert.Equal(t, &testInMemoryEntity{ID: 1, Name: "test"}, second, "the second read should come from l1 untouched")
+	assert.Equal(t, lru.Stats{Hits: 1, Misses: 1, Len: 1}, repo.Stats())
+}
+
+func Test_TieredInMemoryDatabaseRepository_Get_notFound(t *testing.T) {
+	mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+	mockRedis.On("Get", mock.Anything, "key").Return(redis.NewStringResult("", redis.Nil)).Twice()
+	repo := newTestTieredRepository(mockRedis, nil)
+
+	for i := 0; i < 2; i++ {
+		entity, err := repo.Get(context.Background(), "key")
+		assert.Nil(t, entity)
+		assert.Equal(t, http.StatusNotFound, gocerr.GetErrorCode(err))
+	}
+	assert.Equal(t, 0, repo.Stats().Len, "misses should not be cached")
+}
+
+func Test_TieredInMemoryDatabaseRepository_Get_fill(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		setupMock func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity])
+		sleep     time.Duration
+		cached    bool
+	}{
+		{
+			name: "keep the value for the l1 ttl without reading the redis ttl",
+			ttl:  20 * time.Millisecond,
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity]) {
+				m.On("Get", mock.Anything, "key").Return(redis.NewStringResult(`{"ID":1}`, nil))
+			},
+			sleep: 40 * time.Millisecond,
+		},
+		{
+			name: "keep the value for at most the redis ttl without an l1 ttl",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity]) {
+				m.On("Get", mock.Anything, "key").Return(redis.NewStringResult(`{"ID":1}`, nil))
+				m.On("PTTL", mock.Anything, "key").Return(redis.NewDurationResult(20*time.Millisecond, nil))
+			},
+			sleep: 40 * time.Millisecond,
+		},
+		{
+			name: "skip l1 when the redis ttl cannot be read without an l1 ttl",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity]) {
+				m.On("Get", mock.Anything, "key").Return(redis.NewStringResult(`{"ID":1}`, nil))
+				m.On("PTTL", mock.Anything, "key").Return(redis.NewDurationResult(0, errors.New("redis error")))
+			},
+		},
+		{
+			name: "drop a value evicted while it was read",
+			ttl:  time.Minute,
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity]) {
+				m.On("Get", mock.Anything, "key").Return(redis.NewStringResult(`{"ID":1}`, nil)).Run(func(args mock.Arguments) {
+					repo.handleInvalidation(string(invalidationPayload(t, invalidationMessage{Origin: "other", Keys: []string{"key"}})))
+				})
+			},
+		},
+		{
+			name: "drop a value read while l1 was purged",
+			ttl:  time.Minute,
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity]) {
+				m.On("Get", mock.Anything, "key").Return(redis.NewStringResult(`{"ID":1}`, nil)).Run(func(args mock.Arguments) {
+					repo.handleInvalidation(string(invalidationPayload(t, invalidationMessage{Origin: "other", Purge: true})))
+				})
+			},
+		},
+		{
+			name: "keep a value read while another key was evicted",
+			ttl:  time.Minute,
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity]) {
+				m.On("Get", mock.Anything, "key").Return(redis.NewStringResult(`{"ID":1}`, nil)).Run(func(args mock.Arguments) {
+					repo.handleInvalidation(string(invalidationPayload(t, invalidationMessage{Origin: "other", Keys: []string{"other"}})))
+				})
+			},
+			cached: true,
+		},
+		{
+			name: "keep a value read without evictions",
+			ttl:  time.Minute,
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity]) {
+				m.On("Get", mock.Anything, "key").Return(redis.NewStringResult(`{"ID":1}`, nil))
+			},
+			cached: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			repo := newTestTieredRepository(mockRedis, nil)
+			repo.cfg.TTL = tt.ttl
+			tt.setupMock(mockRedis, repo)
+
+			entity, err := repo.Get(context.Background(), "key")
+			assert.NoError(t, err)
+			assert.Equal(t, &testInMemoryEntity{ID: 1}, entity)
+
+			time.Sleep(tt.sleep)
+			_, cached := repo.cache.Get("key")
+			assert.Equal(t, tt.cached, cached)
+			assert.Empty(t, repo.pending, "the fill should be unregistered")
+		})
+	}
+}
+
+func Test_TieredInMemoryDatabaseRepository_GetList(t *testing.T) {
+	mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+	mockRedis.On("Get", mock.Anything, "list").Return(redis.NewStringResult(`[{"ID":1,"Name":"a"},{"ID":2,"Name":"b"}]`, nil)).Once()
+	repo := newTestTieredRepository(mockRedis, nil)
+
+	first, err := repo.GetList(context.Background(), "list")
+	assert.NoError(t, err)
+	first[0].Name = "changed"
+
+	second, err := repo.GetList(context.Background(), "list")
+	assert.NoError(t, err)
+	assert.Equal(t, []testInMemoryEntity{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, second)
+}
+
+func Test_TieredInMemoryDatabaseRepository_GetCount(t *testing.T) {
+	mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+	mockRedis.On("Get", mock.Anything, "count").Return(redis.NewStringResult("3", nil)).Once()
+	repo := newTestTieredRepository(mockRedis, nil)
+
+	for i := 0; i < 2; i++ {
+		count, err := repo.GetCount(context.Background(), "count")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(3), count)
+	}
+}
+
+func Test_TieredInMemoryDatabaseRepository_Set(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(m *in_memory_database_mocks.RedisClientMock, p *in_memory_database_mocks.RedisPubSubClientMock)
+		expectErr bool
+		cached    bool
+	}{
+		{
+			name: "set in redis, publish and keep in l1",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock, p *in_memory_database_mocks.RedisPubSubClientMock) {
+				m.On("Set", mock.Anything, "key", mock.Anything, time.Minute).Return(redis.NewStatusResult("OK", nil))
+				p.On("Publish", mock.Anything, "invalidations", invalidationPayload(t, invalidationMessage{Origin: "self", Keys: []string{"key"}})).
+					Return(redis.NewIntResult(1, nil))
+			},
+			cached: true,
+		},
+		{
+			name: "keep in l1 when publish fails",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock, p *in_memory_database_mocks.RedisPubSubClientMock) {
+				m.On("Set", mock.Anything, "key", mock.Anything, time.Minute).Return(redis.NewStatusResult("OK", nil))
+				p.On("Publish", mock.Anything, "invalidations", mock.Anything).Return(redis.NewIntResult(0, errors.New("publish error")))
+			},
+			cached: true,
+		},
+		{
+			name: "neither publish nor cache when redis fails",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock, p *in_memory_database_mocks.RedisPubSubClientMock) {
+				m.On("Set", mock.Anything, "key", mock.Anything, time.Minute).Return(redis.NewStatusResult("", errors.New("redis error")))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			mockPubSub := in_memory_database_mocks.NewRedisPubSubClientMock(t)
+			tt.setupMock(mockRedis, mockPubSub)
+			repo := newTestTieredRepository(mockRedis, mockPubSub)
+
+			err := repo.Set(context.Background(), "key", &testInMemoryEntity{ID: 1, Name: "test"}, time.Minute)
+
+			assert.Equal(t, tt.expectErr, err != nil)
+			_, cached := repo.cache.Get("key")
+			assert.Equal(t, tt.cached, cached)
+		})
+	}
+}
+
+func Test_TieredInMemoryDatabaseRepository_Set_fill(t *testing.T) {
+	tests := []struct {
+		name       string
+		expiration time.Duration
+		evict      bool
+		cached     bool
+	}{
+		{
+			name:       "keep the value for at most its expiration",
+			expiration: 20 * time.Millisecond,
+		},
+		{
+			name:       "drop the value when another eviction ran during the write",
+			expiration: time.Minute,
+			evict:      true,
+		},
+		{
+			name:       "keep the value for the l1 ttl",
+			expiration: time.Hour,
+			cached:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			repo := newTestTieredRepository(mockRedis, nil)
+			call := mockRedis.On("Set", mock.Anything, "key", mock.Anything, tt.expiration).Return(redis.NewStatusResult("OK", nil))
+			if tt.evict {
+				call.Run(func(args mock.Arguments) {
+					repo.handleInvalidation(string(invalidationPayload(t, invalidationMessage{Origin: "other", Keys: []string{"key"}})))
+				})
+			}
+
+			assert.NoError(t, repo.Set(context.Background(), "key", &testInMemoryEntity{ID: 1}, tt.expiration))
+
+			time.Sleep(40 * time.Millisecond)
+			_, cached := repo.cache.Get("key")
+			assert.Equal(t, tt.cached, cached)
+		})
+	}
+}
+
+func Test_TieredInMemoryDatabaseRepository_SetList(t *testing.T) {
+	mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+	mockRedis.On("Set", mock.Anything, "list", mock.Anything, time.Minute).Return(redis.NewStatusResult("OK", nil))
+	repo := newTestTieredRepository(mockRedis, nil)
+	values := []testInMemoryEntity{{ID: 1, Name: "a"}}
+
+	err := repo.SetList(context.Background(), "list", values, time.Minute)
+	values[0].Name = "changed"
+
+	assert.NoError(t, err)
+	result, err := repo.GetList(context.Background(), "list")
+	assert.NoError(t, err)
+	assert.Equal(t, []testInMemoryEntity{{ID: 1, Name: "a"}}, result)
+}
+
+func Test_TieredInMemoryDatabaseRepository_SetCount(t *testing.T) {
+	mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+	mockRedis.On("Set", mock.Anything, "count", uint64(5), time.Minute).Return(redis.NewStatusResult("OK", nil))
+	repo := newTestTieredRepository(mockRedis, nil)
+
+	err := repo.SetCount(context.Background(), "count", 5, time.Minute)
+
+	assert.NoError(t, err)
+	count, err := repo.GetCount(context.Background(), "count")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), count)
+}
+
+func Test_TieredInMemoryDatabaseRepository_Delete(t *testing.T) {
+	mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+	mockRedis.On("Del", mock.Anything, []string{"a", "b"}).Return(redis.NewIntResult(2, nil))
+	mockPubSub := in_memory_database_mocks.NewRedisPubSubClientMock(t)
+	mockPubSub.On("Publish", mock.Anything, "invalidations", invalidationPayload(t, invalidationMessage{Origin: "self", Keys: []string{"a", "b"}})).
+		Return(redis.NewIntResult(1, nil))
+	repo := newTestTieredRepository(mockRedis, mockPubSub)
+	repo.cache.Set("a", tieredEntry{value: testInMemoryEntity{ID: 1}})
+	repo.cache.Set("b", tieredEntry{value: testInMemoryEntity{ID: 2}})
+	repo.cache.Set("c", tieredEntry{value: testInMemoryEntity{ID: 3}})
+
+	err := repo.Delete(context.Background(), "a", "b")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, repo.cache.Len())
+}
+
+func Test_TieredInMemoryDatabaseRepository_Delete_error(t *testing.T) {
+	mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+	mockRedis.On("Del", mock.Anything, []string{"a"}).Return(redis.NewIntResult(0, errors.New("redis error")))
+	repo := newTestTieredRepository(mockRedis, in_memory_database_mocks.NewRedisPubSubClientMock(t))
+	repo.cache.Set("a", tieredEntry{value: testInMemoryEntity{ID: 1}})
+
+	err := repo.Delete(context.Background(), "a")
+
+	assert.Equal(t, http.StatusInternalServerError, gocerr.GetErrorCode(err))
+	assert.Equal(t, 1, repo.cache.Len())
+}
+
+func Test_TieredInMemoryDatabaseRepository_DeleteByPattern(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(m *in_memory_database_mocks.RedisClientMock)
+		expectedDeleted uint64
+		expectErr       bool
+	}{
+		{
+			name: "purge l1 after deleting the pattern",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Scan", mock.Anything, uint64(0), "guest:*", scanCount).Return(redis.NewScanCmdResult([]string{"guest:1"}, 0, nil))
+				m.On("Del", mock.Anything, []string{"guest:1"}).Return(redis.NewIntResult(1, nil))
+			},
+			expectedDeleted: 1,
+		},
+		{
+			name: "purge l1 even when the scan fails",
+			setupMock: func(m *in_memory_database_mocks.RedisClientMock) {
+				m.On("Scan", mock.Anything, uint64(0), "guest:*", scanCount).Return(redis.NewScanCmdResult(nil, 0, errors.New("redis error")))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			tt.setupMock(mockRedis)
+			mockPubSub := in_memory_database_mocks.NewRedisPubSubClientMock(t)
+			mockPubSub.On("Publish", mock.Anything, "invalidations", invalidationPayload(t, invalidationMessage{Origin: "self", Purge: true})).
+				Return(redis.NewIntResult(1, nil))
+			repo := newTestTieredRepository(mockRedis, mockPubSub)
+			repo.cache.Set("guest:1", tieredEntry{value: testInMemoryEntity{ID: 1}})
+			repo.cache.Set("other", tieredEntry{value: testInMemoryEntity{ID: 2}})
+
+			deleted, err := repo.DeleteByPattern(context.Background(), "guest:*")
+
+			assert.Equal(t, tt.expectedDeleted, deleted)
+			assert.Equal(t, tt.expectErr, err != nil)
+			assert.Equal(t, 0, repo.cache.Len())
+		})
+	}
+}
+
+func Test_TieredInMemoryDatabaseRepository_SetCountIfNotExists(t *testing.T) {
+	tests := []struct {
+		name           string
+		created        bool
+		expectedCached interface{}
+	}{
+		{
+			name:           "cache the created key",
+			created:        true,
+			expectedCached: uint64(2),
+		},
+		{
+			name:    "leave l1 alone when the key exists",
+			created: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			mockRedis.On("SetNX", mock.Anything, "generation", uint64(2), time.Duration(0)).Return(redis.NewBoolResult(tt.created, nil))
+			repo := newTestTieredRepository(mockRedis, nil)
+
+			created, err := repo.SetCountIfNotExists(context.Background(), "generation", 2, 0)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.created, created)
+			cached, _ := repo.cache.Get("generation")
+			assert.Equal(t, tt.expectedCached, cached.value)
+		})
+	}
+}
+
+func Test_TieredInMemoryDatabaseRepository_SetTombstone(t *testing.T) {
+	mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+	mockRedis.On("Set", mock.Anything, "key", tombstoneValue, 10*time.Second).Return(redis.NewStatusResult("OK", nil))
+	mockRedis.On("Get", mock.Anything, "key").Return(redis.NewStringResult(tombstoneValue, nil))
+	mockPubSub := in_memory_database_mocks.NewRedisPubSubClientMock(t)
+	mockPubSub.On("Publish", mock.Anything, "invalidations", invalidationPayload(t, invalidationMessage{Origin: "self", Keys: []string{"key"}})).
+		Return(redis.NewIntResult(1, nil))
+	repo := newTestTieredRepository(mockRedis, mockPubSub)
+	repo.cache.Set("key", tieredEntry{value: testInMemoryEntity{ID: 1}})
+
+	err := repo.SetTombstone(context.Background(), "key", 10*time.Second)
+	assert.NoError(t, err)
+
+	entity, err := repo.Get(context.Background(), "key")
+	assert.Nil(t, entity)
+	assert.True(t, IsTombstone(err), "the tombstone should be read from redis, got %v", err)
+}
+
+func Test_TieredInMemoryDatabaseRepository_Set_cacheFill(t *testing.T) {
+	mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+	mockRedis.On("Set", mock.Anything, "key", mock.Anything, time.Minute).Return(redis.NewStatusResult("OK", nil))
+	repo := newTestTieredRepository(mockRedis, in_memory_database_mocks.NewRedisPubSubClientMock(t))
+
+	err := repo.Set(WithCacheFill(context.Background()), "key", &testInMemoryEntity{ID: 1}, time.Minute)
+
+	assert.NoError(t, err)
+	_, cached := repo.cache.Get("key")
+	assert.True(t, cached, "a fill should be kept in l1 without being published")
+}
+
+func Test_TieredInMemoryDatabaseRepository_TTL(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity])
+		expected    time.Duration
+		expectedErr int
+		cached      bool
+	}{
+		{
+			name: "answer a written key from l1",
+			setup: func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity]) {
+				repo.cache.Set("key", *writtenEntry(testInMemoryEntity{ID: 1}, time.Hour))
+			},
+			expected: time.Hour,
+			cached:   true,
+		},
+		{
+			name: "answer a written key without expiry from l1",
+			setup: func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity]) {
+				repo.cache.Set("key", *writtenEntry(uint64(1), 0))
+			},
+			expected: -1,
+			cached:   true,
+		},
+		{
+			name: "read the ttl of a read key from redis once",
+			setup: func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity]) {
+				repo.cache.Set("key", tieredEntry{value: testInMemoryEntity{ID: 1}})
+				m.On("PTTL", mock.Anything, "key").Return(redis.NewDurationResult(time.Hour, nil)).Once()
+			},
+			expected: time.Hour,
+			cached:   true,
+		},
+		{
+			name: "read the ttl of a key missing from l1 from redis",
+			setup: func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity]) {
+				m.On("PTTL", mock.Anything, "key").Return(redis.NewDurationResult(time.Hour, nil)).Twice()
+			},
+			expected: time.Hour,
+		},
+		{
+			name: "evict a key gone from redis",
+			setup: func(m *in_memory_database_mocks.RedisClientMock, repo *TieredInMemoryDatabaseRepository[testInMemoryEntity]) {
+				repo.cache.Set("key", tieredEntry{value: testInMemoryEntity{ID: 1}})
+				m.On("PTTL", mock.Anything, "key").Return(redis.NewDurationResult(-2, nil)).Twice()
+			},
+			expectedErr: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := in_memory_database_mocks.NewRedisClientMock(t)
+			repo := newTestTieredRepository(mockRedis, nil)
+			tt.setup(mockRedis, repo)
+
+			for i := 0; i < 2; i++ {
+				ttl, err := repo.TTL(context.Background(), "key")
+
+				assert.Equal(t, tt.expectedErr, gocerr.GetErrorCode(err))
+				assert.InDelta(t, tt.expected, ttl, float64(time.Second))
+			}
+			_, cached := repo.cache.Peek("key")
+			assert.Equal(t, tt.cached, cached)
+			assert.Empty(t, repo.pending, "the fill should be unregistered")
+		})
+	}
+}
+
+func Test_TieredInMemoryDatabaseRepository_handleInvalidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     string
+		expectedLen int
+	}{
+		{
+			name:        "evict keys from another replica",
+			payload:     `{"origin":"other","keys":["a","b"]}`,
+			expectedLen: 1,
+		},
+		{
+			name:        "purge on request of another replica",
+			payload:     `{"origin":"other","purge":true}`,
+			expectedLen: 0,
+		},
+		{
+			name:        "ignore own invalidations",
+			payload:     `{"origin":"self","purge":true}`,
+			expectedLen: 3,
+		},
+		{
+			name:        "ignore malformed invalidations",
+			payload:     `not json`,
+			expectedLen: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestTieredRepository(in_memory_database_mocks.NewRedisClientMock(t), nil)
+			repo.cache.Set("a", tieredEntry{value: testInMemoryEntity{ID: 1}})
+			repo.cache.Set("b", tieredEntry{value: testInMemoryEntity{ID: 2}})
+			repo.cache.Set("c", tieredEntry{value: testInMemoryEntity{ID: 3}})
+
+			repo.handleInvalidation(tt.payload)
+
+			assert.Equal(t, tt.expectedLen, repo.cache.Len())
+		})
+	}
+}
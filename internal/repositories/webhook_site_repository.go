@@ -14,7 +14,9 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -44,11 +46,26 @@ func (r *WebhookSiteRepository) SendWebhook(ctx context.Context, requestData *en
 		logFields          map[string]interface{}
 		logLevel           zerolog.Level
 		httpRequestHeaders map[string]string
+		url                string
 		httpResponse       *resty.Response
 		err                error
 	)
 
-	ctx, span = tracer.Start(ctx, "[WebhookSiteRepository][SendWebhook]")
+	url = fmt.Sprintf(
+		"%s%s",
+		r.cfg.Datasource.WebhookSiteHTTPClient.BaseURL,
+		r.cfg.Datasource.WebhookSiteHTTPClient.Endpoint.Webhook,
+	)
+
+	ctx, span = tracer.Start(
+		ctx,
+		"[WebhookSiteRepository][SendWebhook]",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodPost,
+			semconv.URLFull(url),
+		),
+	)
 	defer span.End()
 
 	httpRequestHeaders = map[string]string{
@@ -58,11 +75,7 @@ func (r *WebhookSiteRepository) SendWebhook(ctx context.Context, requestData *en
 	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(httpRequestHeaders))
 
 	logFields = map[string]interface{}{
-		"url": fmt.Sprintf(
-			"%s%s",
-			r.cfg.Datasource.WebhookSiteHTTPClient.BaseURL,
-			r.cfg.Datasource.WebhookSiteHTTPClient.Endpoint.Webhook,
-		),
+		"url":            url,
 		"requestHeaders": httpRequestHeaders,
 		"requestData":    requestData,
 		"requestMethod":  http.MethodPost,
@@ -79,9 +92,12 @@ func (r *WebhookSiteRepository) SendWebhook(ctx context.Context, requestData *en
 			Fields(logFields).
 			Msg("[WebhookSiteRepository][SendWebhook][Post] failed to request http")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 
+	span.SetAttributes(semconv.HTTPResponseStatusCode(httpResponse.StatusCode()))
+
 	logFields["statusCode"] = httpResponse.StatusCode()
 	logFields["responseHeaders"] = httpResponse.Header()
 	logFields["responseBody"] = string(httpResponse.Body())
@@ -89,6 +105,7 @@ func (r *WebhookSiteRepository) SendWebhook(ctx context.Context, requestData *en
 	if httpResponse.StatusCode() >= http.StatusBadRequest {
 		logLevel = zerolog.WarnLevel
 		err = gocerr.New(httpResponse.StatusCode(), string(httpResponse.Body()))
+		span.SetStatus(codes.Error, err.Error())
 
 		if httpResponse.StatusCode() >= http.StatusInternalServerError {
 			logLevel = zerolog.ErrorLevel
@@ -101,6 +118,7 @@ func (r *WebhookSiteRepository) SendWebhook(ctx context.Context, requestData *en
 			Ctx(ctx).
 			Fields(logFields).
 			Msgf("[WebhookSiteRepository][SendWebhook] http response is not success")
+		tracer.RecordError(span, err)
 		return err
 	}
 
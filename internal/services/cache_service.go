@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go-boilerplate/configs"
+	"go-boilerplate/internal/models/dtos"
+	"go-boilerplate/internal/repositories"
+	"go-boilerplate/pkg/tracer"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//mockery:generate: true
+//mockery:structname: CacheServiceMock
+//mockery:filename: cache_service_mock.go
+//mockery:output: internal/services/mocks/
+type ICacheService interface {
+	Flush(ctx context.Context, requestDTO *dtos.FlushCacheRequestDTO) (*dtos.FlushCacheResponseDTO, error)
+	Stats(ctx context.Context, requestDTO *dtos.CacheStatsRequestDTO) (*dtos.CacheStatsResponseDTO, error)
+	Warm(ctx context.Context) (*dtos.WarmCacheResponseDTO, error)
+}
+
+// CacheService manages the guest cache as a whole, warming goes through
+// GuestService, so the entries are written exactly like on a request.
+type CacheService struct {
+	cfg                  *configs.Config
+	guestService         IGuestService
+	guestCacheRepository repositories.IGuestCacheRepository
+}
+
+func NewCacheService(
+	cfg *configs.Config,
+	guestService IGuestService,
+	guestCacheRepository repositories.IGuestCacheRepository,
+) *CacheService {
+	return &CacheService{
+		cfg:                  cfg,
+		guestService:         guestService,
+		guestCacheRepository: guestCacheRepository,
+	}
+}
+
+// keyPrefix is GUEST.CACHE.KEYF up to its placeholder, every guest cache key
+// starts with it.
+func (s *CacheService) keyPrefix() string {
+	return strings.SplitN(s.cfg.Guest.Cache.Keyf, "%s", 2)[0]
+}
+
+// Flush deletes the keys matching the pattern with SCAN. The pattern has to
+// start with the guest cache prefix, so locks and feature flags sharing the
+// Redis are out of reach.
+func (s *CacheService) Flush(ctx context.Context, requestDTO *dtos.FlushCacheRequestDTO) (*dtos.FlushCacheResponseDTO, error) {
+	var (
+		span        trace.Span
+		logFields   map[string]interface{}
+		responseDTO *dtos.FlushCacheResponseDTO
+		err         error
+	)
+
+	ctx, span = tracer.Start(ctx, "[CacheService][Flush]")
+	defer span.End()
+
+	if requestDTO == nil {
+		err = gocerr.New(http.StatusBadRequest, "requestDTO is nil")
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	logFields = map[string]interface{}{
+		"requestDTO": requestDTO,
+	}
+
+	err = requestDTO.Validate()
+	if err != nil {
+		log.Warn().
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[CacheService][Flush][Validate] invalid requestDTO")
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	if !strings.HasPrefix(requestDTO.Pattern, s.keyPrefix()) {
+		err = gocerr.New(http.StatusBadRequest, fmt.Sprintf("pattern must start with %q", s.keyPrefix()))
+		log.Warn().
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[CacheService][Flush] pattern outside of the cache keys")
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	responseDTO = &dtos.FlushCacheResponseDTO{}
+
+	responseDTO.Deleted, err = s.guestCacheRepository.DeleteByPattern(ctx, requestDTO.Pattern)
+	logFields["deleted"] = responseDTO.Deleted
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[CacheService][Flush][DeleteByPattern] failed to flush caches")
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Fields(logFields).
+		Msg("[CacheService][Flush] caches flushed")
+
+	return responseDTO, nil
+}
+
+// Stats counts the keys and their memory per prefix. The pattern defaults to
+// every guest cache key and the depth to one segment past the cache prefix,
+// which separates the entities from the lists.
+func (s *CacheService) Stats(ctx context.Context, requestDTO *dtos.CacheStatsRequestDTO) (*dtos.CacheStatsResponseDTO, error) {
+	var (
+		span      trace.Span
+		logFields map[string]interface{}
+		mutex     sync.Mutex
+		prefixes  map[string]*dtos.CachePrefixStatsDTO
+		err       error
+	)
+
+	ctx, span = tracer.Start(ctx, "[CacheService][Stats]")
+	defer span.End()
+
+	if requestDTO == nil {
+		requestDTO = &dtos.CacheStatsRequestDTO{}
+	}
+
+	if requestDTO.Pattern == "" {
+		requestDTO.Pattern = s.keyPrefix() + "*"
+	}
+
+	if requestDTO.Depth == 0 {
+		requestDTO.Depth = strings.Count(s.keyPrefix(), ":") + 1
+	}
+
+	logFields = map[string]interface{}{
+		"requestDTO": requestDTO,
+	}
+
+	err = requestDTO.Validate()
+	if err != nil {
+		log.Warn().
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[CacheService][Stats][Validate] invalid requestDTO")
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	prefixes = map[string]*dtos.CachePrefixStatsDTO{}
+
+	err = s.guestCacheRepository.MemoryUsageByPattern(ctx, requestDTO.Pattern, func(key string, bytes int64) {
+		var (
+			prefix string = requestDTO.Prefix(key)
+			stats  *dtos.CachePrefixStatsDTO
+			ok     bool
+		)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		stats, ok = prefixes[prefix]
+		if !ok {
+			stats = &dtos.CachePrefixStatsDTO{Prefix: prefix}
+			prefixes[prefix] = stats
+		}
+
+		stats.Keys++
+		stats.MemoryBytes += bytes
+	})
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[CacheService][Stats][MemoryUsageByPattern] failed to get memory usage")
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	return dtos.NewCacheStatsResponseDTO(prefixes), nil
+}
+
+// Warm loads the first GUEST.CACHE.WARM.PAGES default pages and the
+// GUEST.CACHE.WARM.IDS guests one by one, so a cold cache does not send the
+// first minutes of traffic to the database. A failed load is counted and
+// skipped, it is read through on the next request anyway.
+func (s *CacheService) Warm(ctx context.Context) (*dtos.WarmCacheResponseDTO, error) {
+	var (
+		span          trace.Span
+		logFields     map[string]interface{}
+		responseDTO   *dtos.WarmCacheResponseDTO
+		page          int
+		findAllDTO    *dtos.FindAllGuestRequestDTO
+		findAllResult *dtos.FindAllGuestResponseDTO
+		id            string
+		err           error
+	)
+
+	ctx, span = tracer.Start(ctx, "[CacheService][Warm]")
+	defer span.End()
+
+	logFields = map[string]interface{}{
+		"pages": s.cfg.Guest.Cache.Warm.Pages,
+		"ids":   len(s.cfg.Guest.Cache.Warm.IDs),
+	}
+
+	responseDTO = &dtos.WarmCacheResponseDTO{}
+
+	for page = 0; page < s.cfg.Guest.Cache.Warm.Pages; page++ {
+		if ctx.Err() != nil {
+			break
+		}
+
+		findAllDTO = dtos.NewFindAllGuestRequestDTO()
+		findAllDTO.Skip = uint64(page) * findAllDTO.Take
+
+		findAllResult, err = s.guestService.FindAll(ctx, findAllDTO)
+		if err != nil {
+			log.Warn().
+				Ctx(ctx).
+				Err(err).
+				Fields(logFields).
+				Int("page", page).
+				Msg("[CacheService][Warm][FindAll] failed to warm page")
+			responseDTO.Failed++
+			continue
+		}
+		responseDTO.Pages++
+
+		// the later pages are empty
+		if uint64(len(findAllResult.List)) < findAllDTO.Take {
+			break
+		}
+	}
+
+	for _, id = range s.cfg.Guest.Cache.Warm.IDs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		_, err = s.guestService.FindByID(ctx, &dtos.FindGuestByIDRequestDTO{ID: id})
+		// a missing guest is cached as a tombstone, it still counts
+		if err != nil && gocerr.GetErrorCode(err) != http.StatusNotFound {
+			log.Warn().
+				Ctx(ctx).
+				Err(err).
+				Fields(logFields).
+				Str("id", id).
+				Msg("[CacheService][Warm][FindByID] failed to warm guest")
+			responseDTO.Failed++
+			continue
+		}
+		responseDTO.IDs++
+	}
+
+	logFields["responseDTO"] = responseDTO
+
+	err = ctx.Err()
+	if err != nil {
+		log.Warn().
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[CacheService][Warm] warm up interrupted")
+		err = gocerr.New(http.StatusRequestTimeout, err.Error())
+		tracer.RecordError(span, err)
+		return responseDTO, err
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Fields(logFields).
+		Msg("[CacheService][Warm] caches warmed")
+
+	return responseDTO, nil
+}
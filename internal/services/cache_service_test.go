@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-boilerplate/configs"
+	"go-boilerplate/internal/models/dtos"
+	repo_mocks "go-boilerplate/internal/repositories/mocks"
+	service_mocks "go-boilerplate/internal/services/mocks"
+	"net/http"
+	"testing"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestCacheConfig() *configs.Config {
+	cfg := &configs.Config{}
+	cfg.Guest.Cache.Keyf = "caches:guests:%s"
+	return cfg
+}
+
+func Test_NewCacheService(t *testing.T) {
+	cfg := newTestCacheConfig()
+	guestService := service_mocks.NewGuestServiceMock(t)
+	guestCacheRepository := repo_mocks.NewGuestCacheRepositoryMock(t)
+
+	service := NewCacheService(cfg, guestService, guestCacheRepository)
+
+	assert.Equal(t, cfg, service.cfg)
+	assert.Equal(t, guestService, service.guestService)
+	assert.Equal(t, guestCacheRepository, service.guestCacheRepository)
+}
+
+func Test_CacheService_Flush(t *testing.T) {
+	tests := []struct {
+		name            string
+		requestDTO      *dtos.FlushCacheRequestDTO
+		setupCache      func(m *repo_mocks.GuestCacheRepositoryMock)
+		expectedDeleted uint64
+		expectedCode    int
+	}{
+		{
+			name:       "flush keys matching the pattern",
+			requestDTO: &dtos.FlushCacheRequestDTO{Pattern: "caches:guests:list:*"},
+			setupCache: func(m *repo_mocks.GuestCacheRepositoryMock) {
+				m.On("DeleteByPattern", mock.Anything, "caches:guests:list:*").Return(uint64(7), nil)
+			},
+			expectedDeleted: 7,
+		},
+		{
+			name:         "reject pattern outside of the cache keys",
+			requestDTO:   &dtos.FlushCacheRequestDTO{Pattern: "*"},
+			setupCache:   func(m *repo_mocks.GuestCacheRepositoryMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "reject missing pattern",
+			requestDTO:   &dtos.FlushCacheRequestDTO{},
+			setupCache:   func(m *repo_mocks.GuestCacheRepositoryMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "reject nil request",
+			setupCache:   func(m *repo_mocks.GuestCacheRepositoryMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:       "scan error",
+			requestDTO: &dtos.FlushCacheRequestDTO{Pattern: "caches:guests:*"},
+			setupCache: func(m *repo_mocks.GuestCacheRepositoryMock) {
+				m.On("DeleteByPattern", mock.Anything, "caches:guests:*").
+					Return(uint64(3), gocerr.New(http.StatusInternalServerError, "redis scan error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guestCacheRepository := repo_mocks.NewGuestCacheRepositoryMock(t)
+			tt.setupCache(guestCacheRepository)
+			service := NewCacheService(newTestCacheConfig(), nil, guestCacheRepository)
+
+			responseDTO, err := service.Flush(context.Background(), tt.requestDTO)
+
+			if tt.expectedCode != 0 {
+				assert.Equal(t, tt.expectedCode, gocerr.GetErrorCode(err))
+				assert.Nil(t, responseDTO)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedDeleted, responseDTO.Deleted)
+		})
+	}
+}
+
+func Test_CacheService_Stats(t *testing.T) {
+	usage := map[string]int64{
+		"caches:guests:01932293-d710-7f55-a9f6-66e6248ae72f": 100,
+		"caches:guests:01932293-d710-7f55-a9f6-66e6248ae730": 120,
+		"caches:guests:list:1:take=10&skip=0":                400,
+		"caches:guests:list:1:take=10&skip=0:count":          60,
+		"caches:guests:list:generation":                      50,
+	}
+
+	tests := []struct {
+		name            string
+		requestDTO      *dtos.CacheStatsRequestDTO
+		expectedPattern string
+		expected        *dtos.CacheStatsResponseDTO
+		scanErr         error
+		expectedCode    int
+	}{
+		{
+			name:            "group every guest cache key one segment past the prefix",
+			expectedPattern: "caches:guests:*",
+			expected: &dtos.CacheStatsResponseDTO{
+				Prefixes: []dtos.CachePrefixStatsDTO{
+					{Prefix: "caches:guests", Keys: 2, MemoryBytes: 220},
+					{Prefix: "caches:guests:list", Keys: 3, MemoryBytes: 510},
+				},
+				Keys:        5,
+				MemoryBytes: 730,
+			},
+		},
+		{
+			name:            "group with the requested pattern and depth",
+			requestDTO:      &dtos.CacheStatsRequestDTO{Pattern: "caches:guests:list:*", Depth: 2},
+			expectedPattern: "caches:guests:list:*",
+			expected: &dtos.CacheStatsResponseDTO{
+				Prefixes: []dtos.CachePrefixStatsDTO{
+					{Prefix: "caches:guests", Keys: 5, MemoryBytes: 730},
+				},
+				Keys:        5,
+				MemoryBytes: 730,
+			},
+		},
+		{
+			name:         "reject negative depth",
+			requestDTO:   &dtos.CacheStatsRequestDTO{Depth: -1},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:            "scan error",
+			expectedPattern: "caches:guests:*",
+			scanErr:         gocerr.New(http.StatusInternalServerError, "redis scan error"),
+			expectedCode:    http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guestCacheRepository := repo_mocks.NewGuestCacheRepositoryMock(t)
+			if tt.expectedPattern != "" {
+				guestCacheRepository.On("MemoryUsageByPattern", mock.Anything, tt.expectedPattern, mock.Anything).
+					Run(func(args mock.Arguments) {
+						fn := args.Get(2).(func(key string, bytes int64))
+						for key, bytes := range usage {
+							fn(key, bytes)
+						}
+					}).
+					Return(tt.scanErr)
+			}
+			service := NewCacheService(newTestCacheConfig(), nil, guestCacheRepository)
+
+			responseDTO, err := service.Stats(context.Background(), tt.requestDTO)
+
+			if tt.expectedCode != 0 {
+				assert.Equal(t, tt.expectedCode, gocerr.GetErrorCode(err))
+				assert.Nil(t, responseDTO)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, responseDTO)
+		})
+	}
+}
+
+func Test_CacheService_Warm(t *testing.T) {
+	page := func(size int) *dtos.FindAllGuestResponseDTO {
+		return &dtos.FindAllGuestResponseDTO{List: make([]dtos.GuestResponseDTO, size)}
+	}
+
+	tests := []struct {
+		name         string
+		pages        int
+		ids          []string
+		setupService func(m *service_mocks.GuestServiceMock)
+		expected     *dtos.WarmCacheResponseDTO
+	}{
+		{
+			name:  "load every configured page and id",
+			pages: 2,
+			ids:   []string{"a", "b"},
+			setupService: func(m *service_mocks.GuestServiceMock) {
+				m.On("FindAll", mock.Anything, &dtos.FindAllGuestRequestDTO{Take: 10}).Return(page(10), nil).Once()
+				m.On("FindAll", mock.Anything, &dtos.FindAllGuestRequestDTO{Take: 10, Skip: 10}).Return(page(10), nil).Once()
+				m.On("FindByID", mock.Anything, &dtos.FindGuestByIDRequestDTO{ID: "a"}).Return(&dtos.GuestResponseDTO{}, nil).Once()
+				m.On("FindByID", mock.Anything, &dtos.FindGuestByIDRequestDTO{ID: "b"}).Return(&dtos.GuestResponseDTO{}, nil).Once()
+			},
+			expected: &dtos.WarmCacheResponseDTO{Pages: 2, IDs: 2},
+		},
+		{
+			name:  "stop at the last page",
+			pages: 5,
+			setupService: func(m *service_mocks.GuestServiceMock) {
+				m.On("FindAll", mock.Anything, &dtos.FindAllGuestRequestDTO{Take: 10}).Return(page(10), nil).Once()
+				m.On("FindAll", mock.Anything, &dtos.FindAllGuestRequestDTO{Take: 10, Skip: 10}).Return(page(3), nil).Once()
+			},
+			expected: &dtos.WarmCacheResponseDTO{Pages: 2},
+		},
+		{
+			name:  "count failures and keep going",
+			pages: 1,
+			ids:   []string{"missing", "broken", "c"},
+			setupService: func(m *service_mocks.GuestServiceMock) {
+				m.On("FindAll", mock.Anything, mock.Anything).Return(nil, errors.New("database error")).Once()
+				m.On("FindByID", mock.Anything, &dtos.FindGuestByIDRequestDTO{ID: "missing"}).
+					Return(nil, gocerr.New(http.StatusNotFound, "not found")).Once()
+				m.On("FindByID", mock.Anything, &dtos.FindGuestByIDRequestDTO{ID: "broken"}).
+					Return(nil, gocerr.New(http.StatusInternalServerError, "database error")).Once()
+				m.On("FindByID", mock.Anything, &dtos.FindGuestByIDRequestDTO{ID: "c"}).Return(&dtos.GuestResponseDTO{}, nil).Once()
+			},
+			expected: &dtos.WarmCacheResponseDTO{IDs: 2, Failed: 2},
+		},
+		{
+			name:         "nothing configured",
+			setupService: func(m *service_mocks.GuestServiceMock) {},
+			expected:     &dtos.WarmCacheResponseDTO{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestCacheConfig()
+			cfg.Guest.Cache.Warm.Pages = tt.pages
+			cfg.Guest.Cache.Warm.IDs = tt.ids
+			guestService := service_mocks.NewGuestServiceMock(t)
+			tt.setupService(guestService)
+			service := NewCacheService(cfg, guestService, nil)
+
+			responseDTO, err := service.Warm(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, responseDTO)
+		})
+	}
+
+	t.Run("interrupted", func(t *testing.T) {
+		cfg := newTestCacheConfig()
+		cfg.Guest.Cache.Warm.Pages = 3
+		ctx, cancel := context.WithCancel(context.Background())
+		guestService := service_mocks.NewGuestServiceMock(t)
+		guestService.On("FindAll", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				cancel()
+			}).
+			Return(page(10), nil).Once()
+		service := NewCacheService(cfg, guestService, nil)
+
+		responseDTO, err := service.Warm(ctx)
+
+		assert.Equal(t, http.StatusRequestTimeout, gocerr.GetErrorCode(err))
+		assert.Equal(t, &dtos.WarmCacheResponseDTO{Pages: 1}, responseDTO)
+	})
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-boilerplate/configs"
+	"go-boilerplate/internal/repositories"
+	"go-boilerplate/pkg/feature_flag"
+	"go-boilerplate/pkg/lru"
+	"go-boilerplate/pkg/tracer"
+	"net/http"
+	"time"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// defaultFeatureFlagCacheDuration applies while FEATURE_FLAG.CACHE_DURATION
+	// is unset
+	defaultFeatureFlagCacheDuration time.Duration = 5 * time.Second
+	featureFlagCacheCapacity        int           = 1024
+)
+
+var errFeatureFlagBackendNotConfigured error = gocerr.New(http.StatusNotImplemented, "feature flag backend is not configured")
+
+//mockery:generate: true
+//mockery:structname: FeatureFlagServiceMock
+//mockery:filename: feature_flag_service_mock.go
+//mockery:output: internal/services/mocks/
+type IFeatureFlagService interface {
+	IsEnabled(ctx context.Context, name string, fallback bool) bool
+	List(ctx context.Context) ([]feature_flag.Flag, error)
+	Get(ctx context.Context, name string) (*feature_flag.Flag, error)
+	Set(ctx context.Context, flag *feature_flag.Flag) error
+	Delete(ctx context.Context, name string) error
+}
+
+// FeatureFlagService evaluates the flags of the configured backend, without a
+// backend every flag evaluates to its fallback.
+type FeatureFlagService struct {
+	store feature_flag.Store
+
+	// cache keeps the flags IsEnabled read from the redis backend, a nil flag
+	// is an unknown one. It is nil for the file backend, which is already in
+	// memory.
+	cache *lru.Cache[*feature_flag.Flag]
+}
+
+// NewFeatureFlagService uses the file store of the datasources, so every
+// transport built on them sees the flags changed by another.
+func NewFeatureFlagService(
+	cfg *configs.Config,
+	featureFlagFileStore *feature_flag.FileStore,
+	featureFlagRepository repositories.IFeatureFlagRepository,
+) *FeatureFlagService {
+	var (
+		store         feature_flag.Store
+		cache         *lru.Cache[*feature_flag.Flag]
+		cacheDuration time.Duration = cfg.FeatureFlag.CacheDuration
+	)
+
+	if cacheDuration <= 0 {
+		cacheDuration = defaultFeatureFlagCacheDuration
+	}
+
+	switch cfg.FeatureFlag.Backend {
+	case feature_flag.BackendFile:
+		if featureFlagFileStore != nil {
+			store = featureFlagFileStore
+		}
+	case feature_flag.BackendRedis:
+		store = featureFlagRepository
+		cache = lru.New[*feature_flag.Flag](featureFlagCacheCapacity, cacheDuration)
+	}
+
+	return &FeatureFlagService{
+		store: store,
+		cache: cache,
+	}
+}
+
+// storeError keeps the code of repository errors and maps the store errors to
+// their HTTP status.
+func storeError(err error) error {
+	if errors.Is(err, feature_flag.ErrFlagNotFound) {
+		return gocerr.New(http.StatusNotFound, err.Error())
+	}
+
+	if gocerr.GetErrorCode(err) != 0 {
+		return err
+	}
+
+	return gocerr.New(http.StatusInternalServerError, err.Error())
+}
+
+// getCached reads the flag through the cache, unknown flags are cached too
+// while backend errors are not.
+func (s *FeatureFlagService) getCached(ctx context.Context, name string) (*feature_flag.Flag, error) {
+	var (
+		flag  *feature_flag.Flag
+		found bool
+		err   error
+	)
+
+	if s.cache == nil {
+		return s.store.Get(ctx, name)
+	}
+
+	flag, found = s.cache.Get(name)
+	if found {
+		if flag == nil {
+			return nil, feature_flag.ErrFlagNotFound
+		}
+		return flag, nil
+	}
+
+	flag, err = s.store.Get(ctx, name)
+	if err != nil {
+		if errors.Is(err, feature_flag.ErrFlagNotFound) {
+			s.cache.Set(name, nil)
+		}
+		return nil, err
+	}
+
+	s.cache.Set(name, flag)
+
+	return flag, nil
+}
+
+// forget drops the cached flag, so this instance sees its own change at once.
+func (s *FeatureFlagService) forget(name string) {
+	if s.cache != nil {
+		s.cache.Delete(name)
+	}
+}
+
+// IsEnabled returns fallback when there is no backend, the flag is unknown or
+// the backend fails, so a missing flag keeps the static config behavior.
+func (s *FeatureFlagService) IsEnabled(ctx context.Context, name string, fallback bool) bool {
+	var (
+		span trace.Span
+		flag *feature_flag.Flag
+		err  error
+	)
+
+	if s.store == nil {
+		return fallback
+	}
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagService][IsEnabled]")
+	defer span.End()
+
+	flag, err = s.getCached(ctx, name)
+	if err != nil {
+		if !errors.Is(err, feature_flag.ErrFlagNotFound) {
+			log.Err(err).
+				Ctx(ctx).
+				Str("flag", name).
+				Msg("[FeatureFlagService][IsEnabled][getCached] failed to get feature flag, using the fallback")
+			tracer.RecordError(span, err)
+		}
+		return fallback
+	}
+
+	return flag.Evaluate(feature_flag.NewEvaluationContext(ctx))
+}
+
+func (s *FeatureFlagService) List(ctx context.Context) ([]feature_flag.Flag, error) {
+	var (
+		span  trace.Span
+		flags []feature_flag.Flag
+		err   error
+	)
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagService][List]")
+	defer span.End()
+
+	if s.store == nil {
+		err = errFeatureFlagBackendNotConfigured
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	flags, err = s.store.List(ctx)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Msg("[FeatureFlagService][List][List] failed to list feature flags")
+		err = storeError(err)
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+func (s *FeatureFlagService) Get(ctx context.Context, name string) (*feature_flag.Flag, error) {
+	var (
+		span trace.Span
+		flag *feature_flag.Flag
+		err  error
+	)
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagService][Get]")
+	defer span.End()
+
+	if s.store == nil {
+		err = errFeatureFlagBackendNotConfigured
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	flag, err = s.store.Get(ctx, name)
+	if err != nil {
+		if !errors.Is(err, feature_flag.ErrFlagNotFound) {
+			log.Err(err).
+				Ctx(ctx).
+				Str("flag", name).
+				Msg("[FeatureFlagService][Get][Get] failed to get feature flag")
+		}
+		err = storeError(err)
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	return flag, nil
+}
+
+func (s *FeatureFlagService) Set(ctx context.Context, flag *feature_flag.Flag) error {
+	var (
+		span trace.Span
+		err  error
+	)
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagService][Set]")
+	defer span.End()
+
+	if s.store == nil {
+		err = errFeatureFlagBackendNotConfigured
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	err = flag.Validate()
+	if err != nil {
+		err = gocerr.New(http.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	err = s.store.Set(ctx, flag)
+	s.forget(flag.Name)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Interface("flag", flag).
+			Msg("[FeatureFlagService][Set][Set] failed to set feature flag")
+		err = storeError(err)
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Interface("flag", flag).
+		Msg("[FeatureFlagService][Set] feature flag set")
+
+	return nil
+}
+
+func (s *FeatureFlagService) Delete(ctx context.Context, name string) error {
+	var (
+		span trace.Span
+		err  error
+	)
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagService][Delete]")
+	defer span.End()
+
+	if s.store == nil {
+		err = errFeatureFlagBackendNotConfigured
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	err = s.store.Delete(ctx, name)
+	s.forget(name)
+	if err != nil {
+		if !errors.Is(err, feature_flag.ErrFlagNotFound) {
+			log.Err(err).
+				Ctx(ctx).
+				Str("flag", name).
+				Msg("[FeatureFlagService][Delete][Delete] failed to delete feature flag")
+		}
+		err = storeError(err)
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Str("flag", name).
+		Msg("[FeatureFlagService][Delete] feature flag deleted")
+
+	return nil
+}
@@ -0,0 +1,443 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-boilerplate/configs"
+	repo_mocks "go-boilerplate/internal/repositories/mocks"
+	"go-boilerplate/pkg/constants"
+	"go-boilerplate/pkg/feature_flag"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewFeatureFlagService(t *testing.T) {
+	fileStore, err := feature_flag.NewFileStore(filepath.Join(t.TempDir(), "feature_flags.json"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		backend     string
+		fileStore   *feature_flag.FileStore
+		expectStore bool
+		expectFile  bool
+		expectCache bool
+	}{
+		{
+			name: "no backend",
+		},
+		{
+			name:        "file backend uses the shared file store",
+			backend:     feature_flag.BackendFile,
+			fileStore:   fileStore,
+			expectStore: true,
+			expectFile:  true,
+		},
+		{
+			name:    "file backend without file store",
+			backend: feature_flag.BackendFile,
+		},
+		{
+			name:        "redis backend caches the flags",
+			backend:     feature_flag.BackendRedis,
+			fileStore:   fileStore,
+			expectStore: true,
+			expectCache: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			featureFlagRepository := repo_mocks.NewFeatureFlagRepositoryMock(t)
+			cfg := &configs.Config{}
+			cfg.FeatureFlag.Backend = tt.backend
+
+			service := NewFeatureFlagService(cfg, tt.fileStore, featureFlagRepository)
+
+			assert.Equal(t, tt.expectStore, service.store != nil)
+			assert.Equal(t, tt.expectCache, service.cache != nil)
+			if tt.expectStore && !tt.expectFile {
+				assert.Equal(t, featureFlagRepository, service.store)
+			}
+			if tt.expectFile {
+				assert.Same(t, tt.fileStore, service.store)
+			}
+		})
+	}
+
+	t.Run("services sharing the file store see each other's changes", func(t *testing.T) {
+		cfg := &configs.Config{}
+		cfg.FeatureFlag.Backend = feature_flag.BackendFile
+		httpService := NewFeatureFlagService(cfg, fileStore, nil)
+		grpcService := NewFeatureFlagService(cfg, fileStore, nil)
+
+		assert.False(t, grpcService.IsEnabled(context.Background(), "shared", false))
+		assert.NoError(t, httpService.Set(context.Background(), &feature_flag.Flag{Name: "shared", Enabled: true, Percentage: 100}))
+		assert.True(t, grpcService.IsEnabled(context.Background(), "shared", false))
+	})
+}
+
+func Test_FeatureFlagService_IsEnabled(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		setupMock func(m *repo_mocks.FeatureFlagRepositoryMock)
+		noStore   bool
+		fallback  bool
+		expected  bool
+	}{
+		{
+			name:     "no backend returns fallback",
+			ctx:      context.Background(),
+			noStore:  true,
+			fallback: true,
+			expected: true,
+		},
+		{
+			name: "unknown flag returns fallback",
+			ctx:  context.Background(),
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(nil, feature_flag.ErrFlagNotFound)
+			},
+			fallback: true,
+			expected: true,
+		},
+		{
+			name: "backend error returns fallback",
+			ctx:  context.Background(),
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(nil, gocerr.New(http.StatusInternalServerError, "connection refused"))
+			},
+			fallback: true,
+			expected: true,
+		},
+		{
+			name: "disabled flag overrides fallback",
+			ctx:  context.Background(),
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(&feature_flag.Flag{Name: "guest.cache", Percentage: 100}, nil)
+			},
+			fallback: true,
+			expected: false,
+		},
+		{
+			name: "flag is evaluated against the tenant of the context",
+			ctx:  context.WithValue(context.Background(), constants.ContextKeyTenantID, "acme"),
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(&feature_flag.Flag{Name: "guest.cache", Enabled: true, Tenants: []string{"acme"}}, nil)
+			},
+			fallback: false,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &FeatureFlagService{}
+			if !tt.noStore {
+				featureFlagRepository := repo_mocks.NewFeatureFlagRepositoryMock(t)
+				tt.setupMock(featureFlagRepository)
+				service.store = featureFlagRepository
+			}
+
+			assert.Equal(t, tt.expected, service.IsEnabled(tt.ctx, "guest.cache", tt.fallback))
+		})
+	}
+}
+
+func Test_FeatureFlagService_IsEnabled_cache(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(m *repo_mocks.FeatureFlagRepositoryMock)
+		between   func(t *testing.T, service *FeatureFlagService)
+		expected  []bool
+	}{
+		{
+			name: "flag is read once",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(&feature_flag.Flag{Name: "guest.cache", Enabled: true, Percentage: 100}, nil).Once()
+			},
+			expected: []bool{true, true},
+		},
+		{
+			name: "unknown flag is read once",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(nil, feature_flag.ErrFlagNotFound).Once()
+			},
+			expected: []bool{false, false},
+		},
+		{
+			name: "backend error is not cached",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(nil, errors.New("connection refused")).Once()
+				m.On("Get", mock.Anything, "guest.cache").Return(&feature_flag.Flag{Name: "guest.cache", Enabled: true, Percentage: 100}, nil).Once()
+			},
+			expected: []bool{false, true},
+		},
+		{
+			name: "set flag is read again",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(nil, feature_flag.ErrFlagNotFound).Once()
+				m.On("Set", mock.Anything, mock.Anything).Return(nil).Once()
+				m.On("Get", mock.Anything, "guest.cache").Return(&feature_flag.Flag{Name: "guest.cache", Enabled: true, Percentage: 100}, nil).Once()
+			},
+			between: func(t *testing.T, service *FeatureFlagService) {
+				assert.NoError(t, service.Set(context.Background(), &feature_flag.Flag{Name: "guest.cache", Enabled: true, Percentage: 100}))
+			},
+			expected: []bool{false, true},
+		},
+		{
+			name: "deleted flag is read again",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(&feature_flag.Flag{Name: "guest.cache", Enabled: true, Percentage: 100}, nil).Once()
+				m.On("Delete", mock.Anything, "guest.cache").Return(nil).Once()
+				m.On("Get", mock.Anything, "guest.cache").Return(nil, feature_flag.ErrFlagNotFound).Once()
+			},
+			between: func(t *testing.T, service *FeatureFlagService) {
+				assert.NoError(t, service.Delete(context.Background(), "guest.cache"))
+			},
+			expected: []bool{true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			featureFlagRepository := repo_mocks.NewFeatureFlagRepositoryMock(t)
+			tt.setupMock(featureFlagRepository)
+			cfg := &configs.Config{}
+			cfg.FeatureFlag.Backend = feature_flag.BackendRedis
+			cfg.FeatureFlag.CacheDuration = time.Minute
+			service := NewFeatureFlagService(cfg, nil, featureFlagRepository)
+
+			assert.Equal(t, tt.expected[0], service.IsEnabled(context.Background(), "guest.cache", false))
+			if tt.between != nil {
+				tt.between(t, service)
+			}
+			assert.Equal(t, tt.expected[1], service.IsEnabled(context.Background(), "guest.cache", false))
+		})
+	}
+}
+
+func Test_FeatureFlagService_List(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMock    func(m *repo_mocks.FeatureFlagRepositoryMock)
+		noStore      bool
+		expected     []feature_flag.Flag
+		expectedCode int
+	}{
+		{
+			name:         "no backend returns not implemented",
+			noStore:      true,
+			expectedCode: http.StatusNotImplemented,
+		},
+		{
+			name: "flags are returned",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("List", mock.Anything).Return([]feature_flag.Flag{{Name: "guest.cache"}}, nil)
+			},
+			expected: []feature_flag.Flag{{Name: "guest.cache"}},
+		},
+		{
+			name: "store error returns internal server error",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("List", mock.Anything).Return(nil, errors.New("disk failure"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &FeatureFlagService{}
+			if !tt.noStore {
+				featureFlagRepository := repo_mocks.NewFeatureFlagRepositoryMock(t)
+				tt.setupMock(featureFlagRepository)
+				service.store = featureFlagRepository
+			}
+
+			flags, err := service.List(context.Background())
+
+			if tt.expectedCode != 0 {
+				assert.Equal(t, tt.expectedCode, gocerr.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, flags)
+		})
+	}
+}
+
+func Test_FeatureFlagService_Get(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMock    func(m *repo_mocks.FeatureFlagRepositoryMock)
+		noStore      bool
+		expected     *feature_flag.Flag
+		expectedCode int
+	}{
+		{
+			name:         "no backend returns not implemented",
+			noStore:      true,
+			expectedCode: http.StatusNotImplemented,
+		},
+		{
+			name: "flag is returned",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(&feature_flag.Flag{Name: "guest.cache"}, nil)
+			},
+			expected: &feature_flag.Flag{Name: "guest.cache"},
+		},
+		{
+			name: "unknown flag returns not found",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(nil, feature_flag.ErrFlagNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "repository error keeps its code",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(nil, gocerr.New(http.StatusServiceUnavailable, "unavailable"))
+			},
+			expectedCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &FeatureFlagService{}
+			if !tt.noStore {
+				featureFlagRepository := repo_mocks.NewFeatureFlagRepositoryMock(t)
+				tt.setupMock(featureFlagRepository)
+				service.store = featureFlagRepository
+			}
+
+			flag, err := service.Get(context.Background(), "guest.cache")
+
+			if tt.expectedCode != 0 {
+				assert.Equal(t, tt.expectedCode, gocerr.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, flag)
+		})
+	}
+}
+
+func Test_FeatureFlagService_Set(t *testing.T) {
+	tests := []struct {
+		name         string
+		flag         *feature_flag.Flag
+		setupMock    func(m *repo_mocks.FeatureFlagRepositoryMock)
+		noStore      bool
+		expectedCode int
+	}{
+		{
+			name:         "no backend returns not implemented",
+			flag:         &feature_flag.Flag{Name: "guest.cache"},
+			noStore:      true,
+			expectedCode: http.StatusNotImplemented,
+		},
+		{
+			name: "flag is set",
+			flag: &feature_flag.Flag{Name: "guest.cache", Enabled: true, Percentage: 50},
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Set", mock.Anything, &feature_flag.Flag{Name: "guest.cache", Enabled: true, Percentage: 50}).Return(nil)
+			},
+		},
+		{
+			name:         "invalid flag returns bad request",
+			flag:         &feature_flag.Flag{Name: "guest.cache", Percentage: 150},
+			setupMock:    func(m *repo_mocks.FeatureFlagRepositoryMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "store error returns internal server error",
+			flag: &feature_flag.Flag{Name: "guest.cache"},
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Set", mock.Anything, mock.Anything).Return(errors.New("disk failure"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &FeatureFlagService{}
+			if !tt.noStore {
+				featureFlagRepository := repo_mocks.NewFeatureFlagRepositoryMock(t)
+				tt.setupMock(featureFlagRepository)
+				service.store = featureFlagRepository
+			}
+
+			err := service.Set(context.Background(), tt.flag)
+
+			if tt.expectedCode != 0 {
+				assert.Equal(t, tt.expectedCode, gocerr.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_FeatureFlagService_Delete(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMock    func(m *repo_mocks.FeatureFlagRepositoryMock)
+		noStore      bool
+		expectedCode int
+	}{
+		{
+			name:         "no backend returns not implemented",
+			noStore:      true,
+			expectedCode: http.StatusNotImplemented,
+		},
+		{
+			name: "flag is deleted",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Delete", mock.Anything, "guest.cache").Return(nil)
+			},
+		},
+		{
+			name: "unknown flag returns not found",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Delete", mock.Anything, "guest.cache").Return(feature_flag.ErrFlagNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "store error returns internal server error",
+			setupMock: func(m *repo_mocks.FeatureFlagRepositoryMock) {
+				m.On("Delete", mock.Anything, "guest.cache").Return(errors.New("disk failure"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &FeatureFlagService{}
+			if !tt.noStore {
+				featureFlagRepository := repo_mocks.NewFeatureFlagRepositoryMock(t)
+				tt.setupMock(featureFlagRepository)
+				service.store = featureFlagRepository
+			}
+
+			err := service.Delete(context.Background(), "guest.cache")
+
+			if tt.expectedCode != 0 {
+				assert.Equal(t, tt.expectedCode, gocerr.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -7,17 +7,41 @@ import (
 	"go-boilerplate/internal/models/dtos"
 	"go-boilerplate/internal/models/entities"
 	"go-boilerplate/internal/repositories"
+	"go-boilerplate/pkg/metrics"
 	"go-boilerplate/pkg/tracer"
 	"net/http"
 	"regexp"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/fikri240794/gocerr"
 	"github.com/fikri240794/goqube"
 	"github.com/fikri240794/gotask"
+	"github.com/gofrs/uuid/v5"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	guestEntityCacheName string = "guest"
+	guestListCacheName   string = "guest_list"
+	guestCountCacheName  string = "guest_count"
+
+	// defaultCacheLoadTimeout applies while GUEST.CACHE.LOAD_TIMEOUT is unset
+	defaultCacheLoadTimeout time.Duration = 10 * time.Second
+
+	// without a stored flag the matching GUEST.* config applies
+	featureFlagGuestCache            string = "guest.cache"
+	featureFlagGuestEventCreated     string = "guest.event.created"
+	featureFlagGuestEventDeleted     string = "guest.event.deleted"
+	featureFlagGuestEventUpdated     string = "guest.event.updated"
+	featureFlagGuestEventBulkCreated string = "guest.event.bulk_created"
+	featureFlagGuestEventBulkUpdated string = "guest.event.bulk_updated"
+	featureFlagGuestEventBulkDeleted string = "guest.event.bulk_deleted"
+	featureFlagGuestEventErased      string = "guest.event.erased"
 )
 
 //mockery:generate: true
@@ -30,8 +54,11 @@ type IGuestService interface {
 	BulkUpdate(ctx context.Context, requestDTO *dtos.BulkUpdateGuestsRequestDTO) (*dtos.BulkUpdateGuestsResponseDTO, error)
 	Create(ctx context.Context, requestDTO *dtos.CreateGuestRequestDTO) (*dtos.GuestResponseDTO, error)
 	DeleteByID(ctx context.Context, requestDTO *dtos.DeleteGuestByIDRequestDTO) error
+	EraseByID(ctx context.Context, requestDTO *dtos.EraseGuestByIDRequestDTO) error
+	ExportByID(ctx context.Context, requestDTO *dtos.ExportGuestByIDRequestDTO) (*dtos.ExportGuestResponseDTO, error)
 	FindAll(ctx context.Context, requestDTO *dtos.FindAllGuestRequestDTO) (*dtos.FindAllGuestResponseDTO, error)
 	FindByID(ctx context.Context, requestDTO *dtos.FindGuestByIDRequestDTO) (*dtos.GuestResponseDTO, error)
+	PurgeCaches(ctx context.Context) (uint64, error)
 	UpdateByID(ctx context.Context, requestDTO *dtos.UpdateGuestByIDRequestDTO) (*dtos.GuestResponseDTO, error)
 	ProcessEvent(ctx context.Context, requestDTO *dtos.GuestEventRequestDTO) (*dtos.GuestEventResponseDTO, error)
 }
@@ -42,6 +69,16 @@ type GuestService struct {
 	guestCacheRepository         repositories.IGuestCacheRepository
 	guestEventProducerRepository repositories.IGuestEventProducerRepository
 	webhookSiteRepository        repositories.IWebhookSiteRepository
+	featureFlagService           IFeatureFlagService
+
+	// loadGroup coalesces the database loads behind a cache key
+	loadGroup singleflight.Group
+
+	// reloadedCfg is the latest hot reloaded snapshot, nil until a reload
+	reloadedCfg atomic.Pointer[configs.Config]
+
+	// unsubscribe stops following the config hot reload
+	unsubscribe func()
 }
 
 func NewGuestService(
@@ -50,54 +87,384 @@ func NewGuestService(
 	guestCacheRepository repositories.IGuestCacheRepository,
 	guestEventProducerRepository repositories.IGuestEventProducerRepository,
 	webhookSiteRepository repositories.IWebhookSiteRepository,
+	featureFlagService IFeatureFlagService,
 ) *GuestService {
-	return &GuestService{
+	var service *GuestService = &GuestService{
 		cfg:                          cfg,
 		guestRepository:              guestRepository,
 		guestCacheRepository:         guestCacheRepository,
 		guestEventProducerRepository: guestEventProducerRepository,
 		webhookSiteRepository:        webhookSiteRepository,
+		featureFlagService:           featureFlagService,
+	}
+
+	// cache TTLs and event toggles are read on every call, so the latest
+	// snapshot is enough to pick them up
+	service.unsubscribe = configs.Subscribe(func(change *configs.Change) {
+		service.reloadedCfg.Store(change.Current)
+	})
+
+	return service
+}
+
+// ProvideGuestService is NewGuestService for wire, the cleanup closes the
+// service.
+func ProvideGuestService(
+	cfg *configs.Config,
+	guestRepository repositories.IGuestRepository,
+	guestCacheRepository repositories.IGuestCacheRepository,
+	guestEventProducerRepository repositories.IGuestEventProducerRepository,
+	webhookSiteRepository repositories.IWebhookSiteRepository,
+	featureFlagService IFeatureFlagService,
+) (*GuestService, func()) {
+	var service *GuestService = NewGuestService(
+		cfg,
+		guestRepository,
+		guestCacheRepository,
+		guestEventProducerRepository,
+		webhookSiteRepository,
+		featureFlagService,
+	)
+
+	return service, service.Close
+}
+
+// Close stops following the config hot reload, the service keeps the last
+// snapshot.
+func (s *GuestService) Close() {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+}
+
+func (s *GuestService) config() *configs.Config {
+	var cfg *configs.Config = s.reloadedCfg.Load()
+
+	if cfg == nil {
+		cfg = s.cfg
+	}
+
+	return cfg
+}
+
+// isEnabled evaluates the feature flag name, falling back to the static config
+// when there is no feature flag service.
+func (s *GuestService) isEnabled(ctx context.Context, name string, fallback bool) bool {
+	if s.featureFlagService == nil {
+		return fallback
+	}
+
+	return s.featureFlagService.IsEnabled(ctx, name, fallback)
+}
+
+// cacheHardTTL is the expiration a cache is written with, falling back to
+// GUEST.CACHE.DURATION.
+func (s *GuestService) cacheHardTTL(hard time.Duration) time.Duration {
+	if hard <= 0 {
+		return s.config().Guest.Cache.Duration
+	}
+
+	return hard
+}
+
+// isCacheStale reports whether the entry at cacheKey is older than its soft
+// TTL. Redis only knows the hard expiry, so the age is derived from the
+// remaining TTL and nothing is stale while the soft TTL is unset.
+func (s *GuestService) isCacheStale(ctx context.Context, cacheKey string, soft time.Duration, hard time.Duration) bool {
+	var (
+		logFields map[string]interface{}
+		ttl       time.Duration
+		err       error
+	)
+
+	hard = s.cacheHardTTL(hard)
+	if soft <= 0 || soft >= hard {
+		return false
+	}
+
+	logFields = map[string]interface{}{
+		"cacheKey": cacheKey,
+		"soft":     soft,
+		"hard":     hard,
+	}
+
+	ttl, err = s.guestCacheRepository.TTL(ctx, cacheKey)
+	if err != nil {
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[GuestService][isCacheStale][TTL] failed to get cache ttl")
+		}
+		return false
+	}
+
+	return ttl > 0 && hard-ttl >= soft
+}
+
+// cacheLoadTimeout bounds a load that no longer ends with its caller.
+func (s *GuestService) cacheLoadTimeout() time.Duration {
+	if s.config().Guest.Cache.LoadTimeout <= 0 {
+		return defaultCacheLoadTimeout
 	}
+
+	return s.config().Guest.Cache.LoadTimeout
+}
+
+// detachLoad runs load without the cancellation of ctx, since the result is
+// shared by callers other than the one that started it.
+func (s *GuestService) detachLoad(
+	ctx context.Context,
+	load func(ctx context.Context) (interface{}, error),
+) (interface{}, error) {
+	var cancel context.CancelFunc
+
+	ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), s.cacheLoadTimeout())
+	defer cancel()
+
+	return load(ctx)
+}
+
+// loadCache coalesces concurrent loads of cacheKey, only the first caller
+// reaches the database and the others wait for its result. A cancelled first
+// caller does not fail the others.
+func (s *GuestService) loadCache(
+	ctx context.Context,
+	cacheKey string,
+	load func(ctx context.Context) (interface{}, error),
+) (interface{}, error) {
+	var (
+		result interface{}
+		err    error
+	)
+
+	result, err, _ = s.loadGroup.Do(cacheKey, func() (interface{}, error) {
+		return s.detachLoad(ctx, load)
+	})
+
+	return result, err
+}
+
+// refreshCache reloads a stale entry in the background while the caller is
+// served the stale one, concurrent refreshes of cacheKey are coalesced.
+func (s *GuestService) refreshCache(
+	ctx context.Context,
+	cacheKey string,
+	load func(ctx context.Context) (interface{}, error),
+) {
+	s.loadGroup.DoChan(cacheKey, func() (interface{}, error) {
+		return s.detachLoad(ctx, load)
+	})
 }
 
-func (s *GuestService) deleteEntityCaches(ctx context.Context) error {
+// listCacheGenerationKey holds the generation the list and count cache keys
+// are built on, replacing it leaves every cached page behind to expire.
+func (s *GuestService) listCacheGenerationKey() string {
+	return fmt.Sprintf(s.config().Guest.Cache.Keyf, "list:generation")
+}
+
+// newListCacheGeneration is a timestamp, so a generation lost to an eviction
+// or a flush is never handed out again while its pages may still be cached.
+func newListCacheGeneration() uint64 {
+	return uint64(time.Now().UnixNano())
+}
+
+// listCacheGeneration returns the current generation, starting a new one when
+// the key is missing. The first caller to set it wins.
+func (s *GuestService) listCacheGeneration(ctx context.Context) (uint64, error) {
+	var (
+		span       trace.Span
+		generation uint64
+		created    bool
+		err        error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestService][listCacheGeneration]")
+	defer span.End()
+
+	generation, err = s.guestCacheRepository.GetCount(ctx, s.listCacheGenerationKey())
+	if err == nil {
+		return generation, nil
+	}
+
+	if gocerr.GetErrorCode(err) != http.StatusNotFound {
+		tracer.RecordError(span, err)
+		return 0, err
+	}
+
+	generation = newListCacheGeneration()
+	created, err = s.guestCacheRepository.SetCountIfNotExists(ctx, s.listCacheGenerationKey(), generation, 0)
+	if err != nil {
+		tracer.RecordError(span, err)
+		return 0, err
+	}
+
+	if created {
+		return generation, nil
+	}
+
+	generation, err = s.guestCacheRepository.GetCount(ctx, s.listCacheGenerationKey())
+	if err != nil {
+		tracer.RecordError(span, err)
+		return 0, err
+	}
+
+	return generation, nil
+}
+
+// startListCacheGeneration starts a new list cache generation. Writes start it
+// before touching an ID key, so a load that read the database before the write
+// sees the generation change and does not cache what it read.
+func (s *GuestService) startListCacheGeneration(ctx context.Context) error {
+	var (
+		span trace.Span
+		err  error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestService][startListCacheGeneration]")
+	defer span.End()
+
+	err = s.guestCacheRepository.SetCount(ctx, s.listCacheGenerationKey(), newListCacheGeneration(), 0)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Msg("[GuestService][startListCacheGeneration][SetCount] failed to start list cache generation")
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// deleteEntityCaches deletes the cache of each entity by its ID.
+func (s *GuestService) deleteEntityCaches(ctx context.Context, entities_ ...entities.GuestEntity) error {
 	var (
 		span      trace.Span
 		logFields map[string]interface{}
-		pattern   string
 		keys      []string
 		err       error
 	)
 
+	if len(entities_) == 0 {
+		return nil
+	}
+
 	ctx, span = tracer.Start(ctx, "[GuestService][deleteEntityCaches]")
 	defer span.End()
 
-	logFields = map[string]interface{}{}
+	for i := range entities_ {
+		keys = append(keys, fmt.Sprintf(s.config().Guest.Cache.Keyf, entities_[i].ID.String()))
+	}
 
-	pattern = fmt.Sprintf(s.cfg.Guest.Cache.Keyf, "*")
-	logFields["pattern"] = pattern
+	logFields = map[string]interface{}{
+		"keys": keys,
+	}
 
-	keys, err = s.guestCacheRepository.Keys(ctx, pattern)
+	err = s.guestCacheRepository.Delete(ctx, keys...)
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
-			Msg("[GuestService][deleteEntityCaches][Keys] failed to get cache keys")
+			Msg("[GuestService][deleteEntityCaches][Delete] failed to delete caches")
+		tracer.RecordError(span, err)
 		return err
 	}
 
-	if len(keys) <= 0 {
-		return nil
+	return nil
+}
+
+// invalidateEntityCaches starts a new list cache generation and deletes the
+// cache of each entity by its ID, so no write has to walk the keyspace.
+func (s *GuestService) invalidateEntityCaches(ctx context.Context, entities_ ...entities.GuestEntity) error {
+	var (
+		span trace.Span
+		err  error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestService][invalidateEntityCaches]")
+	defer span.End()
+
+	err = s.startListCacheGeneration(ctx)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Msg("[GuestService][invalidateEntityCaches][startListCacheGeneration] failed to start list cache generation")
+		tracer.RecordError(span, err)
+		return err
 	}
 
-	logFields["keys"] = keys
+	err = s.deleteEntityCaches(ctx, entities_...)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Msg("[GuestService][invalidateEntityCaches][deleteEntityCaches] failed to delete caches")
+		tracer.RecordError(span, err)
+		return err
+	}
 
-	err = s.guestCacheRepository.Delete(ctx, keys...)
+	return nil
+}
+
+// writeThroughEntityCaches starts a new list cache generation and writes each
+// entity to its ID key, replacing any tombstone. An ID that appears more than
+// once, or whose write fails, is deleted instead, since which of its versions
+// the database kept is not known here.
+func (s *GuestService) writeThroughEntityCaches(ctx context.Context, entities_ ...entities.GuestEntity) error {
+	var (
+		span          trace.Span
+		logFields     map[string]interface{}
+		occurrences   map[uuid.UUID]int
+		id            uuid.UUID
+		staleEntities []entities.GuestEntity
+		err           error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestService][writeThroughEntityCaches]")
+	defer span.End()
+
+	logFields = map[string]interface{}{}
+
+	err = s.startListCacheGeneration(ctx)
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
-			Msg("[GuestService][deleteEntityCaches][Delete] failed to delete caches")
+			Msg("[GuestService][writeThroughEntityCaches][startListCacheGeneration] failed to start list cache generation")
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	occurrences = map[uuid.UUID]int{}
+	for i := range entities_ {
+		occurrences[entities_[i].ID]++
+	}
+
+	for i := range entities_ {
+		id = entities_[i].ID
+
+		switch occurrences[id] {
+		case 0:
+			// a duplicate that is already deleted
+		case 1:
+			err = s.setEntityByIDCache(ctx, fmt.Sprintf(s.config().Guest.Cache.Keyf, id.String()), &entities_[i])
+			if err != nil {
+				staleEntities = append(staleEntities, entities_[i])
+			}
+		default:
+			staleEntities = append(staleEntities, entities_[i])
+			occurrences[id] = 0
+		}
+	}
+
+	logFields["staleEntities"] = staleEntities
+
+	err = s.deleteEntityCaches(ctx, staleEntities...)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[GuestService][writeThroughEntityCaches][deleteEntityCaches] failed to delete caches")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -189,15 +556,53 @@ func (s *GuestService) buildActiveEntityFilterByIDs(ids ...string) *goqube.Filte
 	}
 }
 
-func (s *GuestService) tryDeleteEntityCaches(ctx context.Context, logFields map[string]interface{}, fnName string) {
+func (s *GuestService) buildEntityFilterByID(id string) *goqube.Filter {
+	return &goqube.Filter{
+		Field:    goqube.Field{Column: entities.GuestEntityDatabaseFieldID},
+		Operator: goqube.OperatorEqual,
+		Value:    goqube.FilterValue{Value: id},
+	}
+}
+
+func (s *GuestService) tryInvalidateEntityCaches(
+	ctx context.Context,
+	logFields map[string]interface{},
+	fnName string,
+	entities_ ...entities.GuestEntity,
+) {
 	var err error
 
-	err = s.deleteEntityCaches(ctx)
+	err = s.invalidateEntityCaches(ctx, entities_...)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg(fmt.Sprintf("[GuestService][%s][invalidateEntityCaches] failed to invalidate caches", fnName))
+	}
+}
+
+// tryRefreshEntityCaches writes the entities through when writeThrough is on
+// and the guest cache is enabled, and invalidates them otherwise.
+func (s *GuestService) tryRefreshEntityCaches(
+	ctx context.Context,
+	logFields map[string]interface{},
+	fnName string,
+	writeThrough bool,
+	entities_ ...entities.GuestEntity,
+) {
+	var err error
+
+	if !writeThrough || !s.isEnabled(ctx, featureFlagGuestCache, s.config().Guest.Cache.Enable) {
+		s.tryInvalidateEntityCaches(ctx, logFields, fnName, entities_...)
+		return
+	}
+
+	err = s.writeThroughEntityCaches(ctx, entities_...)
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
-			Msg(fmt.Sprintf("[GuestService][%s][deleteEntityCaches] failed to delete caches", fnName))
+			Msg(fmt.Sprintf("[GuestService][%s][writeThroughEntityCaches] failed to write through caches", fnName))
 	}
 }
 
@@ -280,6 +685,7 @@ func (s *GuestService) Create(ctx context.Context, requestDTO *dtos.CreateGuestR
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestService][Create][Validate] failed to validate dto")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -290,14 +696,15 @@ func (s *GuestService) Create(ctx context.Context, requestDTO *dtos.CreateGuestR
 		return s.guestRepository.WithTransaction(tx).Create(ctx, entity)
 	})
 	if err != nil {
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
 	responseDTO = dtos.NewGuestResponseDTO(entity)
 	logFields["responseDTO"] = responseDTO
 
-	s.tryDeleteEntityCaches(ctx, logFields, "Create")
-	s.publishEvent(ctx, logFields, s.cfg.Guest.Event.Created.Enable, s.cfg.Guest.Event.Created.Topic, "Create", *entity)
+	s.tryRefreshEntityCaches(ctx, logFields, "Create", s.config().Guest.Cache.WriteThrough.Create, *entity)
+	s.publishEvent(ctx, logFields, s.isEnabled(ctx, featureFlagGuestEventCreated, s.config().Guest.Event.Created.Enable), s.config().Guest.Event.Created.Topic, "Create", *entity)
 
 	return responseDTO, nil
 }
@@ -330,6 +737,7 @@ func (s *GuestService) DeleteByID(ctx context.Context, requestDTO *dtos.DeleteGu
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestService][DeleteByID][Validate] failed to validate dto")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -348,6 +756,7 @@ func (s *GuestService) DeleteByID(ctx context.Context, requestDTO *dtos.DeleteGu
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestService][DeleteByID][FindOne] failed to find entity")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -358,15 +767,160 @@ func (s *GuestService) DeleteByID(ctx context.Context, requestDTO *dtos.DeleteGu
 		return s.guestRepository.WithTransaction(tx).Update(ctx, entity, filter)
 	})
 	if err != nil {
+		tracer.RecordError(span, err)
 		return err
 	}
 
-	s.tryDeleteEntityCaches(ctx, logFields, "DeleteByID")
-	s.publishEvent(ctx, logFields, s.cfg.Guest.Event.Deleted.Enable, s.cfg.Guest.Event.Deleted.Topic, "DeleteByID", *entity)
+	s.tryInvalidateEntityCaches(ctx, logFields, "DeleteByID", *entity)
+	s.publishEvent(ctx, logFields, s.isEnabled(ctx, featureFlagGuestEventDeleted, s.config().Guest.Event.Deleted.Enable), s.config().Guest.Event.Deleted.Topic, "DeleteByID", *entity)
 
 	return nil
 }
 
+func (s *GuestService) EraseByID(ctx context.Context, requestDTO *dtos.EraseGuestByIDRequestDTO) error {
+	var (
+		span      trace.Span
+		logFields map[string]interface{}
+		filter    *goqube.Filter
+		entity    *entities.GuestEntity
+		logLevel  zerolog.Level
+		err       error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestService][EraseByID]")
+	defer span.End()
+
+	if requestDTO == nil {
+		return gocerr.New(http.StatusBadRequest, "requestDTO is nil")
+	}
+
+	logFields = map[string]interface{}{
+		"requestDTO": requestDTO,
+	}
+
+	err = requestDTO.Validate()
+	if err != nil {
+		log.Warn().
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[GuestService][EraseByID][Validate] failed to validate dto")
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	filter = s.buildEntityFilterByID(requestDTO.ID)
+	logFields["filter"] = filter
+
+	entity, err = s.guestRepository.FindOne(ctx, filter, nil, false)
+	if err != nil {
+		logLevel = zerolog.WarnLevel
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			logLevel = zerolog.ErrorLevel
+		}
+
+		log.WithLevel(logLevel).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[GuestService][EraseByID][FindOne] failed to find entity")
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	entity = entity.Erase(requestDTO.ErasedBy)
+	logFields["entity"] = entity
+
+	err = s.withTransaction(ctx, logFields, "EraseByID", func(tx repositories.IBoilerplateDatabaseTransaction) error {
+		return s.guestRepository.WithTransaction(tx).Update(ctx, entity, filter)
+	})
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	s.tryInvalidateEntityCaches(ctx, logFields, "EraseByID", *entity)
+	s.publishEvent(ctx, logFields, s.isEnabled(ctx, featureFlagGuestEventErased, s.config().Guest.Event.Erased.Enable), s.config().Guest.Event.Erased.Topic, "EraseByID", *entity)
+
+	return nil
+}
+
+func (s *GuestService) ExportByID(ctx context.Context, requestDTO *dtos.ExportGuestByIDRequestDTO) (*dtos.ExportGuestResponseDTO, error) {
+	var (
+		span        trace.Span
+		logFields   map[string]interface{}
+		filter      *goqube.Filter
+		entity      *entities.GuestEntity
+		cacheKey    string
+		cacheEntity *entities.GuestEntity
+		logLevel    zerolog.Level
+		responseDTO *dtos.ExportGuestResponseDTO
+		err         error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestService][ExportByID]")
+	defer span.End()
+
+	if requestDTO == nil {
+		return nil, gocerr.New(http.StatusBadRequest, "requestDTO is nil")
+	}
+
+	logFields = map[string]interface{}{
+		"requestDTO": requestDTO,
+	}
+
+	err = requestDTO.Validate()
+	if err != nil {
+		log.Warn().
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[GuestService][ExportByID][Validate] failed to validate dto")
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	filter = s.buildEntityFilterByID(requestDTO.ID)
+	logFields["filter"] = filter
+
+	entity, err = s.guestRepository.FindOne(ctx, filter, nil, false)
+	if err != nil {
+		logLevel = zerolog.WarnLevel
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			logLevel = zerolog.ErrorLevel
+		}
+
+		log.WithLevel(logLevel).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[GuestService][ExportByID][FindOne] failed to find entity")
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	cacheKey = fmt.Sprintf(s.config().Guest.Cache.Keyf, requestDTO.ID)
+	logFields["cacheKey"] = cacheKey
+
+	cacheEntity, err = s.guestCacheRepository.Get(ctx, cacheKey)
+	if err != nil {
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[GuestService][ExportByID][Get] failed to get entity cache")
+			tracer.RecordError(span, err)
+			return nil, err
+		}
+
+		cacheEntity = nil
+	}
+
+	responseDTO = dtos.NewExportGuestResponseDTO(entity, cacheEntity)
+
+	return responseDTO, nil
+}
+
 func (s *GuestService) getListEntityCache(
 	ctx context.Context,
 	listEntityCacheKey string,
@@ -393,6 +947,7 @@ func (s *GuestService) getListEntityCache(
 				Fields(logFields).
 				Msg("[GuestService][getListEntityCache][GetList] failed to get list entity cache")
 		}
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 	logFields["listEntity"] = listEntity
@@ -417,28 +972,93 @@ func (s *GuestService) setListEntityCache(
 	logFields = map[string]interface{}{
 		"listEntityCacheKey": listEntityCacheKey,
 		"listEntity":         listEntity,
-		"expiration":         s.cfg.Guest.Cache.Duration,
+		"expiration":         s.cacheHardTTL(s.config().Guest.Cache.List.HardDuration),
 	}
 
 	err = s.guestCacheRepository.SetList(
 		ctx,
 		listEntityCacheKey,
 		listEntity,
-		s.cfg.Guest.Cache.Duration,
+		s.cacheHardTTL(s.config().Guest.Cache.List.HardDuration),
 	)
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestService][setListEntityCache][SetList] failed to set list entity cache")
+		tracer.RecordError(span, err)
 		return err
 	}
 
-	return nil
+	return nil
+}
+
+func (s *GuestService) loadListEntity(
+	ctx context.Context,
+	useCache bool,
+	listEntityCacheKey string,
+	filter *goqube.Filter,
+	sorts []goqube.Sort,
+	take uint64,
+	skip uint64,
+) ([]entities.GuestEntity, error) {
+	var (
+		span       trace.Span
+		logFields  map[string]interface{}
+		listEntity []entities.GuestEntity
+		err        error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestService][loadListEntity]")
+	defer span.End()
+
+	logFields = map[string]interface{}{
+		"listEntityCacheKey": listEntityCacheKey,
+		"filter":             filter,
+		"sorts":              sorts,
+		"take":               take,
+		"skip":               skip,
+	}
+
+	listEntity, err = s.guestRepository.FindAll(
+		ctx,
+		filter,
+		sorts,
+		take,
+		skip,
+		false,
+	)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[GuestService][loadListEntity][FindAll] failed to find list entity")
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+	logFields["listEntity"] = listEntity
+
+	if useCache && len(listEntity) > 0 {
+		err = s.setListEntityCache(
+			repositories.WithCacheFill(ctx),
+			listEntityCacheKey,
+			listEntity,
+		)
+		if err != nil {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[GuestService][loadListEntity][setListEntityCache] failed to set list entity cache")
+			err = nil
+		}
+	}
+
+	return listEntity, nil
 }
 
 func (s *GuestService) findListEntity(
 	ctx context.Context,
+	useCache bool,
 	listEntityCacheKey string,
 	filter *goqube.Filter,
 	sorts []goqube.Sort,
@@ -449,6 +1069,8 @@ func (s *GuestService) findListEntity(
 		span       trace.Span
 		logFields  map[string]interface{}
 		listEntity []entities.GuestEntity
+		load       func(ctx context.Context) (interface{}, error)
+		result     interface{}
 		err        error
 	)
 
@@ -463,7 +1085,11 @@ func (s *GuestService) findListEntity(
 		"skip":               skip,
 	}
 
-	if s.cfg.Guest.Cache.Enable {
+	load = func(ctx context.Context) (interface{}, error) {
+		return s.loadListEntity(ctx, useCache, listEntityCacheKey, filter, sorts, take, skip)
+	}
+
+	if useCache {
 		listEntity, err = s.getListEntityCache(ctx, listEntityCacheKey)
 		if err != nil {
 			if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
@@ -476,41 +1102,28 @@ func (s *GuestService) findListEntity(
 		}
 
 		if len(listEntity) > 0 {
+			if s.isCacheStale(ctx, listEntityCacheKey, s.config().Guest.Cache.List.SoftDuration, s.config().Guest.Cache.List.HardDuration) {
+				metrics.ObserveCacheStale(guestListCacheName)
+				s.refreshCache(ctx, listEntityCacheKey, load)
+				return listEntity, nil
+			}
+
+			metrics.ObserveCacheHit(guestListCacheName)
 			return listEntity, nil
 		}
+		metrics.ObserveCacheMiss(guestListCacheName)
 	}
 
-	listEntity, err = s.guestRepository.FindAll(
-		ctx,
-		filter,
-		sorts,
-		take,
-		skip,
-		false,
-	)
+	result, err = s.loadCache(ctx, listEntityCacheKey, load)
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
-			Msg("[GuestService][findListEntity][FindAll] failed to find list entity")
+			Msg("[GuestService][findListEntity][loadCache] failed to load list entity")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
-	logFields["listEntity"] = listEntity
-
-	if s.cfg.Guest.Cache.Enable && len(listEntity) > 0 {
-		err = s.setListEntityCache(
-			ctx,
-			listEntityCacheKey,
-			listEntity,
-		)
-		if err != nil {
-			log.Err(err).
-				Ctx(ctx).
-				Fields(logFields).
-				Msg("[GuestService][findListEntity][setListEntityCache] failed to set list entity cache")
-			err = nil
-		}
-	}
+	listEntity, _ = result.([]entities.GuestEntity)
 
 	return listEntity, nil
 }
@@ -541,6 +1154,7 @@ func (s *GuestService) getCountEntitiesCache(
 				Fields(logFields).
 				Msg("[GuestService][getCountEntitiesCache][GetCount] failed to get entities count cache")
 		}
+		tracer.RecordError(span, err)
 		return 0, err
 	}
 
@@ -564,28 +1178,87 @@ func (s *GuestService) setEntitiesCountCache(
 	logFields = map[string]interface{}{
 		"entitiesCountCacheKey": entitiesCountCacheKey,
 		"entitiesCount":         entitiesCount,
-		"expiration":            s.cfg.Guest.Cache.Duration,
+		"expiration":            s.cacheHardTTL(s.config().Guest.Cache.Count.HardDuration),
 	}
 
 	err = s.guestCacheRepository.SetCount(
 		ctx,
 		entitiesCountCacheKey,
 		entitiesCount,
-		s.cfg.Guest.Cache.Duration,
+		s.cacheHardTTL(s.config().Guest.Cache.Count.HardDuration),
 	)
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestService][setEntitiesCountCache][SetCount] failed to set entities count cache")
+		tracer.RecordError(span, err)
 		return err
 	}
 
 	return nil
 }
 
+func (s *GuestService) loadEntitiesCount(
+	ctx context.Context,
+	useCache bool,
+	entitiesCountCacheKey string,
+	filter *goqube.Filter,
+) (uint64, error) {
+	var (
+		span          trace.Span
+		logFields     map[string]interface{}
+		entitiesCount uint64
+		err           error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestService][loadEntitiesCount]")
+	defer span.End()
+
+	logFields = map[string]interface{}{
+		"entitiesCountCacheKey": entitiesCountCacheKey,
+		"filter":                filter,
+	}
+
+	entitiesCount, err = s.guestRepository.Count(
+		ctx,
+		filter,
+		false,
+	)
+	if err != nil {
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[GuestService][loadEntitiesCount][Count] failed to count entities")
+		}
+
+		tracer.RecordError(span, err)
+		return 0, err
+	}
+	logFields["entitiesCount"] = entitiesCount
+
+	if useCache && entitiesCount > 0 {
+		err = s.setEntitiesCountCache(
+			repositories.WithCacheFill(ctx),
+			entitiesCountCacheKey,
+			entitiesCount,
+		)
+		if err != nil {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[GuestService][loadEntitiesCount][setEntitiesCountCache] failed to set count entities cache")
+			err = nil
+		}
+	}
+
+	return entitiesCount, nil
+}
+
 func (s *GuestService) countEntities(
 	ctx context.Context,
+	useCache bool,
 	entitiesCountCacheKey string,
 	filter *goqube.Filter,
 ) (uint64, error) {
@@ -593,6 +1266,8 @@ func (s *GuestService) countEntities(
 		span          trace.Span
 		logFields     map[string]interface{}
 		entitiesCount uint64
+		load          func(ctx context.Context) (interface{}, error)
+		result        interface{}
 		err           error
 	)
 
@@ -604,7 +1279,11 @@ func (s *GuestService) countEntities(
 		"filter":                filter,
 	}
 
-	if s.cfg.Guest.Cache.Enable {
+	load = func(ctx context.Context) (interface{}, error) {
+		return s.loadEntitiesCount(ctx, useCache, entitiesCountCacheKey, filter)
+	}
+
+	if useCache {
 		entitiesCount, err = s.getCountEntitiesCache(ctx, entitiesCountCacheKey)
 		if err != nil {
 			if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
@@ -618,41 +1297,31 @@ func (s *GuestService) countEntities(
 		}
 
 		if entitiesCount > 0 {
+			if s.isCacheStale(ctx, entitiesCountCacheKey, s.config().Guest.Cache.Count.SoftDuration, s.config().Guest.Cache.Count.HardDuration) {
+				metrics.ObserveCacheStale(guestCountCacheName)
+				s.refreshCache(ctx, entitiesCountCacheKey, load)
+				return entitiesCount, nil
+			}
+
+			metrics.ObserveCacheHit(guestCountCacheName)
 			return entitiesCount, nil
 		}
+		metrics.ObserveCacheMiss(guestCountCacheName)
 	}
 
-	entitiesCount, err = s.guestRepository.Count(
-		ctx,
-		filter,
-		false,
-	)
+	result, err = s.loadCache(ctx, entitiesCountCacheKey, load)
 	if err != nil {
 		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
 			log.Err(err).
 				Ctx(ctx).
 				Fields(logFields).
-				Msg("[GuestService][countEntities][Count] failed to count entities")
+				Msg("[GuestService][countEntities][loadCache] failed to load entities count")
 		}
 
+		tracer.RecordError(span, err)
 		return 0, err
 	}
-	logFields["entitiesCount"] = entitiesCount
-
-	if s.cfg.Guest.Cache.Enable && entitiesCount > 0 {
-		err = s.setEntitiesCountCache(
-			ctx,
-			entitiesCountCacheKey,
-			entitiesCount,
-		)
-		if err != nil {
-			log.Err(err).
-				Ctx(ctx).
-				Fields(logFields).
-				Msg("[GuestService][countEntities][setEntitiesCountCache] failed to set count entities cache")
-			err = nil
-		}
-	}
+	entitiesCount, _ = result.(uint64)
 
 	return entitiesCount, nil
 }
@@ -663,6 +1332,8 @@ func (s *GuestService) FindAll(ctx context.Context, requestDTO *dtos.FindAllGues
 		logFields             map[string]interface{}
 		filter                *goqube.Filter
 		sorts                 []goqube.Sort
+		useCache              bool
+		generation            uint64
 		listEntityCacheKey    string
 		entitiesCountCacheKey string
 		errTask               gotask.ErrorTask
@@ -692,6 +1363,7 @@ func (s *GuestService) FindAll(ctx context.Context, requestDTO *dtos.FindAllGues
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestService][FindAll][ToFilter] failed to transform requestDTO into filter and sorts")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 	logFields["filter"] = filter
@@ -706,7 +1378,25 @@ func (s *GuestService) FindAll(ctx context.Context, requestDTO *dtos.FindAllGues
 	)
 	listEntityCacheKey = regexp.MustCompile(`[^a-zA-Z0-9:_&=-]+`).
 		ReplaceAllString(strings.TrimSpace(listEntityCacheKey), "_")
-	listEntityCacheKey = fmt.Sprintf(s.cfg.Guest.Cache.Keyf, listEntityCacheKey)
+
+	// without the current generation a cached page may be from before the
+	// last write, so the list cache is skipped
+	useCache = s.isEnabled(ctx, featureFlagGuestCache, s.config().Guest.Cache.Enable)
+	if useCache {
+		generation, err = s.listCacheGeneration(ctx)
+		if err != nil {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[GuestService][FindAll][listCacheGeneration] failed to get list cache generation")
+			useCache = false
+			err = nil
+		}
+	}
+	logFields["useCache"] = useCache
+	logFields["generation"] = generation
+
+	listEntityCacheKey = fmt.Sprintf(s.config().Guest.Cache.Keyf, fmt.Sprintf("list:%d:%s", generation, listEntityCacheKey))
 	logFields["listEntityCacheKey"] = listEntityCacheKey
 
 	entitiesCountCacheKey = fmt.Sprintf("%s:count", listEntityCacheKey)
@@ -719,6 +1409,7 @@ func (s *GuestService) FindAll(ctx context.Context, requestDTO *dtos.FindAllGues
 
 		listEntity, errRoutine = s.findListEntity(
 			errTaskCtx,
+			useCache,
 			listEntityCacheKey,
 			filter,
 			sorts,
@@ -741,6 +1432,7 @@ func (s *GuestService) FindAll(ctx context.Context, requestDTO *dtos.FindAllGues
 
 		entitiesCount, errRoutine = s.countEntities(
 			errTaskCtx,
+			useCache,
 			entitiesCountCacheKey,
 			filter,
 		)
@@ -767,6 +1459,7 @@ func (s *GuestService) FindAll(ctx context.Context, requestDTO *dtos.FindAllGues
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestService][FindAll][Wait] failed to find or count entities")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -799,6 +1492,7 @@ func (s *GuestService) getEntityByIDCache(ctx context.Context, cacheKey string)
 				Msg("[GuestService][getEntityByIDCache][Get] failed to get entity by id cache")
 		}
 
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -822,34 +1516,88 @@ func (s *GuestService) setEntityByIDCache(
 	logFields = map[string]interface{}{
 		"cacheKey":   cacheKey,
 		"entity":     entity,
-		"expiration": s.cfg.Guest.Cache.Duration,
+		"expiration": s.cacheHardTTL(s.config().Guest.Cache.Entity.HardDuration),
 	}
 
-	err = s.guestCacheRepository.Set(ctx, cacheKey, entity, s.cfg.Guest.Cache.Duration)
+	err = s.guestCacheRepository.Set(ctx, cacheKey, entity, s.cacheHardTTL(s.config().Guest.Cache.Entity.HardDuration))
 	if err != nil {
 		log.Err(err).
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestService][setEntityByIDCache][Set] failed to set entity cache")
+		tracer.RecordError(span, err)
 		return err
 	}
 
 	return nil
 }
 
-func (s *GuestService) findEntityByID(
+// trySetEntityTombstone caches that the entity at cacheKey does not exist,
+// writes delete the ID key so a created guest is not hidden by it.
+func (s *GuestService) trySetEntityTombstone(ctx context.Context, cacheKey string) {
+	var (
+		logFields  map[string]interface{}
+		expiration time.Duration = s.config().Guest.Cache.Entity.NotFoundDuration
+		err        error
+	)
+
+	if expiration <= 0 || !s.isEnabled(ctx, featureFlagGuestCache, s.config().Guest.Cache.Enable) {
+		return
+	}
+
+	logFields = map[string]interface{}{
+		"cacheKey":   cacheKey,
+		"expiration": expiration,
+	}
+
+	err = s.guestCacheRepository.SetTombstone(ctx, cacheKey, expiration)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[GuestService][trySetEntityTombstone][SetTombstone] failed to set entity tombstone")
+	}
+}
+
+// isListCacheGenerationCurrent reports whether no write started a new list
+// cache generation since generation was read, a failed read counts as a write.
+func (s *GuestService) isListCacheGenerationCurrent(ctx context.Context, generation uint64) bool {
+	var (
+		current uint64
+		err     error
+	)
+
+	current, err = s.guestCacheRepository.GetCount(ctx, s.listCacheGenerationKey())
+	if err != nil {
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			log.Err(err).
+				Ctx(ctx).
+				Msg("[GuestService][isListCacheGenerationCurrent][GetCount] failed to get list cache generation")
+		}
+		return false
+	}
+
+	return current == generation
+}
+
+// loadEntity reads the entity from the database and caches it, unless a write
+// started a new list cache generation meanwhile. That write may have cached a
+// newer version or deleted the key, and the one read here must not replace it.
+func (s *GuestService) loadEntity(
 	ctx context.Context,
 	cacheKey string,
 	filter *goqube.Filter,
 ) (*entities.GuestEntity, error) {
 	var (
-		span      trace.Span
-		logFields map[string]interface{}
-		entity    *entities.GuestEntity
-		err       error
+		span       trace.Span
+		logFields  map[string]interface{}
+		useCache   bool
+		generation uint64
+		entity     *entities.GuestEntity
+		err        error
 	)
 
-	ctx, span = tracer.Start(ctx, "[GuestService][findEntityByID]")
+	ctx, span = tracer.Start(ctx, "[GuestService][loadEntity]")
 	defer span.End()
 
 	logFields = map[string]interface{}{
@@ -857,22 +1605,17 @@ func (s *GuestService) findEntityByID(
 		"filter":   filter,
 	}
 
-	if s.cfg.Guest.Cache.Enable {
-		entity, err = s.getEntityByIDCache(ctx, cacheKey)
+	useCache = s.isEnabled(ctx, featureFlagGuestCache, s.config().Guest.Cache.Enable)
+	if useCache {
+		generation, err = s.listCacheGeneration(ctx)
 		if err != nil {
-			if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
-				log.Err(err).
-					Ctx(ctx).
-					Fields(logFields).
-					Msg("[GuestService][findEntityByID][getEntityByIDCache] failed to get entity by id cache")
-			}
-
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[GuestService][loadEntity][listCacheGeneration] failed to get list cache generation")
+			useCache = false
 			err = nil
 		}
-
-		if entity != nil {
-			return entity, nil
-		}
 	}
 
 	entity, err = s.guestRepository.FindOne(
@@ -886,16 +1629,21 @@ func (s *GuestService) findEntityByID(
 			log.Err(err).
 				Ctx(ctx).
 				Fields(logFields).
-				Msg("[GuestService][findEntityByID][FindOne] failed to find entity")
+				Msg("[GuestService][loadEntity][FindOne] failed to find entity")
+		}
+
+		if gocerr.GetErrorCode(err) == http.StatusNotFound && useCache && s.isListCacheGenerationCurrent(ctx, generation) {
+			s.trySetEntityTombstone(repositories.WithCacheFill(ctx), cacheKey)
 		}
 
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 	logFields["entity"] = entity
 
-	if s.cfg.Guest.Cache.Enable {
+	if useCache && s.isListCacheGenerationCurrent(ctx, generation) {
 		err = s.setEntityByIDCache(
-			ctx,
+			repositories.WithCacheFill(ctx),
 			cacheKey,
 			entity,
 		)
@@ -903,10 +1651,89 @@ func (s *GuestService) findEntityByID(
 			log.Err(err).
 				Ctx(ctx).
 				Fields(logFields).
-				Msg("[GuestService][findEntityByID][setEntityByIDCache] failed to set entity by id cache")
+				Msg("[GuestService][loadEntity][setEntityByIDCache] failed to set entity by id cache")
+			err = nil
+		}
+	}
+
+	return entity, nil
+}
+
+func (s *GuestService) findEntityByID(
+	ctx context.Context,
+	cacheKey string,
+	filter *goqube.Filter,
+) (*entities.GuestEntity, error) {
+	var (
+		span      trace.Span
+		logFields map[string]interface{}
+		entity    *entities.GuestEntity
+		load      func(ctx context.Context) (interface{}, error)
+		result    interface{}
+		err       error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestService][findEntityByID]")
+	defer span.End()
+
+	logFields = map[string]interface{}{
+		"cacheKey": cacheKey,
+		"filter":   filter,
+	}
+
+	load = func(ctx context.Context) (interface{}, error) {
+		return s.loadEntity(ctx, cacheKey, filter)
+	}
+
+	if s.isEnabled(ctx, featureFlagGuestCache, s.config().Guest.Cache.Enable) {
+		entity, err = s.getEntityByIDCache(ctx, cacheKey)
+		if repositories.IsTombstone(err) {
+			metrics.ObserveCacheTombstone(guestEntityCacheName)
+			log.Debug().
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[GuestService][findEntityByID][getEntityByIDCache] tombstone hit, entity is known to be missing")
+			err = gocerr.New(http.StatusNotFound, "entity not found")
+			tracer.RecordError(span, err)
+			return nil, err
+		}
+		if err != nil {
+			if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+				log.Err(err).
+					Ctx(ctx).
+					Fields(logFields).
+					Msg("[GuestService][findEntityByID][getEntityByIDCache] failed to get entity by id cache")
+			}
+
 			err = nil
 		}
+
+		if entity != nil {
+			if s.isCacheStale(ctx, cacheKey, s.config().Guest.Cache.Entity.SoftDuration, s.config().Guest.Cache.Entity.HardDuration) {
+				metrics.ObserveCacheStale(guestEntityCacheName)
+				s.refreshCache(ctx, cacheKey, load)
+				return entity, nil
+			}
+
+			metrics.ObserveCacheHit(guestEntityCacheName)
+			return entity, nil
+		}
+		metrics.ObserveCacheMiss(guestEntityCacheName)
+	}
+
+	result, err = s.loadCache(ctx, cacheKey, load)
+	if err != nil {
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			log.Err(err).
+				Ctx(ctx).
+				Fields(logFields).
+				Msg("[GuestService][findEntityByID][loadCache] failed to load entity")
+		}
+
+		tracer.RecordError(span, err)
+		return nil, err
 	}
+	entity, _ = result.(*entities.GuestEntity)
 
 	return entity, nil
 }
@@ -940,10 +1767,11 @@ func (s *GuestService) FindByID(ctx context.Context, requestDTO *dtos.FindGuestB
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestService][FindByID][Validate] failed to validate dto")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
-	cacheKey = fmt.Sprintf(s.cfg.Guest.Cache.Keyf, requestDTO.ID)
+	cacheKey = fmt.Sprintf(s.config().Guest.Cache.Keyf, requestDTO.ID)
 	logFields["cacheKey"] = cacheKey
 
 	filter = s.buildActiveEntityFilterByIDs(requestDTO.ID)
@@ -955,6 +1783,7 @@ func (s *GuestService) FindByID(ctx context.Context, requestDTO *dtos.FindGuestB
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestService][FindByID][FindOne] failed to find entity by id")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -963,6 +1792,44 @@ func (s *GuestService) FindByID(ctx context.Context, requestDTO *dtos.FindGuestB
 	return responseDTO, nil
 }
 
+// PurgeCaches deletes every guest cache by walking the keyspace, it is the
+// admin fallback for caches that invalidation missed.
+func (s *GuestService) PurgeCaches(ctx context.Context) (uint64, error) {
+	var (
+		span      trace.Span
+		logFields map[string]interface{}
+		pattern   string
+		purged    uint64
+		err       error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestService][PurgeCaches]")
+	defer span.End()
+
+	pattern = fmt.Sprintf(s.config().Guest.Cache.Keyf, "*")
+	logFields = map[string]interface{}{
+		"pattern": pattern,
+	}
+
+	purged, err = s.guestCacheRepository.DeleteByPattern(ctx, pattern)
+	logFields["purged"] = purged
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[GuestService][PurgeCaches][DeleteByPattern] failed to purge caches")
+		tracer.RecordError(span, err)
+		return purged, err
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Fields(logFields).
+		Msg("[GuestService][PurgeCaches] caches purged")
+
+	return purged, nil
+}
+
 func (s *GuestService) UpdateByID(ctx context.Context, requestDTO *dtos.UpdateGuestByIDRequestDTO) (*dtos.GuestResponseDTO, error) {
 	var (
 		span        trace.Span
@@ -992,6 +1859,7 @@ func (s *GuestService) UpdateByID(ctx context.Context, requestDTO *dtos.UpdateGu
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestService][UpdateByID][Validate] failed to validate dto")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -1010,6 +1878,7 @@ func (s *GuestService) UpdateByID(ctx context.Context, requestDTO *dtos.UpdateGu
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestService][UpdateByID][FindOne] failed to find entity")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -1020,14 +1889,15 @@ func (s *GuestService) UpdateByID(ctx context.Context, requestDTO *dtos.UpdateGu
 		return s.guestRepository.WithTransaction(tx).Update(ctx, entity, filter)
 	})
 	if err != nil {
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
 	responseDTO = dtos.NewGuestResponseDTO(entity)
 	logFields["responseDTO"] = responseDTO
 
-	s.tryDeleteEntityCaches(ctx, logFields, "UpdateByID")
-	s.publishEvent(ctx, logFields, s.cfg.Guest.Event.Updated.Enable, s.cfg.Guest.Event.Updated.Topic, "UpdateByID", *entity)
+	s.tryRefreshEntityCaches(ctx, logFields, "UpdateByID", s.config().Guest.Cache.WriteThrough.UpdateByID, *entity)
+	s.publishEvent(ctx, logFields, s.isEnabled(ctx, featureFlagGuestEventUpdated, s.config().Guest.Event.Updated.Enable), s.config().Guest.Event.Updated.Topic, "UpdateByID", *entity)
 
 	return responseDTO, nil
 }
@@ -1061,6 +1931,7 @@ func (s *GuestService) ProcessEvent(ctx context.Context, requestDTO *dtos.GuestE
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestService][ProcessEvent][SendWebhook] failed to send webhook")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -1096,6 +1967,7 @@ func (s *GuestService) BulkCreate(ctx context.Context, requestDTO *dtos.BulkCrea
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestService][BulkCreate][Validate] failed to validate dto")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -1106,14 +1978,15 @@ func (s *GuestService) BulkCreate(ctx context.Context, requestDTO *dtos.BulkCrea
 		return s.guestRepository.WithTransaction(tx).BulkCreate(ctx, newEntities)
 	})
 	if err != nil {
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
 	responseDTO = dtos.NewBulkCreateGuestsResponseDTO(newEntities)
 	logFields["responseDTO"] = responseDTO
 
-	s.tryDeleteEntityCaches(ctx, logFields, "BulkCreate")
-	s.publishEvent(ctx, logFields, s.cfg.Guest.Event.BulkCreated.Enable, s.cfg.Guest.Event.BulkCreated.Topic, "BulkCreate", newEntities...)
+	s.tryRefreshEntityCaches(ctx, logFields, "BulkCreate", s.config().Guest.Cache.WriteThrough.BulkCreate, newEntities...)
+	s.publishEvent(ctx, logFields, s.isEnabled(ctx, featureFlagGuestEventBulkCreated, s.config().Guest.Event.BulkCreated.Enable), s.config().Guest.Event.BulkCreated.Topic, "BulkCreate", newEntities...)
 
 	return responseDTO, nil
 }
@@ -1152,6 +2025,7 @@ func (s *GuestService) BulkUpdate(ctx context.Context, requestDTO *dtos.BulkUpda
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestService][BulkUpdate][Validate] failed to validate dto")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -1166,6 +2040,7 @@ func (s *GuestService) BulkUpdate(ctx context.Context, requestDTO *dtos.BulkUpda
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestService][BulkUpdate][FindAll] failed to find entities")
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
@@ -1186,6 +2061,7 @@ func (s *GuestService) BulkUpdate(ctx context.Context, requestDTO *dtos.BulkUpda
 		existingEntity, ok = existingEntitiesMap[item.ID]
 		if !ok {
 			err = gocerr.New(http.StatusNotFound, "entity not found for id: "+item.ID)
+			tracer.RecordError(span, err)
 			return nil, err
 		}
 
@@ -1198,14 +2074,15 @@ func (s *GuestService) BulkUpdate(ctx context.Context, requestDTO *dtos.BulkUpda
 		return s.guestRepository.WithTransaction(tx).BulkUpdate(ctx, updatedEntities)
 	})
 	if err != nil {
+		tracer.RecordError(span, err)
 		return nil, err
 	}
 
 	responseDTO = dtos.NewBulkUpdateGuestsResponseDTO(updatedEntities)
 	logFields["responseDTO"] = responseDTO
 
-	s.tryDeleteEntityCaches(ctx, logFields, "BulkUpdate")
-	s.publishEvent(ctx, logFields, s.cfg.Guest.Event.BulkUpdated.Enable, s.cfg.Guest.Event.BulkUpdated.Topic, "BulkUpdate", updatedEntities...)
+	s.tryRefreshEntityCaches(ctx, logFields, "BulkUpdate", s.config().Guest.Cache.WriteThrough.BulkUpdate, updatedEntities...)
+	s.publishEvent(ctx, logFields, s.isEnabled(ctx, featureFlagGuestEventBulkUpdated, s.config().Guest.Event.BulkUpdated.Enable), s.config().Guest.Event.BulkUpdated.Topic, "BulkUpdate", updatedEntities...)
 
 	return responseDTO, nil
 }
@@ -1239,6 +2116,7 @@ func (s *GuestService) BulkDelete(ctx context.Context, requestDTO *dtos.BulkDele
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestService][BulkDelete][Validate] failed to validate dto")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -1253,6 +2131,7 @@ func (s *GuestService) BulkDelete(ctx context.Context, requestDTO *dtos.BulkDele
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestService][BulkDelete][FindAll] failed to find entities")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -1270,11 +2149,12 @@ func (s *GuestService) BulkDelete(ctx context.Context, requestDTO *dtos.BulkDele
 		return s.guestRepository.WithTransaction(tx).BulkUpdate(ctx, deletedEntities)
 	})
 	if err != nil {
+		tracer.RecordError(span, err)
 		return err
 	}
 
-	s.tryDeleteEntityCaches(ctx, logFields, "BulkDelete")
-	s.publishEvent(ctx, logFields, s.cfg.Guest.Event.BulkDeleted.Enable, s.cfg.Guest.Event.BulkDeleted.Topic, "BulkDelete", deletedEntities...)
+	s.tryInvalidateEntityCaches(ctx, logFields, "BulkDelete", deletedEntities...)
+	s.publishEvent(ctx, logFields, s.isEnabled(ctx, featureFlagGuestEventBulkDeleted, s.config().Guest.Event.BulkDeleted.Enable), s.config().Guest.Event.BulkDeleted.Topic, "BulkDelete", deletedEntities...)
 
 	return nil
 }
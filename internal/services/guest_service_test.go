@@ -8,7 +8,11 @@ import (
 	"go-boilerplate/internal/models/entities"
 	"go-boilerplate/internal/repositories"
 	repo_mocks "go-boilerplate/internal/repositories/mocks"
+	service_mocks "go-boilerplate/internal/services/mocks"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -37,6 +41,7 @@ func Test_NewGuestService(t *testing.T) {
 		guestCacheRepository         repositories.IGuestCacheRepository
 		guestEventProducerRepository repositories.IGuestEventProducerRepository
 		webhookSiteRepository        repositories.IWebhookSiteRepository
+		featureFlagService           IFeatureFlagService
 		expectNil                    bool
 	}{
 		{
@@ -53,6 +58,7 @@ func Test_NewGuestService(t *testing.T) {
 			guestCacheRepository:         repo_mocks.NewGuestCacheRepositoryMock(t),
 			guestEventProducerRepository: repo_mocks.NewGuestEventProducerRepositoryMock(t),
 			webhookSiteRepository:        repo_mocks.NewWebhookSiteRepositoryMock(t),
+			featureFlagService:           service_mocks.NewFeatureFlagServiceMock(t),
 			expectNil:                    false,
 		},
 		{
@@ -62,6 +68,7 @@ func Test_NewGuestService(t *testing.T) {
 			guestCacheRepository:         nil,
 			guestEventProducerRepository: nil,
 			webhookSiteRepository:        nil,
+			featureFlagService:           nil,
 			expectNil:                    false,
 		},
 	}
@@ -74,6 +81,7 @@ func Test_NewGuestService(t *testing.T) {
 				tt.guestCacheRepository,
 				tt.guestEventProducerRepository,
 				tt.webhookSiteRepository,
+				tt.featureFlagService,
 			)
 
 			if tt.expectNil && service != nil {
@@ -104,151 +112,921 @@ func Test_NewGuestService(t *testing.T) {
 				if service.webhookSiteRepository != tt.webhookSiteRepository {
 					t.Error("NewGuestService() webhookSiteRepository not set correctly")
 				}
+
+				if service.featureFlagService != tt.featureFlagService {
+					t.Error("NewGuestService() featureFlagService not set correctly")
+				}
 			}
 		})
 	}
 }
 
-func Test_GuestService_deleteEntityCaches(t *testing.T) {
+func Test_GuestService_Close(t *testing.T) {
 	tests := []struct {
-		name          string
-		setupService  func(t *testing.T) *GuestService
-		expectError   bool
-		validateError func(t *testing.T, err error)
+		name        string
+		unsubscribe bool
 	}{
 		{
-			name: "delete caches successfully",
-			setupService: func(t *testing.T) *GuestService {
-				cfg := &configs.Config{}
-				cfg.Guest.Cache.Keyf = "guest:%s"
+			name:        "unsubscribes from the config hot reload",
+			unsubscribe: true,
+		},
+		{
+			name: "does nothing without a subscription",
+		},
+	}
 
-				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{"guest:key1", "guest:key2"}, nil)
-				mockCache.On("Delete", mock.Anything, mock.MatchedBy(func(keys []string) bool {
-					return len(keys) == 2 && keys[0] == "guest:key1" && keys[1] == "guest:key2"
-				})).Return(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			service := &GuestService{}
+			if tt.unsubscribe {
+				service.unsubscribe = func() { calls++ }
+			}
 
-				return NewGuestService(
-					cfg,
-					repo_mocks.NewGuestRepositoryMock(t),
-					mockCache,
-					repo_mocks.NewGuestEventProducerRepositoryMock(t),
-					repo_mocks.NewWebhookSiteRepositoryMock(t),
-				)
+			service.Close()
+
+			if tt.unsubscribe && calls != 1 {
+				t.Errorf("Close() unsubscribed %d times, want 1", calls)
+			}
+		})
+	}
+}
+
+func Test_ProvideGuestService(t *testing.T) {
+	cfg := &configs.Config{}
+
+	service, cleanup := ProvideGuestService(cfg, nil, nil, nil, nil, nil)
+
+	if service == nil || service.cfg != cfg {
+		t.Fatal("ProvideGuestService() service not set correctly")
+	}
+
+	if service.unsubscribe == nil || cleanup == nil {
+		t.Fatal("ProvideGuestService() expected an unsubscribe and a cleanup")
+	}
+
+	cleanup()
+}
+
+func Test_GuestService_config(t *testing.T) {
+	var (
+		cfg         *configs.Config = &configs.Config{}
+		reloadedCfg *configs.Config = &configs.Config{}
+	)
+
+	tests := []struct {
+		name        string
+		reloadedCfg *configs.Config
+		expected    *configs.Config
+	}{
+		{
+			name:        "return built config before any reload",
+			reloadedCfg: nil,
+			expected:    cfg,
+		},
+		{
+			name:        "return reloaded config after a reload",
+			reloadedCfg: reloadedCfg,
+			expected:    reloadedCfg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &GuestService{cfg: cfg}
+			if tt.reloadedCfg != nil {
+				service.reloadedCfg.Store(tt.reloadedCfg)
+			}
+
+			if actual := service.config(); actual != tt.expected {
+				t.Errorf("config() = %p, expected %p", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_GuestService_isEnabled(t *testing.T) {
+	tests := []struct {
+		name                    string
+		setupFeatureFlagService func(t *testing.T) IFeatureFlagService
+		fallback                bool
+		expected                bool
+	}{
+		{
+			name: "return fallback without feature flag service",
+			setupFeatureFlagService: func(t *testing.T) IFeatureFlagService {
+				return nil
 			},
-			expectError: false,
-			validateError: func(t *testing.T, err error) {
-				if err != nil {
-					t.Errorf("deleteEntityCaches() unexpected error: %v", err)
+			fallback: true,
+			expected: true,
+		},
+		{
+			name: "return feature flag evaluation",
+			setupFeatureFlagService: func(t *testing.T) IFeatureFlagService {
+				mockFeatureFlagService := service_mocks.NewFeatureFlagServiceMock(t)
+				mockFeatureFlagService.On("IsEnabled", mock.Anything, featureFlagGuestCache, true).Return(false)
+				return mockFeatureFlagService
+			},
+			fallback: true,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &GuestService{featureFlagService: tt.setupFeatureFlagService(t)}
+
+			if actual := service.isEnabled(context.Background(), featureFlagGuestCache, tt.fallback); actual != tt.expected {
+				t.Errorf("isEnabled() = %v, expected %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_GuestService_listCacheGeneration(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupCache  func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock
+		expected    uint64
+		expectError bool
+		validate    func(t *testing.T, generation uint64)
+	}{
+		{
+			name: "return stored generation",
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, "guest:list:generation").Return(uint64(3), nil)
+				return mockCache
+			},
+			expected: 3,
+		},
+		{
+			name: "start a new generation when the key is missing",
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, "guest:list:generation").Return(uint64(0), gocerr.New(http.StatusNotFound, "redis: nil"))
+				mockCache.On("SetCountIfNotExists", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(true, nil)
+				return mockCache
+			},
+			validate: func(t *testing.T, generation uint64) {
+				if generation <= 3 {
+					t.Errorf("listCacheGeneration() = %d, expected a timestamp generation", generation)
 				}
 			},
 		},
 		{
-			name: "delete caches with no keys found",
+			name: "return the generation another caller started first",
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, "guest:list:generation").Return(uint64(0), gocerr.New(http.StatusNotFound, "redis: nil")).Once()
+				mockCache.On("SetCountIfNotExists", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(false, nil)
+				mockCache.On("GetCount", mock.Anything, "guest:list:generation").Return(uint64(7), nil).Once()
+				return mockCache
+			},
+			expected: 7,
+		},
+		{
+			name: "return error when the new generation cannot be set",
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, "guest:list:generation").Return(uint64(0), gocerr.New(http.StatusNotFound, "redis: nil"))
+				mockCache.On("SetCountIfNotExists", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(false, gocerr.New(http.StatusInternalServerError, "redis error"))
+				return mockCache
+			},
+			expected:    0,
+			expectError: true,
+		},
+		{
+			name: "return error when cache fails",
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, "guest:list:generation").Return(uint64(0), gocerr.New(http.StatusInternalServerError, "redis error"))
+				return mockCache
+			},
+			expected:    0,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Guest.Cache.Keyf = "guest:%s"
+			service := &GuestService{cfg: cfg, guestCacheRepository: tt.setupCache(t)}
+
+			generation, err := service.listCacheGeneration(context.Background())
+
+			if tt.expectError != (err != nil) {
+				t.Errorf("listCacheGeneration() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.validate != nil {
+				tt.validate(t, generation)
+			} else if generation != tt.expected {
+				t.Errorf("listCacheGeneration() = %d, expected %d", generation, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_GuestService_invalidateEntityCaches(t *testing.T) {
+	entity1 := newTestGuestEntity("019a9a5f-aaf4-7506-a942-6ed217773e2a", "John Doe", "123 Main St", "admin", 1763526552308)
+	entity2 := newTestGuestEntity("019a9a5f-aaf4-7506-a942-6ed217773e2b", "Jane Doe", "456 Main St", "admin", 1763526552308)
+
+	tests := []struct {
+		name        string
+		entities    []entities.GuestEntity
+		setupCache  func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock
+		expectError bool
+	}{
+		{
+			name:     "delete entity caches by id and bump list generation",
+			entities: []entities.GuestEntity{*entity1, *entity2},
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Delete", mock.Anything, []string{
+					"guest:019a9a5f-aaf4-7506-a942-6ed217773e2a",
+					"guest:019a9a5f-aaf4-7506-a942-6ed217773e2b",
+				}).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				return mockCache
+			},
+		},
+		{
+			name:     "bump list generation without entities",
+			entities: nil,
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				return mockCache
+			},
+		},
+		{
+			name:     "return error when delete fails",
+			entities: []entities.GuestEntity{*entity1},
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				mockCache.On("Delete", mock.Anything, []string{"guest:019a9a5f-aaf4-7506-a942-6ed217773e2a"}).Return(errors.New("redis delete error"))
+				return mockCache
+			},
+			expectError: true,
+		},
+		{
+			name:     "return error without deleting when the list generation cannot be started",
+			entities: []entities.GuestEntity{*entity1},
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(errors.New("redis set error"))
+				return mockCache
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Guest.Cache.Keyf = "guest:%s"
+			service := &GuestService{cfg: cfg, guestCacheRepository: tt.setupCache(t)}
+
+			err := service.invalidateEntityCaches(context.Background(), tt.entities...)
+
+			if tt.expectError != (err != nil) {
+				t.Errorf("invalidateEntityCaches() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+
+func Test_GuestService_writeThroughEntityCaches(t *testing.T) {
+	entity1 := newTestGuestEntity("019a9a5f-aaf4-7506-a942-6ed217773e2a", "John Doe", "123 Main St", "admin", 1763526552308)
+	entity2 := newTestGuestEntity("019a9a5f-aaf4-7506-a942-6ed217773e2b", "Jane Doe", "456 Main St", "admin", 1763526552308)
+	entity2Renamed := newTestGuestEntity("019a9a5f-aaf4-7506-a942-6ed217773e2b", "Jane Roe", "456 Main St", "admin", 1763526552308)
+
+	tests := []struct {
+		name        string
+		entities    []entities.GuestEntity
+		setupCache  func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock
+		expectError bool
+	}{
+		{
+			name:     "set entity caches by id and bump list generation",
+			entities: []entities.GuestEntity{*entity1, *entity2},
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Set", mock.Anything, "guest:019a9a5f-aaf4-7506-a942-6ed217773e2a", entity1, 5*time.Minute).Return(nil)
+				mockCache.On("Set", mock.Anything, "guest:019a9a5f-aaf4-7506-a942-6ed217773e2b", entity2, 5*time.Minute).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				return mockCache
+			},
+		},
+		{
+			name:     "delete the cache of an id written more than once",
+			entities: []entities.GuestEntity{*entity2, *entity1, *entity2Renamed},
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Set", mock.Anything, "guest:019a9a5f-aaf4-7506-a942-6ed217773e2a", entity1, 5*time.Minute).Return(nil)
+				mockCache.On("Delete", mock.Anything, []string{"guest:019a9a5f-aaf4-7506-a942-6ed217773e2b"}).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				return mockCache
+			},
+		},
+		{
+			name:     "delete the cache that fails to be set",
+			entities: []entities.GuestEntity{*entity1, *entity2},
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Set", mock.Anything, "guest:019a9a5f-aaf4-7506-a942-6ed217773e2a", entity1, 5*time.Minute).Return(errors.New("redis set error"))
+				mockCache.On("Set", mock.Anything, "guest:019a9a5f-aaf4-7506-a942-6ed217773e2b", entity2, 5*time.Minute).Return(nil)
+				mockCache.On("Delete", mock.Anything, []string{"guest:019a9a5f-aaf4-7506-a942-6ed217773e2a"}).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				return mockCache
+			},
+		},
+		{
+			name:     "return error when the failed cache cannot be deleted",
+			entities: []entities.GuestEntity{*entity1},
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Set", mock.Anything, "guest:019a9a5f-aaf4-7506-a942-6ed217773e2a", entity1, 5*time.Minute).Return(errors.New("redis set error"))
+				mockCache.On("Delete", mock.Anything, []string{"guest:019a9a5f-aaf4-7506-a942-6ed217773e2a"}).Return(errors.New("redis delete error"))
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				return mockCache
+			},
+			expectError: true,
+		},
+		{
+			name:     "return error without setting when the list generation cannot be started",
+			entities: []entities.GuestEntity{*entity1},
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(errors.New("redis set error"))
+				return mockCache
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Guest.Cache.Keyf = "guest:%s"
+			cfg.Guest.Cache.Entity.HardDuration = 5 * time.Minute
+			service := &GuestService{cfg: cfg, guestCacheRepository: tt.setupCache(t)}
+
+			err := service.writeThroughEntityCaches(context.Background(), tt.entities...)
+
+			if tt.expectError != (err != nil) {
+				t.Errorf("writeThroughEntityCaches() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+
+func Test_GuestService_tryRefreshEntityCaches(t *testing.T) {
+	entity := newTestGuestEntity("019a9a5f-aaf4-7506-a942-6ed217773e2a", "John Doe", "123 Main St", "admin", 1763526552308)
+
+	tests := []struct {
+		name         string
+		writeThrough bool
+		cacheEnable  bool
+		setupCache   func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock
+	}{
+		{
+			name:         "write through when enabled",
+			writeThrough: true,
+			cacheEnable:  true,
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Set", mock.Anything, "guest:019a9a5f-aaf4-7506-a942-6ed217773e2a", entity, 5*time.Minute).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				return mockCache
+			},
+		},
+		{
+			name:         "invalidate when write through is off",
+			writeThrough: false,
+			cacheEnable:  true,
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Delete", mock.Anything, []string{"guest:019a9a5f-aaf4-7506-a942-6ed217773e2a"}).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				return mockCache
+			},
+		},
+		{
+			name:         "invalidate when the cache is disabled",
+			writeThrough: true,
+			cacheEnable:  false,
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Delete", mock.Anything, []string{"guest:019a9a5f-aaf4-7506-a942-6ed217773e2a"}).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				return mockCache
+			},
+		},
+		{
+			name:         "swallow write through error",
+			writeThrough: true,
+			cacheEnable:  true,
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(errors.New("redis set error"))
+				return mockCache
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Guest.Cache.Enable = tt.cacheEnable
+			cfg.Guest.Cache.Keyf = "guest:%s"
+			cfg.Guest.Cache.Entity.HardDuration = 5 * time.Minute
+			service := &GuestService{cfg: cfg, guestCacheRepository: tt.setupCache(t)}
+
+			service.tryRefreshEntityCaches(context.Background(), map[string]interface{}{}, "Create", tt.writeThrough, *entity)
+		})
+	}
+}
+
+func Test_GuestService_cacheHardTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		hard     time.Duration
+		expected time.Duration
+	}{
+		{
+			name:     "use configured hard ttl",
+			hard:     10 * time.Minute,
+			expected: 10 * time.Minute,
+		},
+		{
+			name:     "fall back to cache duration",
+			hard:     0,
+			expected: 5 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Guest.Cache.Duration = 5 * time.Minute
+			service := &GuestService{cfg: cfg}
+
+			if hard := service.cacheHardTTL(tt.hard); hard != tt.expected {
+				t.Errorf("cacheHardTTL() = %v, expected %v", hard, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_GuestService_isCacheStale(t *testing.T) {
+	tests := []struct {
+		name       string
+		soft       time.Duration
+		hard       time.Duration
+		setupCache func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock
+		expected   bool
+	}{
+		{
+			name: "never stale without soft ttl",
+			soft: 0,
+			hard: 5 * time.Minute,
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				return repo_mocks.NewGuestCacheRepositoryMock(t)
+			},
+		},
+		{
+			name: "never stale when soft ttl is not below hard ttl",
+			soft: 5 * time.Minute,
+			hard: 5 * time.Minute,
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				return repo_mocks.NewGuestCacheRepositoryMock(t)
+			},
+		},
+		{
+			name: "stale when older than soft ttl",
+			soft: time.Minute,
+			hard: 5 * time.Minute,
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("TTL", mock.Anything, "guest:1").Return(4*time.Minute, nil)
+				return mockCache
+			},
+			expected: true,
+		},
+		{
+			name: "fresh when younger than soft ttl",
+			soft: time.Minute,
+			hard: 5 * time.Minute,
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("TTL", mock.Anything, "guest:1").Return(4*time.Minute+time.Second, nil)
+				return mockCache
+			},
+		},
+		{
+			name: "fall back to cache duration as hard ttl",
+			soft: time.Minute,
+			hard: 0,
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("TTL", mock.Anything, "guest:1").Return(8*time.Minute, nil)
+				return mockCache
+			},
+			expected: true,
+		},
+		{
+			name: "fresh when key never expires",
+			soft: time.Minute,
+			hard: 5 * time.Minute,
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("TTL", mock.Anything, "guest:1").Return(time.Duration(-1), nil)
+				return mockCache
+			},
+		},
+		{
+			name: "fresh when ttl fails",
+			soft: time.Minute,
+			hard: 5 * time.Minute,
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("TTL", mock.Anything, "guest:1").Return(time.Duration(0), gocerr.New(http.StatusInternalServerError, "redis error"))
+				return mockCache
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Guest.Cache.Duration = 10 * time.Minute
+			service := &GuestService{cfg: cfg, guestCacheRepository: tt.setupCache(t)}
+
+			if stale := service.isCacheStale(context.Background(), "guest:1", tt.soft, tt.hard); stale != tt.expected {
+				t.Errorf("isCacheStale() = %v, expected %v", stale, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_GuestService_loadCache(t *testing.T) {
+	var (
+		service  *GuestService = &GuestService{cfg: &configs.Config{}}
+		release  chan struct{} = make(chan struct{})
+		started  chan struct{} = make(chan struct{})
+		loads    atomic.Int32
+		wg       sync.WaitGroup
+		results  [5]interface{}
+		errs     [5]error
+		startOne sync.Once
+	)
+
+	load := func(ctx context.Context) (interface{}, error) {
+		loads.Add(1)
+		startOne.Do(func() { close(started) })
+		<-release
+		return uint64(7), nil
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0], errs[0] = service.loadCache(context.Background(), "guest:1", load)
+	}()
+	<-started
+
+	for i := 1; i < len(results); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = service.loadCache(context.Background(), "guest:1", load)
+		}(i)
+	}
+
+	// give the followers time to join the in-flight load
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if loads.Load() != 1 {
+		t.Errorf("loadCache() expected 1 load, got %d", loads.Load())
+	}
+	for i := range results {
+		if errs[i] != nil || results[i] != uint64(7) {
+			t.Errorf("loadCache() call %d = %v, %v", i, results[i], errs[i])
+		}
+	}
+}
+
+func Test_GuestService_refreshCache(t *testing.T) {
+	var (
+		service   *GuestService = &GuestService{cfg: &configs.Config{}}
+		refreshed chan struct{} = make(chan struct{})
+		ctx       context.Context
+		cancel    context.CancelFunc
+	)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	service.refreshCache(ctx, "guest:1", func(ctx context.Context) (interface{}, error) {
+		if ctx.Err() != nil {
+			t.Errorf("refreshCache() expected a context detached from the request, got %v", ctx.Err())
+		}
+		close(refreshed)
+		return nil, nil
+	})
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Error("refreshCache() expected load to run in background")
+	}
+}
+
+func Test_GuestService_loadCache_cancelledFirstCaller(t *testing.T) {
+	var (
+		cfg         *configs.Config = &configs.Config{}
+		service     *GuestService
+		release     chan struct{} = make(chan struct{})
+		started     chan struct{} = make(chan struct{})
+		firstCtx    context.Context
+		cancelFirst context.CancelFunc
+		wg          sync.WaitGroup
+		results     [2]interface{}
+		errs        [2]error
+	)
+
+	cfg.Guest.Cache.LoadTimeout = time.Minute
+	service = &GuestService{cfg: cfg}
+
+	load := func(ctx context.Context) (interface{}, error) {
+		close(started)
+		<-release
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		deadline, ok := ctx.Deadline()
+		if !ok || time.Until(deadline) > time.Minute {
+			t.Errorf("loadCache() expected the load bounded by GUEST.CACHE.LOAD_TIMEOUT, got %v, %v", deadline, ok)
+		}
+
+		return uint64(7), nil
+	}
+
+	firstCtx, cancelFirst = context.WithCancel(context.Background())
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0], errs[0] = service.loadCache(firstCtx, "guest:1", load)
+	}()
+	<-started
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[1], errs[1] = service.loadCache(context.Background(), "guest:1", load)
+	}()
+
+	// give the follower time to join the in-flight load
+	time.Sleep(50 * time.Millisecond)
+	cancelFirst()
+	close(release)
+	wg.Wait()
+
+	for i := range results {
+		if errs[i] != nil || results[i] != uint64(7) {
+			t.Errorf("loadCache() call %d = %v, %v", i, results[i], errs[i])
+		}
+	}
+}
+
+func Test_GuestService_cacheLoadTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		loadTimeout time.Duration
+		expected    time.Duration
+	}{
+		{
+			name:     "default when unset",
+			expected: defaultCacheLoadTimeout,
+		},
+		{
+			name:        "configured timeout",
+			loadTimeout: 3 * time.Second,
+			expected:    3 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Guest.Cache.LoadTimeout = tt.loadTimeout
+			service := &GuestService{cfg: cfg}
+
+			if timeout := service.cacheLoadTimeout(); timeout != tt.expected {
+				t.Errorf("cacheLoadTimeout() = %v, expected %v", timeout, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_GuestService_PurgeCaches(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupCache     func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock
+		expectedPurged uint64
+		expectError    bool
+	}{
+		{
+			name: "purge every guest cache",
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("DeleteByPattern", mock.Anything, "guest:*").Return(uint64(12), nil)
+				return mockCache
+			},
+			expectedPurged: 12,
+		},
+		{
+			name: "return purged count and error when scan fails",
+			setupCache: func(t *testing.T) *repo_mocks.GuestCacheRepositoryMock {
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("DeleteByPattern", mock.Anything, "guest:*").Return(uint64(5), errors.New("redis scan error"))
+				return mockCache
+			},
+			expectedPurged: 5,
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Guest.Cache.Keyf = "guest:%s"
+			service := &GuestService{cfg: cfg, guestCacheRepository: tt.setupCache(t)}
+
+			purged, err := service.PurgeCaches(context.Background())
+
+			if purged != tt.expectedPurged {
+				t.Errorf("PurgeCaches() purged = %d, expected %d", purged, tt.expectedPurged)
+			}
+			if tt.expectError != (err != nil) {
+				t.Errorf("PurgeCaches() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+func Test_GuestService_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupService func(t *testing.T) *GuestService
+		requestDTO   *dtos.CreateGuestRequestDTO
+		expectError  bool
+		validate     func(t *testing.T, responseDTO *dtos.GuestResponseDTO, err error)
+	}{
+		{
+			name: "create successfully without cache and event",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = false
 				cfg.Guest.Cache.Keyf = "guest:%s"
+				cfg.Guest.Event.Created.Enable = false
+
+				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
+				mockTx.On("Commit").Return(nil)
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
+				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
+				mockGuestRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				return NewGuestService(
 					cfg,
-					repo_mocks.NewGuestRepositoryMock(t),
+					mockGuestRepo,
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
+			requestDTO: &dtos.CreateGuestRequestDTO{
+				Name:    "John Doe",
+				Address: "123 Main St",
+
+				CreatedBy: "admin",
+			},
 			expectError: false,
-			validateError: func(t *testing.T, err error) {
+			validate: func(t *testing.T, responseDTO *dtos.GuestResponseDTO, err error) {
 				if err != nil {
-					t.Errorf("deleteEntityCaches() unexpected error: %v", err)
+					t.Errorf("Create() unexpected error: %v", err)
+				}
+				if responseDTO == nil {
+					t.Error("Create() expected responseDTO, got nil")
 				}
 			},
 		},
 		{
-			name: "delete caches with Keys error",
+			name: "create writes the entity through to its cache",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = true
 				cfg.Guest.Cache.Keyf = "guest:%s"
+				cfg.Guest.Cache.Entity.HardDuration = 5 * time.Minute
+				cfg.Guest.Cache.WriteThrough.Create = true
+
+				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
+				mockTx.On("Commit").Return(nil)
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
+				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
+				mockGuestRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string(nil), errors.New("redis keys error"))
+				mockCache.On("Set", mock.Anything, mock.AnythingOfType("string"), mock.MatchedBy(func(entity *entities.GuestEntity) bool {
+					return entity.Name == "John Doe"
+				}), 5*time.Minute).Return(nil).Once()
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil).Once()
 
 				return NewGuestService(
 					cfg,
-					repo_mocks.NewGuestRepositoryMock(t),
+					mockGuestRepo,
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
-			expectError: true,
-			validateError: func(t *testing.T, err error) {
-				if err == nil {
-					t.Error("deleteEntityCaches() expected error for Keys failure, got nil")
+			requestDTO: &dtos.CreateGuestRequestDTO{
+				Name:      "John Doe",
+				CreatedBy: "admin",
+			},
+			expectError: false,
+			validate: func(t *testing.T, responseDTO *dtos.GuestResponseDTO, err error) {
+				if err != nil {
+					t.Errorf("Create() unexpected error: %v", err)
 				}
 			},
 		},
 		{
-			name: "delete caches with Delete error",
+			name: "create skips the event when its feature flag is off",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
 				cfg.Guest.Cache.Keyf = "guest:%s"
+				cfg.Guest.Event.Created.Enable = true
+				cfg.Guest.Event.Created.Topic = "guest.created"
+
+				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
+				mockTx.On("Commit").Return(nil)
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
+				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
+				mockGuestRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{"guest:key1"}, nil)
-				mockCache.On("Delete", mock.Anything, mock.MatchedBy(func(keys []string) bool {
-					return len(keys) == 1 && keys[0] == "guest:key1"
-				})).Return(errors.New("redis delete error"))
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+
+				mockFeatureFlagService := service_mocks.NewFeatureFlagServiceMock(t)
+				mockFeatureFlagService.On("IsEnabled", mock.Anything, featureFlagGuestEventCreated, true).Return(false)
 
 				return NewGuestService(
 					cfg,
-					repo_mocks.NewGuestRepositoryMock(t),
+					mockGuestRepo,
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					mockFeatureFlagService,
 				)
 			},
-			expectError: true,
-			validateError: func(t *testing.T, err error) {
-				if err == nil {
-					t.Error("deleteEntityCaches() expected error for Delete failure, got nil")
+			requestDTO: &dtos.CreateGuestRequestDTO{
+				Name:      "John Doe",
+				CreatedBy: "admin",
+			},
+			expectError: false,
+			validate: func(t *testing.T, responseDTO *dtos.GuestResponseDTO, err error) {
+				if err != nil {
+					t.Errorf("Create() unexpected error: %v", err)
 				}
 			},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			service := tt.setupService(t)
-			ctx := context.Background()
-
-			err := service.deleteEntityCaches(ctx)
-
-			if tt.validateError != nil {
-				tt.validateError(t, err)
-			}
-		})
-	}
-}
-
-func Test_GuestService_Create(t *testing.T) {
-	tests := []struct {
-		name         string
-		setupService func(t *testing.T) *GuestService
-		requestDTO   *dtos.CreateGuestRequestDTO
-		expectError  bool
-		validate     func(t *testing.T, responseDTO *dtos.GuestResponseDTO, err error)
-	}{
 		{
-			name: "create successfully without cache and event",
+			name: "create publishes the event when its feature flag is on",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
-				cfg.Guest.Cache.Enable = false
 				cfg.Guest.Cache.Keyf = "guest:%s"
 				cfg.Guest.Event.Created.Enable = false
+				cfg.Guest.Event.Created.Topic = "guest.created"
 
 				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
 				mockTx.On("Commit").Return(nil)
@@ -259,20 +1037,26 @@ func Test_GuestService_Create(t *testing.T) {
 				mockGuestRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+
+				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
+				mockEventProducer.On("Publish", mock.Anything, "guest.created", mock.AnythingOfType("*entities.EventEntity[go-boilerplate/internal/models/entities.GuestEventEntity]")).Return(nil)
+
+				mockFeatureFlagService := service_mocks.NewFeatureFlagServiceMock(t)
+				mockFeatureFlagService.On("IsEnabled", mock.Anything, featureFlagGuestEventCreated, false).Return(true)
 
 				return NewGuestService(
 					cfg,
 					mockGuestRepo,
 					mockCache,
-					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					mockEventProducer,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					mockFeatureFlagService,
 				)
 			},
 			requestDTO: &dtos.CreateGuestRequestDTO{
-				Name:    "John Doe",
-				Address: "123 Main St",
-
+				Name:      "John Doe",
 				CreatedBy: "admin",
 			},
 			expectError: false,
@@ -280,9 +1064,6 @@ func Test_GuestService_Create(t *testing.T) {
 				if err != nil {
 					t.Errorf("Create() unexpected error: %v", err)
 				}
-				if responseDTO == nil {
-					t.Error("Create() expected responseDTO, got nil")
-				}
 			},
 		},
 		{
@@ -295,6 +1076,7 @@ func Test_GuestService_Create(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO:  nil,
@@ -318,6 +1100,7 @@ func Test_GuestService_Create(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.CreateGuestRequestDTO{
@@ -349,6 +1132,7 @@ func Test_GuestService_Create(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.CreateGuestRequestDTO{
@@ -386,6 +1170,7 @@ func Test_GuestService_Create(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.CreateGuestRequestDTO{
@@ -426,6 +1211,7 @@ func Test_GuestService_Create(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.CreateGuestRequestDTO{
@@ -464,6 +1250,7 @@ func Test_GuestService_Create(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.CreateGuestRequestDTO{
@@ -505,6 +1292,7 @@ func Test_GuestService_Create(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.CreateGuestRequestDTO{
@@ -532,10 +1320,8 @@ func Test_GuestService_Create(t *testing.T) {
 				cfg.Guest.Event.Created.Enable = false
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{"guest:key1"}, nil)
-				mockCache.On("Delete", mock.Anything, mock.MatchedBy(func(keys []string) bool {
-					return len(keys) == 1 && keys[0] == "guest:key1"
-				})).Return(errors.New("cache delete error"))
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(errors.New("cache delete error"))
 
 				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
 				mockTx.On("Commit").Return(nil)
@@ -551,6 +1337,7 @@ func Test_GuestService_Create(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.CreateGuestRequestDTO{
@@ -587,7 +1374,8 @@ func Test_GuestService_Create(t *testing.T) {
 				mockGuestRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockEventProducer.On("Publish", mock.Anything, "guest.created", mock.AnythingOfType("*entities.EventEntity[go-boilerplate/internal/models/entities.GuestEventEntity]")).Return(nil)
@@ -598,6 +1386,7 @@ func Test_GuestService_Create(t *testing.T) {
 					mockCache,
 					mockEventProducer,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.CreateGuestRequestDTO{
@@ -634,7 +1423,8 @@ func Test_GuestService_Create(t *testing.T) {
 				mockGuestRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockEventProducer.On("Publish", mock.Anything, "guest.created", mock.AnythingOfType("*entities.EventEntity[go-boilerplate/internal/models/entities.GuestEventEntity]")).Return(errors.New("event publish error"))
@@ -645,6 +1435,7 @@ func Test_GuestService_Create(t *testing.T) {
 					mockCache,
 					mockEventProducer,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.CreateGuestRequestDTO{
@@ -719,7 +1510,8 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				return NewGuestService(
 					cfg,
@@ -727,6 +1519,7 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
@@ -750,6 +1543,7 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO:  nil,
@@ -766,30 +1560,266 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 				cfg := &configs.Config{}
 				return NewGuestService(
 					cfg,
-					repo_mocks.NewGuestRepositoryMock(t),
+					repo_mocks.NewGuestRepositoryMock(t),
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
+				ID:        "",
+				DeletedBy: "admin",
+			},
+			expectError: true,
+			validate: func(t *testing.T, err error) {
+				if err == nil {
+					t.Error("DeleteByID() expected validation error, got nil")
+				}
+			},
+		},
+		{
+			name: "delete with FindOne error (404)",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(nil, gocerr.New(http.StatusNotFound, "guest not found"))
+
+				return NewGuestService(
+					cfg,
+					mockGuestRepo,
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
+				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
+				DeletedBy: "admin",
+			},
+			expectError: true,
+			validate: func(t *testing.T, err error) {
+				if err == nil {
+					t.Error("DeleteByID() expected FindOne error, got nil")
+				}
+			},
+		},
+		{
+			name: "delete with FindOne error (500)",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(nil, gocerr.New(http.StatusInternalServerError, "database error"))
+
+				return NewGuestService(
+					cfg,
+					mockGuestRepo,
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
+				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
+				DeletedBy: "admin",
+			},
+			expectError: true,
+			validate: func(t *testing.T, err error) {
+				if err == nil {
+					t.Error("DeleteByID() expected FindOne error, got nil")
+				}
+			},
+		},
+		{
+			name: "delete with begin transaction error",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+
+				entity := newTestGuestEntity(
+					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
+					"John Doe",
+					"123 Main St",
+					"admin",
+					1763526552308,
+				)
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
+				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(nil, errors.New("begin transaction error"))
+
+				return NewGuestService(
+					cfg,
+					mockGuestRepo,
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
+				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
+				DeletedBy: "admin",
+			},
+			expectError: true,
+			validate: func(t *testing.T, err error) {
+				if err == nil {
+					t.Error("DeleteByID() expected begin transaction error, got nil")
+				}
+			},
+		},
+		{
+			name: "delete with repository update error and rollback success",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+
+				entity := newTestGuestEntity(
+					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
+					"John Doe",
+					"123 Main St",
+					"admin",
+					1763526552308,
+				)
+
+				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
+				mockTx.On("Rollback").Return(nil)
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
+				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
+				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
+				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(errors.New("update entity error"))
+
+				return NewGuestService(
+					cfg,
+					mockGuestRepo,
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
+				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
+				DeletedBy: "admin",
+			},
+			expectError: true,
+			validate: func(t *testing.T, err error) {
+				if err == nil {
+					t.Error("DeleteByID() expected update error, got nil")
+				}
+			},
+		},
+		{
+			name: "delete with repository update error and rollback error",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+
+				entity := newTestGuestEntity(
+					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
+					"John Doe",
+					"123 Main St",
+					"admin",
+					1763526552308,
+				)
+
+				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
+				mockTx.On("Rollback").Return(errors.New("rollback error"))
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
+				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
+				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
+				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(errors.New("update entity error"))
+
+				return NewGuestService(
+					cfg,
+					mockGuestRepo,
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
+				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
+				DeletedBy: "admin",
+			},
+			expectError: true,
+			validate: func(t *testing.T, err error) {
+				if err == nil {
+					t.Error("DeleteByID() expected update error, got nil")
+				}
+			},
+		},
+		{
+			name: "delete with commit error and rollback success",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+
+				entity := newTestGuestEntity(
+					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
+					"John Doe",
+					"123 Main St",
+					"admin",
+					1763526552308,
+				)
+
+				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
+				mockTx.On("Commit").Return(errors.New("commit error"))
+				mockTx.On("Rollback").Return(nil)
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
+				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
+				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
+				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
+
+				return NewGuestService(
+					cfg,
+					mockGuestRepo,
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
-				ID:        "",
+				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
 				DeletedBy: "admin",
 			},
 			expectError: true,
 			validate: func(t *testing.T, err error) {
 				if err == nil {
-					t.Error("DeleteByID() expected validation error, got nil")
+					t.Error("DeleteByID() expected commit error, got nil")
 				}
 			},
 		},
 		{
-			name: "delete with FindOne error (404)",
+			name: "delete with commit error and rollback error",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
 
+				entity := newTestGuestEntity(
+					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
+					"John Doe",
+					"123 Main St",
+					"admin",
+					1763526552308,
+				)
+
+				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
+				mockTx.On("Commit").Return(errors.New("commit error"))
+				mockTx.On("Rollback").Return(errors.New("rollback error"))
+
 				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
-				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(nil, gocerr.New(http.StatusNotFound, "guest not found"))
+				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
+				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
+				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
+				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
 
 				return NewGuestService(
 					cfg,
@@ -797,6 +1827,7 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
@@ -806,41 +1837,65 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 			expectError: true,
 			validate: func(t *testing.T, err error) {
 				if err == nil {
-					t.Error("DeleteByID() expected FindOne error, got nil")
+					t.Error("DeleteByID() expected commit error, got nil")
 				}
 			},
 		},
 		{
-			name: "delete with FindOne error (500)",
+			name: "delete successfully with cache delete error (non-fatal)",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = true
+				cfg.Guest.Cache.Keyf = "guest:%s"
+
+				entity := newTestGuestEntity(
+					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
+					"John Doe",
+					"123 Main St",
+					"admin",
+					1763526552308,
+				)
+
+				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
+				mockTx.On("Commit").Return(nil)
 
 				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
-				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(nil, gocerr.New(http.StatusInternalServerError, "database error"))
+				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
+				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
+				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
+				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
+
+				mockCacheRepo := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCacheRepo.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				mockCacheRepo.On("Delete", mock.Anything, mock.Anything).Return(errors.New("cache delete error"))
 
 				return NewGuestService(
 					cfg,
 					mockGuestRepo,
-					repo_mocks.NewGuestCacheRepositoryMock(t),
+					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
 				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
 				DeletedBy: "admin",
 			},
-			expectError: true,
+			expectError: false,
 			validate: func(t *testing.T, err error) {
-				if err == nil {
-					t.Error("DeleteByID() expected FindOne error, got nil")
+				if err != nil {
+					t.Errorf("DeleteByID() unexpected error: %v", err)
 				}
 			},
 		},
 		{
-			name: "delete with begin transaction error",
+			name: "delete successfully with event publish success",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
+				cfg.Guest.Cache.Keyf = "guest:%s"
+				cfg.Guest.Event.Deleted.Enable = true
+				cfg.Guest.Event.Deleted.Topic = "guest.deleted"
 
 				entity := newTestGuestEntity(
 					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
@@ -850,33 +1905,49 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 					1763526552308,
 				)
 
+				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
+				mockTx.On("Commit").Return(nil)
+
 				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
 				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
-				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(nil, errors.New("begin transaction error"))
+				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
+				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
+				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
+
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+
+				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
+				mockEventProducer.On("Publish", mock.Anything, "guest.deleted", mock.AnythingOfType("*entities.EventEntity[go-boilerplate/internal/models/entities.GuestEventEntity]")).Return(nil)
 
 				return NewGuestService(
 					cfg,
 					mockGuestRepo,
-					repo_mocks.NewGuestCacheRepositoryMock(t),
-					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					mockCache,
+					mockEventProducer,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
 				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
 				DeletedBy: "admin",
 			},
-			expectError: true,
+			expectError: false,
 			validate: func(t *testing.T, err error) {
-				if err == nil {
-					t.Error("DeleteByID() expected begin transaction error, got nil")
+				if err != nil {
+					t.Errorf("DeleteByID() unexpected error: %v", err)
 				}
 			},
 		},
 		{
-			name: "delete with repository update error and rollback success",
+			name: "delete successfully with event publish error (non-fatal)",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
+				cfg.Guest.Cache.Keyf = "guest:%s"
+				cfg.Guest.Event.Deleted.Enable = true
+				cfg.Guest.Event.Deleted.Topic = "guest.deleted"
 
 				entity := newTestGuestEntity(
 					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
@@ -887,37 +1958,80 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 				)
 
 				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
-				mockTx.On("Rollback").Return(nil)
+				mockTx.On("Commit").Return(nil)
 
 				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
 				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
 				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
 				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
-				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(errors.New("update entity error"))
+				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
+
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+
+				mockEventRepo := repo_mocks.NewGuestEventProducerRepositoryMock(t)
+				mockEventRepo.On("Publish", mock.Anything, "guest.deleted", mock.AnythingOfType("*entities.EventEntity[go-boilerplate/internal/models/entities.GuestEventEntity]")).Return(errors.New("event publish error"))
 
 				return NewGuestService(
 					cfg,
 					mockGuestRepo,
-					repo_mocks.NewGuestCacheRepositoryMock(t),
-					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					mockCache,
+					mockEventRepo,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
 				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
 				DeletedBy: "admin",
 			},
-			expectError: true,
+			expectError: false,
 			validate: func(t *testing.T, err error) {
-				if err == nil {
-					t.Error("DeleteByID() expected update error, got nil")
+				if err != nil {
+					t.Errorf("DeleteByID() unexpected error: %v", err)
 				}
 			},
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := tt.setupService(t)
+			ctx := context.Background()
+
+			err := service.DeleteByID(ctx, tt.requestDTO)
+
+			if tt.expectError && err == nil {
+				t.Error("DeleteByID() expected error, got nil")
+			}
+
+			if !tt.expectError && err != nil {
+				t.Errorf("DeleteByID() unexpected error: %v", err)
+			}
+
+			if tt.validate != nil {
+				tt.validate(t, err)
+			}
+		})
+	}
+}
+
+func Test_GuestService_EraseByID(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupService func(t *testing.T) *GuestService
+		requestDTO   *dtos.EraseGuestByIDRequestDTO
+		expectError  bool
+		validate     func(t *testing.T, err error)
+	}{
 		{
-			name: "delete with repository update error and rollback error",
+			name: "erase successfully with cache removal and event publish",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
+				cfg.Guest.Cache.Keyf = "guest:%s"
+				cfg.Guest.Event.Erased.Enable = true
+				cfg.Guest.Event.Erased.Topic = "guest.erased"
 
 				entity := newTestGuestEntity(
 					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
@@ -928,38 +2042,133 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 				)
 
 				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
-				mockTx.On("Rollback").Return(errors.New("rollback error"))
+				mockTx.On("Commit").Return(nil)
 
 				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
-				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
+				mockGuestRepo.On("FindOne", mock.Anything, mock.MatchedBy(func(filter *goqube.Filter) bool {
+					return filter.Field.Column == entities.GuestEntityDatabaseFieldID &&
+						filter.Value.Value == "019a9a5f-aaf4-7506-a942-6ed217773e2a" &&
+						len(filter.Filters) == 0
+				}), mock.Anything, false).Return(entity, nil)
 				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
 				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
-				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(errors.New("update entity error"))
+				mockGuestRepo.On("Update", mock.Anything, mock.MatchedBy(func(entity *entities.GuestEntity) bool {
+					return entity.Name == entities.GuestEntityErasedValue &&
+						!entity.Address.Valid &&
+						entity.DeletedAt.Valid &&
+						entity.UpdatedBy.String == "dpo"
+				}), mock.Anything).Return(nil)
+
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+
+				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
+				mockEventProducer.On("Publish", mock.Anything, "guest.erased", mock.AnythingOfType("*entities.EventEntity[go-boilerplate/internal/models/entities.GuestEventEntity]")).Return(nil)
 
 				return NewGuestService(
 					cfg,
 					mockGuestRepo,
+					mockCache,
+					mockEventProducer,
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.EraseGuestByIDRequestDTO{
+				ID:       "019a9a5f-aaf4-7506-a942-6ed217773e2a",
+				ErasedBy: "dpo",
+			},
+			expectError: false,
+		},
+		{
+			name: "erase with nil requestDTO",
+			setupService: func(t *testing.T) *GuestService {
+				return NewGuestService(
+					&configs.Config{},
+					repo_mocks.NewGuestRepositoryMock(t),
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
-			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
-				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
-				DeletedBy: "admin",
+			requestDTO:  nil,
+			expectError: true,
+			validate: func(t *testing.T, err error) {
+				if gocerr.GetErrorCode(err) != http.StatusBadRequest {
+					t.Errorf("EraseByID() expected 400, got %d", gocerr.GetErrorCode(err))
+				}
+			},
+		},
+		{
+			name: "erase with validation error",
+			setupService: func(t *testing.T) *GuestService {
+				return NewGuestService(
+					&configs.Config{},
+					repo_mocks.NewGuestRepositoryMock(t),
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.EraseGuestByIDRequestDTO{
+				ID:       "019a9a5f-aaf4-7506-a942-6ed217773e2a",
+				ErasedBy: "",
+			},
+			expectError: true,
+		},
+		{
+			name: "erase with FindOne error (404)",
+			setupService: func(t *testing.T) *GuestService {
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(nil, gocerr.New(http.StatusNotFound, "guest not found"))
+
+				return NewGuestService(
+					&configs.Config{},
+					mockGuestRepo,
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.EraseGuestByIDRequestDTO{
+				ID:       "019a9a5f-aaf4-7506-a942-6ed217773e2a",
+				ErasedBy: "dpo",
 			},
 			expectError: true,
 			validate: func(t *testing.T, err error) {
-				if err == nil {
-					t.Error("DeleteByID() expected update error, got nil")
+				if gocerr.GetErrorCode(err) != http.StatusNotFound {
+					t.Errorf("EraseByID() expected 404, got %d", gocerr.GetErrorCode(err))
 				}
 			},
 		},
 		{
-			name: "delete with commit error and rollback success",
+			name: "erase with FindOne error (500)",
 			setupService: func(t *testing.T) *GuestService {
-				cfg := &configs.Config{}
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(nil, gocerr.New(http.StatusInternalServerError, "database error"))
 
+				return NewGuestService(
+					&configs.Config{},
+					mockGuestRepo,
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.EraseGuestByIDRequestDTO{
+				ID:       "019a9a5f-aaf4-7506-a942-6ed217773e2a",
+				ErasedBy: "dpo",
+			},
+			expectError: true,
+		},
+		{
+			name: "erase with repository update error and rollback",
+			setupService: func(t *testing.T) *GuestService {
 				entity := newTestGuestEntity(
 					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
 					"John Doe",
@@ -969,38 +2178,34 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 				)
 
 				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
-				mockTx.On("Commit").Return(errors.New("commit error"))
 				mockTx.On("Rollback").Return(nil)
 
 				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
 				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
 				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
 				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
-				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
+				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(errors.New("update entity error"))
 
 				return NewGuestService(
-					cfg,
+					&configs.Config{},
 					mockGuestRepo,
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
-			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
-				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
-				DeletedBy: "admin",
+			requestDTO: &dtos.EraseGuestByIDRequestDTO{
+				ID:       "019a9a5f-aaf4-7506-a942-6ed217773e2a",
+				ErasedBy: "dpo",
 			},
 			expectError: true,
-			validate: func(t *testing.T, err error) {
-				if err == nil {
-					t.Error("DeleteByID() expected commit error, got nil")
-				}
-			},
 		},
 		{
-			name: "delete with commit error and rollback error",
+			name: "erase successfully with cache delete error (non-fatal)",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
+				cfg.Guest.Cache.Keyf = "guest:%s"
 
 				entity := newTestGuestEntity(
 					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
@@ -1011,8 +2216,7 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 				)
 
 				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
-				mockTx.On("Commit").Return(errors.New("commit error"))
-				mockTx.On("Rollback").Return(errors.New("rollback error"))
+				mockTx.On("Commit").Return(nil)
 
 				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
 				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
@@ -1020,30 +2224,61 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
 				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
 
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(errors.New("cache delete error"))
+
 				return NewGuestService(
 					cfg,
 					mockGuestRepo,
-					repo_mocks.NewGuestCacheRepositoryMock(t),
+					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
-			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
-				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
-				DeletedBy: "admin",
-			},
-			expectError: true,
-			validate: func(t *testing.T, err error) {
-				if err == nil {
-					t.Error("DeleteByID() expected commit error, got nil")
-				}
+			requestDTO: &dtos.EraseGuestByIDRequestDTO{
+				ID:       "019a9a5f-aaf4-7506-a942-6ed217773e2a",
+				ErasedBy: "dpo",
 			},
+			expectError: false,
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := tt.setupService(t)
+			ctx := context.Background()
+
+			err := service.EraseByID(ctx, tt.requestDTO)
+
+			if tt.expectError && err == nil {
+				t.Error("EraseByID() expected error, got nil")
+			}
+
+			if !tt.expectError && err != nil {
+				t.Errorf("EraseByID() unexpected error: %v", err)
+			}
+
+			if tt.validate != nil {
+				tt.validate(t, err)
+			}
+		})
+	}
+}
+
+func Test_GuestService_ExportByID(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupService func(t *testing.T) *GuestService
+		requestDTO   *dtos.ExportGuestByIDRequestDTO
+		expectError  bool
+		validate     func(t *testing.T, responseDTO *dtos.ExportGuestResponseDTO, err error)
+	}{
 		{
-			name: "delete successfully with cache delete error (non-fatal)",
+			name: "export successfully with database and cache records",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
-				cfg.Guest.Cache.Enable = true
 				cfg.Guest.Cache.Keyf = "guest:%s"
 
 				entity := newTestGuestEntity(
@@ -1054,47 +2289,39 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 					1763526552308,
 				)
 
-				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
-				mockTx.On("Commit").Return(nil)
-
 				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
 				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
-				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
-				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
-				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
 
-				mockCacheRepo := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCacheRepo.On("Keys", mock.Anything, "guest:*").Return([]string{"guest:key1"}, nil)
-				mockCacheRepo.On("Delete", mock.Anything, mock.MatchedBy(func(keys []string) bool {
-					return len(keys) == 1 && keys[0] == "guest:key1"
-				})).Return(errors.New("cache delete error"))
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Get", mock.Anything, "guest:019a9a5f-aaf4-7506-a942-6ed217773e2a").Return(entity, nil)
 
 				return NewGuestService(
 					cfg,
 					mockGuestRepo,
-					mockCacheRepo,
+					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
-			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
-				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
-				DeletedBy: "admin",
+			requestDTO: &dtos.ExportGuestByIDRequestDTO{
+				ID: "019a9a5f-aaf4-7506-a942-6ed217773e2a",
 			},
 			expectError: false,
-			validate: func(t *testing.T, err error) {
-				if err != nil {
-					t.Errorf("DeleteByID() unexpected error: %v", err)
+			validate: func(t *testing.T, responseDTO *dtos.ExportGuestResponseDTO, err error) {
+				if responseDTO.Database == nil || responseDTO.Database.Name != "John Doe" {
+					t.Errorf("ExportByID() unexpected database record: %+v", responseDTO.Database)
+				}
+				if responseDTO.Cache == nil || responseDTO.Cache.Address != "123 Main St" {
+					t.Errorf("ExportByID() unexpected cache record: %+v", responseDTO.Cache)
 				}
 			},
 		},
 		{
-			name: "delete successfully with event publish success",
+			name: "export successfully without cache record",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
 				cfg.Guest.Cache.Keyf = "guest:%s"
-				cfg.Guest.Event.Deleted.Enable = true
-				cfg.Guest.Event.Deleted.Topic = "guest.deleted"
 
 				entity := newTestGuestEntity(
 					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
@@ -1104,47 +2331,91 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 					1763526552308,
 				)
 
-				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
-				mockTx.On("Commit").Return(nil)
-
 				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
 				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
-				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
-				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
-				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
-
-				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
-				mockEventProducer.On("Publish", mock.Anything, "guest.deleted", mock.AnythingOfType("*entities.EventEntity[go-boilerplate/internal/models/entities.GuestEventEntity]")).Return(nil)
+				mockCache.On("Get", mock.Anything, "guest:019a9a5f-aaf4-7506-a942-6ed217773e2a").Return(nil, gocerr.New(http.StatusNotFound, "cache not found"))
 
 				return NewGuestService(
 					cfg,
 					mockGuestRepo,
 					mockCache,
-					mockEventProducer,
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
-			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
-				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
-				DeletedBy: "admin",
+			requestDTO: &dtos.ExportGuestByIDRequestDTO{
+				ID: "019a9a5f-aaf4-7506-a942-6ed217773e2a",
 			},
 			expectError: false,
-			validate: func(t *testing.T, err error) {
-				if err != nil {
-					t.Errorf("DeleteByID() unexpected error: %v", err)
+			validate: func(t *testing.T, responseDTO *dtos.ExportGuestResponseDTO, err error) {
+				if responseDTO.Database == nil {
+					t.Error("ExportByID() expected database record, got nil")
+				}
+				if responseDTO.Cache != nil {
+					t.Errorf("ExportByID() expected no cache record, got %+v", responseDTO.Cache)
 				}
 			},
 		},
 		{
-			name: "delete successfully with event publish error (non-fatal)",
+			name: "export with nil requestDTO",
+			setupService: func(t *testing.T) *GuestService {
+				return NewGuestService(
+					&configs.Config{},
+					repo_mocks.NewGuestRepositoryMock(t),
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO:  nil,
+			expectError: true,
+		},
+		{
+			name: "export with validation error",
+			setupService: func(t *testing.T) *GuestService {
+				return NewGuestService(
+					&configs.Config{},
+					repo_mocks.NewGuestRepositoryMock(t),
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.ExportGuestByIDRequestDTO{
+				ID: "invalid-uuid",
+			},
+			expectError: true,
+		},
+		{
+			name: "export with FindOne error (404)",
+			setupService: func(t *testing.T) *GuestService {
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(nil, gocerr.New(http.StatusNotFound, "guest not found"))
+
+				return NewGuestService(
+					&configs.Config{},
+					mockGuestRepo,
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.ExportGuestByIDRequestDTO{
+				ID: "019a9a5f-aaf4-7506-a942-6ed217773e2a",
+			},
+			expectError: true,
+		},
+		{
+			name: "export with cache get error (500)",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
 				cfg.Guest.Cache.Keyf = "guest:%s"
-				cfg.Guest.Event.Deleted.Enable = true
-				cfg.Guest.Event.Deleted.Topic = "guest.deleted"
 
 				entity := newTestGuestEntity(
 					"019a9a5f-aaf4-7506-a942-6ed217773e2a",
@@ -1154,39 +2425,25 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 					1763526552308,
 				)
 
-				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
-				mockTx.On("Commit").Return(nil)
-
 				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
 				mockGuestRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(entity, nil)
-				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
-				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
-				mockGuestRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
-
-				mockEventRepo := repo_mocks.NewGuestEventProducerRepositoryMock(t)
-				mockEventRepo.On("Publish", mock.Anything, "guest.deleted", mock.AnythingOfType("*entities.EventEntity[go-boilerplate/internal/models/entities.GuestEventEntity]")).Return(errors.New("event publish error"))
+				mockCache.On("Get", mock.Anything, "guest:019a9a5f-aaf4-7506-a942-6ed217773e2a").Return(nil, gocerr.New(http.StatusInternalServerError, "cache error"))
 
 				return NewGuestService(
 					cfg,
 					mockGuestRepo,
 					mockCache,
-					mockEventRepo,
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
-			requestDTO: &dtos.DeleteGuestByIDRequestDTO{
-				ID:        "019a9a5f-aaf4-7506-a942-6ed217773e2a",
-				DeletedBy: "admin",
-			},
-			expectError: false,
-			validate: func(t *testing.T, err error) {
-				if err != nil {
-					t.Errorf("DeleteByID() unexpected error: %v", err)
-				}
+			requestDTO: &dtos.ExportGuestByIDRequestDTO{
+				ID: "019a9a5f-aaf4-7506-a942-6ed217773e2a",
 			},
+			expectError: true,
 		},
 	}
 
@@ -1195,18 +2452,18 @@ func Test_GuestService_DeleteByID(t *testing.T) {
 			service := tt.setupService(t)
 			ctx := context.Background()
 
-			err := service.DeleteByID(ctx, tt.requestDTO)
+			responseDTO, err := service.ExportByID(ctx, tt.requestDTO)
 
 			if tt.expectError && err == nil {
-				t.Error("DeleteByID() expected error, got nil")
+				t.Error("ExportByID() expected error, got nil")
 			}
 
 			if !tt.expectError && err != nil {
-				t.Errorf("DeleteByID() unexpected error: %v", err)
+				t.Errorf("ExportByID() unexpected error: %v", err)
 			}
 
 			if tt.validate != nil {
-				tt.validate(t, err)
+				tt.validate(t, responseDTO, err)
 			}
 		})
 	}
@@ -1251,6 +2508,7 @@ func Test_GuestService_getListEntityCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1281,6 +2539,7 @@ func Test_GuestService_getListEntityCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1308,6 +2567,7 @@ func Test_GuestService_getListEntityCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1335,6 +2595,7 @@ func Test_GuestService_getListEntityCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1399,6 +2660,7 @@ func Test_GuestService_setListEntityCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1440,6 +2702,7 @@ func Test_GuestService_setListEntityCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1477,6 +2740,7 @@ func Test_GuestService_setListEntityCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1503,6 +2767,7 @@ func Test_GuestService_setListEntityCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1556,6 +2821,8 @@ func Test_GuestService_findListEntity(t *testing.T) {
 		),
 	}
 
+	listEntityRefreshed := make(chan struct{})
+
 	tests := []struct {
 		name               string
 		setupService       func(t *testing.T) *GuestService
@@ -1582,6 +2849,7 @@ func Test_GuestService_findListEntity(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1614,6 +2882,7 @@ func Test_GuestService_findListEntity(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1651,6 +2920,7 @@ func Test_GuestService_findListEntity(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1688,6 +2958,7 @@ func Test_GuestService_findListEntity(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1725,6 +2996,7 @@ func Test_GuestService_findListEntity(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1757,6 +3029,7 @@ func Test_GuestService_findListEntity(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1794,6 +3067,7 @@ func Test_GuestService_findListEntity(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1826,6 +3100,7 @@ func Test_GuestService_findListEntity(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			listEntityCacheKey: "guest:test-key",
@@ -1843,6 +3118,85 @@ func Test_GuestService_findListEntity(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "serve stale list entity and refresh it in background",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = true
+				cfg.Guest.Cache.List.SoftDuration = time.Minute
+				cfg.Guest.Cache.List.HardDuration = 5 * time.Minute
+
+				mockCacheRepo := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCacheRepo.On("GetList", mock.Anything, "guest:test-key").Return(testEntities[:1], nil)
+				mockCacheRepo.On("TTL", mock.Anything, "guest:test-key").Return(3*time.Minute, nil)
+				mockCacheRepo.On("SetList", mock.Anything, "guest:test-key", testEntities, 5*time.Minute).
+					Return(nil).
+					Run(func(args mock.Arguments) {
+						close(listEntityRefreshed)
+					})
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("FindAll", mock.Anything, mock.Anything, mock.Anything, uint64(10), uint64(0), false).Return(testEntities, nil)
+
+				return NewGuestService(
+					cfg,
+					mockGuestRepo,
+					mockCacheRepo,
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			listEntityCacheKey: "guest:test-key",
+			filter:             &goqube.Filter{},
+			sorts:              []goqube.Sort{},
+			take:               10,
+			skip:               0,
+			expectError:        false,
+			validate: func(t *testing.T, result []entities.GuestEntity, err error) {
+				if len(result) != 1 {
+					t.Errorf("findListEntity() expected stale list with 1 item, got %d items", len(result))
+				}
+				select {
+				case <-listEntityRefreshed:
+				case <-time.After(time.Second):
+					t.Error("findListEntity() expected stale list entity to be refreshed")
+				}
+			},
+		},
+		{
+			name: "serve list entity without refresh within soft ttl",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = true
+				cfg.Guest.Cache.List.SoftDuration = time.Minute
+				cfg.Guest.Cache.List.HardDuration = 5 * time.Minute
+
+				mockCacheRepo := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCacheRepo.On("GetList", mock.Anything, "guest:test-key").Return(testEntities, nil)
+				mockCacheRepo.On("TTL", mock.Anything, "guest:test-key").Return(4*time.Minute+30*time.Second, nil)
+
+				return NewGuestService(
+					cfg,
+					repo_mocks.NewGuestRepositoryMock(t),
+					mockCacheRepo,
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			listEntityCacheKey: "guest:test-key",
+			filter:             &goqube.Filter{},
+			sorts:              []goqube.Sort{},
+			take:               10,
+			skip:               0,
+			expectError:        false,
+			validate: func(t *testing.T, result []entities.GuestEntity, err error) {
+				if len(result) != 2 {
+					t.Errorf("findListEntity() expected 2 items, got %d items", len(result))
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1852,6 +3206,7 @@ func Test_GuestService_findListEntity(t *testing.T) {
 
 			result, err := service.findListEntity(
 				ctx,
+				service.config().Guest.Cache.Enable,
 				tt.listEntityCacheKey,
 				tt.filter,
 				tt.sorts,
@@ -1896,6 +3251,7 @@ func Test_GuestService_getCountEntitiesCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			entitiesCountCacheKey: "guest:count-key",
@@ -1923,6 +3279,7 @@ func Test_GuestService_getCountEntitiesCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			entitiesCountCacheKey: "guest:count-key",
@@ -1950,6 +3307,7 @@ func Test_GuestService_getCountEntitiesCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			entitiesCountCacheKey: "guest:count-key",
@@ -1977,6 +3335,7 @@ func Test_GuestService_getCountEntitiesCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			entitiesCountCacheKey: "guest:count-key",
@@ -2038,6 +3397,7 @@ func Test_GuestService_setEntitiesCountCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			entitiesCountCacheKey: "guest:count-key",
@@ -2064,6 +3424,7 @@ func Test_GuestService_setEntitiesCountCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			entitiesCountCacheKey: "guest:count-key",
@@ -2093,6 +3454,7 @@ func Test_GuestService_setEntitiesCountCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			entitiesCountCacheKey: "guest:count-key",
@@ -2119,6 +3481,7 @@ func Test_GuestService_setEntitiesCountCache(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			entitiesCountCacheKey: "guest:count-key",
@@ -2155,6 +3518,8 @@ func Test_GuestService_setEntitiesCountCache(t *testing.T) {
 }
 
 func Test_GuestService_countEntities(t *testing.T) {
+	entitiesCountRefreshed := make(chan struct{})
+
 	tests := []struct {
 		name                  string
 		setupService          func(t *testing.T) *GuestService
@@ -2180,6 +3545,7 @@ func Test_GuestService_countEntities(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			entitiesCountCacheKey: "test:count",
@@ -2220,6 +3586,7 @@ func Test_GuestService_countEntities(t *testing.T) {
 					mockGuestCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			entitiesCountCacheKey: "test:count",
@@ -2264,6 +3631,7 @@ func Test_GuestService_countEntities(t *testing.T) {
 					mockGuestCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			entitiesCountCacheKey: "test:count",
@@ -2302,7 +3670,7 @@ func Test_GuestService_countEntities(t *testing.T) {
 				mockGuestCacheRepo.On("GetCount", mock.Anything, "test:count").Return(uint64(0), gocerr.New(http.StatusInternalServerError, "cache server error"))
 				mockGuestCacheRepo.On("SetCount", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-				return NewGuestService(cfg, mockGuestRepo, mockGuestCacheRepo, repo_mocks.NewGuestEventProducerRepositoryMock(t), repo_mocks.NewWebhookSiteRepositoryMock(t))
+				return NewGuestService(cfg, mockGuestRepo, mockGuestCacheRepo, repo_mocks.NewGuestEventProducerRepositoryMock(t), repo_mocks.NewWebhookSiteRepositoryMock(t), nil)
 			},
 			entitiesCountCacheKey: "test:count",
 			filter: &goqube.Filter{
@@ -2340,7 +3708,7 @@ func Test_GuestService_countEntities(t *testing.T) {
 				mockGuestCacheRepo.On("GetCount", mock.Anything, "test:count").Return(uint64(0), nil)
 				mockGuestCacheRepo.On("SetCount", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-				return NewGuestService(cfg, mockGuestRepo, mockGuestCacheRepo, repo_mocks.NewGuestEventProducerRepositoryMock(t), repo_mocks.NewWebhookSiteRepositoryMock(t))
+				return NewGuestService(cfg, mockGuestRepo, mockGuestCacheRepo, repo_mocks.NewGuestEventProducerRepositoryMock(t), repo_mocks.NewWebhookSiteRepositoryMock(t), nil)
 			},
 			entitiesCountCacheKey: "test:count",
 			filter: &goqube.Filter{
@@ -2376,7 +3744,7 @@ func Test_GuestService_countEntities(t *testing.T) {
 
 				mockGuestCacheRepo := repo_mocks.NewGuestCacheRepositoryMock(t)
 
-				return NewGuestService(cfg, mockGuestRepo, mockGuestCacheRepo, repo_mocks.NewGuestEventProducerRepositoryMock(t), repo_mocks.NewWebhookSiteRepositoryMock(t))
+				return NewGuestService(cfg, mockGuestRepo, mockGuestCacheRepo, repo_mocks.NewGuestEventProducerRepositoryMock(t), repo_mocks.NewWebhookSiteRepositoryMock(t), nil)
 			},
 			entitiesCountCacheKey: "test:count",
 			filter: &goqube.Filter{
@@ -2417,7 +3785,7 @@ func Test_GuestService_countEntities(t *testing.T) {
 				mockGuestCacheRepo.On("GetCount", mock.Anything, "test:count").Return(uint64(0), gocerr.New(http.StatusNotFound, "cache not found"))
 				mockGuestCacheRepo.On("SetCount", mock.Anything, "test:count", uint64(20), time.Duration(300)).Return(nil)
 
-				return NewGuestService(cfg, mockGuestRepo, mockGuestCacheRepo, repo_mocks.NewGuestEventProducerRepositoryMock(t), repo_mocks.NewWebhookSiteRepositoryMock(t))
+				return NewGuestService(cfg, mockGuestRepo, mockGuestCacheRepo, repo_mocks.NewGuestEventProducerRepositoryMock(t), repo_mocks.NewWebhookSiteRepositoryMock(t), nil)
 			},
 			entitiesCountCacheKey: "test:count",
 			filter: &goqube.Filter{
@@ -2455,7 +3823,7 @@ func Test_GuestService_countEntities(t *testing.T) {
 				mockGuestCacheRepo.On("GetCount", mock.Anything, "test:count").Return(uint64(0), gocerr.New(http.StatusNotFound, "cache not found"))
 				mockGuestCacheRepo.On("SetCount", mock.Anything, "test:count", uint64(12), time.Duration(300)).Return(gocerr.New(http.StatusInternalServerError, "cache set error"))
 
-				return NewGuestService(cfg, mockGuestRepo, mockGuestCacheRepo, repo_mocks.NewGuestEventProducerRepositoryMock(t), repo_mocks.NewWebhookSiteRepositoryMock(t))
+				return NewGuestService(cfg, mockGuestRepo, mockGuestCacheRepo, repo_mocks.NewGuestEventProducerRepositoryMock(t), repo_mocks.NewWebhookSiteRepositoryMock(t), nil)
 			},
 			entitiesCountCacheKey: "test:count",
 			filter: &goqube.Filter{
@@ -2492,7 +3860,7 @@ func Test_GuestService_countEntities(t *testing.T) {
 				mockGuestCacheRepo := repo_mocks.NewGuestCacheRepositoryMock(t)
 				mockGuestCacheRepo.On("GetCount", mock.Anything, "test:count").Return(uint64(0), gocerr.New(http.StatusNotFound, "cache not found"))
 
-				return NewGuestService(cfg, mockGuestRepo, mockGuestCacheRepo, repo_mocks.NewGuestEventProducerRepositoryMock(t), repo_mocks.NewWebhookSiteRepositoryMock(t))
+				return NewGuestService(cfg, mockGuestRepo, mockGuestCacheRepo, repo_mocks.NewGuestEventProducerRepositoryMock(t), repo_mocks.NewWebhookSiteRepositoryMock(t), nil)
 			},
 			entitiesCountCacheKey: "test:count",
 			filter: &goqube.Filter{
@@ -2516,6 +3884,47 @@ func Test_GuestService_countEntities(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "serve stale entities count and refresh it in background",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = true
+				cfg.Guest.Cache.Duration = 5 * time.Minute
+				cfg.Guest.Cache.Count.SoftDuration = time.Minute
+
+				mockCacheRepo := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCacheRepo.On("GetCount", mock.Anything, "count-key").Return(uint64(3), nil)
+				mockCacheRepo.On("TTL", mock.Anything, "count-key").Return(2*time.Minute, nil)
+				mockCacheRepo.On("SetCount", mock.Anything, "count-key", uint64(4), 5*time.Minute).
+					Return(nil).
+					Run(func(args mock.Arguments) {
+						close(entitiesCountRefreshed)
+					})
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("Count", mock.Anything, mock.Anything, false).Return(uint64(4), nil)
+
+				return NewGuestService(
+					cfg,
+					mockGuestRepo,
+					mockCacheRepo,
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			entitiesCountCacheKey: "count-key",
+			filter:                &goqube.Filter{},
+			expectedCount:         3,
+			expectError:           false,
+			validate: func(t *testing.T, count uint64, err error) {
+				select {
+				case <-entitiesCountRefreshed:
+				case <-time.After(time.Second):
+					t.Error("countEntities() expected stale entities count to be refreshed")
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -2523,7 +3932,7 @@ func Test_GuestService_countEntities(t *testing.T) {
 			service := tt.setupService(t)
 			ctx := context.Background()
 
-			count, err := service.countEntities(ctx, tt.entitiesCountCacheKey, tt.filter)
+			count, err := service.countEntities(ctx, service.config().Guest.Cache.Enable, tt.entitiesCountCacheKey, tt.filter)
 
 			if tt.expectError && err == nil {
 				t.Error("expected error, got nil")
@@ -2556,30 +3965,109 @@ func Test_GuestService_FindAll(t *testing.T) {
 		time.Now().Unix(),
 	)
 
-	tests := []struct {
-		name         string
-		setupService func(t *testing.T) *GuestService
-		requestDTO   *dtos.FindAllGuestRequestDTO
-		expectError  bool
-		validate     func(t *testing.T, responseDTO *dtos.FindAllGuestResponseDTO, err error)
-	}{
+	tests := []struct {
+		name         string
+		setupService func(t *testing.T) *GuestService
+		requestDTO   *dtos.FindAllGuestRequestDTO
+		expectError  bool
+		validate     func(t *testing.T, responseDTO *dtos.FindAllGuestResponseDTO, err error)
+	}{
+		{
+			name: "find all with nil requestDTO - should use default",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = false
+				cfg.Guest.Cache.Keyf = "guest:%s"
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("FindAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, false).Return([]entities.GuestEntity{*testEntity1, *testEntity2}, nil)
+				mockGuestRepo.On("Count", mock.Anything, mock.Anything, false).Return(uint64(2), nil)
+
+				return NewGuestService(
+					cfg,
+					mockGuestRepo,
+					repo_mocks.NewGuestCacheRepositoryMock(t),
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO:  nil,
+			expectError: false,
+			validate: func(t *testing.T, responseDTO *dtos.FindAllGuestResponseDTO, err error) {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				if responseDTO == nil {
+					t.Error("expected responseDTO, got nil")
+					return
+				}
+				if len(responseDTO.List) != 2 {
+					t.Errorf("expected 2 guests, got %d", len(responseDTO.List))
+				}
+				if responseDTO.Count != 2 {
+					t.Errorf("expected count 2, got %d", responseDTO.Count)
+				}
+			},
+		},
+		{
+			name: "find all from caches of the current list generation",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = true
+				cfg.Guest.Cache.Keyf = "guest:%s"
+
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, "guest:list:generation").Return(uint64(5), nil)
+				mockCache.On("GetList", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "guest:list:5:keyword=")
+				})).Return([]entities.GuestEntity{*testEntity1, *testEntity2}, nil)
+				mockCache.On("GetCount", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "guest:list:5:keyword=") && strings.HasSuffix(key, ":count")
+				})).Return(uint64(2), nil)
+
+				return NewGuestService(
+					cfg,
+					repo_mocks.NewGuestRepositoryMock(t),
+					mockCache,
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO:  nil,
+			expectError: false,
+			validate: func(t *testing.T, responseDTO *dtos.FindAllGuestResponseDTO, err error) {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				if responseDTO == nil || len(responseDTO.List) != 2 || responseDTO.Count != 2 {
+					t.Errorf("expected 2 cached guests, got %+v", responseDTO)
+				}
+			},
+		},
 		{
-			name: "find all with nil requestDTO - should use default",
+			name: "find all skips the list cache when the list generation read fails",
 			setupService: func(t *testing.T) *GuestService {
 				cfg := &configs.Config{}
-				cfg.Guest.Cache.Enable = false
+				cfg.Guest.Cache.Enable = true
 				cfg.Guest.Cache.Keyf = "guest:%s"
 
 				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
-				mockGuestRepo.On("FindAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, false).Return([]entities.GuestEntity{*testEntity1, *testEntity2}, nil)
-				mockGuestRepo.On("Count", mock.Anything, mock.Anything, false).Return(uint64(2), nil)
+				mockGuestRepo.On("FindAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, false).Return([]entities.GuestEntity{*testEntity1}, nil)
+				mockGuestRepo.On("Count", mock.Anything, mock.Anything, false).Return(uint64(1), nil)
+
+				// no GetList, SetList or SetCount on the page keys is expected
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, "guest:list:generation").Return(uint64(0), gocerr.New(http.StatusInternalServerError, "redis error"))
 
 				return NewGuestService(
 					cfg,
 					mockGuestRepo,
-					repo_mocks.NewGuestCacheRepositoryMock(t),
+					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO:  nil,
@@ -2588,15 +4076,8 @@ func Test_GuestService_FindAll(t *testing.T) {
 				if err != nil {
 					t.Errorf("expected no error, got %v", err)
 				}
-				if responseDTO == nil {
-					t.Error("expected responseDTO, got nil")
-					return
-				}
-				if len(responseDTO.List) != 2 {
-					t.Errorf("expected 2 guests, got %d", len(responseDTO.List))
-				}
-				if responseDTO.Count != 2 {
-					t.Errorf("expected count 2, got %d", responseDTO.Count)
+				if responseDTO == nil || len(responseDTO.List) != 1 || responseDTO.Count != 1 {
+					t.Errorf("expected 1 guest from the database, got %+v", responseDTO)
 				}
 			},
 		},
@@ -2617,6 +4098,7 @@ func Test_GuestService_FindAll(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.FindAllGuestRequestDTO{
@@ -2658,6 +4140,7 @@ func Test_GuestService_FindAll(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.FindAllGuestRequestDTO{
@@ -2694,6 +4177,7 @@ func Test_GuestService_FindAll(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.FindAllGuestRequestDTO{
@@ -2728,6 +4212,7 @@ func Test_GuestService_FindAll(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.FindAllGuestRequestDTO{
@@ -2764,6 +4249,7 @@ func Test_GuestService_FindAll(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.FindAllGuestRequestDTO{
@@ -2800,6 +4286,7 @@ func Test_GuestService_FindAll(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.FindAllGuestRequestDTO{
@@ -2837,6 +4324,7 @@ func Test_GuestService_FindAll(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.FindAllGuestRequestDTO{
@@ -2877,6 +4365,7 @@ func Test_GuestService_FindAll(t *testing.T) {
 				mockCacheRepo.On("GetCount", mock.Anything, mock.Anything).Return(uint64(0), gocerr.New(http.StatusNotFound, "cache not found"))
 				mockCacheRepo.On("SetList", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				mockCacheRepo.On("SetCount", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mockCacheRepo.On("SetCountIfNotExists", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(true, nil)
 
 				return NewGuestService(
 					cfg,
@@ -2884,6 +4373,7 @@ func Test_GuestService_FindAll(t *testing.T) {
 					mockCacheRepo,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.FindAllGuestRequestDTO{
@@ -2924,6 +4414,7 @@ func Test_GuestService_FindAll(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.FindAllGuestRequestDTO{
@@ -3002,6 +4493,7 @@ func Test_GuestService_getEntityByIDCache(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3037,6 +4529,7 @@ func Test_GuestService_getEntityByIDCache(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3068,6 +4561,7 @@ func Test_GuestService_getEntityByIDCache(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3099,6 +4593,7 @@ func Test_GuestService_getEntityByIDCache(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3168,6 +4663,7 @@ func Test_GuestService_setEntityByIDCache(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3194,6 +4690,7 @@ func Test_GuestService_setEntityByIDCache(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3226,6 +4723,7 @@ func Test_GuestService_setEntityByIDCache(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3252,6 +4750,7 @@ func Test_GuestService_setEntityByIDCache(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey: "guest:00000000-0000-0000-0000-000000000002",
@@ -3318,6 +4817,8 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 		},
 	}
 
+	entityRefreshed := make(chan struct{})
+
 	tests := []struct {
 		name         string
 		setupService func(t *testing.T) *GuestService
@@ -3341,6 +4842,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3375,6 +4877,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3404,6 +4907,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(testEntity, nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil)
 				mockCache.On("Get", mock.Anything, "guest:00000000-0000-0000-0000-000000000001").Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "cache not found"))
 				mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -3413,6 +4917,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3442,6 +4947,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(testEntity, nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil)
 				mockCache.On("Get", mock.Anything, "guest:00000000-0000-0000-0000-000000000001").Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusInternalServerError, "cache server error"))
 				mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -3451,6 +4957,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3480,6 +4987,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "entity not found"))
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil)
 				mockCache.On("Get", mock.Anything, "guest:00000000-0000-0000-0000-000000000001").Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "cache not found"))
 
 				return NewGuestService(
@@ -3488,6 +4996,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3507,6 +5016,77 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "find entity by id with tombstone hit",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = true
+
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Get", mock.Anything, "guest:00000000-0000-0000-0000-000000000001").Return((*entities.GuestEntity)(nil), repositories.ErrTombstone)
+
+				return NewGuestService(
+					cfg,
+					repo_mocks.NewGuestRepositoryMock(t),
+					mockCache,
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
+			filter:      testFilter,
+			expectError: true,
+			validate: func(t *testing.T, entity *entities.GuestEntity, err error) {
+				if entity != nil {
+					t.Errorf("expected nil entity, got %v", entity)
+				}
+				code := gocerr.GetErrorCode(err)
+				if code != http.StatusNotFound {
+					t.Errorf("expected error code %d, got %d", http.StatusNotFound, code)
+				}
+				if repositories.IsTombstone(err) {
+					t.Error("expected tombstone to be reported as entity not found")
+				}
+			},
+		},
+		{
+			name: "find entity by id with repository error 404 sets tombstone",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = true
+				cfg.Guest.Cache.Entity.NotFoundDuration = 10 * time.Second
+
+				mockRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "entity not found"))
+
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil)
+				mockCache.On("Get", mock.Anything, "guest:00000000-0000-0000-0000-000000000001").Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "cache not found"))
+				mockCache.On("SetTombstone", mock.Anything, "guest:00000000-0000-0000-0000-000000000001", 10*time.Second).Return(nil)
+
+				return NewGuestService(
+					cfg,
+					mockRepo,
+					mockCache,
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
+			filter:      testFilter,
+			expectError: true,
+			validate: func(t *testing.T, entity *entities.GuestEntity, err error) {
+				if entity != nil {
+					t.Errorf("expected nil entity, got %v", entity)
+				}
+				code := gocerr.GetErrorCode(err)
+				if code != http.StatusNotFound {
+					t.Errorf("expected error code %d, got %d", http.StatusNotFound, code)
+				}
+			},
+		},
 		{
 			name: "find entity by id with repository error 500",
 			setupService: func(t *testing.T) *GuestService {
@@ -3517,6 +5097,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusInternalServerError, "repository server error"))
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil)
 				mockCache.On("Get", mock.Anything, "guest:00000000-0000-0000-0000-000000000001").Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "cache not found"))
 
 				return NewGuestService(
@@ -3525,6 +5106,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3555,6 +5137,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(testEntity, nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil)
 				mockCache.On("Get", mock.Anything, "guest:00000000-0000-0000-0000-000000000001").Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "cache not found"))
 				mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -3564,6 +5147,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3594,6 +5178,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(testEntity, nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil)
 				mockCache.On("Get", mock.Anything, "guest:00000000-0000-0000-0000-000000000001").Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "cache not found"))
 				mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gocerr.New(http.StatusInternalServerError, "cache set error"))
 
@@ -3603,6 +5188,7 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
@@ -3622,6 +5208,120 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "find entity by id without caching it when a write lands during the load",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = true
+				cfg.Guest.Cache.Duration = 300
+
+				mockRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(testEntity, nil)
+
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil).Once()
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(2), nil).Once()
+				mockCache.On("Get", mock.Anything, "guest:00000000-0000-0000-0000-000000000001").Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "cache not found"))
+
+				return NewGuestService(
+					cfg,
+					mockRepo,
+					mockCache,
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
+			filter:      testFilter,
+			expectError: false,
+			validate: func(t *testing.T, entity *entities.GuestEntity, err error) {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+					return
+				}
+				if entity == nil {
+					t.Error("expected entity, got nil")
+				}
+			},
+		},
+		{
+			name: "find entity by id without setting tombstone when a write lands during the load",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = true
+				cfg.Guest.Cache.Entity.NotFoundDuration = time.Minute
+
+				mockRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "entity not found"))
+
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil).Once()
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(2), nil).Once()
+				mockCache.On("Get", mock.Anything, "guest:00000000-0000-0000-0000-000000000001").Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "cache not found"))
+
+				return NewGuestService(
+					cfg,
+					mockRepo,
+					mockCache,
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
+			filter:      testFilter,
+			expectError: true,
+			validate: func(t *testing.T, entity *entities.GuestEntity, err error) {
+				if gocerr.GetErrorCode(err) != http.StatusNotFound {
+					t.Errorf("expected 404 error, got %v", err)
+				}
+			},
+		},
+		{
+			name: "serve stale entity and refresh it in background",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = true
+				cfg.Guest.Cache.Entity.SoftDuration = time.Minute
+				cfg.Guest.Cache.Entity.HardDuration = 5 * time.Minute
+
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil)
+				mockCache.On("Get", mock.Anything, "guest:00000000-0000-0000-0000-000000000001").Return(testEntity, nil)
+				mockCache.On("TTL", mock.Anything, "guest:00000000-0000-0000-0000-000000000001").Return(3*time.Minute, nil)
+				mockCache.On("Set", mock.Anything, "guest:00000000-0000-0000-0000-000000000001", testEntity, 5*time.Minute).
+					Return(nil).
+					Run(func(args mock.Arguments) {
+						close(entityRefreshed)
+					})
+
+				mockRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(testEntity, nil)
+
+				return NewGuestService(
+					cfg,
+					mockRepo,
+					mockCache,
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			cacheKey:    "guest:00000000-0000-0000-0000-000000000001",
+			filter:      testFilter,
+			expectError: false,
+			validate: func(t *testing.T, entity *entities.GuestEntity, err error) {
+				if entity == nil {
+					t.Error("expected stale entity, got nil")
+				}
+				select {
+				case <-entityRefreshed:
+				case <-time.After(time.Second):
+					t.Error("expected stale entity to be refreshed")
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -3646,6 +5346,64 @@ func Test_GuestService_findEntityByID(t *testing.T) {
 	}
 }
 
+func Test_GuestService_trySetEntityTombstone(t *testing.T) {
+	tests := []struct {
+		name      string
+		enable    bool
+		duration  time.Duration
+		setupMock func(m *repo_mocks.GuestCacheRepositoryMock)
+	}{
+		{
+			name:      "skip when negative caching is disabled",
+			enable:    true,
+			setupMock: func(m *repo_mocks.GuestCacheRepositoryMock) {},
+		},
+		{
+			name:      "skip when cache is disabled",
+			duration:  10 * time.Second,
+			setupMock: func(m *repo_mocks.GuestCacheRepositoryMock) {},
+		},
+		{
+			name:     "set tombstone",
+			enable:   true,
+			duration: 10 * time.Second,
+			setupMock: func(m *repo_mocks.GuestCacheRepositoryMock) {
+				m.On("SetTombstone", mock.Anything, "guest:1", 10*time.Second).Return(nil).Once()
+			},
+		},
+		{
+			name:     "ignore set tombstone error",
+			enable:   true,
+			duration: 10 * time.Second,
+			setupMock: func(m *repo_mocks.GuestCacheRepositoryMock) {
+				m.On("SetTombstone", mock.Anything, "guest:1", 10*time.Second).Return(gocerr.New(http.StatusInternalServerError, "cache error")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Guest.Cache.Enable = tt.enable
+			cfg.Guest.Cache.Entity.NotFoundDuration = tt.duration
+
+			mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+			tt.setupMock(mockCache)
+
+			service := NewGuestService(
+				cfg,
+				repo_mocks.NewGuestRepositoryMock(t),
+				mockCache,
+				repo_mocks.NewGuestEventProducerRepositoryMock(t),
+				repo_mocks.NewWebhookSiteRepositoryMock(t),
+				nil,
+			)
+
+			service.trySetEntityTombstone(context.Background(), "guest:1")
+		})
+	}
+}
+
 func Test_GuestService_FindByID(t *testing.T) {
 	testEntity := newTestGuestEntity(
 		"00000000-0000-0000-0000-000000000001",
@@ -3671,7 +5429,7 @@ func Test_GuestService_FindByID(t *testing.T) {
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockWebhook := repo_mocks.NewWebhookSiteRepositoryMock(t)
 
-				return NewGuestService(cfg, mockRepo, mockCache, mockEventProducer, mockWebhook)
+				return NewGuestService(cfg, mockRepo, mockCache, mockEventProducer, mockWebhook, nil)
 			},
 			requestDTO:  nil,
 			expectError: true,
@@ -3698,7 +5456,7 @@ func Test_GuestService_FindByID(t *testing.T) {
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockWebhook := repo_mocks.NewWebhookSiteRepositoryMock(t)
 
-				return NewGuestService(cfg, mockRepo, mockCache, mockEventProducer, mockWebhook)
+				return NewGuestService(cfg, mockRepo, mockCache, mockEventProducer, mockWebhook, nil)
 			},
 			requestDTO: &dtos.FindGuestByIDRequestDTO{
 				ID: "",
@@ -3725,6 +5483,7 @@ func Test_GuestService_FindByID(t *testing.T) {
 				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return(testEntity, nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil)
 				mockCache.On("Get", mock.Anything, mock.Anything).Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "cache not found"))
 				mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -3734,6 +5493,7 @@ func Test_GuestService_FindByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.FindGuestByIDRequestDTO{
@@ -3768,6 +5528,7 @@ func Test_GuestService_FindByID(t *testing.T) {
 				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "entity not found"))
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil)
 				mockCache.On("Get", mock.Anything, mock.Anything).Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "cache not found"))
 
 				return NewGuestService(
@@ -3776,6 +5537,7 @@ func Test_GuestService_FindByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.FindGuestByIDRequestDTO{
@@ -3807,6 +5569,7 @@ func Test_GuestService_FindByID(t *testing.T) {
 				mockRepo.On("FindOne", mock.Anything, mock.Anything, mock.Anything, false).Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusInternalServerError, "repository server error"))
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("GetCount", mock.Anything, mock.Anything).Return(uint64(1), nil)
 				mockCache.On("Get", mock.Anything, mock.Anything).Return((*entities.GuestEntity)(nil), gocerr.New(http.StatusNotFound, "cache not found"))
 
 				return NewGuestService(
@@ -3815,6 +5578,7 @@ func Test_GuestService_FindByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.FindGuestByIDRequestDTO{
@@ -3884,7 +5648,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockWebhook := repo_mocks.NewWebhookSiteRepositoryMock(t)
 
-				return NewGuestService(cfg, mockRepo, mockCache, mockEventProducer, mockWebhook)
+				return NewGuestService(cfg, mockRepo, mockCache, mockEventProducer, mockWebhook, nil)
 			},
 			requestDTO:  nil,
 			expectError: true,
@@ -3911,7 +5675,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockWebhook := repo_mocks.NewWebhookSiteRepositoryMock(t)
 
-				return NewGuestService(cfg, mockRepo, mockCache, mockEventProducer, mockWebhook)
+				return NewGuestService(cfg, mockRepo, mockCache, mockEventProducer, mockWebhook, nil)
 			},
 			requestDTO: &dtos.UpdateGuestByIDRequestDTO{
 				ID: "",
@@ -3941,6 +5705,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.UpdateGuestByIDRequestDTO{
@@ -3978,6 +5743,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.UpdateGuestByIDRequestDTO{
@@ -4016,6 +5782,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.UpdateGuestByIDRequestDTO{
@@ -4055,6 +5822,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.UpdateGuestByIDRequestDTO{
@@ -4094,6 +5862,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.UpdateGuestByIDRequestDTO{
@@ -4134,6 +5903,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.UpdateGuestByIDRequestDTO{
@@ -4174,6 +5944,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.UpdateGuestByIDRequestDTO{
@@ -4209,7 +5980,8 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				return NewGuestService(
 					cfg,
@@ -4217,6 +5989,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.UpdateGuestByIDRequestDTO{
@@ -4256,7 +6029,8 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, errors.New("cache keys error"))
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(errors.New("cache delete error"))
 
 				return NewGuestService(
 					cfg,
@@ -4264,6 +6038,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.UpdateGuestByIDRequestDTO{
@@ -4302,7 +6077,8 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockEventProducer.On("Publish", mock.Anything, "guest.updated", mock.AnythingOfType("*entities.EventEntity[go-boilerplate/internal/models/entities.GuestEventEntity]")).Return(nil)
@@ -4313,6 +6089,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 					mockCache,
 					mockEventProducer,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.UpdateGuestByIDRequestDTO{
@@ -4351,7 +6128,8 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.GuestEntity"), mock.Anything).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockEventProducer.On("Publish", mock.Anything, "guest.updated", mock.AnythingOfType("*entities.EventEntity[go-boilerplate/internal/models/entities.GuestEventEntity]")).Return(errors.New("publish error"))
@@ -4362,6 +6140,7 @@ func Test_GuestService_UpdateByID(t *testing.T) {
 					mockCache,
 					mockEventProducer,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.UpdateGuestByIDRequestDTO{
@@ -4425,6 +6204,7 @@ func Test_GuestService_ProcessEvent(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO:  nil,
@@ -4457,6 +6237,7 @@ func Test_GuestService_ProcessEvent(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					mockWebhook,
+					nil,
 				)
 			},
 			requestDTO: &dtos.GuestEventRequestDTO{
@@ -4493,6 +6274,7 @@ func Test_GuestService_ProcessEvent(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					mockWebhook,
+					nil,
 				)
 			},
 			requestDTO: &dtos.GuestEventRequestDTO{
@@ -4571,7 +6353,8 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 				mockGuestRepo.On("BulkCreate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				return NewGuestService(
 					cfg,
@@ -4579,6 +6362,7 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkCreateGuestsRequestDTO{
@@ -4610,6 +6394,7 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO:  nil,
@@ -4633,6 +6418,7 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkCreateGuestsRequestDTO{
@@ -4662,6 +6448,7 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkCreateGuestsRequestDTO{
@@ -4696,6 +6483,7 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkCreateGuestsRequestDTO{
@@ -4731,6 +6519,7 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkCreateGuestsRequestDTO{
@@ -4765,6 +6554,7 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkCreateGuestsRequestDTO{
@@ -4800,6 +6590,7 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkCreateGuestsRequestDTO{
@@ -4831,10 +6622,8 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 				mockGuestRepo.On("BulkCreate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{"guest:key1"}, nil)
-				mockCache.On("Delete", mock.Anything, mock.MatchedBy(func(keys []string) bool {
-					return len(keys) == 1 && keys[0] == "guest:key1"
-				})).Return(errors.New("cache delete error"))
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(errors.New("cache delete error"))
 
 				return NewGuestService(
 					cfg,
@@ -4842,6 +6631,7 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkCreateGuestsRequestDTO{
@@ -4877,7 +6667,8 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 				mockGuestRepo.On("BulkCreate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockEventProducer.On("PublishBulk", mock.Anything, "guest.bulk.created", mock.Anything).Return(nil)
@@ -4888,6 +6679,7 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 					mockCache,
 					mockEventProducer,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkCreateGuestsRequestDTO{
@@ -4924,7 +6716,8 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 				mockGuestRepo.On("BulkCreate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockEventProducer.On("PublishBulk", mock.Anything, "guest.bulk.created", mock.Anything).Return(errors.New("event publish error"))
@@ -4935,6 +6728,7 @@ func Test_GuestService_BulkCreate(t *testing.T) {
 					mockCache,
 					mockEventProducer,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkCreateGuestsRequestDTO{
@@ -5007,7 +6801,8 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 				mockGuestRepo.On("BulkUpdate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				return NewGuestService(
 					cfg,
@@ -5015,6 +6810,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
@@ -5032,6 +6828,57 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "bulk update writes through and deletes the cache of a duplicated id",
+			setupService: func(t *testing.T) *GuestService {
+				cfg := &configs.Config{}
+				cfg.Guest.Cache.Enable = true
+				cfg.Guest.Cache.Keyf = "guest:%s"
+				cfg.Guest.Cache.Entity.HardDuration = 5 * time.Minute
+				cfg.Guest.Cache.WriteThrough.BulkUpdate = true
+
+				mockTx := repo_mocks.NewBoilerplateDatabaseTransactionMock(t)
+				mockTx.On("Commit").Return(nil)
+
+				mockGuestRepo := repo_mocks.NewGuestRepositoryMock(t)
+				mockGuestRepo.On("FindAll", mock.Anything, mock.AnythingOfType("*goqube.Filter"), mock.Anything, uint64(3), uint64(0), false).Return([]entities.GuestEntity{
+					{ID: uuid.FromStringOrNil("01932293-d710-7f55-a9f6-66e6248ae72f"), Name: "Old Name", CreatedBy: "admin"},
+					{ID: uuid.FromStringOrNil("01932293-d710-7f55-a9f6-66e6248ae730"), Name: "Other Name", CreatedBy: "admin"},
+				}, nil)
+				mockGuestRepo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
+				mockGuestRepo.On("WithTransaction", mockTx).Return(mockGuestRepo)
+				mockGuestRepo.On("BulkUpdate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
+
+				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
+				mockCache.On("Set", mock.Anything, "guest:01932293-d710-7f55-a9f6-66e6248ae730", mock.MatchedBy(func(entity *entities.GuestEntity) bool {
+					return entity.Name == "Other Updated"
+				}), 5*time.Minute).Return(nil).Once()
+				mockCache.On("Delete", mock.Anything, []string{"guest:01932293-d710-7f55-a9f6-66e6248ae72f"}).Return(nil).Once()
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil).Once()
+
+				return NewGuestService(
+					cfg,
+					mockGuestRepo,
+					mockCache,
+					repo_mocks.NewGuestEventProducerRepositoryMock(t),
+					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
+				)
+			},
+			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
+				Items: []dtos.UpdateGuestByIDRequestDTO{
+					{ID: "01932293-d710-7f55-a9f6-66e6248ae72f", Name: "First Name", UpdatedBy: "admin"},
+					{ID: "01932293-d710-7f55-a9f6-66e6248ae730", Name: "Other Updated", UpdatedBy: "admin"},
+					{ID: "01932293-d710-7f55-a9f6-66e6248ae72f", Name: "Second Name", UpdatedBy: "admin"},
+				},
+			},
+			expectError: false,
+			validate: func(t *testing.T, responseDTO *dtos.BulkUpdateGuestsResponseDTO, err error) {
+				if err != nil {
+					t.Errorf("BulkUpdate() unexpected error: %v", err)
+				}
+			},
+		},
 		{
 			name: "bulk update with nil requestDTO",
 			setupService: func(t *testing.T) *GuestService {
@@ -5042,6 +6889,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO:  nil,
@@ -5062,6 +6910,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
@@ -5091,6 +6940,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
@@ -5123,6 +6973,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
@@ -5160,6 +7011,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
@@ -5189,6 +7041,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
@@ -5220,6 +7073,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
@@ -5257,6 +7111,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
@@ -5295,6 +7150,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
@@ -5329,10 +7185,8 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 				mockGuestRepo.On("BulkUpdate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{"guest:key1"}, nil)
-				mockCache.On("Delete", mock.Anything, mock.MatchedBy(func(keys []string) bool {
-					return len(keys) == 1 && keys[0] == "guest:key1"
-				})).Return(errors.New("cache delete error"))
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(errors.New("cache delete error"))
 
 				return NewGuestService(
 					cfg,
@@ -5340,6 +7194,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
@@ -5379,7 +7234,8 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 				mockGuestRepo.On("BulkUpdate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockEventProducer.On("PublishBulk", mock.Anything, "guest.bulk.updated", mock.Anything).Return(nil)
@@ -5390,6 +7246,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					mockCache,
 					mockEventProducer,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
@@ -5429,7 +7286,8 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 				mockGuestRepo.On("BulkUpdate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockEventProducer.On("Publish", mock.Anything, "guest.bulk.updated", mock.Anything).Return(errors.New("event publish error"))
@@ -5440,6 +7298,7 @@ func Test_GuestService_BulkUpdate(t *testing.T) {
 					mockCache,
 					mockEventProducer,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkUpdateGuestsRequestDTO{
@@ -5509,7 +7368,8 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 				mockGuestRepo.On("BulkUpdate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				return NewGuestService(
 					cfg,
@@ -5517,6 +7377,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkDeleteGuestsRequestDTO{
@@ -5540,6 +7401,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO:  nil,
@@ -5560,6 +7422,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkDeleteGuestsRequestDTO{
@@ -5588,6 +7451,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkDeleteGuestsRequestDTO{
@@ -5619,6 +7483,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkDeleteGuestsRequestDTO{
@@ -5655,6 +7520,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkDeleteGuestsRequestDTO{
@@ -5692,6 +7558,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkDeleteGuestsRequestDTO{
@@ -5720,6 +7587,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkDeleteGuestsRequestDTO{
@@ -5756,6 +7624,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkDeleteGuestsRequestDTO{
@@ -5793,6 +7662,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					repo_mocks.NewGuestCacheRepositoryMock(t),
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkDeleteGuestsRequestDTO{
@@ -5826,10 +7696,8 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 				mockGuestRepo.On("BulkUpdate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{"guest:key1"}, nil)
-				mockCache.On("Delete", mock.Anything, mock.MatchedBy(func(keys []string) bool {
-					return len(keys) == 1 && keys[0] == "guest:key1"
-				})).Return(errors.New("cache delete error"))
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(errors.New("cache delete error"))
 
 				return NewGuestService(
 					cfg,
@@ -5837,6 +7705,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					mockCache,
 					repo_mocks.NewGuestEventProducerRepositoryMock(t),
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkDeleteGuestsRequestDTO{
@@ -5871,7 +7740,8 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 				mockGuestRepo.On("BulkUpdate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockEventProducer.On("Publish", mock.Anything, "guest.bulk.deleted", mock.Anything).Return(nil)
@@ -5882,6 +7752,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					mockCache,
 					mockEventProducer,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkDeleteGuestsRequestDTO{
@@ -5916,7 +7787,8 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 				mockGuestRepo.On("BulkUpdate", mock.Anything, mock.AnythingOfType("[]entities.GuestEntity")).Return(nil)
 
 				mockCache := repo_mocks.NewGuestCacheRepositoryMock(t)
-				mockCache.On("Keys", mock.Anything, "guest:*").Return([]string{}, nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("SetCount", mock.Anything, "guest:list:generation", mock.Anything, time.Duration(0)).Return(nil)
 
 				mockEventProducer := repo_mocks.NewGuestEventProducerRepositoryMock(t)
 				mockEventProducer.On("Publish", mock.Anything, "guest.bulk.deleted", mock.Anything).Return(errors.New("event publish error"))
@@ -5927,6 +7799,7 @@ func Test_GuestService_BulkDelete(t *testing.T) {
 					mockCache,
 					mockEventProducer,
 					repo_mocks.NewWebhookSiteRepositoryMock(t),
+					nil,
 				)
 			},
 			requestDTO: &dtos.BulkDeleteGuestsRequestDTO{
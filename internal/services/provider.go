@@ -6,7 +6,15 @@ package services
 import "github.com/google/wire"
 
 var Provider wire.ProviderSet = wire.NewSet(
+	// feature flags
+	NewFeatureFlagService,
+	wire.Bind(new(IFeatureFlagService), new(*FeatureFlagService)),
+
 	// guests
-	NewGuestService,
+	ProvideGuestService,
 	wire.Bind(new(IGuestService), new(*GuestService)),
+
+	// caches
+	NewCacheService,
+	wire.Bind(new(ICacheService), new(*CacheService)),
 )
@@ -2,8 +2,15 @@ package main
 
 import (
 	"go-boilerplate/cmd"
+	"os"
 )
 
+// exit is replaced in tests
+var exit func(code int) = os.Exit
+
 func main() {
-	cmd.Execute()
+	var err error = cmd.Execute()
+	if err != nil {
+		exit(1)
+	}
 }
@@ -9,65 +9,65 @@ import (
 
 func TestMain(t *testing.T) {
 	tests := []struct {
-		name        string
-		setupFunc   func()
-		shouldPanic bool
+		name             string
+		setupFunc        func()
+		expectedExitCode int
 	}{
 		{
 			name: "should call Execute with help flag",
 			setupFunc: func() {
 				os.Args = []string{"cmd", "--help"}
 			},
-			shouldPanic: false,
+			expectedExitCode: 0,
 		},
 		{
 			name: "should call Execute with no args",
 			setupFunc: func() {
 				os.Args = []string{"cmd"}
 			},
-			shouldPanic: false,
+			expectedExitCode: 0,
 		},
 		{
 			name: "should call Execute with http help",
 			setupFunc: func() {
 				os.Args = []string{"cmd", "http", "--help"}
 			},
-			shouldPanic: false,
+			expectedExitCode: 0,
 		},
 		{
 			name: "should call Execute with grpc help",
 			setupFunc: func() {
 				os.Args = []string{"cmd", "grpc", "--help"}
 			},
-			shouldPanic: false,
+			expectedExitCode: 0,
 		},
 		{
 			name: "should call Execute with event-consumer help",
 			setupFunc: func() {
 				os.Args = []string{"cmd", "event-consumer", "--help"}
 			},
-			shouldPanic: false,
+			expectedExitCode: 0,
 		},
 		{
 			name: "should call Execute with app help",
 			setupFunc: func() {
 				os.Args = []string{"cmd", "app", "--help"}
 			},
-			shouldPanic: false,
+			expectedExitCode: 0,
 		},
 		{
 			name: "should call Execute with migrate help",
 			setupFunc: func() {
 				os.Args = []string{"cmd", "migrate", "--help"}
 			},
-			shouldPanic: false,
+			expectedExitCode: 0,
 		},
 		{
-			name: "should panic with invalid command",
+			name: "should exit non-zero with invalid command",
 			setupFunc: func() {
 				os.Args = []string{"cmd", "invalid-command"}
 			},
-			shouldPanic: true,
+			expectedExitCode: 1,
 		},
 	}
 
@@ -78,16 +78,21 @@ func TestMain(t *testing.T) {
 				tt.setupFunc()
 			}
 
-			// Test
-			if tt.shouldPanic {
-				assert.Panics(t, func() {
-					main()
-				})
-			} else {
-				assert.NotPanics(t, func() {
-					main()
-				})
+			var exitCode int
+
+			originalExit := exit
+			exit = func(code int) {
+				exitCode = code
 			}
+			t.Cleanup(func() {
+				exit = originalExit
+			})
+
+			// Test
+			assert.NotPanics(t, func() {
+				main()
+			})
+			assert.Equal(t, tt.expectedExitCode, exitCode)
 		})
 	}
 }
@@ -0,0 +1,88 @@
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/goccy/go-json"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// ID is written in the payload header, never renumber one.
+type ID byte
+
+const (
+	IDJSON        ID = 1
+	IDMessagePack ID = 2
+	IDProtobuf    ID = 3
+)
+
+const (
+	NameJSON        string = "json"
+	NameMessagePack string = "msgpack"
+	NameProtobuf    string = "protobuf"
+)
+
+var ErrUnsupportedValue error = errors.New("codec does not support the value")
+
+type Codec interface {
+	ID() ID
+	Marshal(value interface{}) ([]byte, error)
+	Unmarshal(data []byte, value interface{}) error
+}
+
+type jsonCodec struct{}
+
+var JSON Codec = jsonCodec{}
+
+func (jsonCodec) ID() ID {
+	return IDJSON
+}
+
+func (jsonCodec) Marshal(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (jsonCodec) Unmarshal(data []byte, value interface{}) error {
+	return json.Unmarshal(data, value)
+}
+
+// messagePackCodec reads the json tags, so the fields are named and skipped
+// the same way as in JSON.
+type messagePackCodec struct{}
+
+var MessagePack Codec = messagePackCodec{}
+
+func (messagePackCodec) ID() ID {
+	return IDMessagePack
+}
+
+func (messagePackCodec) Marshal(value interface{}) ([]byte, error) {
+	var (
+		buffer  bytes.Buffer
+		encoder *msgpack.Encoder = msgpack.NewEncoder(&buffer)
+		err     error
+	)
+
+	encoder.SetCustomStructTag("json")
+
+	err = encoder.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (messagePackCodec) Unmarshal(data []byte, value interface{}) error {
+	var decoder *msgpack.Decoder = msgpack.NewDecoder(bytes.NewReader(data))
+
+	decoder.SetCustomStructTag("json")
+
+	return decoder.Decode(value)
+}
+
+func unsupportedValue(id ID, value interface{}) error {
+	return fmt.Errorf("%w: codec %d, %T", ErrUnsupportedValue, id, value)
+}
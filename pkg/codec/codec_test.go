@@ -0,0 +1,69 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testValue struct {
+	ID      int               `json:"id"`
+	Name    string            `json:"name"`
+	Secret  string            `json:"-"`
+	Tags    []string          `json:"tags,omitempty"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+func TestCodec_roundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		codec Codec
+		id    ID
+	}{
+		{
+			name:  "json",
+			codec: JSON,
+			id:    IDJSON,
+		},
+		{
+			name:  "message pack",
+			codec: MessagePack,
+			id:    IDMessagePack,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := &testValue{ID: 1, Name: "John", Secret: "hidden", Tags: []string{"a"}, Details: map[string]string{"k": "v"}}
+			values := []testValue{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+
+			data, err := tt.codec.Marshal(value)
+			assert.NoError(t, err)
+			decoded := &testValue{}
+			assert.NoError(t, tt.codec.Unmarshal(data, decoded))
+			assert.Equal(t, &testValue{ID: 1, Name: "John", Tags: []string{"a"}, Details: map[string]string{"k": "v"}}, decoded, "fields tagged json:\"-\" should be skipped")
+
+			data, err = tt.codec.Marshal(values)
+			assert.NoError(t, err)
+			decodedValues := []testValue{}
+			assert.NoError(t, tt.codec.Unmarshal(data, &decodedValues))
+			assert.Equal(t, values, decodedValues)
+
+			assert.Equal(t, tt.id, tt.codec.ID())
+		})
+	}
+}
+
+func TestMessagePack_isSmallerThanJSON(t *testing.T) {
+	values := make([]testValue, 50)
+	for i := range values {
+		values[i] = testValue{ID: i, Name: "guest name"}
+	}
+
+	jsonData, err := JSON.Marshal(values)
+	assert.NoError(t, err)
+	messagePackData, err := MessagePack.Marshal(values)
+	assert.NoError(t, err)
+
+	assert.Less(t, len(messagePackData), len(jsonData))
+}
@@ -0,0 +1,112 @@
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+// Protobuf encodes a T through the message M. It supports a *T and a list of
+// T, which is written as length delimited messages.
+type Protobuf[T interface{}, M proto.Message] struct {
+	toMessage   func(value *T) M
+	fromMessage func(message M) (T, error)
+	newMessage  func() M
+}
+
+func NewProtobuf[T interface{}, M proto.Message](
+	toMessage func(value *T) M,
+	fromMessage func(message M) (T, error),
+	newMessage func() M,
+) *Protobuf[T, M] {
+	return &Protobuf[T, M]{
+		toMessage:   toMessage,
+		fromMessage: fromMessage,
+		newMessage:  newMessage,
+	}
+}
+
+func (c *Protobuf[T, M]) ID() ID {
+	return IDProtobuf
+}
+
+func (c *Protobuf[T, M]) Marshal(value interface{}) ([]byte, error) {
+	var (
+		buffer bytes.Buffer
+		err    error
+	)
+
+	switch typedValue := value.(type) {
+	case *T:
+		if typedValue == nil {
+			return nil, unsupportedValue(c.ID(), value)
+		}
+
+		return proto.Marshal(c.toMessage(typedValue))
+	case []T:
+		for i := range typedValue {
+			_, err = protodelim.MarshalTo(&buffer, c.toMessage(&typedValue[i]))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return buffer.Bytes(), nil
+	default:
+		return nil, unsupportedValue(c.ID(), value)
+	}
+}
+
+func (c *Protobuf[T, M]) Unmarshal(data []byte, value interface{}) error {
+	var (
+		message M
+		reader  *bytes.Reader
+		values  []T
+		item    T
+		err     error
+	)
+
+	switch typedValue := value.(type) {
+	case *T:
+		message = c.newMessage()
+
+		err = proto.Unmarshal(data, message)
+		if err != nil {
+			return err
+		}
+
+		*typedValue, err = c.fromMessage(message)
+
+		return err
+	case *[]T:
+		reader = bytes.NewReader(data)
+		values = []T{}
+
+		for {
+			message = c.newMessage()
+
+			err = protodelim.UnmarshalFrom(reader, message)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			item, err = c.fromMessage(message)
+			if err != nil {
+				return err
+			}
+			values = append(values, item)
+		}
+
+		*typedValue = values
+
+		return nil
+	default:
+		return unsupportedValue(c.ID(), value)
+	}
+}
@@ -0,0 +1,118 @@
+package codec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var errEmptyValue error = errors.New("empty value")
+
+func newTestProtobuf() *Protobuf[string, *wrapperspb.StringValue] {
+	return NewProtobuf(
+		func(value *string) *wrapperspb.StringValue {
+			return wrapperspb.String(*value)
+		},
+		func(message *wrapperspb.StringValue) (string, error) {
+			if message.GetValue() == "" {
+				return "", errEmptyValue
+			}
+			return message.GetValue(), nil
+		},
+		func() *wrapperspb.StringValue {
+			return &wrapperspb.StringValue{}
+		},
+	)
+}
+
+func TestProtobuf_roundTrip(t *testing.T) {
+	codec := newTestProtobuf()
+	value := "john"
+	values := []string{"a", "b", "c"}
+
+	data, err := codec.Marshal(&value)
+	assert.NoError(t, err)
+	var decoded string
+	assert.NoError(t, codec.Unmarshal(data, &decoded))
+	assert.Equal(t, "john", decoded)
+
+	data, err = codec.Marshal(values)
+	assert.NoError(t, err)
+	var decodedValues []string
+	assert.NoError(t, codec.Unmarshal(data, &decodedValues))
+	assert.Equal(t, values, decodedValues)
+
+	data, err = codec.Marshal([]string{})
+	assert.NoError(t, err)
+	assert.NoError(t, codec.Unmarshal(data, &decodedValues))
+	assert.Equal(t, []string{}, decodedValues)
+
+	assert.Equal(t, IDProtobuf, codec.ID())
+}
+
+func TestProtobuf_errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		run         func(codec *Protobuf[string, *wrapperspb.StringValue]) error
+		expectedErr error
+	}{
+		{
+			name: "marshal unsupported value",
+			run: func(codec *Protobuf[string, *wrapperspb.StringValue]) error {
+				_, err := codec.Marshal(1)
+				return err
+			},
+			expectedErr: ErrUnsupportedValue,
+		},
+		{
+			name: "marshal nil value",
+			run: func(codec *Protobuf[string, *wrapperspb.StringValue]) error {
+				_, err := codec.Marshal((*string)(nil))
+				return err
+			},
+			expectedErr: ErrUnsupportedValue,
+		},
+		{
+			name: "unmarshal into unsupported value",
+			run: func(codec *Protobuf[string, *wrapperspb.StringValue]) error {
+				var value int
+				return codec.Unmarshal(nil, &value)
+			},
+			expectedErr: ErrUnsupportedValue,
+		},
+		{
+			name: "unmarshal conversion error",
+			run: func(codec *Protobuf[string, *wrapperspb.StringValue]) error {
+				var value string
+				return codec.Unmarshal(nil, &value)
+			},
+			expectedErr: errEmptyValue,
+		},
+		{
+			name: "unmarshal list conversion error",
+			run: func(codec *Protobuf[string, *wrapperspb.StringValue]) error {
+				data, _ := codec.Marshal([]string{""})
+				var values []string
+				return codec.Unmarshal(data, &values)
+			},
+			expectedErr: errEmptyValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.run(newTestProtobuf()), tt.expectedErr)
+		})
+	}
+}
+
+func TestProtobuf_truncatedList(t *testing.T) {
+	codec := newTestProtobuf()
+	data, err := codec.Marshal([]string{"a", "b"})
+	assert.NoError(t, err)
+
+	var values []string
+	assert.Error(t, codec.Unmarshal(data[:len(data)-1], &values))
+}
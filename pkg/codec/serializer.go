@@ -0,0 +1,207 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+type Compression byte
+
+const (
+	CompressionNone   Compression = 0
+	CompressionZstd   Compression = 1
+	CompressionSnappy Compression = 2
+)
+
+var compressionNames map[string]Compression = map[string]Compression{
+	"":       CompressionNone,
+	"none":   CompressionNone,
+	"zstd":   CompressionZstd,
+	"snappy": CompressionSnappy,
+}
+
+func ParseCompression(name string) (Compression, error) {
+	var (
+		compression Compression
+		ok          bool
+	)
+
+	compression, ok = compressionNames[name]
+	if !ok {
+		return CompressionNone, fmt.Errorf("unknown compression %q", name)
+	}
+
+	return compression, nil
+}
+
+// The header is magic, format version, codec ID and compression. The magic
+// byte never starts JSON or text, so the payloads written before the header
+// existed are still read as JSON.
+const (
+	headerMagic   byte = 0xfe
+	formatVersion byte = 1
+	headerLength  int  = 4
+)
+
+// maxDecodedSize bounds the memory a corrupted or hostile payload can claim.
+const maxDecodedSize uint64 = 64 << 20
+
+var (
+	ErrUnknownFormat      error = errors.New("unknown payload format version")
+	ErrUnknownCodec       error = errors.New("unknown payload codec")
+	ErrUnknownCompression error = errors.New("unknown payload compression")
+	ErrPayloadTooLarge    error = errors.New("payload too large")
+)
+
+var (
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+)
+
+func init() {
+	// both are safe for concurrent EncodeAll and DecodeAll calls
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxDecodedSize))
+}
+
+type Config struct {
+	// Codec encodes the writes, nil is JSON.
+	Codec       Codec
+	Compression Compression
+	// CompressionThreshold is the smallest encoded size, in bytes, that is
+	// compressed.
+	CompressionThreshold int
+}
+
+// Serializer writes with the configured codec and reads with whichever codec
+// the header names, so the codec or the compression can change while the old
+// payloads are still cached.
+type Serializer struct {
+	cfg    *Config
+	codec  Codec
+	codecs map[ID]Codec
+}
+
+// NewSerializer can read JSON, MessagePack, cfg.Codec and codecs.
+func NewSerializer(cfg *Config, codecs ...Codec) *Serializer {
+	var (
+		serializer *Serializer = &Serializer{
+			cfg:   cfg,
+			codec: cfg.Codec,
+			codecs: map[ID]Codec{
+				IDJSON:        JSON,
+				IDMessagePack: MessagePack,
+			},
+		}
+		codec Codec
+	)
+
+	if serializer.codec == nil {
+		serializer.codec = JSON
+	}
+
+	for _, codec = range append(codecs, serializer.codec) {
+		serializer.codecs[codec.ID()] = codec
+	}
+
+	return serializer
+}
+
+func compress(compression Compression, data []byte) ([]byte, error) {
+	switch compression {
+	case CompressionZstd:
+		return zstdEncoder.EncodeAll(data, nil), nil
+	case CompressionSnappy:
+		return snappy.Encode(nil, data), nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownCompression, compression)
+	}
+}
+
+func decompress(compression Compression, data []byte) ([]byte, error) {
+	var (
+		length int
+		err    error
+	)
+
+	switch compression {
+	case CompressionNone:
+		return data, nil
+	case CompressionZstd:
+		return zstdDecoder.DecodeAll(data, nil)
+	case CompressionSnappy:
+		length, err = snappy.DecodedLen(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if uint64(length) > maxDecodedSize {
+			return nil, ErrPayloadTooLarge
+		}
+
+		return snappy.Decode(nil, data)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownCompression, compression)
+	}
+}
+
+func (s *Serializer) Marshal(value interface{}) ([]byte, error) {
+	var (
+		data        []byte
+		compression Compression = CompressionNone
+		payload     []byte
+		err         error
+	)
+
+	data, err = s.codec.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cfg.Compression != CompressionNone && len(data) >= s.cfg.CompressionThreshold {
+		compression = s.cfg.Compression
+
+		data, err = compress(compression, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	payload = make([]byte, 0, headerLength+len(data))
+	payload = append(payload, headerMagic, formatVersion, byte(s.codec.ID()), byte(compression))
+	payload = append(payload, data...)
+
+	return payload, nil
+}
+
+func (s *Serializer) Unmarshal(payload []byte, value interface{}) error {
+	var (
+		codec Codec
+		data  []byte
+		ok    bool
+		err   error
+	)
+
+	if len(payload) == 0 || payload[0] != headerMagic {
+		return JSON.Unmarshal(payload, value)
+	}
+
+	if len(payload) < headerLength || payload[1] != formatVersion {
+		return ErrUnknownFormat
+	}
+
+	codec, ok = s.codecs[ID(payload[2])]
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrUnknownCodec, payload[2])
+	}
+
+	data, err = decompress(Compression(payload[3]), payload[headerLength:])
+	if err != nil {
+		return err
+	}
+
+	return codec.Unmarshal(data, value)
+}
@@ -0,0 +1,166 @@
+package codec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCompression(t *testing.T) {
+	tests := []struct {
+		name        string
+		expected    Compression
+		expectError bool
+	}{
+		{name: "", expected: CompressionNone},
+		{name: "none", expected: CompressionNone},
+		{name: "zstd", expected: CompressionZstd},
+		{name: "snappy", expected: CompressionSnappy},
+		{name: "gzip", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compression, err := ParseCompression(tt.name)
+
+			assert.Equal(t, tt.expectError, err != nil)
+			assert.Equal(t, tt.expected, compression)
+		})
+	}
+}
+
+func TestSerializer_Marshal(t *testing.T) {
+	large := &testValue{ID: 1, Name: strings.Repeat("guest ", 100)}
+	small := &testValue{ID: 1, Name: "guest"}
+
+	tests := []struct {
+		name                string
+		cfg                 *Config
+		value               *testValue
+		expectedCodec       ID
+		expectedCompression Compression
+	}{
+		{
+			name:          "default codec is json",
+			cfg:           &Config{},
+			value:         small,
+			expectedCodec: IDJSON,
+		},
+		{
+			name:          "message pack",
+			cfg:           &Config{Codec: MessagePack},
+			value:         small,
+			expectedCodec: IDMessagePack,
+		},
+		{
+			name:                "compress with zstd above the threshold",
+			cfg:                 &Config{Codec: JSON, Compression: CompressionZstd, CompressionThreshold: 256},
+			value:               large,
+			expectedCodec:       IDJSON,
+			expectedCompression: CompressionZstd,
+		},
+		{
+			name:                "compress with snappy above the threshold",
+			cfg:                 &Config{Codec: MessagePack, Compression: CompressionSnappy, CompressionThreshold: 256},
+			value:               large,
+			expectedCodec:       IDMessagePack,
+			expectedCompression: CompressionSnappy,
+		},
+		{
+			name:          "leave values below the threshold uncompressed",
+			cfg:           &Config{Codec: JSON, Compression: CompressionZstd, CompressionThreshold: 256},
+			value:         small,
+			expectedCodec: IDJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serializer := NewSerializer(tt.cfg)
+
+			payload, err := serializer.Marshal(tt.value)
+			assert.NoError(t, err)
+
+			assert.Equal(t, []byte{headerMagic, formatVersion, byte(tt.expectedCodec), byte(tt.expectedCompression)}, payload[:headerLength])
+			if tt.expectedCompression != CompressionNone {
+				encoded, _ := serializer.codec.Marshal(tt.value)
+				assert.Less(t, len(payload), len(encoded), "compressed payload should be smaller")
+			}
+
+			decoded := &testValue{}
+			assert.NoError(t, serializer.Unmarshal(payload, decoded))
+			assert.Equal(t, tt.value, decoded)
+		})
+	}
+}
+
+func TestSerializer_Unmarshal(t *testing.T) {
+	messagePackPayload, _ := NewSerializer(&Config{Codec: MessagePack, Compression: CompressionSnappy}).Marshal(&testValue{ID: 2})
+	protobufPayload := append([]byte{headerMagic, formatVersion, byte(IDProtobuf), byte(CompressionNone)}, []byte("x")...)
+
+	tests := []struct {
+		name        string
+		payload     []byte
+		expected    *testValue
+		expectError error
+	}{
+		{
+			name:     "read json written without a header",
+			payload:  []byte(`{"id":1,"name":"legacy"}`),
+			expected: &testValue{ID: 1, Name: "legacy"},
+		},
+		{
+			name:     "read with the codec of the header",
+			payload:  messagePackPayload,
+			expected: &testValue{ID: 2},
+		},
+		{
+			name:        "unknown format version",
+			payload:     []byte{headerMagic, 9, byte(IDJSON), byte(CompressionNone)},
+			expectError: ErrUnknownFormat,
+		},
+		{
+			name:        "truncated header",
+			payload:     []byte{headerMagic, formatVersion},
+			expectError: ErrUnknownFormat,
+		},
+		{
+			name:        "codec not registered",
+			payload:     protobufPayload,
+			expectError: ErrUnknownCodec,
+		},
+		{
+			name:        "unknown compression",
+			payload:     []byte{headerMagic, formatVersion, byte(IDJSON), 9, '{', '}'},
+			expectError: ErrUnknownCompression,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serializer := NewSerializer(&Config{Codec: JSON})
+			decoded := &testValue{}
+
+			err := serializer.Unmarshal(tt.payload, decoded)
+
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, decoded)
+		})
+	}
+}
+
+func TestSerializer_readsRegisteredCodecs(t *testing.T) {
+	protobuf := newTestProtobuf()
+	value := "john"
+	payload, err := NewSerializer(&Config{Codec: protobuf}).Marshal(&value)
+	assert.NoError(t, err)
+
+	var decoded string
+	assert.NoError(t, NewSerializer(&Config{Codec: JSON}, protobuf).Unmarshal(payload, &decoded))
+	assert.Equal(t, "john", decoded)
+}
@@ -4,8 +4,13 @@ type ContextKey string
 
 const (
 	HeaderKeyRequestID string = "X-REQUEST-ID"
+	HeaderKeyTenantID  string = "X-TENANT-ID"
+	HeaderKeyActorID   string = "X-ACTOR-ID"
 
 	ContextKeyRequestID ContextKey = "requestid"
 	ContextKeyTraceID   ContextKey = "traceid"
 	ContextKeySpanID    ContextKey = "spanid"
+	ContextKeyTenantID  ContextKey = "tenantid"
+	ContextKeyActorID   ContextKey = "actorid"
+	ContextKeyCacheFill ContextKey = "cachefill"
 )
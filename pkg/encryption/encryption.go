@@ -0,0 +1,375 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/guregu/null/v5"
+)
+
+const (
+	ciphertextPrefix       string = "enc:v2:"
+	legacyCiphertextPrefix string = "enc:v1:"
+	dataKeySize            int    = 32
+	tagName                string = "encrypted"
+)
+
+type Config struct {
+	Enable          bool
+	KeyringFilePath string
+}
+
+// Binding is where a value is stored, it is sealed into the additional data
+// so a ciphertext copied to another row, column or table fails to decrypt.
+type Binding struct {
+	Table  string
+	Column string
+	RowID  string
+}
+
+var globalKeyring *Keyring
+
+// NewKeyring keeps the previous keyring when the new one fails to load.
+func NewKeyring(cfg *Config) error {
+	var (
+		keyring *Keyring
+		err     error
+	)
+
+	if cfg == nil || !cfg.Enable {
+		globalKeyring = nil
+		return nil
+	}
+
+	keyring, err = LoadKeyring(cfg.KeyringFilePath)
+	if err != nil {
+		return fmt.Errorf("load keyring %q: %w", cfg.KeyringFilePath, err)
+	}
+
+	globalKeyring = keyring
+
+	return nil
+}
+
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, ciphertextPrefix) ||
+		strings.HasPrefix(value, legacyCiphertextPrefix)
+}
+
+// additionalData prefixes every part with its length, so no two bindings
+// produce the same bytes.
+func additionalData(keyID string, binding Binding) []byte {
+	var data []byte
+
+	for _, part := range []string{keyID, binding.Table, binding.Column, binding.RowID} {
+		data = binary.AppendUvarint(data, uint64(len(part)))
+		data = append(data, part...)
+	}
+
+	return data
+}
+
+func seal(aead cipher.AEAD, plaintext []byte, additionalData []byte) ([]byte, error) {
+	var (
+		nonce []byte
+		err   error
+	)
+
+	nonce = make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+}
+
+func newDataAEAD(dataKey []byte) (cipher.AEAD, error) {
+	var (
+		block cipher.Block
+		err   error
+	)
+
+	block, err = aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Encrypt seals plaintext bound to binding with a fresh data key and wraps
+// that data key with the active key, producing
+// enc:v2:<key id>:<wrapped data key>:<ciphertext>.
+func (k *Keyring) Encrypt(plaintext string, binding Binding) (string, error) {
+	var (
+		dataKey        []byte
+		dataAEAD       cipher.AEAD
+		wrappedDataKey []byte
+		ciphertext     []byte
+		err            error
+	)
+
+	dataKey = make([]byte, dataKeySize)
+	_, err = rand.Read(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	dataAEAD, err = newDataAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err = seal(dataAEAD, []byte(plaintext), additionalData(k.activeKeyID, binding))
+	if err != nil {
+		return "", err
+	}
+
+	wrappedDataKey, err = seal(k.keys[k.activeKeyID], dataKey, []byte(k.activeKeyID))
+	if err != nil {
+		return "", err
+	}
+
+	return ciphertextPrefix +
+		k.activeKeyID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrappedDataKey) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt returns values without the ciphertext prefix unchanged, so rows
+// written before encryption was enabled remain readable. enc:v1 values were
+// sealed with the key id only and are read without the binding.
+func (k *Keyring) Decrypt(value string, binding Binding) (string, error) {
+	var (
+		body           string
+		legacy         bool
+		parts          []string
+		keyAEAD        cipher.AEAD
+		wrappedDataKey []byte
+		ciphertext     []byte
+		dataKey        []byte
+		dataAEAD       cipher.AEAD
+		plaintext      []byte
+		data           []byte
+		ok             bool
+		err            error
+	)
+
+	body, legacy = strings.CutPrefix(value, legacyCiphertextPrefix)
+	if !legacy {
+		body, ok = strings.CutPrefix(value, ciphertextPrefix)
+		if !ok {
+			return value, nil
+		}
+	}
+
+	parts = strings.Split(body, ":")
+	if len(parts) != 3 {
+		return "", errors.New("malformed ciphertext")
+	}
+
+	keyAEAD, ok = k.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in keyring", parts[0])
+	}
+
+	wrappedDataKey, err = base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err = base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err = open(keyAEAD, wrappedDataKey, []byte(parts[0]))
+	if err != nil {
+		return "", err
+	}
+
+	dataAEAD, err = newDataAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	data = additionalData(parts[0], binding)
+	if legacy {
+		data = []byte(parts[0])
+	}
+
+	plaintext, err = open(dataAEAD, ciphertext, data)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+func Encrypt(plaintext string, binding Binding) (string, error) {
+	if globalKeyring == nil {
+		return plaintext, nil
+	}
+
+	return globalKeyring.Encrypt(plaintext, binding)
+}
+
+func Decrypt(value string, binding Binding) (string, error) {
+	if globalKeyring == nil {
+		if IsEncrypted(value) {
+			return "", errors.New("keyring is not configured")
+		}
+
+		return value, nil
+	}
+
+	return globalKeyring.Decrypt(value, binding)
+}
+
+// EncryptValue encrypts string and null.String values, other types are
+// returned unchanged.
+func EncryptValue(value interface{}, binding Binding) (interface{}, error) {
+	return transformValue(value, binding, Encrypt)
+}
+
+func DecryptValue(value interface{}, binding Binding) (interface{}, error) {
+	return transformValue(value, binding, Decrypt)
+}
+
+func transformValue(value interface{}, binding Binding, fn func(string, Binding) (string, error)) (interface{}, error) {
+	var (
+		result string
+		err    error
+	)
+
+	switch v := value.(type) {
+	case string:
+		result, err = fn(v, binding)
+		if err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	case null.String:
+		if !v.Valid {
+			return v, nil
+		}
+
+		result, err = fn(v.String, binding)
+		if err != nil {
+			return nil, err
+		}
+
+		return null.StringFrom(result), nil
+	}
+
+	return value, nil
+}
+
+func IsEncryptedField(field reflect.StructField) bool {
+	return field.Tag.Get(tagName) == "true"
+}
+
+// EncryptFields encrypts in place every field of the struct pointed by ptr
+// tagged with encrypted:"true", bound to the table tag, the db tag of the field
+// and the primary_key field. Non struct pointers are ignored.
+func EncryptFields(ptr interface{}) error {
+	return transformFields(ptr, EncryptValue)
+}
+
+func DecryptFields(ptr interface{}) error {
+	return transformFields(ptr, DecryptValue)
+}
+
+func transformFields(ptr interface{}, fn func(interface{}, Binding) (interface{}, error)) error {
+	var (
+		structValue reflect.Value
+		structType  reflect.Type
+		binding     Binding
+		fieldValue  reflect.Value
+		result      interface{}
+		err         error
+	)
+
+	structValue = reflect.ValueOf(ptr)
+	if structValue.Kind() != reflect.Pointer || structValue.IsNil() || structValue.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	structValue = structValue.Elem()
+	structType = structValue.Type()
+	binding = rowBinding(structValue)
+
+	for i := range structType.NumField() {
+		if !IsEncryptedField(structType.Field(i)) {
+			continue
+		}
+
+		fieldValue = structValue.Field(i)
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		binding.Column = columnName(structType.Field(i))
+		result, err = fn(fieldValue.Interface(), binding)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", structType.Field(i).Name, err)
+		}
+
+		fieldValue.Set(reflect.ValueOf(result))
+	}
+
+	return nil
+}
+
+// rowBinding reads the table and the row id of a struct, the column is left
+// for each field.
+func rowBinding(structValue reflect.Value) Binding {
+	var (
+		structType reflect.Type
+		field      reflect.StructField
+		binding    Binding
+	)
+
+	structType = structValue.Type()
+
+	for i := range structType.NumField() {
+		field = structType.Field(i)
+
+		if tableName := field.Tag.Get("table"); tableName != "" && tableName != "-" {
+			binding.Table = tableName
+		}
+
+		if field.Tag.Get("primary_key") == "true" && field.IsExported() {
+			binding.RowID = fmt.Sprint(structValue.Field(i).Interface())
+		}
+	}
+
+	return binding
+}
+
+func columnName(field reflect.StructField) string {
+	var dbTag string = field.Tag.Get("db")
+
+	if dbTag == "" || dbTag == "-" {
+		return field.Name
+	}
+
+	return dbTag
+}
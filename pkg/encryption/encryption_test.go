@@ -0,0 +1,452 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var testBinding Binding = Binding{Table: "guests", Column: "address", RowID: "1"}
+
+type boundTestEntity struct {
+	Table   string `table:"guests" db:"-"`
+	ID      string `db:"id" primary_key:"true"`
+	Address string `db:"address" encrypted:"true"`
+}
+
+type testEntity struct {
+	Name     string      `encrypted:"true"`
+	Address  null.String `encrypted:"true"`
+	Note     string
+	Count    int `encrypted:"true"`
+	internal string
+}
+
+func newTestKeyring(t *testing.T, activeKeyID string) *Keyring {
+	keyring, err := NewKeyringFromKeys(activeKeyID, map[string][]byte{
+		"k1": bytes.Repeat([]byte{1}, 32),
+		"k2": bytes.Repeat([]byte{2}, 32),
+	})
+	assert.NoError(t, err)
+	return keyring
+}
+
+func useGlobalKeyring(t *testing.T, keyring *Keyring) {
+	originalKeyring := globalKeyring
+	globalKeyring = keyring
+	t.Cleanup(func() {
+		globalKeyring = originalKeyring
+	})
+}
+
+func TestNewKeyring(t *testing.T) {
+	validKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+
+	tests := []struct {
+		name        string
+		setupConfig func(t *testing.T) *Config
+		expectError bool
+		expectNil   bool
+	}{
+		{
+			name: "nil config disables encryption",
+			setupConfig: func(t *testing.T) *Config {
+				return nil
+			},
+			expectNil: true,
+		},
+		{
+			name: "disabled config disables encryption",
+			setupConfig: func(t *testing.T) *Config {
+				return &Config{Enable: false, KeyringFilePath: "/does/not/exist"}
+			},
+			expectNil: true,
+		},
+		{
+			name: "enabled config loads keyring",
+			setupConfig: func(t *testing.T) *Config {
+				return &Config{
+					Enable:          true,
+					KeyringFilePath: writeKeyringFile(t, `{"active_key_id":"k1","keys":{"k1":"`+validKey+`"}}`),
+				}
+			},
+			expectNil: false,
+		},
+		{
+			name: "enabled config with missing file fails and keeps the keyring",
+			setupConfig: func(t *testing.T) *Config {
+				return &Config{Enable: true, KeyringFilePath: "/does/not/exist"}
+			},
+			expectError: true,
+		},
+		{
+			name: "enabled config with invalid keyring fails and keeps the keyring",
+			setupConfig: func(t *testing.T) *Config {
+				return &Config{
+					Enable:          true,
+					KeyringFilePath: writeKeyringFile(t, `{"active_key_id":"k9","keys":{"k1":"`+validKey+`"}}`),
+				}
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previousKeyring := newTestKeyring(t, "k1")
+			useGlobalKeyring(t, previousKeyring)
+			cfg := tt.setupConfig(t)
+
+			err := NewKeyring(cfg)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Same(t, previousKeyring, globalKeyring)
+				return
+			}
+
+			assert.NoError(t, err)
+			if tt.expectNil {
+				assert.Nil(t, globalKeyring)
+			} else {
+				assert.NotNil(t, globalKeyring)
+			}
+		})
+	}
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	tests := []struct {
+		name      string
+		plaintext string
+	}{
+		{name: "regular text", plaintext: "123 Main St"},
+		{name: "empty text", plaintext: ""},
+		{name: "text with separator", plaintext: "a:b:c"},
+		{name: "unicode text", plaintext: "Jl. Merdeka No. 1, Jakarta 🇮🇩"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyring := newTestKeyring(t, "k1")
+
+			ciphertext, err := keyring.Encrypt(tt.plaintext, testBinding)
+			assert.NoError(t, err)
+			assert.True(t, IsEncrypted(ciphertext))
+			assert.True(t, strings.HasPrefix(ciphertext, "enc:v2:k1:"))
+			if tt.plaintext != "" {
+				assert.NotContains(t, ciphertext, tt.plaintext)
+			}
+
+			plaintext, err := keyring.Decrypt(ciphertext, testBinding)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.plaintext, plaintext)
+		})
+	}
+}
+
+func TestKeyring_Encrypt_ProducesDifferentCiphertexts(t *testing.T) {
+	keyring := newTestKeyring(t, "k1")
+
+	first, err := keyring.Encrypt("same value", testBinding)
+	assert.NoError(t, err)
+	second, err := keyring.Encrypt("same value", testBinding)
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestKeyring_Decrypt_AfterRotation(t *testing.T) {
+	oldKeyring := newTestKeyring(t, "k1")
+	ciphertext, err := oldKeyring.Encrypt("123 Main St", testBinding)
+	assert.NoError(t, err)
+
+	rotatedKeyring := newTestKeyring(t, "k2")
+	plaintext, err := rotatedKeyring.Decrypt(ciphertext, testBinding)
+	assert.NoError(t, err)
+	assert.Equal(t, "123 Main St", plaintext)
+
+	rotatedCiphertext, err := rotatedKeyring.Encrypt(plaintext, testBinding)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(rotatedCiphertext, "enc:v2:k2:"))
+}
+
+func TestKeyring_Decrypt_Errors(t *testing.T) {
+	keyring := newTestKeyring(t, "k1")
+	validCiphertext, err := keyring.Encrypt("123 Main St", testBinding)
+	assert.NoError(t, err)
+	parts := strings.Split(strings.TrimPrefix(validCiphertext, ciphertextPrefix), ":")
+
+	tests := []struct {
+		name        string
+		value       string
+		binding     Binding
+		expectError bool
+		expected    string
+	}{
+		{
+			name:     "plaintext is returned unchanged",
+			value:    "123 Main St",
+			expected: "123 Main St",
+		},
+		{
+			name:        "malformed ciphertext",
+			value:       ciphertextPrefix + "k1:only-two-parts",
+			expectError: true,
+		},
+		{
+			name:        "unknown key id",
+			value:       ciphertextPrefix + "k9:" + parts[1] + ":" + parts[2],
+			expectError: true,
+		},
+		{
+			name:        "invalid wrapped key encoding",
+			value:       ciphertextPrefix + "k1:!!!:" + parts[2],
+			expectError: true,
+		},
+		{
+			name:        "invalid ciphertext encoding",
+			value:       ciphertextPrefix + "k1:" + parts[1] + ":!!!",
+			expectError: true,
+		},
+		{
+			name:        "key id swapped to another key",
+			value:       ciphertextPrefix + "k2:" + parts[1] + ":" + parts[2],
+			expectError: true,
+		},
+		{
+			name:        "tampered ciphertext",
+			value:       ciphertextPrefix + "k1:" + parts[1] + ":" + base64.RawStdEncoding.EncodeToString([]byte("tampered-ciphertext-value")),
+			expectError: true,
+		},
+		{
+			name:        "value copied to another row",
+			value:       validCiphertext,
+			binding:     Binding{Table: "guests", Column: "address", RowID: "2"},
+			expectError: true,
+		},
+		{
+			name:        "value copied to another column",
+			value:       validCiphertext,
+			binding:     Binding{Table: "guests", Column: "name", RowID: "1"},
+			expectError: true,
+		},
+		{
+			name:        "value copied to another table",
+			value:       validCiphertext,
+			binding:     Binding{Table: "hosts", Column: "address", RowID: "1"},
+			expectError: true,
+		},
+		{
+			name:        "value read as legacy format",
+			value:       legacyCiphertextPrefix + strings.TrimPrefix(validCiphertext, ciphertextPrefix),
+			expectError: true,
+		},
+		{
+			name:        "too short wrapped key",
+			value:       ciphertextPrefix + "k1:" + base64.RawStdEncoding.EncodeToString([]byte("short")) + ":" + parts[2],
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binding := tt.binding
+			if binding == (Binding{}) {
+				binding = testBinding
+			}
+
+			plaintext, err := keyring.Decrypt(tt.value, binding)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Empty(t, plaintext)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, plaintext)
+		})
+	}
+}
+
+func TestKeyring_Decrypt_Legacy(t *testing.T) {
+	keyring := newTestKeyring(t, "k1")
+	dataKey := bytes.Repeat([]byte{3}, dataKeySize)
+	dataAEAD, err := newDataAEAD(dataKey)
+	assert.NoError(t, err)
+	ciphertext, err := seal(dataAEAD, []byte("123 Main St"), []byte("k1"))
+	assert.NoError(t, err)
+	wrappedDataKey, err := seal(keyring.keys["k1"], dataKey, []byte("k1"))
+	assert.NoError(t, err)
+	value := legacyCiphertextPrefix + "k1:" +
+		base64.RawStdEncoding.EncodeToString(wrappedDataKey) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext)
+
+	assert.True(t, IsEncrypted(value))
+	plaintext, err := keyring.Decrypt(value, testBinding)
+	assert.NoError(t, err)
+	assert.Equal(t, "123 Main St", plaintext)
+}
+
+func TestEncryptDecrypt_Global(t *testing.T) {
+	t.Run("without keyring values pass through", func(t *testing.T) {
+		useGlobalKeyring(t, nil)
+
+		ciphertext, err := Encrypt("123 Main St", testBinding)
+		assert.NoError(t, err)
+		assert.Equal(t, "123 Main St", ciphertext)
+
+		plaintext, err := Decrypt("123 Main St", testBinding)
+		assert.NoError(t, err)
+		assert.Equal(t, "123 Main St", plaintext)
+	})
+
+	t.Run("without keyring encrypted values cannot be decrypted", func(t *testing.T) {
+		ciphertext, err := newTestKeyring(t, "k1").Encrypt("123 Main St", testBinding)
+		assert.NoError(t, err)
+
+		useGlobalKeyring(t, nil)
+
+		plaintext, err := Decrypt(ciphertext, testBinding)
+		assert.Error(t, err)
+		assert.Empty(t, plaintext)
+	})
+
+	t.Run("with keyring values are encrypted", func(t *testing.T) {
+		useGlobalKeyring(t, newTestKeyring(t, "k1"))
+
+		ciphertext, err := Encrypt("123 Main St", testBinding)
+		assert.NoError(t, err)
+		assert.True(t, IsEncrypted(ciphertext))
+
+		plaintext, err := Decrypt(ciphertext, testBinding)
+		assert.NoError(t, err)
+		assert.Equal(t, "123 Main St", plaintext)
+	})
+}
+
+func TestEncryptValue(t *testing.T) {
+	useGlobalKeyring(t, newTestKeyring(t, "k1"))
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		validate func(t *testing.T, result interface{})
+	}{
+		{
+			name:  "string is encrypted",
+			value: "123 Main St",
+			validate: func(t *testing.T, result interface{}) {
+				assert.True(t, IsEncrypted(result.(string)))
+			},
+		},
+		{
+			name:  "valid null string is encrypted",
+			value: null.StringFrom("123 Main St"),
+			validate: func(t *testing.T, result interface{}) {
+				assert.True(t, result.(null.String).Valid)
+				assert.True(t, IsEncrypted(result.(null.String).String))
+			},
+		},
+		{
+			name:  "invalid null string stays null",
+			value: null.String{},
+			validate: func(t *testing.T, result interface{}) {
+				assert.False(t, result.(null.String).Valid)
+			},
+		},
+		{
+			name:  "unsupported type is unchanged",
+			value: 42,
+			validate: func(t *testing.T, result interface{}) {
+				assert.Equal(t, 42, result)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := EncryptValue(tt.value, testBinding)
+			assert.NoError(t, err)
+			tt.validate(t, result)
+
+			decrypted, err := DecryptValue(result, testBinding)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.value, decrypted)
+		})
+	}
+}
+
+func TestDecryptValue_Error(t *testing.T) {
+	useGlobalKeyring(t, newTestKeyring(t, "k1"))
+
+	_, err := DecryptValue(ciphertextPrefix+"k1:malformed", testBinding)
+	assert.Error(t, err)
+
+	_, err = DecryptValue(null.StringFrom(ciphertextPrefix+"k1:malformed"), testBinding)
+	assert.Error(t, err)
+}
+
+func TestEncryptDecryptFields(t *testing.T) {
+	useGlobalKeyring(t, newTestKeyring(t, "k1"))
+
+	t.Run("only tagged string fields are encrypted", func(t *testing.T) {
+		entity := &testEntity{
+			Name:     "John Doe",
+			Address:  null.StringFrom("123 Main St"),
+			Note:     "plain",
+			Count:    1,
+			internal: "internal",
+		}
+
+		err := EncryptFields(entity)
+		assert.NoError(t, err)
+		assert.True(t, IsEncrypted(entity.Name))
+		assert.True(t, IsEncrypted(entity.Address.String))
+		assert.Equal(t, "plain", entity.Note)
+		assert.Equal(t, 1, entity.Count)
+		assert.Equal(t, "internal", entity.internal)
+
+		err = DecryptFields(entity)
+		assert.NoError(t, err)
+		assert.Equal(t, "John Doe", entity.Name)
+		assert.Equal(t, null.StringFrom("123 Main St"), entity.Address)
+	})
+
+	t.Run("fields are bound to the table column and row", func(t *testing.T) {
+		entity := &boundTestEntity{ID: "1", Address: "123 Main St"}
+
+		err := EncryptFields(entity)
+		assert.NoError(t, err)
+
+		plaintext, err := Decrypt(entity.Address, testBinding)
+		assert.NoError(t, err)
+		assert.Equal(t, "123 Main St", plaintext)
+
+		entity.ID = "2"
+		err = DecryptFields(entity)
+		assert.Error(t, err)
+	})
+
+	t.Run("non struct pointers are ignored", func(t *testing.T) {
+		value := "123 Main St"
+		assert.NoError(t, EncryptFields(&value))
+		assert.Equal(t, "123 Main St", value)
+		assert.NoError(t, EncryptFields(testEntity{Name: "John Doe"}))
+		assert.NoError(t, EncryptFields((*testEntity)(nil)))
+		assert.NoError(t, EncryptFields(nil))
+	})
+
+	t.Run("decrypt error names the field", func(t *testing.T) {
+		entity := &testEntity{Name: ciphertextPrefix + "k1:malformed"}
+
+		err := DecryptFields(entity)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Name")
+	})
+}
@@ -0,0 +1,103 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+type keyringFile struct {
+	ActiveKeyID string            `json:"active_key_id"`
+	Keys        map[string]string `json:"keys"`
+}
+
+// Keyring holds the key encryption keys by id. New values are always wrapped
+// with the active key, older keys are kept so existing values stay readable
+// after a rotation.
+type Keyring struct {
+	activeKeyID string
+	keys        map[string]cipher.AEAD
+}
+
+func NewKeyringFromKeys(activeKeyID string, keys map[string][]byte) (*Keyring, error) {
+	var (
+		keyring *Keyring
+		block   cipher.Block
+		aead    cipher.AEAD
+		err     error
+	)
+
+	keyring = &Keyring{
+		activeKeyID: activeKeyID,
+		keys:        map[string]cipher.AEAD{},
+	}
+
+	for keyID, key := range keys {
+		if keyID == "" || strings.Contains(keyID, ":") {
+			return nil, fmt.Errorf("invalid key id %q", keyID)
+		}
+
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("key %q must be %d bytes", keyID, dataKeySize)
+		}
+
+		block, err = aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+
+		aead, err = cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		keyring.keys[keyID] = aead
+	}
+
+	if _, ok := keyring.keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active key %q not found in keyring", activeKeyID)
+	}
+
+	return keyring, nil
+}
+
+func LoadKeyring(filePath string) (*Keyring, error) {
+	var (
+		content []byte
+		file    keyringFile
+		keys    map[string][]byte
+		key     []byte
+		err     error
+	)
+
+	content, err = os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(content, &file)
+	if err != nil {
+		return nil, err
+	}
+
+	keys = map[string][]byte{}
+	for keyID, encodedKey := range file.Keys {
+		key, err = base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("key %q is not valid base64: %w", keyID, err)
+		}
+
+		keys[keyID] = key
+	}
+
+	return NewKeyringFromKeys(file.ActiveKeyID, keys)
+}
+
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeKeyID
+}
@@ -0,0 +1,152 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeKeyringFile(t *testing.T, content string) string {
+	filePath := filepath.Join(t.TempDir(), "keyring.json")
+	err := os.WriteFile(filePath, []byte(content), 0600)
+	assert.NoError(t, err)
+	return filePath
+}
+
+func TestNewKeyringFromKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		activeKeyID string
+		keys        map[string][]byte
+		expectError bool
+		errContains string
+	}{
+		{
+			name:        "valid keys",
+			activeKeyID: "k2",
+			keys: map[string][]byte{
+				"k1": bytes.Repeat([]byte{1}, 32),
+				"k2": bytes.Repeat([]byte{2}, 32),
+			},
+			expectError: false,
+		},
+		{
+			name:        "active key not found",
+			activeKeyID: "k3",
+			keys: map[string][]byte{
+				"k1": bytes.Repeat([]byte{1}, 32),
+			},
+			expectError: true,
+			errContains: "active key",
+		},
+		{
+			name:        "invalid key size",
+			activeKeyID: "k1",
+			keys: map[string][]byte{
+				"k1": bytes.Repeat([]byte{1}, 16),
+			},
+			expectError: true,
+			errContains: "must be 32 bytes",
+		},
+		{
+			name:        "key id containing separator",
+			activeKeyID: "k:1",
+			keys: map[string][]byte{
+				"k:1": bytes.Repeat([]byte{1}, 32),
+			},
+			expectError: true,
+			errContains: "invalid key id",
+		},
+		{
+			name:        "empty key id",
+			activeKeyID: "",
+			keys: map[string][]byte{
+				"": bytes.Repeat([]byte{1}, 32),
+			},
+			expectError: true,
+			errContains: "invalid key id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyring, err := NewKeyringFromKeys(tt.activeKeyID, tt.keys)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Nil(t, keyring)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.activeKeyID, keyring.ActiveKeyID())
+			assert.Len(t, keyring.keys, len(tt.keys))
+		})
+	}
+}
+
+func TestLoadKeyring(t *testing.T) {
+	validKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+
+	tests := []struct {
+		name        string
+		setupFile   func(t *testing.T) string
+		expectError bool
+	}{
+		{
+			name: "valid keyring file",
+			setupFile: func(t *testing.T) string {
+				return writeKeyringFile(t, `{"active_key_id":"k1","keys":{"k1":"`+validKey+`"}}`)
+			},
+			expectError: false,
+		},
+		{
+			name: "file not found",
+			setupFile: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "missing.json")
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid json",
+			setupFile: func(t *testing.T) string {
+				return writeKeyringFile(t, `{invalid`)
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid base64 key",
+			setupFile: func(t *testing.T) string {
+				return writeKeyringFile(t, `{"active_key_id":"k1","keys":{"k1":"!!!"}}`)
+			},
+			expectError: true,
+		},
+		{
+			name: "missing active key",
+			setupFile: func(t *testing.T) string {
+				return writeKeyringFile(t, `{"active_key_id":"k2","keys":{"k1":"`+validKey+`"}}`)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyring, err := LoadKeyring(tt.setupFile(t))
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, keyring)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "k1", keyring.ActiveKeyID())
+		})
+	}
+}
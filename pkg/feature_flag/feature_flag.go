@@ -0,0 +1,114 @@
+package feature_flag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-boilerplate/pkg/constants"
+	"hash/fnv"
+	"slices"
+
+	custom_context "go-boilerplate/pkg/context"
+)
+
+const (
+	BackendFile  string = "file"
+	BackendRedis string = "redis"
+)
+
+var ErrFlagNotFound error = errors.New("feature flag not found")
+
+// Flag is off for everyone unless Enabled. Enabled flags are on for the listed
+// tenants and actors, and for Percentage of everyone else.
+type Flag struct {
+	Name       string   `json:"name"`
+	Enabled    bool     `json:"enabled"`
+	Tenants    []string `json:"tenants,omitempty"`
+	Actors     []string `json:"actors,omitempty"`
+	Percentage int      `json:"percentage"`
+}
+
+func (f *Flag) Validate() error {
+	if f.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if f.Percentage < 0 || f.Percentage > 100 {
+		return fmt.Errorf("percentage must be between 0 and 100, got %d", f.Percentage)
+	}
+
+	return nil
+}
+
+func (f *Flag) clone() Flag {
+	var flag Flag = *f
+
+	flag.Tenants = slices.Clone(f.Tenants)
+	flag.Actors = slices.Clone(f.Actors)
+
+	return flag
+}
+
+// EvaluationContext is who a flag is evaluated for.
+type EvaluationContext struct {
+	Actor     string
+	Tenant    string
+	RequestID string
+}
+
+func NewEvaluationContext(ctx context.Context) *EvaluationContext {
+	return &EvaluationContext{
+		Actor:     custom_context.GetCtxValueSafely[string](ctx, constants.ContextKeyActorID),
+		Tenant:    custom_context.GetCtxValueSafely[string](ctx, constants.ContextKeyTenantID),
+		RequestID: custom_context.GetCtxValueSafely[string](ctx, constants.ContextKeyRequestID),
+	}
+}
+
+// rolloutKey keeps the rollout sticky per actor, then per tenant, and only
+// falls back to the request for anonymous calls.
+func (c *EvaluationContext) rolloutKey() string {
+	if c.Actor != "" {
+		return c.Actor
+	}
+
+	if c.Tenant != "" {
+		return c.Tenant
+	}
+
+	return c.RequestID
+}
+
+// bucket spreads the keys over 0-99, salted with the flag name so every flag
+// rolls out to a different part of the population.
+func bucket(name string, key string) int {
+	var hash = fnv.New32a()
+
+	hash.Write([]byte(name + ":" + key))
+
+	return int(hash.Sum32() % 100)
+}
+
+func (f *Flag) Evaluate(evaluationContext *EvaluationContext) bool {
+	if !f.Enabled {
+		return false
+	}
+
+	if evaluationContext.Actor != "" && slices.Contains(f.Actors, evaluationContext.Actor) {
+		return true
+	}
+
+	if evaluationContext.Tenant != "" && slices.Contains(f.Tenants, evaluationContext.Tenant) {
+		return true
+	}
+
+	return bucket(f.Name, evaluationContext.rolloutKey()) < f.Percentage
+}
+
+// Store keeps the flags, Get and Delete return ErrFlagNotFound for unknown
+// flags.
+type Store interface {
+	Get(ctx context.Context, name string) (*Flag, error)
+	List(ctx context.Context) ([]Flag, error)
+	Set(ctx context.Context, flag *Flag) error
+	Delete(ctx context.Context, name string) error
+}
@@ -0,0 +1,213 @@
+package feature_flag
+
+import (
+	"context"
+	"fmt"
+	"go-boilerplate/pkg/constants"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlag_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		flag          *Flag
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:        "valid flag",
+			flag:        &Flag{Name: "guest.cache", Enabled: true, Percentage: 50},
+			expectError: false,
+		},
+		{
+			name:          "missing name",
+			flag:          &Flag{Enabled: true},
+			expectError:   true,
+			errorContains: "name is required",
+		},
+		{
+			name:          "negative percentage",
+			flag:          &Flag{Name: "guest.cache", Percentage: -1},
+			expectError:   true,
+			errorContains: "percentage must be between 0 and 100, got -1",
+		},
+		{
+			name:          "percentage above 100",
+			flag:          &Flag{Name: "guest.cache", Percentage: 101},
+			expectError:   true,
+			errorContains: "percentage must be between 0 and 100, got 101",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.flag.Validate()
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFlag_clone(t *testing.T) {
+	flag := &Flag{Name: "guest.cache", Tenants: []string{"acme"}, Actors: []string{"daenerys"}}
+
+	cloned := flag.clone()
+	cloned.Tenants[0] = "other"
+	cloned.Actors[0] = "other"
+
+	assert.Equal(t, []string{"acme"}, flag.Tenants)
+	assert.Equal(t, []string{"daenerys"}, flag.Actors)
+}
+
+func TestNewEvaluationContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected *EvaluationContext
+	}{
+		{
+			name:     "empty context",
+			ctx:      context.Background(),
+			expected: &EvaluationContext{},
+		},
+		{
+			name: "context with actor, tenant and request id",
+			ctx: func() context.Context {
+				ctx := context.WithValue(context.Background(), constants.ContextKeyActorID, "daenerys")
+				ctx = context.WithValue(ctx, constants.ContextKeyTenantID, "acme")
+				return context.WithValue(ctx, constants.ContextKeyRequestID, "request-1")
+			}(),
+			expected: &EvaluationContext{Actor: "daenerys", Tenant: "acme", RequestID: "request-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewEvaluationContext(tt.ctx))
+		})
+	}
+}
+
+func TestEvaluationContext_rolloutKey(t *testing.T) {
+	tests := []struct {
+		name              string
+		evaluationContext *EvaluationContext
+		expected          string
+	}{
+		{
+			name:              "actor first",
+			evaluationContext: &EvaluationContext{Actor: "daenerys", Tenant: "acme", RequestID: "request-1"},
+			expected:          "daenerys",
+		},
+		{
+			name:              "tenant without actor",
+			evaluationContext: &EvaluationContext{Tenant: "acme", RequestID: "request-1"},
+			expected:          "acme",
+		},
+		{
+			name:              "request id for anonymous calls",
+			evaluationContext: &EvaluationContext{RequestID: "request-1"},
+			expected:          "request-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.evaluationContext.rolloutKey())
+		})
+	}
+}
+
+func Test_bucket(t *testing.T) {
+	t.Run("bucket is stable and within range", func(t *testing.T) {
+		for i := 0; i < 1000; i++ {
+			key := fmt.Sprintf("actor-%d", i)
+			b := bucket("guest.cache", key)
+
+			assert.GreaterOrEqual(t, b, 0)
+			assert.Less(t, b, 100)
+			assert.Equal(t, b, bucket("guest.cache", key))
+		}
+	})
+
+	t.Run("buckets are spread evenly", func(t *testing.T) {
+		enabled := 0
+		for i := 0; i < 10000; i++ {
+			if bucket("guest.cache", fmt.Sprintf("actor-%d", i)) < 25 {
+				enabled++
+			}
+		}
+
+		assert.InDelta(t, 2500, enabled, 250)
+	})
+}
+
+func TestFlag_Evaluate(t *testing.T) {
+	tests := []struct {
+		name              string
+		flag              *Flag
+		evaluationContext *EvaluationContext
+		expected          bool
+	}{
+		{
+			name:              "disabled flag is off for listed actor",
+			flag:              &Flag{Name: "guest.cache", Enabled: false, Actors: []string{"daenerys"}, Percentage: 100},
+			evaluationContext: &EvaluationContext{Actor: "daenerys"},
+			expected:          false,
+		},
+		{
+			name:              "listed actor is on",
+			flag:              &Flag{Name: "guest.cache", Enabled: true, Actors: []string{"daenerys"}},
+			evaluationContext: &EvaluationContext{Actor: "daenerys"},
+			expected:          true,
+		},
+		{
+			name:              "listed tenant is on",
+			flag:              &Flag{Name: "guest.cache", Enabled: true, Tenants: []string{"acme"}},
+			evaluationContext: &EvaluationContext{Actor: "jon", Tenant: "acme"},
+			expected:          true,
+		},
+		{
+			name:              "unlisted actor without rollout is off",
+			flag:              &Flag{Name: "guest.cache", Enabled: true, Actors: []string{"daenerys"}, Tenants: []string{"acme"}},
+			evaluationContext: &EvaluationContext{Actor: "jon", Tenant: "other"},
+			expected:          false,
+		},
+		{
+			name:              "full rollout is on for everyone",
+			flag:              &Flag{Name: "guest.cache", Enabled: true, Percentage: 100},
+			evaluationContext: &EvaluationContext{},
+			expected:          true,
+		},
+		{
+			name:              "empty actor does not match empty list entry",
+			flag:              &Flag{Name: "guest.cache", Enabled: true, Actors: []string{""}},
+			evaluationContext: &EvaluationContext{},
+			expected:          false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.flag.Evaluate(tt.evaluationContext))
+		})
+	}
+
+	t.Run("partial rollout follows the actor bucket", func(t *testing.T) {
+		flag := &Flag{Name: "guest.cache", Enabled: true, Percentage: 30}
+
+		for i := 0; i < 100; i++ {
+			actor := fmt.Sprintf("actor-%d", i)
+			expected := bucket(flag.Name, actor) < 30
+
+			assert.Equal(t, expected, flag.Evaluate(&EvaluationContext{Actor: actor, RequestID: fmt.Sprint(i)}))
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package feature_flag
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+)
+
+// FileStore keeps the flags in memory and writes them back to a JSON file on
+// every change, it suits a single instance. A missing file starts empty.
+type FileStore struct {
+	path  string
+	mutex sync.RWMutex
+	flags map[string]Flag
+}
+
+func NewFileStore(path string) (*FileStore, error) {
+	var (
+		store *FileStore = &FileStore{
+			path:  path,
+			flags: map[string]Flag{},
+		}
+		content []byte
+		flags   []Flag
+		err     error
+	)
+
+	content, err = os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(content, &flags)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range flags {
+		err = flags[i].Validate()
+		if err != nil {
+			return nil, err
+		}
+		store.flags[flags[i].Name] = flags[i]
+	}
+
+	return store, nil
+}
+
+func (s *FileStore) Get(ctx context.Context, name string) (*Flag, error) {
+	var (
+		flag  Flag
+		found bool
+	)
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	flag, found = s.flags[name]
+	if !found {
+		return nil, ErrFlagNotFound
+	}
+	flag = flag.clone()
+
+	return &flag, nil
+}
+
+func (s *FileStore) list() []Flag {
+	var flags []Flag = make([]Flag, 0, len(s.flags))
+
+	for _, flag := range s.flags {
+		flags = append(flags, flag.clone())
+	}
+
+	slices.SortFunc(flags, func(a, b Flag) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return flags
+}
+
+func (s *FileStore) List(ctx context.Context) ([]Flag, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.list(), nil
+}
+
+// save writes to a temporary file first, so a crash never leaves a partially
+// written file behind.
+func (s *FileStore) save() error {
+	var (
+		content []byte
+		tmpPath string
+		err     error
+	)
+
+	content, err = json.MarshalIndent(s.list(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath = filepath.Join(filepath.Dir(s.path), "."+filepath.Base(s.path)+".tmp")
+
+	err = os.WriteFile(tmpPath, content, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, s.path)
+}
+
+func (s *FileStore) Set(ctx context.Context, flag *Flag) error {
+	var (
+		previous Flag
+		found    bool
+		err      error
+	)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, found = s.flags[flag.Name]
+	s.flags[flag.Name] = flag.clone()
+
+	err = s.save()
+	if err != nil {
+		if found {
+			s.flags[flag.Name] = previous
+		} else {
+			delete(s.flags, flag.Name)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *FileStore) Delete(ctx context.Context, name string) error {
+	var (
+		previous Flag
+		found    bool
+		err      error
+	)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, found = s.flags[name]
+	if !found {
+		return ErrFlagNotFound
+	}
+	delete(s.flags, name)
+
+	err = s.save()
+	if err != nil {
+		s.flags[name] = previous
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+package feature_flag
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFlagsFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "feature_flags.json")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestNewFileStore(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          func(t *testing.T) string
+		expectError   bool
+		errorContains string
+		expected      map[string]Flag
+	}{
+		{
+			name: "missing file starts empty",
+			path: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "feature_flags.json")
+			},
+			expected: map[string]Flag{},
+		},
+		{
+			name: "flags are loaded",
+			path: func(t *testing.T) string {
+				return writeFlagsFile(t, `[{"name":"guest.cache","enabled":true,"tenants":["acme"],"percentage":10}]`)
+			},
+			expected: map[string]Flag{
+				"guest.cache": {Name: "guest.cache", Enabled: true, Tenants: []string{"acme"}, Percentage: 10},
+			},
+		},
+		{
+			name: "invalid json returns error",
+			path: func(t *testing.T) string {
+				return writeFlagsFile(t, `{`)
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid flag returns error",
+			path: func(t *testing.T) string {
+				return writeFlagsFile(t, `[{"name":"guest.cache","percentage":150}]`)
+			},
+			expectError:   true,
+			errorContains: "percentage must be between 0 and 100",
+		},
+		{
+			name: "unreadable path returns error",
+			path: func(t *testing.T) string {
+				return t.TempDir()
+			},
+			expectError:   true,
+			errorContains: "is a directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := NewFileStore(tt.path(t))
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, store)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, store.flags)
+		})
+	}
+}
+
+func TestFileStore_Get(t *testing.T) {
+	store, err := NewFileStore(writeFlagsFile(t, `[{"name":"guest.cache","enabled":true,"actors":["daenerys"],"percentage":100}]`))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		flagName    string
+		expected    *Flag
+		expectError error
+	}{
+		{
+			name:     "existing flag",
+			flagName: "guest.cache",
+			expected: &Flag{Name: "guest.cache", Enabled: true, Actors: []string{"daenerys"}, Percentage: 100},
+		},
+		{
+			name:        "unknown flag",
+			flagName:    "guest.unknown",
+			expectError: ErrFlagNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag, err := store.Get(context.Background(), tt.flagName)
+
+			assert.ErrorIs(t, err, tt.expectError)
+			assert.Equal(t, tt.expected, flag)
+		})
+	}
+
+	t.Run("returned flag is a copy", func(t *testing.T) {
+		flag, _ := store.Get(context.Background(), "guest.cache")
+		flag.Actors[0] = "jon"
+
+		flag, _ = store.Get(context.Background(), "guest.cache")
+		assert.Equal(t, []string{"daenerys"}, flag.Actors)
+	})
+}
+
+func TestFileStore_List(t *testing.T) {
+	store, err := NewFileStore(writeFlagsFile(t, `[{"name":"guest.event.created"},{"name":"guest.cache"}]`))
+	assert.NoError(t, err)
+
+	flags, err := store.List(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Flag{{Name: "guest.cache"}, {Name: "guest.event.created"}}, flags)
+}
+
+func TestFileStore_Set(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          func(t *testing.T) string
+		flag          *Flag
+		expectError   bool
+		expectedFlags []Flag
+		expectedFile  string
+	}{
+		{
+			name: "new flag is added and saved",
+			path: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "feature_flags.json")
+			},
+			flag:          &Flag{Name: "guest.cache", Enabled: true, Percentage: 100},
+			expectedFlags: []Flag{{Name: "guest.cache", Enabled: true, Percentage: 100}},
+			expectedFile:  `[{"name":"guest.cache","enabled":true,"percentage":100}]`,
+		},
+		{
+			name: "existing flag is replaced and saved",
+			path: func(t *testing.T) string {
+				return writeFlagsFile(t, `[{"name":"guest.cache","enabled":true,"percentage":100}]`)
+			},
+			flag:          &Flag{Name: "guest.cache", Enabled: false},
+			expectedFlags: []Flag{{Name: "guest.cache", Enabled: false}},
+			expectedFile:  `[{"name":"guest.cache","enabled":false,"percentage":0}]`,
+		},
+		{
+			name: "failed save keeps the previous flags",
+			path: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "nonexistent", "feature_flags.json")
+			},
+			flag:          &Flag{Name: "guest.cache", Enabled: true},
+			expectError:   true,
+			expectedFlags: []Flag{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path(t)
+			store, err := NewFileStore(path)
+			assert.NoError(t, err)
+
+			err = store.Set(context.Background(), tt.flag)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				content, readErr := os.ReadFile(path)
+				assert.NoError(t, readErr)
+				assert.JSONEq(t, tt.expectedFile, string(content))
+			}
+
+			flags, _ := store.List(context.Background())
+			assert.Equal(t, tt.expectedFlags, flags)
+		})
+	}
+}
+
+func TestFileStore_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		flagName      string
+		expectError   error
+		expectedFlags []Flag
+	}{
+		{
+			name:          "existing flag is deleted and saved",
+			flagName:      "guest.cache",
+			expectedFlags: []Flag{{Name: "guest.event.created"}},
+		},
+		{
+			name:          "unknown flag returns not found",
+			flagName:      "guest.unknown",
+			expectError:   ErrFlagNotFound,
+			expectedFlags: []Flag{{Name: "guest.cache"}, {Name: "guest.event.created"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFlagsFile(t, `[{"name":"guest.cache"},{"name":"guest.event.created"}]`)
+			store, err := NewFileStore(path)
+			assert.NoError(t, err)
+
+			err = store.Delete(context.Background(), tt.flagName)
+
+			assert.ErrorIs(t, err, tt.expectError)
+
+			flags, _ := store.List(context.Background())
+			assert.Equal(t, tt.expectedFlags, flags)
+
+			reloaded, err := NewFileStore(path)
+			assert.NoError(t, err)
+			flags, _ = reloaded.List(context.Background())
+			assert.Equal(t, tt.expectedFlags, flags)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// Handler serves /healthz and /readyz for servers without an HTTP transport,
+// e.g. the event consumer.
+func Handler(checker *Checker) http.Handler {
+	var mux *http.ServeMux = http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, &Report{Status: StatusUp})
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		var (
+			report     *Report = checker.Ready(r.Context())
+			statusCode int     = http.StatusOK
+		)
+
+		if !report.IsUp() {
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		writeJSON(w, statusCode, report)
+	})
+
+	return mux
+}
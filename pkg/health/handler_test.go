@@ -0,0 +1,59 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		check          CheckFunc
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "healthz should be up even when a check fails",
+			path:           "/healthz",
+			check:          func(ctx context.Context) error { return errors.New("down") },
+			expectedStatus: http.StatusOK,
+			expectedBody:   StatusUp,
+		},
+		{
+			name:           "readyz should be ok when checks pass",
+			path:           "/readyz",
+			check:          func(ctx context.Context) error { return nil },
+			expectedStatus: http.StatusOK,
+			expectedBody:   StatusUp,
+		},
+		{
+			name:           "readyz should be unavailable when a check fails",
+			path:           "/readyz",
+			check:          func(ctx context.Context) error { return errors.New("down") },
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   StatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(nil)
+			checker.Register("database", tt.check)
+
+			recorder := httptest.NewRecorder()
+			Handler(checker).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			var report Report
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedBody, report.Status)
+		})
+	}
+}
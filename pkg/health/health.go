@@ -0,0 +1,225 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   string = "up"
+	StatusDown string = "down"
+
+	defaultTimeout time.Duration = time.Second
+
+	drainCheckName string = "shutdown"
+)
+
+type CheckFunc func(ctx context.Context) error
+
+type Config struct {
+	Timeout time.Duration
+	// CacheDuration keeps the result of a check so frequent probes do not hit
+	// the dependencies on every request.
+	CacheDuration time.Duration
+}
+
+type CheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	checkedAt time.Time
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+func (r *Report) IsUp() bool {
+	return r.Status == StatusUp
+}
+
+type Checker struct {
+	cfg     Config
+	mutex   sync.Mutex
+	checks  map[string]CheckFunc
+	results map[string]CheckResult
+	now     func() time.Time
+
+	draining atomic.Bool
+}
+
+func NewChecker(cfg *Config) *Checker {
+	var checker *Checker = &Checker{
+		checks:  map[string]CheckFunc{},
+		results: map[string]CheckResult{},
+		now:     time.Now,
+	}
+
+	if cfg != nil {
+		checker.cfg = *cfg
+	}
+
+	if checker.cfg.Timeout <= 0 {
+		checker.cfg.Timeout = defaultTimeout
+	}
+
+	return checker
+}
+
+// Register replaces the check registered with the same name.
+func (c *Checker) Register(name string, check CheckFunc) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.checks[name] = check
+	delete(c.results, name)
+}
+
+func (c *Checker) Names() []string {
+	var names []string
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	names = make([]string, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (c *Checker) cachedResult(name string) (CheckResult, bool) {
+	var (
+		result CheckResult
+		ok     bool
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	result, ok = c.results[name]
+	if !ok || c.now().Sub(result.checkedAt) >= c.cfg.CacheDuration {
+		return CheckResult{}, false
+	}
+
+	return result, true
+}
+
+func (c *Checker) runCheck(ctx context.Context, name string, check CheckFunc) CheckResult {
+	var (
+		cancel  context.CancelFunc
+		errChan chan error
+		result  CheckResult
+		ok      bool
+		err     error
+	)
+
+	result, ok = c.cachedResult(name)
+	if ok {
+		return result
+	}
+
+	ctx, cancel = context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	// the check runs in its own goroutine so checks that ignore the context,
+	// such as the NSQ ping, are still bounded by the timeout
+	errChan = make(chan error, 1)
+	go func() {
+		errChan <- check(ctx)
+	}()
+
+	select {
+	case err = <-errChan:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result = CheckResult{
+		Status:    StatusUp,
+		checkedAt: c.now(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	c.mutex.Lock()
+	_, ok = c.checks[name]
+	if ok {
+		c.results[name] = result
+	}
+	c.mutex.Unlock()
+
+	return result
+}
+
+// Drain fails readiness from now on so load balancers stop routing to the
+// process before it stops listening, liveness is unaffected.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Ready runs every check concurrently, each bounded by the configured timeout,
+// and reports down when any of them fails.
+func (c *Checker) Ready(ctx context.Context) *Report {
+	var (
+		checks  map[string]CheckFunc
+		report  *Report
+		mutex   sync.Mutex
+		wg      sync.WaitGroup
+		name    string
+		checkFn CheckFunc
+	)
+
+	if c.draining.Load() {
+		return &Report{
+			Status: StatusDown,
+			Checks: map[string]CheckResult{
+				drainCheckName: {
+					Status: StatusDown,
+					Error:  "shutting down",
+				},
+			},
+		}
+	}
+
+	c.mutex.Lock()
+	checks = make(map[string]CheckFunc, len(c.checks))
+	for name, checkFn = range c.checks {
+		checks[name] = checkFn
+	}
+	c.mutex.Unlock()
+
+	report = &Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult, len(checks)),
+	}
+
+	for name, checkFn = range checks {
+		wg.Add(1)
+		go func(name string, checkFn CheckFunc) {
+			defer wg.Done()
+
+			var result CheckResult = c.runCheck(ctx, name, checkFn)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			report.Checks[name] = result
+			if result.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}(name, checkFn)
+	}
+
+	wg.Wait()
+
+	return report
+}
@@ -0,0 +1,210 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewChecker(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             *Config
+		expectedTimeout time.Duration
+		expectedCache   time.Duration
+	}{
+		{
+			name:            "nil config should use default timeout",
+			cfg:             nil,
+			expectedTimeout: defaultTimeout,
+			expectedCache:   0,
+		},
+		{
+			name:            "zero timeout should use default timeout",
+			cfg:             &Config{CacheDuration: 5 * time.Second},
+			expectedTimeout: defaultTimeout,
+			expectedCache:   5 * time.Second,
+		},
+		{
+			name:            "configured values should be kept",
+			cfg:             &Config{Timeout: 2 * time.Second, CacheDuration: 10 * time.Second},
+			expectedTimeout: 2 * time.Second,
+			expectedCache:   10 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(tt.cfg)
+
+			assert.NotNil(t, checker)
+			assert.Equal(t, tt.expectedTimeout, checker.cfg.Timeout)
+			assert.Equal(t, tt.expectedCache, checker.cfg.CacheDuration)
+		})
+	}
+}
+
+func TestChecker_Register(t *testing.T) {
+	checker := NewChecker(nil)
+
+	checker.Register("b", func(ctx context.Context) error { return nil })
+	checker.Register("a", func(ctx context.Context) error { return nil })
+	checker.Register("a", func(ctx context.Context) error { return errors.New("replaced") })
+
+	assert.Equal(t, []string{"a", "b"}, checker.Names())
+
+	report := checker.Ready(context.Background())
+	assert.Equal(t, StatusDown, report.Checks["a"].Status)
+	assert.Equal(t, "replaced", report.Checks["a"].Error)
+}
+
+func TestChecker_Ready(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            *Config
+		checks         map[string]CheckFunc
+		expectedStatus string
+		validate       func(t *testing.T, report *Report)
+	}{
+		{
+			name:           "no checks should be up",
+			cfg:            &Config{},
+			checks:         map[string]CheckFunc{},
+			expectedStatus: StatusUp,
+			validate: func(t *testing.T, report *Report) {
+				assert.Empty(t, report.Checks)
+				assert.True(t, report.IsUp())
+			},
+		},
+		{
+			name: "all checks passing should be up",
+			cfg:  &Config{},
+			checks: map[string]CheckFunc{
+				"database": func(ctx context.Context) error { return nil },
+				"cache":    func(ctx context.Context) error { return nil },
+			},
+			expectedStatus: StatusUp,
+			validate: func(t *testing.T, report *Report) {
+				assert.Equal(t, StatusUp, report.Checks["database"].Status)
+				assert.Equal(t, StatusUp, report.Checks["cache"].Status)
+			},
+		},
+		{
+			name: "one failing check should be down",
+			cfg:  &Config{},
+			checks: map[string]CheckFunc{
+				"database": func(ctx context.Context) error { return nil },
+				"cache":    func(ctx context.Context) error { return errors.New("connection refused") },
+			},
+			expectedStatus: StatusDown,
+			validate: func(t *testing.T, report *Report) {
+				assert.False(t, report.IsUp())
+				assert.Equal(t, StatusUp, report.Checks["database"].Status)
+				assert.Equal(t, StatusDown, report.Checks["cache"].Status)
+				assert.Equal(t, "connection refused", report.Checks["cache"].Error)
+			},
+		},
+		{
+			name: "check ignoring context should time out",
+			cfg:  &Config{Timeout: 10 * time.Millisecond},
+			checks: map[string]CheckFunc{
+				"producer": func(ctx context.Context) error {
+					time.Sleep(time.Second)
+					return nil
+				},
+			},
+			expectedStatus: StatusDown,
+			validate: func(t *testing.T, report *Report) {
+				assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["producer"].Error)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(tt.cfg)
+			for name, check := range tt.checks {
+				checker.Register(name, check)
+			}
+
+			report := checker.Ready(context.Background())
+
+			assert.Equal(t, tt.expectedStatus, report.Status)
+			if tt.validate != nil {
+				tt.validate(t, report)
+			}
+		})
+	}
+}
+
+func TestChecker_Ready_Cache(t *testing.T) {
+	tests := []struct {
+		name          string
+		cacheDuration time.Duration
+		advance       time.Duration
+		expectedCalls int32
+	}{
+		{
+			name:          "result within cache duration should be reused",
+			cacheDuration: 5 * time.Second,
+			advance:       time.Second,
+			expectedCalls: 1,
+		},
+		{
+			name:          "expired result should be checked again",
+			cacheDuration: 5 * time.Second,
+			advance:       5 * time.Second,
+			expectedCalls: 2,
+		},
+		{
+			name:          "zero cache duration should always check",
+			cacheDuration: 0,
+			advance:       0,
+			expectedCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			now := time.Now()
+
+			checker := NewChecker(&Config{CacheDuration: tt.cacheDuration})
+			checker.now = func() time.Time { return now }
+			checker.Register("database", func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return nil
+			})
+
+			checker.Ready(context.Background())
+			now = now.Add(tt.advance)
+			checker.Ready(context.Background())
+
+			assert.Equal(t, tt.expectedCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestChecker_Drain(t *testing.T) {
+	var calls int32
+
+	checker := NewChecker(nil)
+	checker.Register("database", func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	assert.True(t, checker.Ready(context.Background()).IsUp())
+
+	checker.Drain()
+	report := checker.Ready(context.Background())
+
+	assert.False(t, report.IsUp())
+	assert.Equal(t, StatusDown, report.Checks[drainCheckName].Status)
+	assert.Equal(t, "shutting down", report.Checks[drainCheckName].Error)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,213 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Component is started without blocking and stopped once the manager shuts
+// down.
+type Component interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// Watcher is implemented by components serving in the background, the channel
+// receives the result of serving so a failing component shuts down the rest.
+type Watcher interface {
+	Errors() <-chan error
+}
+
+// Drainer is implemented by components that can fail their readiness ahead of
+// stopping.
+type Drainer interface {
+	Drain()
+}
+
+// Hook adapts plain functions to a Component, a nil function does nothing.
+type Hook struct {
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+func (h *Hook) Start(ctx context.Context) error {
+	if h.OnStart == nil {
+		return nil
+	}
+
+	return h.OnStart(ctx)
+}
+
+func (h *Hook) Stop(ctx context.Context) error {
+	if h.OnStop == nil {
+		return nil
+	}
+
+	return h.OnStop(ctx)
+}
+
+type namedComponent struct {
+	name      string
+	component Component
+}
+
+type Config struct {
+	// PreStopDelay is waited between draining the components and stopping
+	// them, giving load balancers time to notice the failing readiness.
+	PreStopDelay time.Duration
+}
+
+type Manager struct {
+	cfg        Config
+	components []namedComponent
+}
+
+func NewManager(cfg *Config) *Manager {
+	var manager *Manager = &Manager{}
+
+	if cfg != nil {
+		manager.cfg = *cfg
+	}
+
+	return manager
+}
+
+// Append adds a component, components start in the order they are appended
+// and stop in the reverse order.
+func (m *Manager) Append(name string, component Component) {
+	m.components = append(m.components, namedComponent{
+		name:      name,
+		component: component,
+	})
+}
+
+func (m *Manager) start(ctx context.Context, c namedComponent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic recovered while starting %s: %v", c.name, r)
+		}
+	}()
+
+	log.Info().
+		Str("component", c.name).
+		Msg("[Manager][start] starting component")
+
+	err = c.component.Start(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", c.name, err)
+	}
+
+	return nil
+}
+
+func (m *Manager) stop(ctx context.Context, c namedComponent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic recovered while stopping %s: %v", c.name, r)
+		}
+	}()
+
+	log.Info().
+		Str("component", c.name).
+		Msg("[Manager][stop] stopping component")
+
+	err = c.component.Stop(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to stop %s: %w", c.name, err)
+	}
+
+	return nil
+}
+
+func (m *Manager) stopAll(ctx context.Context, started []namedComponent) error {
+	var (
+		errs []error
+		i    int
+		err  error
+	)
+
+	for i = len(started) - 1; i >= 0; i-- {
+		err = m.stop(ctx, started[i])
+		if err != nil {
+			log.Err(err).
+				Str("component", started[i].name).
+				Msg("[Manager][stopAll][stop] failed to stop component")
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (m *Manager) drainAll(started []namedComponent) {
+	var (
+		drainer Drainer
+		ok      bool
+	)
+
+	for _, c := range started {
+		drainer, ok = c.component.(Drainer)
+		if ok {
+			drainer.Drain()
+		}
+	}
+
+	if m.cfg.PreStopDelay > 0 {
+		log.Info().
+			Dur("pre_stop_delay", m.cfg.PreStopDelay).
+			Msg("[Manager][drainAll] waiting before stopping components")
+		time.Sleep(m.cfg.PreStopDelay)
+	}
+}
+
+// Run starts the components in order and blocks until ctx is done or a
+// watched component stops serving. It then drains every started component,
+// waits for the pre-stop delay and stops them in the reverse order. A
+// component failing to start stops the ones started before it right away.
+func (m *Manager) Run(ctx context.Context) error {
+	var (
+		started []namedComponent
+		errChan chan error
+		stopCtx context.Context
+		c       namedComponent
+		watcher Watcher
+		ok      bool
+		err     error
+	)
+
+	errChan = make(chan error, len(m.components))
+	stopCtx = context.WithoutCancel(ctx)
+
+	for _, c = range m.components {
+		err = m.start(ctx, c)
+		if err != nil {
+			return errors.Join(err, m.stopAll(stopCtx, started))
+		}
+
+		started = append(started, c)
+
+		watcher, ok = c.component.(Watcher)
+		if ok {
+			go func(name string, watcher Watcher) {
+				var err error = <-watcher.Errors()
+				if err != nil {
+					err = fmt.Errorf("%s stopped serving: %w", name, err)
+				}
+				errChan <- err
+			}(c.name, watcher)
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+	case err = <-errChan:
+	}
+
+	m.drainAll(started)
+
+	return errors.Join(err, m.stopAll(stopCtx, started))
+}
@@ -0,0 +1,241 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeComponent struct {
+	name     string
+	events   *[]string
+	startErr error
+	stopErr  error
+	panics   bool
+}
+
+func (c *fakeComponent) Start(ctx context.Context) error {
+	if c.panics {
+		panic("boom")
+	}
+
+	*c.events = append(*c.events, "start "+c.name)
+	return c.startErr
+}
+
+func (c *fakeComponent) Stop(ctx context.Context) error {
+	*c.events = append(*c.events, "stop "+c.name)
+	return c.stopErr
+}
+
+type fakeWatcher struct {
+	fakeComponent
+	errChan chan error
+}
+
+func (w *fakeWatcher) Errors() <-chan error {
+	return w.errChan
+}
+
+type fakeDrainer struct {
+	fakeComponent
+}
+
+func (d *fakeDrainer) Drain() {
+	*d.events = append(*d.events, "drain "+d.name)
+}
+
+func TestHook(t *testing.T) {
+	tests := []struct {
+		name             string
+		hook             *Hook
+		expectedStartErr error
+		expectedStopErr  error
+	}{
+		{
+			name:             "nil functions should do nothing",
+			hook:             &Hook{},
+			expectedStartErr: nil,
+			expectedStopErr:  nil,
+		},
+		{
+			name: "functions should be called",
+			hook: &Hook{
+				OnStart: func(ctx context.Context) error { return errors.New("start error") },
+				OnStop:  func(ctx context.Context) error { return errors.New("stop error") },
+			},
+			expectedStartErr: errors.New("start error"),
+			expectedStopErr:  errors.New("stop error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedStartErr, tt.hook.Start(context.Background()))
+			assert.Equal(t, tt.expectedStopErr, tt.hook.Stop(context.Background()))
+		})
+	}
+}
+
+func TestManager_Run(t *testing.T) {
+	tests := []struct {
+		name           string
+		setup          func(events *[]string) (*Manager, context.Context)
+		expectError    bool
+		errorContains  string
+		expectedEvents []string
+	}{
+		{
+			name: "components should stop in reverse order once ctx is done",
+			setup: func(events *[]string) (*Manager, context.Context) {
+				var (
+					manager     *Manager = NewManager(nil)
+					ctx, cancel          = context.WithCancel(context.Background())
+				)
+
+				manager.Append("datasources", &fakeComponent{name: "datasources", events: events})
+				manager.Append("consumer", &fakeComponent{name: "consumer", events: events})
+				manager.Append("server", &Hook{
+					OnStart: func(ctx context.Context) error {
+						*events = append(*events, "start server")
+						cancel()
+						return nil
+					},
+					OnStop: func(ctx context.Context) error {
+						*events = append(*events, "stop server")
+						return nil
+					},
+				})
+
+				return manager, ctx
+			},
+			expectError: false,
+			expectedEvents: []string{
+				"start datasources", "start consumer", "start server",
+				"stop server", "stop consumer", "stop datasources",
+			},
+		},
+		{
+			name: "drainers should be drained before any component stops",
+			setup: func(events *[]string) (*Manager, context.Context) {
+				var (
+					manager     *Manager = NewManager(&Config{PreStopDelay: 10 * time.Millisecond})
+					ctx, cancel          = context.WithCancel(context.Background())
+				)
+
+				cancel()
+				manager.Append("consumer", &fakeDrainer{fakeComponent: fakeComponent{name: "consumer", events: events}})
+				manager.Append("datasources", &fakeComponent{name: "datasources", events: events})
+				manager.Append("server", &fakeDrainer{fakeComponent: fakeComponent{name: "server", events: events}})
+
+				return manager, ctx
+			},
+			expectError: false,
+			expectedEvents: []string{
+				"start consumer", "start datasources", "start server",
+				"drain consumer", "drain server",
+				"stop server", "stop datasources", "stop consumer",
+			},
+		},
+		{
+			name: "start failure should stop the components started before",
+			setup: func(events *[]string) (*Manager, context.Context) {
+				var manager *Manager = NewManager(nil)
+
+				manager.Append("datasources", &fakeDrainer{fakeComponent: fakeComponent{name: "datasources", events: events}})
+				manager.Append("consumer", &fakeComponent{name: "consumer", events: events, startErr: errors.New("lookupd unavailable")})
+				manager.Append("server", &fakeComponent{name: "server", events: events})
+
+				return manager, context.Background()
+			},
+			expectError:   true,
+			errorContains: "failed to start consumer: lookupd unavailable",
+			expectedEvents: []string{
+				"start datasources", "start consumer",
+				"stop datasources",
+			},
+		},
+		{
+			name: "start panic should be recovered",
+			setup: func(events *[]string) (*Manager, context.Context) {
+				var manager *Manager = NewManager(nil)
+
+				manager.Append("datasources", &fakeComponent{name: "datasources", events: events})
+				manager.Append("server", &fakeComponent{name: "server", events: events, panics: true})
+
+				return manager, context.Background()
+			},
+			expectError:   true,
+			errorContains: "panic recovered while starting server: boom",
+			expectedEvents: []string{
+				"start datasources",
+				"stop datasources",
+			},
+		},
+		{
+			name: "watched component failing should stop every component",
+			setup: func(events *[]string) (*Manager, context.Context) {
+				var (
+					manager *Manager     = NewManager(nil)
+					watcher *fakeWatcher = &fakeWatcher{
+						fakeComponent: fakeComponent{name: "server", events: events},
+						errChan:       make(chan error, 1),
+					}
+				)
+
+				watcher.errChan <- errors.New("address already in use")
+				manager.Append("datasources", &fakeComponent{name: "datasources", events: events})
+				manager.Append("server", watcher)
+
+				return manager, context.Background()
+			},
+			expectError:   true,
+			errorContains: "server stopped serving: address already in use",
+			expectedEvents: []string{
+				"start datasources", "start server",
+				"stop server", "stop datasources",
+			},
+		},
+		{
+			name: "stop errors should be joined without skipping components",
+			setup: func(events *[]string) (*Manager, context.Context) {
+				var (
+					manager     *Manager = NewManager(nil)
+					ctx, cancel          = context.WithCancel(context.Background())
+				)
+
+				cancel()
+				manager.Append("datasources", &fakeComponent{name: "datasources", events: events, stopErr: errors.New("close error")})
+				manager.Append("server", &fakeComponent{name: "server", events: events, stopErr: errors.New("shutdown error")})
+
+				return manager, ctx
+			},
+			expectError:   true,
+			errorContains: "failed to stop server: shutdown error\nfailed to stop datasources: close error",
+			expectedEvents: []string{
+				"start datasources", "start server",
+				"stop server", "stop datasources",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []string
+
+			manager, ctx := tt.setup(&events)
+			err := manager.Run(ctx)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedEvents, events)
+		})
+	}
+}
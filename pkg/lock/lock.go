@@ -0,0 +1,324 @@
+// Package lock is a Redis lock with fencing tokens. It is a library for the
+// services built on this boilerplate, nothing in the boilerplate itself takes a
+// lock.
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-boilerplate/datasources/in_memory_database"
+	"go-boilerplate/pkg/tracer"
+	"go-boilerplate/pkg/uuid"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	ErrNotAcquired        error = errors.New("lock is held by another owner")
+	ErrNotHeld            error = errors.New("lock is no longer held")
+	ErrUnsupportedClient  error = errors.New("redis client cannot run scripts")
+	ErrInvalidExpiration  error = errors.New("lock expiration must be positive")
+	errUnexpectedResponse error = errors.New("unexpected script response")
+)
+
+// acquireScript sets the lock only when it is free and increments the fence
+// in the same script, so every owner gets a greater token than the last one.
+// The fence never expires, it has to outlive every lock.
+const acquireScript string = `
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("INCR", KEYS[2])
+end
+return 0
+`
+
+const renewScript string = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`
+
+const releaseScript string = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`
+
+type Options struct {
+	// Expiration is how long the lock outlives its owner.
+	Expiration time.Duration
+	// RenewInterval defaults to a third of Expiration, below 0 turns the
+	// renewal off.
+	RenewInterval time.Duration
+}
+
+type Locker struct {
+	client in_memory_database.IRedisScriptClient
+}
+
+func NewLocker(inMemoryDatabase *in_memory_database.InMemoryDatabase) (*Locker, error) {
+	var (
+		client in_memory_database.IRedisScriptClient
+		ok     bool
+	)
+
+	client, ok = inMemoryDatabase.RedisClient.(in_memory_database.IRedisScriptClient)
+	if !ok {
+		return nil, ErrUnsupportedClient
+	}
+
+	return &Locker{
+		client: client,
+	}, nil
+}
+
+// keys wraps the key in a hash tag, so the lock and its fence share a cluster
+// slot and one script can touch both.
+func keys(key string) []string {
+	return []string{
+		fmt.Sprintf("lock:{%s}", key),
+		fmt.Sprintf("lock:{%s}:fence", key),
+	}
+}
+
+func (l *Locker) eval(ctx context.Context, script string, key string, args ...interface{}) (int64, error) {
+	var (
+		result interface{}
+		value  int64
+		ok     bool
+		err    error
+	)
+
+	result, err = l.client.Eval(ctx, script, keys(key), args...).
+		Result()
+	if err != nil {
+		return 0, err
+	}
+
+	value, ok = result.(int64)
+	if !ok {
+		return 0, fmt.Errorf("%w: %v", errUnexpectedResponse, result)
+	}
+
+	return value, nil
+}
+
+// Acquire returns ErrNotAcquired when another owner holds the key. The lock is
+// renewed in the background until it is released or lost.
+func (l *Locker) Acquire(ctx context.Context, key string, opts *Options) (*Lock, error) {
+	var (
+		span          trace.Span
+		lock          *Lock
+		token         string = uuid.NewV7().String()
+		renewInterval time.Duration
+		acquiredAt    time.Time
+		fence         int64
+		err           error
+	)
+
+	ctx, span = tracer.Start(ctx, "[Locker][Acquire]")
+	defer span.End()
+
+	if opts == nil || opts.Expiration <= 0 {
+		tracer.RecordError(span, ErrInvalidExpiration)
+		return nil, ErrInvalidExpiration
+	}
+
+	// the expiration runs from before the script, the earliest Redis may
+	// have started it
+	acquiredAt = time.Now()
+
+	fence, err = l.eval(ctx, acquireScript, key, token, opts.Expiration.Milliseconds())
+	if err != nil {
+		tracer.RecordError(span, err)
+		return nil, err
+	}
+
+	if fence == 0 {
+		tracer.RecordError(span, ErrNotAcquired)
+		return nil, ErrNotAcquired
+	}
+
+	lock = &Lock{
+		locker:     l,
+		key:        key,
+		token:      token,
+		fence:      uint64(fence),
+		expiration: opts.Expiration,
+		lost:       make(chan struct{}),
+		stop:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	lock.heldUntil.Store(acquiredAt.Add(opts.Expiration).UnixNano())
+
+	renewInterval = opts.RenewInterval
+	if renewInterval == 0 {
+		renewInterval = opts.Expiration / 3
+	}
+
+	if renewInterval > 0 {
+		go lock.renewLoop(renewInterval)
+	} else {
+		close(lock.stopped)
+	}
+
+	return lock, nil
+}
+
+type Lock struct {
+	locker     *Locker
+	key        string
+	token      string
+	fence      uint64
+	expiration time.Duration
+
+	// heldUntil is when the lock expires unless renewed, in Unix nanoseconds,
+	// as of the last successful acquisition or renewal
+	heldUntil atomic.Int64
+
+	lostOnce sync.Once
+	lost     chan struct{}
+	stopOnce sync.Once
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Fence grows with every acquisition of the key. Pass it along with the
+// writes the lock guards, so the store can reject a write from an owner that
+// lost the lock to a newer one.
+func (l *Lock) Fence() uint64 {
+	return l.fence
+}
+
+// Lost is closed once the renewal finds the lock taken, or its expiration
+// passes without a successful renewal.
+func (l *Lock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+func (l *Lock) markLost() {
+	l.lostOnce.Do(func() {
+		close(l.lost)
+	})
+}
+
+func (l *Lock) expired() bool {
+	return !time.Now().Before(time.Unix(0, l.heldUntil.Load()))
+}
+
+func (l *Lock) renewLoop(interval time.Duration) {
+	var (
+		ticker *time.Ticker = time.NewTicker(interval)
+		ctx    context.Context
+		cancel context.CancelFunc
+		err    error
+	)
+
+	defer close(l.stopped)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			// a renewal finishing after the expiration cannot keep the lock
+			ctx, cancel = context.WithDeadline(context.Background(), time.Unix(0, l.heldUntil.Load()))
+			err = l.Renew(ctx)
+			cancel()
+			if errors.Is(err, ErrNotHeld) {
+				return
+			}
+			if err != nil && l.expired() {
+				l.markLost()
+				log.Warn().
+					Err(err).
+					Str("key", l.key).
+					Msg("[Lock][renewLoop][Renew] lock expired without a successful renewal")
+				return
+			}
+			// a transient error is retried on the next tick, the lock is still
+			// held until its expiration
+			if err != nil {
+				log.Warn().
+					Err(err).
+					Str("key", l.key).
+					Msg("[Lock][renewLoop][Renew] failed to renew lock")
+			}
+		}
+	}
+}
+
+// Renew extends the lock by its expiration, it returns ErrNotHeld when the
+// lock expired or another owner took it.
+func (l *Lock) Renew(ctx context.Context) error {
+	var (
+		span      trace.Span
+		renewedAt time.Time
+		renewed   int64
+		err       error
+	)
+
+	ctx, span = tracer.Start(ctx, "[Lock][Renew]")
+	defer span.End()
+
+	renewedAt = time.Now()
+
+	renewed, err = l.locker.eval(ctx, renewScript, l.key, l.token, l.expiration.Milliseconds())
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	if renewed == 0 {
+		l.markLost()
+		tracer.RecordError(span, ErrNotHeld)
+		return ErrNotHeld
+	}
+
+	l.heldUntil.Store(renewedAt.Add(l.expiration).UnixNano())
+
+	return nil
+}
+
+// Release stops the renewal and deletes the lock if it is still ours, it
+// returns ErrNotHeld otherwise.
+func (l *Lock) Release(ctx context.Context) error {
+	var (
+		span     trace.Span
+		released int64
+		err      error
+	)
+
+	ctx, span = tracer.Start(ctx, "[Lock][Release]")
+	defer span.End()
+
+	l.stopOnce.Do(func() {
+		close(l.stop)
+	})
+	<-l.stopped
+
+	released, err = l.locker.eval(ctx, releaseScript, l.key, l.token)
+	if err != nil {
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	if released == 0 {
+		l.markLost()
+		tracer.RecordError(span, ErrNotHeld)
+		return ErrNotHeld
+	}
+
+	return nil
+}
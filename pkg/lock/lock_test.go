@@ -0,0 +1,262 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"go-boilerplate/datasources/in_memory_database"
+	"go-boilerplate/datasources/in_memory_database/mocks"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type scriptingRedisClient struct {
+	*mocks.RedisClientMock
+	*mocks.RedisScriptClientMock
+}
+
+var lockKeys []string = []string{"lock:{job}", "lock:{job}:fence"}
+
+func newTestLocker(t *testing.T) (*Locker, *mocks.RedisScriptClientMock) {
+	client := mocks.NewRedisScriptClientMock(t)
+	return &Locker{client: client}, client
+}
+
+func Test_NewLocker(t *testing.T) {
+	tests := []struct {
+		name        string
+		redisClient in_memory_database.IRedisClient
+		expectedErr error
+	}{
+		{
+			name: "client runs scripts",
+			redisClient: &scriptingRedisClient{
+				RedisClientMock:       mocks.NewRedisClientMock(t),
+				RedisScriptClientMock: mocks.NewRedisScriptClientMock(t),
+			},
+		},
+		{
+			name:        "client cannot run scripts",
+			redisClient: mocks.NewRedisClientMock(t),
+			expectedErr: ErrUnsupportedClient,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locker, err := NewLocker(&in_memory_database.InMemoryDatabase{RedisClient: tt.redisClient})
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedErr == nil, locker != nil)
+		})
+	}
+}
+
+func Test_Locker_Acquire(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          *Options
+		setupMock     func(m *mocks.RedisScriptClientMock)
+		expectedErr   error
+		expectedFence uint64
+	}{
+		{
+			name: "acquire free lock",
+			opts: &Options{Expiration: 3 * time.Second, RenewInterval: -1},
+			setupMock: func(m *mocks.RedisScriptClientMock) {
+				m.On("Eval", mock.Anything, acquireScript, lockKeys, mock.MatchedBy(func(args []interface{}) bool {
+					return len(args) == 2 && args[0] != "" && args[1] == int64(3000)
+				})).Return(redis.NewCmdResult(int64(7), nil))
+			},
+			expectedFence: 7,
+		},
+		{
+			name: "lock held by another owner",
+			opts: &Options{Expiration: 3 * time.Second},
+			setupMock: func(m *mocks.RedisScriptClientMock) {
+				m.On("Eval", mock.Anything, acquireScript, lockKeys, mock.Anything).Return(redis.NewCmdResult(int64(0), nil))
+			},
+			expectedErr: ErrNotAcquired,
+		},
+		{
+			name: "redis error",
+			opts: &Options{Expiration: 3 * time.Second},
+			setupMock: func(m *mocks.RedisScriptClientMock) {
+				m.On("Eval", mock.Anything, acquireScript, lockKeys, mock.Anything).Return(redis.NewCmdResult(nil, redis.ErrClosed))
+			},
+			expectedErr: redis.ErrClosed,
+		},
+		{
+			name: "unexpected response",
+			opts: &Options{Expiration: 3 * time.Second},
+			setupMock: func(m *mocks.RedisScriptClientMock) {
+				m.On("Eval", mock.Anything, acquireScript, lockKeys, mock.Anything).Return(redis.NewCmdResult("OK", nil))
+			},
+			expectedErr: errUnexpectedResponse,
+		},
+		{
+			name:        "missing expiration",
+			opts:        &Options{},
+			setupMock:   func(m *mocks.RedisScriptClientMock) {},
+			expectedErr: ErrInvalidExpiration,
+		},
+		{
+			name:        "missing options",
+			setupMock:   func(m *mocks.RedisScriptClientMock) {},
+			expectedErr: ErrInvalidExpiration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locker, client := newTestLocker(t)
+			tt.setupMock(client)
+
+			lock, err := locker.Acquire(context.Background(), "job", tt.opts)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				assert.Nil(t, lock)
+				return
+			}
+			assert.Equal(t, "job", lock.Key())
+			assert.Equal(t, tt.expectedFence, lock.Fence())
+		})
+	}
+}
+
+func Test_Lock_Renew(t *testing.T) {
+	tests := []struct {
+		name         string
+		result       *redis.Cmd
+		expectedErr  error
+		expectedLost bool
+	}{
+		{
+			name:   "renew held lock",
+			result: redis.NewCmdResult(int64(1), nil),
+		},
+		{
+			name:         "lock taken or expired",
+			result:       redis.NewCmdResult(int64(0), nil),
+			expectedErr:  ErrNotHeld,
+			expectedLost: true,
+		},
+		{
+			name:        "redis error keeps the lock",
+			result:      redis.NewCmdResult(nil, redis.ErrClosed),
+			expectedErr: redis.ErrClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locker, client := newTestLocker(t)
+			client.On("Eval", mock.Anything, renewScript, lockKeys, []interface{}{"token", int64(3000)}).Return(tt.result)
+			lock := &Lock{locker: locker, key: "job", token: "token", expiration: 3 * time.Second, lost: make(chan struct{})}
+
+			err := lock.Renew(context.Background())
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			select {
+			case <-lock.Lost():
+				assert.True(t, tt.expectedLost, "lock should not be lost")
+			default:
+				assert.False(t, tt.expectedLost, "lock should be lost")
+			}
+		})
+	}
+}
+
+func Test_Lock_Release(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      *redis.Cmd
+		expectedErr error
+	}{
+		{
+			name:   "release held lock",
+			result: redis.NewCmdResult(int64(1), nil),
+		},
+		{
+			name:        "lock taken by another owner",
+			result:      redis.NewCmdResult(int64(0), nil),
+			expectedErr: ErrNotHeld,
+		},
+		{
+			name:        "redis error",
+			result:      redis.NewCmdResult(nil, redis.ErrClosed),
+			expectedErr: redis.ErrClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locker, client := newTestLocker(t)
+			client.On("Eval", mock.Anything, acquireScript, lockKeys, mock.Anything).Return(redis.NewCmdResult(int64(1), nil))
+			client.On("Eval", mock.Anything, releaseScript, lockKeys, mock.MatchedBy(func(args []interface{}) bool {
+				return len(args) == 1
+			})).Return(tt.result)
+			lock, err := locker.Acquire(context.Background(), "job", &Options{Expiration: time.Minute})
+			assert.NoError(t, err)
+
+			err = lock.Release(context.Background())
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func Test_Lock_renewLoop(t *testing.T) {
+	locker, client := newTestLocker(t)
+	client.On("Eval", mock.Anything, acquireScript, lockKeys, mock.Anything).Return(redis.NewCmdResult(int64(1), nil))
+	client.On("Eval", mock.Anything, renewScript, lockKeys, mock.Anything).Return(redis.NewCmdResult(nil, errors.New("timeout"))).Once()
+	client.On("Eval", mock.Anything, renewScript, lockKeys, mock.Anything).Return(redis.NewCmdResult(int64(1), nil)).Once()
+	client.On("Eval", mock.Anything, renewScript, lockKeys, mock.Anything).Return(redis.NewCmdResult(int64(0), nil)).Once()
+
+	lock, err := locker.Acquire(context.Background(), "job", &Options{Expiration: time.Second, RenewInterval: 10 * time.Millisecond})
+	assert.NoError(t, err)
+
+	select {
+	case <-lock.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("lock should be lost once the renewal finds it taken")
+	}
+
+	// the renewal already stopped, so the release only checks the owner
+	client.On("Eval", mock.Anything, releaseScript, lockKeys, mock.Anything).Return(redis.NewCmdResult(int64(0), nil))
+	assert.ErrorIs(t, lock.Release(context.Background()), ErrNotHeld)
+}
+
+func Test_Lock_renewLoop_expired(t *testing.T) {
+	locker, client := newTestLocker(t)
+	client.On("Eval", mock.Anything, acquireScript, lockKeys, mock.Anything).Return(redis.NewCmdResult(int64(1), nil))
+	client.On("Eval", mock.Anything, renewScript, lockKeys, mock.Anything).Return(redis.NewCmdResult(nil, errors.New("timeout")))
+
+	lock, err := locker.Acquire(context.Background(), "job", &Options{Expiration: 50 * time.Millisecond, RenewInterval: 10 * time.Millisecond})
+	assert.NoError(t, err)
+
+	select {
+	case <-lock.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("lock should be lost once its expiration passes without a renewal")
+	}
+	assert.True(t, lock.expired())
+
+	client.On("Eval", mock.Anything, releaseScript, lockKeys, mock.Anything).Return(redis.NewCmdResult(int64(0), nil))
+	assert.ErrorIs(t, lock.Release(context.Background()), ErrNotHeld)
+}
+
+func Test_Lock_Renew_extendsExpiration(t *testing.T) {
+	locker, client := newTestLocker(t)
+	client.On("Eval", mock.Anything, renewScript, lockKeys, mock.Anything).Return(redis.NewCmdResult(int64(1), nil))
+	lock := &Lock{locker: locker, key: "job", token: "token", expiration: time.Minute, lost: make(chan struct{})}
+	lock.heldUntil.Store(time.Now().UnixNano())
+
+	assert.NoError(t, lock.Renew(context.Background()))
+
+	assert.False(t, lock.expired(), "a renewal should push the expiration forward")
+}
@@ -0,0 +1,291 @@
+package logger
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	ComponentRepository    string = "repository"
+	ComponentService       string = "service"
+	ComponentHTTP          string = "http"
+	ComponentGRPC          string = "grpc"
+	ComponentEventConsumer string = "event_consumer"
+	ComponentCLI           string = "cli"
+	ComponentDatasource    string = "datasource"
+)
+
+type componentPackage struct {
+	path      string
+	component string
+}
+
+var componentPackages []componentPackage = []componentPackage{
+	{path: "go-boilerplate/internal/repositories", component: ComponentRepository},
+	{path: "go-boilerplate/internal/services", component: ComponentService},
+	{path: "go-boilerplate/transports/http", component: ComponentHTTP},
+	{path: "go-boilerplate/transports/grpc", component: ComponentGRPC},
+	{path: "go-boilerplate/transports/event_consumer", component: ComponentEventConsumer},
+	{path: "go-boilerplate/transports/cli", component: ComponentCLI},
+	{path: "go-boilerplate/datasources", component: ComponentDatasource},
+}
+
+// Levels holds the base level and the per component overrides. The zerolog
+// global level is kept at the lowest of them so the ComponentLevelHook can
+// decide per event.
+type Levels struct {
+	mutex      sync.RWMutex
+	base       zerolog.Level
+	components map[string]zerolog.Level
+}
+
+func NewLevels(base zerolog.Level) *Levels {
+	var levels *Levels = &Levels{
+		base:       base,
+		components: map[string]zerolog.Level{},
+	}
+
+	levels.apply()
+
+	return levels
+}
+
+func isComponent(component string) bool {
+	for i := range componentPackages {
+		if componentPackages[i].component == component {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseComponentLevels parses entries formatted as component=level, e.g.
+// repository=warn.
+func ParseComponentLevels(entries []string) (map[string]zerolog.Level, error) {
+	var (
+		componentLevels map[string]zerolog.Level = map[string]zerolog.Level{}
+		component       string
+		rawLevel        string
+		level           zerolog.Level
+		found           bool
+		err             error
+	)
+
+	for i := range entries {
+		if strings.TrimSpace(entries[i]) == "" {
+			continue
+		}
+
+		component, rawLevel, found = strings.Cut(entries[i], "=")
+		if !found {
+			return nil, fmt.Errorf("invalid component level %q, expected component=level", entries[i])
+		}
+
+		component = strings.TrimSpace(component)
+		if !isComponent(component) {
+			return nil, fmt.Errorf("unknown log component %q", component)
+		}
+
+		level, err = zerolog.ParseLevel(strings.TrimSpace(rawLevel))
+		if err != nil {
+			return nil, fmt.Errorf("invalid level for component %q: %w", component, err)
+		}
+
+		componentLevels[component] = level
+	}
+
+	return componentLevels, nil
+}
+
+func (l *Levels) apply() {
+	var minimum zerolog.Level = l.base
+
+	for _, level := range l.components {
+		if level < minimum {
+			minimum = level
+		}
+	}
+
+	zerolog.SetGlobalLevel(minimum)
+}
+
+// SetLevel changes the level of a component, an empty component changes the
+// base level.
+func (l *Levels) SetLevel(component string, level zerolog.Level) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if component == "" {
+		l.base = level
+		l.apply()
+		return nil
+	}
+
+	if !isComponent(component) {
+		return fmt.Errorf("unknown log component %q", component)
+	}
+
+	l.components[component] = level
+	l.apply()
+
+	return nil
+}
+
+// ResetLevel removes the override of a component so it follows the base level
+// again.
+func (l *Levels) ResetLevel(component string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !isComponent(component) {
+		return fmt.Errorf("unknown log component %q", component)
+	}
+
+	delete(l.components, component)
+	l.apply()
+
+	return nil
+}
+
+// Replace sets the base level and swaps every component override at once,
+// components missing from components follow the base level again.
+func (l *Levels) Replace(base zerolog.Level, components map[string]zerolog.Level) error {
+	var replaced map[string]zerolog.Level = make(map[string]zerolog.Level, len(components))
+
+	for component, level := range components {
+		if !isComponent(component) {
+			return fmt.Errorf("unknown log component %q", component)
+		}
+
+		replaced[component] = level
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.base = base
+	l.components = replaced
+	l.apply()
+
+	return nil
+}
+
+func (l *Levels) Base() zerolog.Level {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.base
+}
+
+func (l *Levels) Components() map[string]zerolog.Level {
+	var components map[string]zerolog.Level
+
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	components = make(map[string]zerolog.Level, len(l.components))
+	for component, level := range l.components {
+		components[component] = level
+	}
+
+	return components
+}
+
+func (l *Levels) enabled(component string, level zerolog.Level) bool {
+	var (
+		threshold zerolog.Level
+		ok        bool
+	)
+
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	threshold, ok = l.components[component]
+	if !ok {
+		threshold = l.base
+	}
+
+	return level >= threshold
+}
+
+func (l *Levels) hasOverrides() bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return len(l.components) > 0
+}
+
+// callerComponent walks the stack past zerolog and this package and maps the
+// first remaining frame to a component.
+func callerComponent() string {
+	var (
+		pcs    [32]uintptr
+		frames *runtime.Frames
+		frame  runtime.Frame
+		more   bool
+	)
+
+	frames = runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	for {
+		frame, more = frames.Next()
+		if !strings.HasPrefix(frame.Function, "github.com/rs/zerolog") &&
+			!strings.HasPrefix(frame.Function, "go-boilerplate/pkg/logger.") {
+			return componentOf(frame.Function)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+func componentOf(function string) string {
+	var rest string
+
+	for i := range componentPackages {
+		if !strings.HasPrefix(function, componentPackages[i].path) {
+			continue
+		}
+
+		rest = strings.TrimPrefix(function, componentPackages[i].path)
+		if rest == "" || rest[0] == '.' || rest[0] == '/' {
+			return componentPackages[i].component
+		}
+	}
+
+	return ""
+}
+
+// ComponentLevelHook discards events below the level configured for the
+// component that emitted them.
+type ComponentLevelHook struct {
+	levels *Levels
+}
+
+func NewComponentLevelHook(levels *Levels) ComponentLevelHook {
+	return ComponentLevelHook{
+		levels: levels,
+	}
+}
+
+func (h ComponentLevelHook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
+	if h.levels == nil || level == zerolog.NoLevel {
+		return
+	}
+
+	if !h.levels.hasOverrides() {
+		if level < h.levels.Base() {
+			e.Discard()
+		}
+		return
+	}
+
+	if !h.levels.enabled(callerComponent(), level) {
+		e.Discard()
+	}
+}
@@ -0,0 +1,414 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseComponentLevels(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []string
+		expected map[string]zerolog.Level
+		hasError bool
+	}{
+		{
+			name:     "empty entries should return empty map",
+			entries:  nil,
+			expected: map[string]zerolog.Level{},
+		},
+		{
+			name:    "valid entries should be parsed",
+			entries: []string{"repository=warn", " http = info ", ""},
+			expected: map[string]zerolog.Level{
+				ComponentRepository: zerolog.WarnLevel,
+				ComponentHTTP:       zerolog.InfoLevel,
+			},
+		},
+		{
+			name:     "entry without separator should return error",
+			entries:  []string{"repository"},
+			hasError: true,
+		},
+		{
+			name:     "unknown component should return error",
+			entries:  []string{"unknown=warn"},
+			hasError: true,
+		},
+		{
+			name:     "invalid level should return error",
+			entries:  []string{"repository=loud"},
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseComponentLevels(tt.entries)
+
+			if tt.hasError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestLevels_SetLevel(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	tests := []struct {
+		name                string
+		component           string
+		level               zerolog.Level
+		hasError            bool
+		expectedBase        zerolog.Level
+		expectedComponents  map[string]zerolog.Level
+		expectedGlobalLevel zerolog.Level
+	}{
+		{
+			name:                "empty component should change base level",
+			component:           "",
+			level:               zerolog.ErrorLevel,
+			expectedBase:        zerolog.ErrorLevel,
+			expectedComponents:  map[string]zerolog.Level{},
+			expectedGlobalLevel: zerolog.ErrorLevel,
+		},
+		{
+			name:                "lower component level should lower global level",
+			component:           ComponentRepository,
+			level:               zerolog.DebugLevel,
+			expectedBase:        zerolog.InfoLevel,
+			expectedComponents:  map[string]zerolog.Level{ComponentRepository: zerolog.DebugLevel},
+			expectedGlobalLevel: zerolog.DebugLevel,
+		},
+		{
+			name:                "higher component level should keep global level",
+			component:           ComponentRepository,
+			level:               zerolog.WarnLevel,
+			expectedBase:        zerolog.InfoLevel,
+			expectedComponents:  map[string]zerolog.Level{ComponentRepository: zerolog.WarnLevel},
+			expectedGlobalLevel: zerolog.InfoLevel,
+		},
+		{
+			name:                "unknown component should return error",
+			component:           "unknown",
+			level:               zerolog.WarnLevel,
+			hasError:            true,
+			expectedBase:        zerolog.InfoLevel,
+			expectedComponents:  map[string]zerolog.Level{},
+			expectedGlobalLevel: zerolog.InfoLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels := NewLevels(zerolog.InfoLevel)
+
+			err := levels.SetLevel(tt.component, tt.level)
+
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedBase, levels.Base())
+			assert.Equal(t, tt.expectedComponents, levels.Components())
+			assert.Equal(t, tt.expectedGlobalLevel, zerolog.GlobalLevel())
+		})
+	}
+}
+
+func TestLevels_ResetLevel(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	tests := []struct {
+		name                string
+		component           string
+		hasError            bool
+		expectedComponents  map[string]zerolog.Level
+		expectedGlobalLevel zerolog.Level
+	}{
+		{
+			name:                "known component should remove override",
+			component:           ComponentRepository,
+			expectedComponents:  map[string]zerolog.Level{},
+			expectedGlobalLevel: zerolog.InfoLevel,
+		},
+		{
+			name:                "component without override should succeed",
+			component:           ComponentGRPC,
+			expectedComponents:  map[string]zerolog.Level{ComponentRepository: zerolog.DebugLevel},
+			expectedGlobalLevel: zerolog.DebugLevel,
+		},
+		{
+			name:                "unknown component should return error",
+			component:           "unknown",
+			hasError:            true,
+			expectedComponents:  map[string]zerolog.Level{ComponentRepository: zerolog.DebugLevel},
+			expectedGlobalLevel: zerolog.DebugLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels := NewLevels(zerolog.InfoLevel)
+			assert.NoError(t, levels.SetLevel(ComponentRepository, zerolog.DebugLevel))
+
+			err := levels.ResetLevel(tt.component)
+
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedComponents, levels.Components())
+			assert.Equal(t, tt.expectedGlobalLevel, zerolog.GlobalLevel())
+		})
+	}
+}
+
+func TestLevels_Replace(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	tests := []struct {
+		name                string
+		base                zerolog.Level
+		components          map[string]zerolog.Level
+		hasError            bool
+		expectedBase        zerolog.Level
+		expectedComponents  map[string]zerolog.Level
+		expectedGlobalLevel zerolog.Level
+	}{
+		{
+			name:                "overrides should be swapped",
+			base:                zerolog.WarnLevel,
+			components:          map[string]zerolog.Level{ComponentHTTP: zerolog.InfoLevel},
+			expectedBase:        zerolog.WarnLevel,
+			expectedComponents:  map[string]zerolog.Level{ComponentHTTP: zerolog.InfoLevel},
+			expectedGlobalLevel: zerolog.InfoLevel,
+		},
+		{
+			name:                "nil components should remove every override",
+			base:                zerolog.ErrorLevel,
+			components:          nil,
+			expectedBase:        zerolog.ErrorLevel,
+			expectedComponents:  map[string]zerolog.Level{},
+			expectedGlobalLevel: zerolog.ErrorLevel,
+		},
+		{
+			name:                "unknown component should return error and keep the levels",
+			base:                zerolog.ErrorLevel,
+			components:          map[string]zerolog.Level{"unknown": zerolog.InfoLevel},
+			hasError:            true,
+			expectedBase:        zerolog.InfoLevel,
+			expectedComponents:  map[string]zerolog.Level{ComponentRepository: zerolog.DebugLevel},
+			expectedGlobalLevel: zerolog.DebugLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels := NewLevels(zerolog.InfoLevel)
+			assert.NoError(t, levels.SetLevel(ComponentRepository, zerolog.DebugLevel))
+
+			err := levels.Replace(tt.base, tt.components)
+
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedBase, levels.Base())
+			assert.Equal(t, tt.expectedComponents, levels.Components())
+			assert.Equal(t, tt.expectedGlobalLevel, zerolog.GlobalLevel())
+		})
+	}
+}
+
+func TestLevels_enabled(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	levels := NewLevels(zerolog.InfoLevel)
+	assert.NoError(t, levels.SetLevel(ComponentRepository, zerolog.WarnLevel))
+	assert.NoError(t, levels.SetLevel(ComponentHTTP, zerolog.DebugLevel))
+
+	tests := []struct {
+		name      string
+		component string
+		level     zerolog.Level
+		expected  bool
+	}{
+		{
+			name:      "component below override should be disabled",
+			component: ComponentRepository,
+			level:     zerolog.InfoLevel,
+			expected:  false,
+		},
+		{
+			name:      "component at override should be enabled",
+			component: ComponentRepository,
+			level:     zerolog.WarnLevel,
+			expected:  true,
+		},
+		{
+			name:      "component override below base should be enabled",
+			component: ComponentHTTP,
+			level:     zerolog.DebugLevel,
+			expected:  true,
+		},
+		{
+			name:      "component without override should follow base",
+			component: ComponentService,
+			level:     zerolog.DebugLevel,
+			expected:  false,
+		},
+		{
+			name:      "unknown caller should follow base",
+			component: "",
+			level:     zerolog.InfoLevel,
+			expected:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, levels.enabled(tt.component, tt.level))
+		})
+	}
+}
+
+func TestComponentOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		function string
+		expected string
+	}{
+		{
+			name:     "repository method should map to repository",
+			function: "go-boilerplate/internal/repositories.(*BoilerplateDatabaseRepository[...]).Create",
+			expected: ComponentRepository,
+		},
+		{
+			name:     "service method should map to service",
+			function: "go-boilerplate/internal/services.(*GuestService).Create",
+			expected: ComponentService,
+		},
+		{
+			name:     "http sub package should map to http",
+			function: "go-boilerplate/transports/http/middlewares.(*LogMiddleware).Log",
+			expected: ComponentHTTP,
+		},
+		{
+			name:     "event consumer should map to event_consumer",
+			function: "go-boilerplate/transports/event_consumer/consumers.(*GuestConsumer).Consume",
+			expected: ComponentEventConsumer,
+		},
+		{
+			name:     "datasource sub package should map to datasource",
+			function: "go-boilerplate/datasources/boilerplate_database.NewBoilerplateDatabase",
+			expected: ComponentDatasource,
+		},
+		{
+			name:     "package sharing a prefix should not match",
+			function: "go-boilerplate/transports/httpx.Handle",
+			expected: "",
+		},
+		{
+			name:     "unknown package should map to empty component",
+			function: "main.main",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, componentOf(tt.function))
+		})
+	}
+}
+
+func TestComponentLevelHook_Run(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	tests := []struct {
+		name          string
+		setupLevels   func(t *testing.T) *Levels
+		level         zerolog.Level
+		expectWritten bool
+	}{
+		{
+			name: "nil levels should write every event",
+			setupLevels: func(t *testing.T) *Levels {
+				return nil
+			},
+			level:         zerolog.DebugLevel,
+			expectWritten: true,
+		},
+		{
+			name: "event below base level should be discarded",
+			setupLevels: func(t *testing.T) *Levels {
+				return NewLevels(zerolog.InfoLevel)
+			},
+			level:         zerolog.DebugLevel,
+			expectWritten: false,
+		},
+		{
+			name: "event at base level should be written",
+			setupLevels: func(t *testing.T) *Levels {
+				return NewLevels(zerolog.InfoLevel)
+			},
+			level:         zerolog.InfoLevel,
+			expectWritten: true,
+		},
+		{
+			name: "event from unknown component below base should be discarded with overrides",
+			setupLevels: func(t *testing.T) *Levels {
+				levels := NewLevels(zerolog.InfoLevel)
+				assert.NoError(t, levels.SetLevel(ComponentRepository, zerolog.DebugLevel))
+				return levels
+			},
+			level:         zerolog.DebugLevel,
+			expectWritten: false,
+		},
+		{
+			name: "event from unknown component at base should be written with overrides",
+			setupLevels: func(t *testing.T) *Levels {
+				levels := NewLevels(zerolog.InfoLevel)
+				assert.NoError(t, levels.SetLevel(ComponentRepository, zerolog.DebugLevel))
+				return levels
+			},
+			level:         zerolog.WarnLevel,
+			expectWritten: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			levels := tt.setupLevels(t)
+			zerolog.SetGlobalLevel(zerolog.TraceLevel)
+			logger := zerolog.New(&buf).Hook(NewComponentLevelHook(levels))
+
+			logger.WithLevel(tt.level).Msg("test message")
+
+			if tt.expectWritten {
+				assert.Contains(t, buf.String(), "test message")
+			} else {
+				assert.Empty(t, buf.String())
+			}
+		})
+	}
+}
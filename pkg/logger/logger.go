@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"fmt"
+	"go-boilerplate/pkg/redactor"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	FormatConsole string = "console"
+	FormatJSON    string = "json"
+)
+
+type FileConfig struct {
+	Enable     bool
+	Path       string
+	MaxSizeMB  int
+	MaxAgeDays int
+	MaxBackups int
+	Compress   bool
+}
+
+type Config struct {
+	Level           int8
+	Format          string
+	File            FileConfig
+	ComponentLevels []string
+}
+
+var globalLevels *Levels
+
+func init() {
+	globalLevels = &Levels{
+		base:       zerolog.GlobalLevel(),
+		components: map[string]zerolog.Level{},
+	}
+}
+
+func newConsoleWriter(out io.Writer) io.Writer {
+	return zerolog.ConsoleWriter{
+		Out:        out,
+		TimeFormat: time.RFC3339,
+	}
+}
+
+// newWriter builds the output of the logger. The file sink always writes JSON
+// so rotated files stay parseable whatever the stderr format is.
+func newWriter(cfg *Config, stderr io.Writer) (io.Writer, error) {
+	var writer io.Writer
+
+	switch cfg.Format {
+	case "", FormatConsole:
+		writer = newConsoleWriter(stderr)
+	case FormatJSON:
+		writer = stderr
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	if !cfg.File.Enable {
+		return writer, nil
+	}
+
+	if cfg.File.Path == "" {
+		return nil, fmt.Errorf("log file path is required when log file is enabled")
+	}
+
+	return zerolog.MultiLevelWriter(
+		writer,
+		&lumberjack.Logger{
+			Filename:   cfg.File.Path,
+			MaxSize:    cfg.File.MaxSizeMB,
+			MaxAge:     cfg.File.MaxAgeDays,
+			MaxBackups: cfg.File.MaxBackups,
+			Compress:   cfg.File.Compress,
+			LocalTime:  true,
+		},
+	), nil
+}
+
+// New builds a logger and its levels without touching the global logger.
+func New(cfg *Config, stderr io.Writer) (zerolog.Logger, *Levels, error) {
+	var (
+		writer          io.Writer
+		componentLevels map[string]zerolog.Level
+		levels          *Levels
+		err             error
+	)
+
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	writer, err = newWriter(cfg, stderr)
+	if err != nil {
+		return zerolog.Logger{}, nil, err
+	}
+
+	componentLevels, err = ParseComponentLevels(cfg.ComponentLevels)
+	if err != nil {
+		return zerolog.Logger{}, nil, err
+	}
+
+	levels = NewLevels(zerolog.Level(cfg.Level))
+	for component, level := range componentLevels {
+		err = levels.SetLevel(component, level)
+		if err != nil {
+			return zerolog.Logger{}, nil, err
+		}
+	}
+
+	return zerolog.New(redactor.NewWriter(writer)).
+			With().
+			Timestamp().
+			Logger().
+			Hook(NewComponentLevelHook(levels)).
+			Hook(NewContextHook()),
+		levels,
+		nil
+}
+
+// NewLogger replaces the global logger, it panics on invalid config.
+func NewLogger(cfg *Config) {
+	var (
+		logger zerolog.Logger
+		levels *Levels
+		err    error
+	)
+
+	logger, levels, err = New(cfg, os.Stderr)
+	if err != nil {
+		panic(err)
+	}
+
+	log.Logger = logger
+	globalLevels = levels
+}
+
+func GetLevels() *Levels {
+	return globalLevels
+}
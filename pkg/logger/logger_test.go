@@ -0,0 +1,242 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"go-boilerplate/pkg/redactor"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	tests := []struct {
+		name     string
+		setupCfg func(t *testing.T) *Config
+		hasError bool
+		validate func(t *testing.T, cfg *Config, stderr *bytes.Buffer, levels *Levels)
+	}{
+		{
+			name: "nil config should default to console output",
+			setupCfg: func(t *testing.T) *Config {
+				return nil
+			},
+			validate: func(t *testing.T, cfg *Config, stderr *bytes.Buffer, levels *Levels) {
+				assert.Equal(t, zerolog.DebugLevel, levels.Base())
+				assert.Contains(t, stderr.String(), "test message")
+				assert.False(t, json.Valid(stderr.Bytes()), "console output should not be JSON")
+			},
+		},
+		{
+			name: "json format should write JSON to stderr",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{
+					Level:  int8(zerolog.InfoLevel),
+					Format: FormatJSON,
+				}
+			},
+			validate: func(t *testing.T, cfg *Config, stderr *bytes.Buffer, levels *Levels) {
+				var output map[string]interface{}
+				assert.NoError(t, json.Unmarshal(stderr.Bytes(), &output))
+				assert.Equal(t, "test message", output["message"])
+				assert.Equal(t, "info", output["level"])
+				assert.Contains(t, output, "time")
+			},
+		},
+		{
+			name: "level above event should discard it",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{
+					Level:  int8(zerolog.ErrorLevel),
+					Format: FormatJSON,
+				}
+			},
+			validate: func(t *testing.T, cfg *Config, stderr *bytes.Buffer, levels *Levels) {
+				assert.Empty(t, stderr.String())
+			},
+		},
+		{
+			name: "file sink should write JSON to the file",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{
+					Level:  int8(zerolog.InfoLevel),
+					Format: FormatConsole,
+					File: FileConfig{
+						Enable:     true,
+						Path:       filepath.Join(t.TempDir(), "logs", "test.log"),
+						MaxSizeMB:  1,
+						MaxAgeDays: 1,
+						MaxBackups: 1,
+					},
+				}
+			},
+			validate: func(t *testing.T, cfg *Config, stderr *bytes.Buffer, levels *Levels) {
+				content, err := os.ReadFile(cfg.File.Path)
+				assert.NoError(t, err)
+
+				var output map[string]interface{}
+				assert.NoError(t, json.Unmarshal(content, &output))
+				assert.Equal(t, "test message", output["message"])
+				assert.Contains(t, stderr.String(), "test message")
+			},
+		},
+		{
+			name: "component levels should be applied",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{
+					Level:           int8(zerolog.InfoLevel),
+					Format:          FormatJSON,
+					ComponentLevels: []string{"repository=warn", "http=debug"},
+				}
+			},
+			validate: func(t *testing.T, cfg *Config, stderr *bytes.Buffer, levels *Levels) {
+				assert.Equal(t, map[string]zerolog.Level{
+					ComponentRepository: zerolog.WarnLevel,
+					ComponentHTTP:       zerolog.DebugLevel,
+				}, levels.Components())
+				assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+			},
+		},
+		{
+			name: "unknown format should return error",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{Format: "xml"}
+			},
+			hasError: true,
+		},
+		{
+			name: "enabled file without path should return error",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{
+					File: FileConfig{Enable: true},
+				}
+			},
+			hasError: true,
+		},
+		{
+			name: "invalid component level should return error",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{ComponentLevels: []string{"repository"}}
+			},
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			cfg := tt.setupCfg(t)
+
+			logger, levels, err := New(cfg, &stderr)
+
+			if tt.hasError {
+				assert.Error(t, err)
+				assert.Nil(t, levels)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, levels)
+
+			logger.Info().Msg("test message")
+
+			if tt.validate != nil {
+				tt.validate(t, cfg, &stderr, levels)
+			}
+		})
+	}
+}
+
+func TestNew_Redaction(t *testing.T) {
+	var stderr bytes.Buffer
+	path := filepath.Join(t.TempDir(), "test.log")
+
+	redactor.NewRedactor(&redactor.Config{Fields: []string{"$..address"}})
+	t.Cleanup(func() {
+		redactor.NewRedactor(nil)
+	})
+
+	logger, _, err := New(&Config{
+		Level:  int8(zerolog.InfoLevel),
+		Format: FormatConsole,
+		File:   FileConfig{Enable: true, Path: path},
+	}, &stderr)
+	assert.NoError(t, err)
+
+	logger.Info().
+		Fields(map[string]interface{}{"entity": map[string]string{"address": "123 Main St"}}).
+		Str("address", "456 Oak Ave").
+		Msg("test message")
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	for _, output := range []string{stderr.String(), string(content)} {
+		assert.Contains(t, output, "test message")
+		assert.Contains(t, output, redactor.RedactedValue)
+		assert.NotContains(t, output, "123 Main St")
+		assert.NotContains(t, output, "456 Oak Ave")
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	originalLogger := log.Logger
+	originalLevels := globalLevels
+	originalLevel := zerolog.GlobalLevel()
+	defer func() {
+		log.Logger = originalLogger
+		globalLevels = originalLevels
+		zerolog.SetGlobalLevel(originalLevel)
+	}()
+
+	tests := []struct {
+		name        string
+		cfg         *Config
+		shouldPanic bool
+		validate    func(t *testing.T)
+	}{
+		{
+			name: "valid config should replace global levels",
+			cfg: &Config{
+				Level:           int8(zerolog.WarnLevel),
+				Format:          FormatJSON,
+				ComponentLevels: []string{"service=error"},
+			},
+			validate: func(t *testing.T) {
+				assert.Equal(t, zerolog.WarnLevel, GetLevels().Base())
+				assert.Equal(t, map[string]zerolog.Level{ComponentService: zerolog.ErrorLevel}, GetLevels().Components())
+			},
+		},
+		{
+			name:        "invalid config should panic",
+			cfg:         &Config{Format: "xml"},
+			shouldPanic: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.shouldPanic {
+				assert.Panics(t, func() {
+					NewLogger(tt.cfg)
+				})
+				return
+			}
+
+			assert.NotPanics(t, func() {
+				NewLogger(tt.cfg)
+			})
+
+			if tt.validate != nil {
+				tt.validate(t)
+			}
+		})
+	}
+}
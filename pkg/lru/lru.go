@@ -0,0 +1,190 @@
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Len       int
+}
+
+type entry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// Cache keeps at most capacity entries, evicting the least recently used one
+// first, and expires every entry ttl after it was set. It is safe for
+// concurrent use.
+type Cache[V any] struct {
+	mutex     sync.Mutex
+	capacity  int
+	ttl       time.Duration
+	items     map[string]*list.Element
+	order     *list.List
+	hits      uint64
+	misses    uint64
+	evictions uint64
+
+	// now is swapped in the tests
+	now func() time.Time
+}
+
+// New creates a cache, a capacity below 1 is unbounded and a ttl below 1 never
+// expires.
+func New[V any](capacity int, ttl time.Duration) *Cache[V] {
+	return &Cache[V]{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+func (c *Cache[V]) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*entry[V]).key)
+}
+
+func (c *Cache[V]) Get(key string) (V, bool) {
+	var (
+		element *list.Element
+		item    *entry[V]
+		zero    V
+		ok      bool
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok = c.items[key]
+	if !ok {
+		c.misses++
+		return zero, false
+	}
+
+	item = element.Value.(*entry[V])
+	if !item.expiresAt.IsZero() && !c.now().Before(item.expiresAt) {
+		c.removeElement(element)
+		c.misses++
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+	c.hits++
+
+	return item.value, true
+}
+
+// Peek returns the entry at key like Get, without counting a hit or a miss or
+// marking it as used.
+func (c *Cache[V]) Peek(key string) (V, bool) {
+	var (
+		element *list.Element
+		item    *entry[V]
+		zero    V
+		ok      bool
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok = c.items[key]
+	if !ok {
+		return zero, false
+	}
+
+	item = element.Value.(*entry[V])
+	if !item.expiresAt.IsZero() && !c.now().Before(item.expiresAt) {
+		return zero, false
+	}
+
+	return item.value, true
+}
+
+func (c *Cache[V]) Set(key string, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL sets an entry that expires after ttl instead of the cache ttl, a
+// ttl below 1 never expires.
+func (c *Cache[V]) SetWithTTL(key string, value V, ttl time.Duration) {
+	var (
+		element   *list.Element
+		expiresAt time.Time
+		ok        bool
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+
+	element, ok = c.items[key]
+	if ok {
+		element.Value = &entry[V]{key: key, value: value, expiresAt: expiresAt}
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry[V]{key: key, value: value, expiresAt: expiresAt})
+
+	if c.capacity > 0 && c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+		c.evictions++
+	}
+}
+
+func (c *Cache[V]) Delete(keys ...string) {
+	var (
+		element *list.Element
+		ok      bool
+	)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, key := range keys {
+		element, ok = c.items[key]
+		if ok {
+			c.removeElement(element)
+		}
+	}
+}
+
+// Purge removes every entry, the stats are kept.
+func (c *Cache[V]) Purge() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+func (c *Cache[V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *Cache[V]) Stats() Stats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return Stats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Len:       c.order.Len(),
+	}
+}
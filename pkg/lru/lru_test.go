@@ -0,0 +1,214 @@
+package lru
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCache(capacity int, ttl time.Duration, now *time.Time) *Cache[string] {
+	cache := New[string](capacity, ttl)
+	cache.now = func() time.Time {
+		return *now
+	}
+	return cache
+}
+
+func TestCache_Get(t *testing.T) {
+	tests := []struct {
+		name          string
+		ttl           time.Duration
+		setup         func(cache *Cache[string], now *time.Time)
+		key           string
+		expectedValue string
+		expectedOK    bool
+	}{
+		{
+			name: "return value set before",
+			ttl:  time.Minute,
+			setup: func(cache *Cache[string], now *time.Time) {
+				cache.Set("a", "1")
+			},
+			key:           "a",
+			expectedValue: "1",
+			expectedOK:    true,
+		},
+		{
+			name:  "miss unknown key",
+			ttl:   time.Minute,
+			setup: func(cache *Cache[string], now *time.Time) {},
+			key:   "a",
+		},
+		{
+			name: "miss expired key",
+			ttl:  time.Minute,
+			setup: func(cache *Cache[string], now *time.Time) {
+				cache.Set("a", "1")
+				*now = now.Add(time.Minute)
+			},
+			key: "a",
+		},
+		{
+			name: "never expire without ttl",
+			ttl:  0,
+			setup: func(cache *Cache[string], now *time.Time) {
+				cache.Set("a", "1")
+				*now = now.Add(24 * time.Hour)
+			},
+			key:           "a",
+			expectedValue: "1",
+			expectedOK:    true,
+		},
+		{
+			name: "miss key expired by its own ttl",
+			ttl:  time.Minute,
+			setup: func(cache *Cache[string], now *time.Time) {
+				cache.SetWithTTL("a", "1", time.Second)
+				*now = now.Add(time.Second)
+			},
+			key: "a",
+		},
+		{
+			name: "return key set with a longer ttl than the cache",
+			ttl:  time.Minute,
+			setup: func(cache *Cache[string], now *time.Time) {
+				cache.SetWithTTL("a", "1", time.Hour)
+				*now = now.Add(2 * time.Minute)
+			},
+			key:           "a",
+			expectedValue: "1",
+			expectedOK:    true,
+		},
+		{
+			name: "return latest value of overwritten key",
+			ttl:  time.Minute,
+			setup: func(cache *Cache[string], now *time.Time) {
+				cache.Set("a", "1")
+				cache.Set("a", "2")
+			},
+			key:           "a",
+			expectedValue: "2",
+			expectedOK:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(1700000000, 0)
+			cache := newTestCache(10, tt.ttl, &now)
+			tt.setup(cache, &now)
+
+			value, ok := cache.Get(tt.key)
+
+			assert.Equal(t, tt.expectedValue, value)
+			assert.Equal(t, tt.expectedOK, ok)
+		})
+	}
+}
+
+func TestCache_Set_evictsLeastRecentlyUsed(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := newTestCache(2, time.Minute, &now)
+
+	cache.Set("a", "1")
+	cache.Set("b", "2")
+	cache.Get("a")
+	cache.Set("c", "3")
+
+	_, ok := cache.Get("b")
+	assert.False(t, ok, "least recently used key should be evicted")
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+	_, ok = cache.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, cache.Len())
+	assert.Equal(t, uint64(1), cache.Stats().Evictions)
+}
+
+func TestCache_Peek(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := newTestCache(2, time.Minute, &now)
+	cache.Set("a", "1")
+	cache.Set("b", "2")
+
+	value, ok := cache.Peek("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+	_, ok = cache.Peek("unknown")
+	assert.False(t, ok)
+	assert.Equal(t, Stats{Len: 2}, cache.Stats(), "a peek should not be counted")
+
+	cache.Set("c", "3")
+	_, ok = cache.Peek("a")
+	assert.False(t, ok, "a peek should not mark the entry as used")
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Peek("b")
+	assert.False(t, ok, "an expired entry should not be returned")
+}
+
+func TestCache_Delete(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := newTestCache(10, time.Minute, &now)
+	cache.Set("a", "1")
+	cache.Set("b", "2")
+	cache.Set("c", "3")
+
+	cache.Delete("a", "c", "unknown")
+
+	assert.Equal(t, 1, cache.Len())
+	_, ok := cache.Get("b")
+	assert.True(t, ok)
+}
+
+func TestCache_Purge(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := newTestCache(10, time.Minute, &now)
+	cache.Set("a", "1")
+	cache.Get("a")
+
+	cache.Purge()
+
+	assert.Equal(t, 0, cache.Len())
+	assert.Equal(t, uint64(1), cache.Stats().Hits, "stats should survive a purge")
+	cache.Set("b", "2")
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestCache_Stats(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := newTestCache(10, time.Minute, &now)
+	cache.Set("a", "1")
+
+	cache.Get("a")
+	cache.Get("a")
+	cache.Get("b")
+
+	assert.Equal(t, Stats{Hits: 2, Misses: 1, Len: 1}, cache.Stats())
+}
+
+func TestCache_concurrentUse(t *testing.T) {
+	cache := New[int](50, time.Minute)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				key := fmt.Sprintf("%d", j%100)
+				cache.Set(key, i)
+				cache.Get(key)
+				if j%50 == 0 {
+					cache.Delete(key)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, cache.Len(), 50)
+}
@@ -0,0 +1,252 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	namespace string = "boilerplate"
+
+	CacheResultHit       string = "hit"
+	CacheResultMiss      string = "miss"
+	CacheResultStale     string = "stale"
+	CacheResultTombstone string = "tombstone"
+)
+
+type Config struct {
+	Enable bool
+	Port   int
+	Path   string
+}
+
+var (
+	registry *prometheus.Registry
+	server   *http.Server
+
+	httpRequestsTotal          *prometheus.CounterVec
+	httpRequestDurationSeconds *prometheus.HistogramVec
+	grpcRequestsTotal          *prometheus.CounterVec
+	grpcRequestDurationSeconds *prometheus.HistogramVec
+	eventsProcessedTotal       *prometheus.CounterVec
+	eventsFailedTotal          *prometheus.CounterVec
+	eventAttempts              *prometheus.HistogramVec
+	eventLagSeconds            *prometheus.HistogramVec
+	databaseQueryDuration      *prometheus.HistogramVec
+	cacheRequestsTotal         *prometheus.CounterVec
+)
+
+func init() {
+	registry = prometheus.NewRegistry()
+
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Total HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request duration by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	grpcRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Total gRPC requests by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcRequestDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "gRPC request duration by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	eventsProcessedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "event_consumer",
+		Name:      "processed_total",
+		Help:      "Total events processed successfully by topic.",
+	}, []string{"topic"})
+
+	eventsFailedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "event_consumer",
+		Name:      "failed_total",
+		Help:      "Total events failed by topic.",
+	}, []string{"topic"})
+
+	eventAttempts = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "event_consumer",
+		Name:      "attempts",
+		Help:      "Delivery attempts of received events by topic.",
+		Buckets:   []float64{1, 2, 3, 5, 10, 20},
+	}, []string{"topic"})
+
+	eventLagSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "event_consumer",
+		Name:      "lag_seconds",
+		Help:      "Time between event publish and receive by topic.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 15, 60, 300},
+	}, []string{"topic"})
+
+	databaseQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "database",
+		Name:      "query_duration_seconds",
+		Help:      "Database query duration by table and operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"table", "operation"})
+
+	cacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Total cache lookups by cache and result.",
+	}, []string{"cache", "result"})
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDurationSeconds,
+		grpcRequestsTotal,
+		grpcRequestDurationSeconds,
+		eventsProcessedTotal,
+		eventsFailedTotal,
+		eventAttempts,
+		eventLagSeconds,
+		databaseQueryDuration,
+		cacheRequestsTotal,
+	)
+}
+
+func Registry() *prometheus.Registry {
+	return registry
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// NewMetrics starts the admin server exposing the metrics on its own port so
+// it is never routed through the public HTTP server.
+func NewMetrics(cfg *Config) {
+	var (
+		mux  *http.ServeMux
+		path string
+	)
+
+	if cfg == nil || !cfg.Enable {
+		return
+	}
+
+	path = cfg.Path
+	if path == "" {
+		path = "/metrics"
+	}
+
+	mux = http.NewServeMux()
+	mux.Handle(path, Handler())
+
+	server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func(server *http.Server) {
+		var err error = server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Err(err).
+				Msg("[metrics][NewMetrics][ListenAndServe] failed to serve metrics")
+		}
+	}(server)
+}
+
+func Shutdown(ctx context.Context) error {
+	if server == nil {
+		return nil
+	}
+
+	return server.Shutdown(ctx)
+}
+
+// RegisterDBStats exposes the pool stats of db labeled with name. Registering
+// the same name twice keeps the first collector.
+func RegisterDBStats(name string, db *sql.DB) error {
+	var (
+		alreadyRegisteredError prometheus.AlreadyRegisteredError
+		err                    error
+	)
+
+	err = registry.Register(collectors.NewDBStatsCollector(db, name))
+	if errors.As(err, &alreadyRegisteredError) {
+		return nil
+	}
+
+	return err
+}
+
+func ObserveHTTPRequest(method string, route string, statusCode int, duration time.Duration) {
+	httpRequestsTotal.WithLabelValues(method, route, strconv.Itoa(statusCode)).Inc()
+	httpRequestDurationSeconds.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+func ObserveGRPCRequest(method string, code string, duration time.Duration) {
+	grpcRequestsTotal.WithLabelValues(method, code).Inc()
+	grpcRequestDurationSeconds.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+func ObserveEventReceived(topic string, attempts uint16, lag time.Duration) {
+	eventAttempts.WithLabelValues(topic).Observe(float64(attempts))
+	eventLagSeconds.WithLabelValues(topic).Observe(lag.Seconds())
+}
+
+func ObserveEventHandled(topic string, err error) {
+	if err != nil {
+		eventsFailedTotal.WithLabelValues(topic).Inc()
+		return
+	}
+
+	eventsProcessedTotal.WithLabelValues(topic).Inc()
+}
+
+func ObserveDatabaseQuery(table string, operation string, duration time.Duration) {
+	databaseQueryDuration.WithLabelValues(table, operation).Observe(duration.Seconds())
+}
+
+func ObserveCacheHit(cache string) {
+	cacheRequestsTotal.WithLabelValues(cache, CacheResultHit).Inc()
+}
+
+func ObserveCacheMiss(cache string) {
+	cacheRequestsTotal.WithLabelValues(cache, CacheResultMiss).Inc()
+}
+
+func ObserveCacheStale(cache string) {
+	cacheRequestsTotal.WithLabelValues(cache, CacheResultStale).Inc()
+}
+
+func ObserveCacheTombstone(cache string) {
+	cacheRequestsTotal.WithLabelValues(cache, CacheResultTombstone).Inc()
+}
@@ -0,0 +1,225 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func getFreePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestNewMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		setupCfg func(t *testing.T) *Config
+		validate func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "nil config should not start server",
+			setupCfg: func(t *testing.T) *Config {
+				return nil
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Nil(t, server)
+			},
+		},
+		{
+			name: "disabled config should not start server",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{Enable: false, Port: getFreePort(t)}
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Nil(t, server)
+			},
+		},
+		{
+			name: "enabled config should serve metrics on path",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{Enable: true, Port: getFreePort(t), Path: "/custom-metrics"}
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.NotNil(t, server)
+
+				ObserveCacheHit("test_new_metrics")
+
+				var (
+					resp *http.Response
+					err  error
+				)
+				assert.Eventually(t, func() bool {
+					resp, err = http.Get(fmt.Sprintf("http://127.0.0.1:%d%s", cfg.Port, cfg.Path))
+					return err == nil
+				}, time.Second, 10*time.Millisecond)
+				defer resp.Body.Close()
+
+				body, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Contains(t, string(body), `boilerplate_cache_requests_total{cache="test_new_metrics",result="hit"} 1`)
+			},
+		},
+		{
+			name: "empty path should default to /metrics",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{Enable: true, Port: getFreePort(t)}
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				var (
+					resp *http.Response
+					err  error
+				)
+				assert.Eventually(t, func() bool {
+					resp, err = http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", cfg.Port))
+					return err == nil
+				}, time.Second, 10*time.Millisecond)
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server = nil
+			defer func() {
+				assert.NoError(t, Shutdown(context.Background()))
+				server = nil
+			}()
+
+			cfg := tt.setupCfg(t)
+
+			NewMetrics(cfg)
+
+			tt.validate(t, cfg)
+		})
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	server = nil
+
+	assert.NoError(t, Shutdown(context.Background()), "Shutdown without server should not fail")
+
+	t.Run("running server should stop serving", func(t *testing.T) {
+		port := getFreePort(t)
+		NewMetrics(&Config{Enable: true, Port: port})
+		t.Cleanup(func() {
+			server = nil
+		})
+
+		assert.Eventually(t, func() bool {
+			resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", port))
+			if err == nil {
+				resp.Body.Close()
+			}
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+
+		assert.NoError(t, Shutdown(context.Background()))
+
+		_, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", port))
+		assert.Error(t, err)
+	})
+}
+
+func TestRegisterDBStats(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	assert.NoError(t, RegisterDBStats("test_register_db_stats", db))
+	assert.NoError(t, RegisterDBStats("test_register_db_stats", db), "Registering the same name twice should be ignored")
+
+	count, err := testutil.GatherAndCount(registry, "go_sql_max_open_connections")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, count, 1)
+}
+
+func TestObserveHTTPRequest(t *testing.T) {
+	ObserveHTTPRequest(http.MethodGet, "/test-http/:id", http.StatusNotFound, 20*time.Millisecond)
+	ObserveHTTPRequest(http.MethodGet, "/test-http/:id", http.StatusNotFound, 40*time.Millisecond)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(httpRequestsTotal.WithLabelValues(http.MethodGet, "/test-http/:id", "404")))
+
+	count, err := testutil.GatherAndCount(registry, "boilerplate_http_request_duration_seconds")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, count, 1)
+}
+
+func TestObserveGRPCRequest(t *testing.T) {
+	ObserveGRPCRequest("/test.Service/Method", "OK", 10*time.Millisecond)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(grpcRequestsTotal.WithLabelValues("/test.Service/Method", "OK")))
+}
+
+func TestObserveDatabaseQuery(t *testing.T) {
+	ObserveDatabaseQuery("test_table", "FindOne", 5*time.Millisecond)
+
+	count, err := testutil.GatherAndCount(registry, "boilerplate_database_query_duration_seconds")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, count, 1)
+}
+
+func TestObserveEventHandled(t *testing.T) {
+	tests := []struct {
+		name              string
+		topic             string
+		err               error
+		expectedProcessed float64
+		expectedFailed    float64
+	}{
+		{
+			name:              "nil error should count processed",
+			topic:             "test.event.processed",
+			err:               nil,
+			expectedProcessed: 1,
+			expectedFailed:    0,
+		},
+		{
+			name:              "error should count failed",
+			topic:             "test.event.failed",
+			err:               errors.New("failed"),
+			expectedProcessed: 0,
+			expectedFailed:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ObserveEventReceived(tt.topic, 2, time.Second)
+			ObserveEventHandled(tt.topic, tt.err)
+
+			assert.Equal(t, tt.expectedProcessed, testutil.ToFloat64(eventsProcessedTotal.WithLabelValues(tt.topic)))
+			assert.Equal(t, tt.expectedFailed, testutil.ToFloat64(eventsFailedTotal.WithLabelValues(tt.topic)))
+		})
+	}
+}
+
+func TestObserveCache(t *testing.T) {
+	ObserveCacheHit("test_cache")
+	ObserveCacheMiss("test_cache")
+	ObserveCacheMiss("test_cache")
+	ObserveCacheStale("test_cache")
+	ObserveCacheTombstone("test_cache")
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(cacheRequestsTotal.WithLabelValues("test_cache", CacheResultHit)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(cacheRequestsTotal.WithLabelValues("test_cache", CacheResultMiss)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(cacheRequestsTotal.WithLabelValues("test_cache", CacheResultStale)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(cacheRequestsTotal.WithLabelValues("test_cache", CacheResultTombstone)))
+}
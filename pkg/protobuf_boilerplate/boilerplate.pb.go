@@ -118,6 +118,262 @@ func (x *DeleteGuestByIDRequestVM) GetId() string {
 	return ""
 }
 
+type EraseGuestByIDRequestVM struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseGuestByIDRequestVM) Reset() {
+	*x = EraseGuestByIDRequestVM{}
+	mi := &file_boilerplate_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseGuestByIDRequestVM) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseGuestByIDRequestVM) ProtoMessage() {}
+
+func (x *EraseGuestByIDRequestVM) ProtoReflect() protoreflect.Message {
+	mi := &file_boilerplate_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseGuestByIDRequestVM.ProtoReflect.Descriptor instead.
+func (*EraseGuestByIDRequestVM) Descriptor() ([]byte, []int) {
+	return file_boilerplate_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EraseGuestByIDRequestVM) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ExportGuestByIDRequestVM struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportGuestByIDRequestVM) Reset() {
+	*x = ExportGuestByIDRequestVM{}
+	mi := &file_boilerplate_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportGuestByIDRequestVM) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportGuestByIDRequestVM) ProtoMessage() {}
+
+func (x *ExportGuestByIDRequestVM) ProtoReflect() protoreflect.Message {
+	mi := &file_boilerplate_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportGuestByIDRequestVM.ProtoReflect.Descriptor instead.
+func (*ExportGuestByIDRequestVM) Descriptor() ([]byte, []int) {
+	return file_boilerplate_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExportGuestByIDRequestVM) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GuestRecordVM struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,7,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	DeletedAt     int64                  `protobuf:"varint,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	DeletedBy     string                 `protobuf:"bytes,9,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuestRecordVM) Reset() {
+	*x = GuestRecordVM{}
+	mi := &file_boilerplate_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuestRecordVM) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestRecordVM) ProtoMessage() {}
+
+func (x *GuestRecordVM) ProtoReflect() protoreflect.Message {
+	mi := &file_boilerplate_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestRecordVM.ProtoReflect.Descriptor instead.
+func (*GuestRecordVM) Descriptor() ([]byte, []int) {
+	return file_boilerplate_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GuestRecordVM) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GuestRecordVM) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GuestRecordVM) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GuestRecordVM) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *GuestRecordVM) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *GuestRecordVM) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *GuestRecordVM) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *GuestRecordVM) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+func (x *GuestRecordVM) GetDeletedBy() string {
+	if x != nil {
+		return x.DeletedBy
+	}
+	return ""
+}
+
+type ExportGuestResponseVM struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Database      *GuestRecordVM         `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
+	Cache         *GuestRecordVM         `protobuf:"bytes,2,opt,name=cache,proto3" json:"cache,omitempty"`
+	ExportedAt    int64                  `protobuf:"varint,3,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportGuestResponseVM) Reset() {
+	*x = ExportGuestResponseVM{}
+	mi := &file_boilerplate_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportGuestResponseVM) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportGuestResponseVM) ProtoMessage() {}
+
+func (x *ExportGuestResponseVM) ProtoReflect() protoreflect.Message {
+	mi := &file_boilerplate_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportGuestResponseVM.ProtoReflect.Descriptor instead.
+func (*ExportGuestResponseVM) Descriptor() ([]byte, []int) {
+	return file_boilerplate_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExportGuestResponseVM) GetDatabase() *GuestRecordVM {
+	if x != nil {
+		return x.Database
+	}
+	return nil
+}
+
+func (x *ExportGuestResponseVM) GetCache() *GuestRecordVM {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
+func (x *ExportGuestResponseVM) GetExportedAt() int64 {
+	if x != nil {
+		return x.ExportedAt
+	}
+	return 0
+}
+
 type FindAllGuestRequestVM struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Keyword       string                 `protobuf:"bytes,1,opt,name=keyword,proto3" json:"keyword,omitempty"`
@@ -130,7 +386,7 @@ type FindAllGuestRequestVM struct {
 
 func (x *FindAllGuestRequestVM) Reset() {
 	*x = FindAllGuestRequestVM{}
-	mi := &file_boilerplate_proto_msgTypes[2]
+	mi := &file_boilerplate_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -142,7 +398,7 @@ func (x *FindAllGuestRequestVM) String() string {
 func (*FindAllGuestRequestVM) ProtoMessage() {}
 
 func (x *FindAllGuestRequestVM) ProtoReflect() protoreflect.Message {
-	mi := &file_boilerplate_proto_msgTypes[2]
+	mi := &file_boilerplate_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -155,7 +411,7 @@ func (x *FindAllGuestRequestVM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindAllGuestRequestVM.ProtoReflect.Descriptor instead.
 func (*FindAllGuestRequestVM) Descriptor() ([]byte, []int) {
-	return file_boilerplate_proto_rawDescGZIP(), []int{2}
+	return file_boilerplate_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *FindAllGuestRequestVM) GetKeyword() string {
@@ -196,7 +452,7 @@ type FindAllGuestResponseVM struct {
 
 func (x *FindAllGuestResponseVM) Reset() {
 	*x = FindAllGuestResponseVM{}
-	mi := &file_boilerplate_proto_msgTypes[3]
+	mi := &file_boilerplate_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -208,7 +464,7 @@ func (x *FindAllGuestResponseVM) String() string {
 func (*FindAllGuestResponseVM) ProtoMessage() {}
 
 func (x *FindAllGuestResponseVM) ProtoReflect() protoreflect.Message {
-	mi := &file_boilerplate_proto_msgTypes[3]
+	mi := &file_boilerplate_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -221,7 +477,7 @@ func (x *FindAllGuestResponseVM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindAllGuestResponseVM.ProtoReflect.Descriptor instead.
 func (*FindAllGuestResponseVM) Descriptor() ([]byte, []int) {
-	return file_boilerplate_proto_rawDescGZIP(), []int{3}
+	return file_boilerplate_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *FindAllGuestResponseVM) GetList() []*GuestResponseVM {
@@ -247,7 +503,7 @@ type FindGuestByIDRequestVM struct {
 
 func (x *FindGuestByIDRequestVM) Reset() {
 	*x = FindGuestByIDRequestVM{}
-	mi := &file_boilerplate_proto_msgTypes[4]
+	mi := &file_boilerplate_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -259,7 +515,7 @@ func (x *FindGuestByIDRequestVM) String() string {
 func (*FindGuestByIDRequestVM) ProtoMessage() {}
 
 func (x *FindGuestByIDRequestVM) ProtoReflect() protoreflect.Message {
-	mi := &file_boilerplate_proto_msgTypes[4]
+	mi := &file_boilerplate_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -272,7 +528,7 @@ func (x *FindGuestByIDRequestVM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindGuestByIDRequestVM.ProtoReflect.Descriptor instead.
 func (*FindGuestByIDRequestVM) Descriptor() ([]byte, []int) {
-	return file_boilerplate_proto_rawDescGZIP(), []int{4}
+	return file_boilerplate_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FindGuestByIDRequestVM) GetId() string {
@@ -297,7 +553,7 @@ type GuestResponseVM struct {
 
 func (x *GuestResponseVM) Reset() {
 	*x = GuestResponseVM{}
-	mi := &file_boilerplate_proto_msgTypes[5]
+	mi := &file_boilerplate_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -309,7 +565,7 @@ func (x *GuestResponseVM) String() string {
 func (*GuestResponseVM) ProtoMessage() {}
 
 func (x *GuestResponseVM) ProtoReflect() protoreflect.Message {
-	mi := &file_boilerplate_proto_msgTypes[5]
+	mi := &file_boilerplate_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -322,7 +578,7 @@ func (x *GuestResponseVM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuestResponseVM.ProtoReflect.Descriptor instead.
 func (*GuestResponseVM) Descriptor() ([]byte, []int) {
-	return file_boilerplate_proto_rawDescGZIP(), []int{5}
+	return file_boilerplate_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GuestResponseVM) GetId() string {
@@ -385,7 +641,7 @@ type UpdateGuestByIDRequestVM struct {
 
 func (x *UpdateGuestByIDRequestVM) Reset() {
 	*x = UpdateGuestByIDRequestVM{}
-	mi := &file_boilerplate_proto_msgTypes[6]
+	mi := &file_boilerplate_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -397,7 +653,7 @@ func (x *UpdateGuestByIDRequestVM) String() string {
 func (*UpdateGuestByIDRequestVM) ProtoMessage() {}
 
 func (x *UpdateGuestByIDRequestVM) ProtoReflect() protoreflect.Message {
-	mi := &file_boilerplate_proto_msgTypes[6]
+	mi := &file_boilerplate_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,7 +666,7 @@ func (x *UpdateGuestByIDRequestVM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateGuestByIDRequestVM.ProtoReflect.Descriptor instead.
 func (*UpdateGuestByIDRequestVM) Descriptor() ([]byte, []int) {
-	return file_boilerplate_proto_rawDescGZIP(), []int{6}
+	return file_boilerplate_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateGuestByIDRequestVM) GetId() string {
@@ -443,7 +699,7 @@ type BulkCreateGuestsRequestVM struct {
 
 func (x *BulkCreateGuestsRequestVM) Reset() {
 	*x = BulkCreateGuestsRequestVM{}
-	mi := &file_boilerplate_proto_msgTypes[7]
+	mi := &file_boilerplate_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -455,7 +711,7 @@ func (x *BulkCreateGuestsRequestVM) String() string {
 func (*BulkCreateGuestsRequestVM) ProtoMessage() {}
 
 func (x *BulkCreateGuestsRequestVM) ProtoReflect() protoreflect.Message {
-	mi := &file_boilerplate_proto_msgTypes[7]
+	mi := &file_boilerplate_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -468,7 +724,7 @@ func (x *BulkCreateGuestsRequestVM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkCreateGuestsRequestVM.ProtoReflect.Descriptor instead.
 func (*BulkCreateGuestsRequestVM) Descriptor() ([]byte, []int) {
-	return file_boilerplate_proto_rawDescGZIP(), []int{7}
+	return file_boilerplate_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BulkCreateGuestsRequestVM) GetItems() []*CreateGuestRequestVM {
@@ -487,7 +743,7 @@ type BulkCreateGuestsResponseVM struct {
 
 func (x *BulkCreateGuestsResponseVM) Reset() {
 	*x = BulkCreateGuestsResponseVM{}
-	mi := &file_boilerplate_proto_msgTypes[8]
+	mi := &file_boilerplate_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -499,7 +755,7 @@ func (x *BulkCreateGuestsResponseVM) String() string {
 func (*BulkCreateGuestsResponseVM) ProtoMessage() {}
 
 func (x *BulkCreateGuestsResponseVM) ProtoReflect() protoreflect.Message {
-	mi := &file_boilerplate_proto_msgTypes[8]
+	mi := &file_boilerplate_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -512,7 +768,7 @@ func (x *BulkCreateGuestsResponseVM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkCreateGuestsResponseVM.ProtoReflect.Descriptor instead.
 func (*BulkCreateGuestsResponseVM) Descriptor() ([]byte, []int) {
-	return file_boilerplate_proto_rawDescGZIP(), []int{8}
+	return file_boilerplate_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *BulkCreateGuestsResponseVM) GetData() []*GuestResponseVM {
@@ -531,7 +787,7 @@ type BulkUpdateGuestsRequestVM struct {
 
 func (x *BulkUpdateGuestsRequestVM) Reset() {
 	*x = BulkUpdateGuestsRequestVM{}
-	mi := &file_boilerplate_proto_msgTypes[9]
+	mi := &file_boilerplate_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -543,7 +799,7 @@ func (x *BulkUpdateGuestsRequestVM) String() string {
 func (*BulkUpdateGuestsRequestVM) ProtoMessage() {}
 
 func (x *BulkUpdateGuestsRequestVM) ProtoReflect() protoreflect.Message {
-	mi := &file_boilerplate_proto_msgTypes[9]
+	mi := &file_boilerplate_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -556,7 +812,7 @@ func (x *BulkUpdateGuestsRequestVM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkUpdateGuestsRequestVM.ProtoReflect.Descriptor instead.
 func (*BulkUpdateGuestsRequestVM) Descriptor() ([]byte, []int) {
-	return file_boilerplate_proto_rawDescGZIP(), []int{9}
+	return file_boilerplate_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *BulkUpdateGuestsRequestVM) GetItems() []*UpdateGuestByIDRequestVM {
@@ -575,7 +831,7 @@ type BulkUpdateGuestsResponseVM struct {
 
 func (x *BulkUpdateGuestsResponseVM) Reset() {
 	*x = BulkUpdateGuestsResponseVM{}
-	mi := &file_boilerplate_proto_msgTypes[10]
+	mi := &file_boilerplate_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -587,7 +843,7 @@ func (x *BulkUpdateGuestsResponseVM) String() string {
 func (*BulkUpdateGuestsResponseVM) ProtoMessage() {}
 
 func (x *BulkUpdateGuestsResponseVM) ProtoReflect() protoreflect.Message {
-	mi := &file_boilerplate_proto_msgTypes[10]
+	mi := &file_boilerplate_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -600,7 +856,7 @@ func (x *BulkUpdateGuestsResponseVM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkUpdateGuestsResponseVM.ProtoReflect.Descriptor instead.
 func (*BulkUpdateGuestsResponseVM) Descriptor() ([]byte, []int) {
-	return file_boilerplate_proto_rawDescGZIP(), []int{10}
+	return file_boilerplate_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *BulkUpdateGuestsResponseVM) GetData() []*GuestResponseVM {
@@ -619,7 +875,7 @@ type BulkDeleteGuestsRequestVM struct {
 
 func (x *BulkDeleteGuestsRequestVM) Reset() {
 	*x = BulkDeleteGuestsRequestVM{}
-	mi := &file_boilerplate_proto_msgTypes[11]
+	mi := &file_boilerplate_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -631,7 +887,7 @@ func (x *BulkDeleteGuestsRequestVM) String() string {
 func (*BulkDeleteGuestsRequestVM) ProtoMessage() {}
 
 func (x *BulkDeleteGuestsRequestVM) ProtoReflect() protoreflect.Message {
-	mi := &file_boilerplate_proto_msgTypes[11]
+	mi := &file_boilerplate_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,7 +900,7 @@ func (x *BulkDeleteGuestsRequestVM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkDeleteGuestsRequestVM.ProtoReflect.Descriptor instead.
 func (*BulkDeleteGuestsRequestVM) Descriptor() ([]byte, []int) {
-	return file_boilerplate_proto_rawDescGZIP(), []int{11}
+	return file_boilerplate_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *BulkDeleteGuestsRequestVM) GetIds() []string {
@@ -663,7 +919,32 @@ const file_boilerplate_proto_rawDesc = "" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
 	"\aaddress\x18\x02 \x01(\tR\aaddress\"*\n" +
 	"\x18DeleteGuestByIDRequestVM\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"o\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\")\n" +
+	"\x17EraseGuestByIDRequestVM\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"*\n" +
+	"\x18ExportGuestByIDRequestVM\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x87\x02\n" +
+	"\rGuestRecordVM\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\a \x01(\tR\tupdatedBy\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\b \x01(\x03R\tdeletedAt\x12\x1d\n" +
+	"\n" +
+	"deleted_by\x18\t \x01(\tR\tdeletedBy\"\xb4\x01\n" +
+	"\x15ExportGuestResponseVM\x12?\n" +
+	"\bdatabase\x18\x01 \x01(\v2#.protobuf_boilerplate.GuestRecordVMR\bdatabase\x129\n" +
+	"\x05cache\x18\x02 \x01(\v2#.protobuf_boilerplate.GuestRecordVMR\x05cache\x12\x1f\n" +
+	"\vexported_at\x18\x03 \x01(\x03R\n" +
+	"exportedAt\"o\n" +
 	"\x15FindAllGuestRequestVM\x12\x18\n" +
 	"\akeyword\x18\x01 \x01(\tR\akeyword\x12\x14\n" +
 	"\x05sorts\x18\x02 \x01(\tR\x05sorts\x12\x12\n" +
@@ -699,10 +980,12 @@ const file_boilerplate_proto_rawDesc = "" +
 	"\x1aBulkUpdateGuestsResponseVM\x129\n" +
 	"\x04data\x18\x01 \x03(\v2%.protobuf_boilerplate.GuestResponseVMR\x04data\"-\n" +
 	"\x19BulkDeleteGuestsRequestVM\x12\x10\n" +
-	"\x03ids\x18\x01 \x03(\tR\x03ids2\xd0\x06\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids2\x99\b\n" +
 	"\vBoilerplate\x12`\n" +
 	"\vCreateGuest\x12*.protobuf_boilerplate.CreateGuestRequestVM\x1a%.protobuf_boilerplate.GuestResponseVM\x12Y\n" +
-	"\x0fDeleteGuestByID\x12..protobuf_boilerplate.DeleteGuestByIDRequestVM\x1a\x16.google.protobuf.Empty\x12i\n" +
+	"\x0fDeleteGuestByID\x12..protobuf_boilerplate.DeleteGuestByIDRequestVM\x1a\x16.google.protobuf.Empty\x12W\n" +
+	"\x0eEraseGuestByID\x12-.protobuf_boilerplate.EraseGuestByIDRequestVM\x1a\x16.google.protobuf.Empty\x12n\n" +
+	"\x0fExportGuestByID\x12..protobuf_boilerplate.ExportGuestByIDRequestVM\x1a+.protobuf_boilerplate.ExportGuestResponseVM\x12i\n" +
 	"\fFindAllGuest\x12+.protobuf_boilerplate.FindAllGuestRequestVM\x1a,.protobuf_boilerplate.FindAllGuestResponseVM\x12d\n" +
 	"\rFindGuestByID\x12,.protobuf_boilerplate.FindGuestByIDRequestVM\x1a%.protobuf_boilerplate.GuestResponseVM\x12h\n" +
 	"\x0fUpdateGuestByID\x12..protobuf_boilerplate.UpdateGuestByIDRequestVM\x1a%.protobuf_boilerplate.GuestResponseVM\x12u\n" +
@@ -722,49 +1005,59 @@ func file_boilerplate_proto_rawDescGZIP() []byte {
 	return file_boilerplate_proto_rawDescData
 }
 
-var file_boilerplate_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_boilerplate_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_boilerplate_proto_goTypes = []any{
 	(*CreateGuestRequestVM)(nil),       // 0: protobuf_boilerplate.CreateGuestRequestVM
 	(*DeleteGuestByIDRequestVM)(nil),   // 1: protobuf_boilerplate.DeleteGuestByIDRequestVM
-	(*FindAllGuestRequestVM)(nil),      // 2: protobuf_boilerplate.FindAllGuestRequestVM
-	(*FindAllGuestResponseVM)(nil),     // 3: protobuf_boilerplate.FindAllGuestResponseVM
-	(*FindGuestByIDRequestVM)(nil),     // 4: protobuf_boilerplate.FindGuestByIDRequestVM
-	(*GuestResponseVM)(nil),            // 5: protobuf_boilerplate.GuestResponseVM
-	(*UpdateGuestByIDRequestVM)(nil),   // 6: protobuf_boilerplate.UpdateGuestByIDRequestVM
-	(*BulkCreateGuestsRequestVM)(nil),  // 7: protobuf_boilerplate.BulkCreateGuestsRequestVM
-	(*BulkCreateGuestsResponseVM)(nil), // 8: protobuf_boilerplate.BulkCreateGuestsResponseVM
-	(*BulkUpdateGuestsRequestVM)(nil),  // 9: protobuf_boilerplate.BulkUpdateGuestsRequestVM
-	(*BulkUpdateGuestsResponseVM)(nil), // 10: protobuf_boilerplate.BulkUpdateGuestsResponseVM
-	(*BulkDeleteGuestsRequestVM)(nil),  // 11: protobuf_boilerplate.BulkDeleteGuestsRequestVM
-	(*emptypb.Empty)(nil),              // 12: google.protobuf.Empty
+	(*EraseGuestByIDRequestVM)(nil),    // 2: protobuf_boilerplate.EraseGuestByIDRequestVM
+	(*ExportGuestByIDRequestVM)(nil),   // 3: protobuf_boilerplate.ExportGuestByIDRequestVM
+	(*GuestRecordVM)(nil),              // 4: protobuf_boilerplate.GuestRecordVM
+	(*ExportGuestResponseVM)(nil),      // 5: protobuf_boilerplate.ExportGuestResponseVM
+	(*FindAllGuestRequestVM)(nil),      // 6: protobuf_boilerplate.FindAllGuestRequestVM
+	(*FindAllGuestResponseVM)(nil),     // 7: protobuf_boilerplate.FindAllGuestResponseVM
+	(*FindGuestByIDRequestVM)(nil),     // 8: protobuf_boilerplate.FindGuestByIDRequestVM
+	(*GuestResponseVM)(nil),            // 9: protobuf_boilerplate.GuestResponseVM
+	(*UpdateGuestByIDRequestVM)(nil),   // 10: protobuf_boilerplate.UpdateGuestByIDRequestVM
+	(*BulkCreateGuestsRequestVM)(nil),  // 11: protobuf_boilerplate.BulkCreateGuestsRequestVM
+	(*BulkCreateGuestsResponseVM)(nil), // 12: protobuf_boilerplate.BulkCreateGuestsResponseVM
+	(*BulkUpdateGuestsRequestVM)(nil),  // 13: protobuf_boilerplate.BulkUpdateGuestsRequestVM
+	(*BulkUpdateGuestsResponseVM)(nil), // 14: protobuf_boilerplate.BulkUpdateGuestsResponseVM
+	(*BulkDeleteGuestsRequestVM)(nil),  // 15: protobuf_boilerplate.BulkDeleteGuestsRequestVM
+	(*emptypb.Empty)(nil),              // 16: google.protobuf.Empty
 }
 var file_boilerplate_proto_depIdxs = []int32{
-	5,  // 0: protobuf_boilerplate.FindAllGuestResponseVM.list:type_name -> protobuf_boilerplate.GuestResponseVM
-	0,  // 1: protobuf_boilerplate.BulkCreateGuestsRequestVM.items:type_name -> protobuf_boilerplate.CreateGuestRequestVM
-	5,  // 2: protobuf_boilerplate.BulkCreateGuestsResponseVM.data:type_name -> protobuf_boilerplate.GuestResponseVM
-	6,  // 3: protobuf_boilerplate.BulkUpdateGuestsRequestVM.items:type_name -> protobuf_boilerplate.UpdateGuestByIDRequestVM
-	5,  // 4: protobuf_boilerplate.BulkUpdateGuestsResponseVM.data:type_name -> protobuf_boilerplate.GuestResponseVM
-	0,  // 5: protobuf_boilerplate.Boilerplate.CreateGuest:input_type -> protobuf_boilerplate.CreateGuestRequestVM
-	1,  // 6: protobuf_boilerplate.Boilerplate.DeleteGuestByID:input_type -> protobuf_boilerplate.DeleteGuestByIDRequestVM
-	2,  // 7: protobuf_boilerplate.Boilerplate.FindAllGuest:input_type -> protobuf_boilerplate.FindAllGuestRequestVM
-	4,  // 8: protobuf_boilerplate.Boilerplate.FindGuestByID:input_type -> protobuf_boilerplate.FindGuestByIDRequestVM
-	6,  // 9: protobuf_boilerplate.Boilerplate.UpdateGuestByID:input_type -> protobuf_boilerplate.UpdateGuestByIDRequestVM
-	7,  // 10: protobuf_boilerplate.Boilerplate.BulkCreateGuests:input_type -> protobuf_boilerplate.BulkCreateGuestsRequestVM
-	9,  // 11: protobuf_boilerplate.Boilerplate.BulkUpdateGuests:input_type -> protobuf_boilerplate.BulkUpdateGuestsRequestVM
-	11, // 12: protobuf_boilerplate.Boilerplate.BulkDeleteGuests:input_type -> protobuf_boilerplate.BulkDeleteGuestsRequestVM
-	5,  // 13: protobuf_boilerplate.Boilerplate.CreateGuest:output_type -> protobuf_boilerplate.GuestResponseVM
-	12, // 14: protobuf_boilerplate.Boilerplate.DeleteGuestByID:output_type -> google.protobuf.Empty
-	3,  // 15: protobuf_boilerplate.Boilerplate.FindAllGuest:output_type -> protobuf_boilerplate.FindAllGuestResponseVM
-	5,  // 16: protobuf_boilerplate.Boilerplate.FindGuestByID:output_type -> protobuf_boilerplate.GuestResponseVM
-	5,  // 17: protobuf_boilerplate.Boilerplate.UpdateGuestByID:output_type -> protobuf_boilerplate.GuestResponseVM
-	8,  // 18: protobuf_boilerplate.Boilerplate.BulkCreateGuests:output_type -> protobuf_boilerplate.BulkCreateGuestsResponseVM
-	10, // 19: protobuf_boilerplate.Boilerplate.BulkUpdateGuests:output_type -> protobuf_boilerplate.BulkUpdateGuestsResponseVM
-	12, // 20: protobuf_boilerplate.Boilerplate.BulkDeleteGuests:output_type -> google.protobuf.Empty
-	13, // [13:21] is the sub-list for method output_type
-	5,  // [5:13] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	4,  // 0: protobuf_boilerplate.ExportGuestResponseVM.database:type_name -> protobuf_boilerplate.GuestRecordVM
+	4,  // 1: protobuf_boilerplate.ExportGuestResponseVM.cache:type_name -> protobuf_boilerplate.GuestRecordVM
+	9,  // 2: protobuf_boilerplate.FindAllGuestResponseVM.list:type_name -> protobuf_boilerplate.GuestResponseVM
+	0,  // 3: protobuf_boilerplate.BulkCreateGuestsRequestVM.items:type_name -> protobuf_boilerplate.CreateGuestRequestVM
+	9,  // 4: protobuf_boilerplate.BulkCreateGuestsResponseVM.data:type_name -> protobuf_boilerplate.GuestResponseVM
+	10, // 5: protobuf_boilerplate.BulkUpdateGuestsRequestVM.items:type_name -> protobuf_boilerplate.UpdateGuestByIDRequestVM
+	9,  // 6: protobuf_boilerplate.BulkUpdateGuestsResponseVM.data:type_name -> protobuf_boilerplate.GuestResponseVM
+	0,  // 7: protobuf_boilerplate.Boilerplate.CreateGuest:input_type -> protobuf_boilerplate.CreateGuestRequestVM
+	1,  // 8: protobuf_boilerplate.Boilerplate.DeleteGuestByID:input_type -> protobuf_boilerplate.DeleteGuestByIDRequestVM
+	2,  // 9: protobuf_boilerplate.Boilerplate.EraseGuestByID:input_type -> protobuf_boilerplate.EraseGuestByIDRequestVM
+	3,  // 10: protobuf_boilerplate.Boilerplate.ExportGuestByID:input_type -> protobuf_boilerplate.ExportGuestByIDRequestVM
+	6,  // 11: protobuf_boilerplate.Boilerplate.FindAllGuest:input_type -> protobuf_boilerplate.FindAllGuestRequestVM
+	8,  // 12: protobuf_boilerplate.Boilerplate.FindGuestByID:input_type -> protobuf_boilerplate.FindGuestByIDRequestVM
+	10, // 13: protobuf_boilerplate.Boilerplate.UpdateGuestByID:input_type -> protobuf_boilerplate.UpdateGuestByIDRequestVM
+	11, // 14: protobuf_boilerplate.Boilerplate.BulkCreateGuests:input_type -> protobuf_boilerplate.BulkCreateGuestsRequestVM
+	13, // 15: protobuf_boilerplate.Boilerplate.BulkUpdateGuests:input_type -> protobuf_boilerplate.BulkUpdateGuestsRequestVM
+	15, // 16: protobuf_boilerplate.Boilerplate.BulkDeleteGuests:input_type -> protobuf_boilerplate.BulkDeleteGuestsRequestVM
+	9,  // 17: protobuf_boilerplate.Boilerplate.CreateGuest:output_type -> protobuf_boilerplate.GuestResponseVM
+	16, // 18: protobuf_boilerplate.Boilerplate.DeleteGuestByID:output_type -> google.protobuf.Empty
+	16, // 19: protobuf_boilerplate.Boilerplate.EraseGuestByID:output_type -> google.protobuf.Empty
+	5,  // 20: protobuf_boilerplate.Boilerplate.ExportGuestByID:output_type -> protobuf_boilerplate.ExportGuestResponseVM
+	7,  // 21: protobuf_boilerplate.Boilerplate.FindAllGuest:output_type -> protobuf_boilerplate.FindAllGuestResponseVM
+	9,  // 22: protobuf_boilerplate.Boilerplate.FindGuestByID:output_type -> protobuf_boilerplate.GuestResponseVM
+	9,  // 23: protobuf_boilerplate.Boilerplate.UpdateGuestByID:output_type -> protobuf_boilerplate.GuestResponseVM
+	12, // 24: protobuf_boilerplate.Boilerplate.BulkCreateGuests:output_type -> protobuf_boilerplate.BulkCreateGuestsResponseVM
+	14, // 25: protobuf_boilerplate.Boilerplate.BulkUpdateGuests:output_type -> protobuf_boilerplate.BulkUpdateGuestsResponseVM
+	16, // 26: protobuf_boilerplate.Boilerplate.BulkDeleteGuests:output_type -> google.protobuf.Empty
+	17, // [17:27] is the sub-list for method output_type
+	7,  // [7:17] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_boilerplate_proto_init() }
@@ -778,7 +1071,7 @@ func file_boilerplate_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_boilerplate_proto_rawDesc), len(file_boilerplate_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
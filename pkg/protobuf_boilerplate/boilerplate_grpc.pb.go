@@ -22,6 +22,8 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	Boilerplate_CreateGuest_FullMethodName      = "/protobuf_boilerplate.Boilerplate/CreateGuest"
 	Boilerplate_DeleteGuestByID_FullMethodName  = "/protobuf_boilerplate.Boilerplate/DeleteGuestByID"
+	Boilerplate_EraseGuestByID_FullMethodName   = "/protobuf_boilerplate.Boilerplate/EraseGuestByID"
+	Boilerplate_ExportGuestByID_FullMethodName  = "/protobuf_boilerplate.Boilerplate/ExportGuestByID"
 	Boilerplate_FindAllGuest_FullMethodName     = "/protobuf_boilerplate.Boilerplate/FindAllGuest"
 	Boilerplate_FindGuestByID_FullMethodName    = "/protobuf_boilerplate.Boilerplate/FindGuestByID"
 	Boilerplate_UpdateGuestByID_FullMethodName  = "/protobuf_boilerplate.Boilerplate/UpdateGuestByID"
@@ -36,6 +38,8 @@ const (
 type BoilerplateClient interface {
 	CreateGuest(ctx context.Context, in *CreateGuestRequestVM, opts ...grpc.CallOption) (*GuestResponseVM, error)
 	DeleteGuestByID(ctx context.Context, in *DeleteGuestByIDRequestVM, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	EraseGuestByID(ctx context.Context, in *EraseGuestByIDRequestVM, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ExportGuestByID(ctx context.Context, in *ExportGuestByIDRequestVM, opts ...grpc.CallOption) (*ExportGuestResponseVM, error)
 	FindAllGuest(ctx context.Context, in *FindAllGuestRequestVM, opts ...grpc.CallOption) (*FindAllGuestResponseVM, error)
 	FindGuestByID(ctx context.Context, in *FindGuestByIDRequestVM, opts ...grpc.CallOption) (*GuestResponseVM, error)
 	UpdateGuestByID(ctx context.Context, in *UpdateGuestByIDRequestVM, opts ...grpc.CallOption) (*GuestResponseVM, error)
@@ -72,6 +76,26 @@ func (c *boilerplateClient) DeleteGuestByID(ctx context.Context, in *DeleteGuest
 	return out, nil
 }
 
+func (c *boilerplateClient) EraseGuestByID(ctx context.Context, in *EraseGuestByIDRequestVM, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Boilerplate_EraseGuestByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boilerplateClient) ExportGuestByID(ctx context.Context, in *ExportGuestByIDRequestVM, opts ...grpc.CallOption) (*ExportGuestResponseVM, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportGuestResponseVM)
+	err := c.cc.Invoke(ctx, Boilerplate_ExportGuestByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *boilerplateClient) FindAllGuest(ctx context.Context, in *FindAllGuestRequestVM, opts ...grpc.CallOption) (*FindAllGuestResponseVM, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FindAllGuestResponseVM)
@@ -138,6 +162,8 @@ func (c *boilerplateClient) BulkDeleteGuests(ctx context.Context, in *BulkDelete
 type BoilerplateServer interface {
 	CreateGuest(context.Context, *CreateGuestRequestVM) (*GuestResponseVM, error)
 	DeleteGuestByID(context.Context, *DeleteGuestByIDRequestVM) (*emptypb.Empty, error)
+	EraseGuestByID(context.Context, *EraseGuestByIDRequestVM) (*emptypb.Empty, error)
+	ExportGuestByID(context.Context, *ExportGuestByIDRequestVM) (*ExportGuestResponseVM, error)
 	FindAllGuest(context.Context, *FindAllGuestRequestVM) (*FindAllGuestResponseVM, error)
 	FindGuestByID(context.Context, *FindGuestByIDRequestVM) (*GuestResponseVM, error)
 	UpdateGuestByID(context.Context, *UpdateGuestByIDRequestVM) (*GuestResponseVM, error)
@@ -160,6 +186,12 @@ func (UnimplementedBoilerplateServer) CreateGuest(context.Context, *CreateGuestR
 func (UnimplementedBoilerplateServer) DeleteGuestByID(context.Context, *DeleteGuestByIDRequestVM) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteGuestByID not implemented")
 }
+func (UnimplementedBoilerplateServer) EraseGuestByID(context.Context, *EraseGuestByIDRequestVM) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method EraseGuestByID not implemented")
+}
+func (UnimplementedBoilerplateServer) ExportGuestByID(context.Context, *ExportGuestByIDRequestVM) (*ExportGuestResponseVM, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportGuestByID not implemented")
+}
 func (UnimplementedBoilerplateServer) FindAllGuest(context.Context, *FindAllGuestRequestVM) (*FindAllGuestResponseVM, error) {
 	return nil, status.Error(codes.Unimplemented, "method FindAllGuest not implemented")
 }
@@ -235,6 +267,42 @@ func _Boilerplate_DeleteGuestByID_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Boilerplate_EraseGuestByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseGuestByIDRequestVM)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoilerplateServer).EraseGuestByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Boilerplate_EraseGuestByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoilerplateServer).EraseGuestByID(ctx, req.(*EraseGuestByIDRequestVM))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Boilerplate_ExportGuestByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportGuestByIDRequestVM)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoilerplateServer).ExportGuestByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Boilerplate_ExportGuestByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoilerplateServer).ExportGuestByID(ctx, req.(*ExportGuestByIDRequestVM))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Boilerplate_FindAllGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FindAllGuestRequestVM)
 	if err := dec(in); err != nil {
@@ -358,6 +426,14 @@ var Boilerplate_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteGuestByID",
 			Handler:    _Boilerplate_DeleteGuestByID_Handler,
 		},
+		{
+			MethodName: "EraseGuestByID",
+			Handler:    _Boilerplate_EraseGuestByID_Handler,
+		},
+		{
+			MethodName: "ExportGuestByID",
+			Handler:    _Boilerplate_ExportGuestByID_Handler,
+		},
 		{
 			MethodName: "FindAllGuest",
 			Handler:    _Boilerplate_FindAllGuest_Handler,
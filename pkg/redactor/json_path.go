@@ -0,0 +1,157 @@
+package redactor
+
+import (
+	"strconv"
+	"strings"
+)
+
+type pathSegment struct {
+	key       string
+	index     int
+	any       bool
+	recursive bool
+}
+
+// parsePath splits a JSON path such as $.data[*].address or $..address into
+// segments. "*" matches every key or element and ".." matches at any depth.
+func parsePath(path string) []pathSegment {
+	var (
+		segments  []pathSegment
+		recursive bool
+		end       int
+		name      string
+	)
+
+	path = strings.TrimPrefix(path, "$")
+
+	for len(path) > 0 {
+		switch {
+		case strings.HasPrefix(path, ".."):
+			recursive = true
+			path = path[2:]
+			continue
+		case path[0] == '.':
+			path = path[1:]
+			continue
+		case path[0] == '[':
+			end = strings.IndexByte(path, ']')
+			if end < 0 {
+				end = len(path)
+				path += "]"
+			}
+			name = strings.Trim(path[1:end], `'"`)
+			path = path[end+1:]
+		default:
+			end = strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			name = path[:end]
+			path = path[end:]
+		}
+
+		segments = append(segments, newPathSegment(name, recursive))
+		recursive = false
+	}
+
+	return segments
+}
+
+func newPathSegment(name string, recursive bool) pathSegment {
+	var (
+		segment pathSegment
+		index   int
+		err     error
+	)
+
+	segment = pathSegment{
+		key:       name,
+		index:     -1,
+		recursive: recursive,
+	}
+
+	if name == "*" {
+		segment.any = true
+		return segment
+	}
+
+	index, err = strconv.Atoi(name)
+	if err == nil && index >= 0 {
+		segment.index = index
+	}
+
+	return segment
+}
+
+func (r *Redactor) mask(node interface{}) interface{} {
+	for i := range r.fieldPaths {
+		node = maskPath(node, r.fieldPaths[i])
+	}
+
+	if r.secrets != nil {
+		node = r.maskSecrets(node)
+	}
+
+	return node
+}
+
+func (r *Redactor) maskSecrets(node interface{}) interface{} {
+	switch v := node.(type) {
+	case string:
+		return r.secrets.Replace(v)
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = r.maskSecrets(v[key])
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = r.maskSecrets(v[i])
+		}
+	}
+
+	return node
+}
+
+func maskPath(node interface{}, segments []pathSegment) interface{} {
+	var (
+		segment pathSegment
+		rest    []pathSegment
+	)
+
+	if len(segments) == 0 {
+		return RedactedValue
+	}
+
+	segment = segments[0]
+	rest = segments[1:]
+
+	if segment.recursive {
+		switch v := node.(type) {
+		case map[string]interface{}:
+			for key := range v {
+				v[key] = maskPath(v[key], segments)
+			}
+		case []interface{}:
+			for i := range v {
+				v[i] = maskPath(v[i], segments)
+			}
+		}
+	}
+
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key := range v {
+			if segment.any || key == segment.key {
+				v[key] = maskPath(v[key], rest)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			if segment.any || i == segment.index {
+				v[i] = maskPath(v[i], rest)
+			}
+		}
+	}
+
+	return node
+}
@@ -0,0 +1,161 @@
+package redactor
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected []pathSegment
+	}{
+		{
+			name: "root field",
+			path: "$.address",
+			expected: []pathSegment{
+				{key: "address", index: -1},
+			},
+		},
+		{
+			name: "nested field",
+			path: "$.data.address",
+			expected: []pathSegment{
+				{key: "data", index: -1},
+				{key: "address", index: -1},
+			},
+		},
+		{
+			name: "array wildcard",
+			path: "$.data[*].address",
+			expected: []pathSegment{
+				{key: "data", index: -1},
+				{key: "*", index: -1, any: true},
+				{key: "address", index: -1},
+			},
+		},
+		{
+			name: "array index",
+			path: "$.data[0]",
+			expected: []pathSegment{
+				{key: "data", index: -1},
+				{key: "0", index: 0},
+			},
+		},
+		{
+			name: "quoted bracket key",
+			path: "$['address']",
+			expected: []pathSegment{
+				{key: "address", index: -1},
+			},
+		},
+		{
+			name: "recursive descent",
+			path: "$..address",
+			expected: []pathSegment{
+				{key: "address", index: -1, recursive: true},
+			},
+		},
+		{
+			name: "without root symbol",
+			path: "address",
+			expected: []pathSegment{
+				{key: "address", index: -1},
+			},
+		},
+		{
+			name: "unterminated bracket",
+			path: "$.data[*",
+			expected: []pathSegment{
+				{key: "data", index: -1},
+				{key: "*", index: -1, any: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parsePath(tt.path))
+		})
+	}
+}
+
+func TestRedactor_mask(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   []string
+		input    string
+		expected string
+	}{
+		{
+			name:     "mask root field",
+			fields:   []string{"$.address"},
+			input:    `{"name":"John","address":"123 Main St"}`,
+			expected: `{"name":"John","address":"[REDACTED]"}`,
+		},
+		{
+			name:     "mask nested field",
+			fields:   []string{"$.data.address"},
+			input:    `{"data":{"name":"John","address":"123 Main St"}}`,
+			expected: `{"data":{"name":"John","address":"[REDACTED]"}}`,
+		},
+		{
+			name:     "mask every array element",
+			fields:   []string{"$.data[*].address"},
+			input:    `{"data":[{"address":"a"},{"address":"b"}]}`,
+			expected: `{"data":[{"address":"[REDACTED]"},{"address":"[REDACTED]"}]}`,
+		},
+		{
+			name:     "mask single array element",
+			fields:   []string{"$.data[1].address"},
+			input:    `{"data":[{"address":"a"},{"address":"b"}]}`,
+			expected: `{"data":[{"address":"a"},{"address":"[REDACTED]"}]}`,
+		},
+		{
+			name:     "mask at any depth",
+			fields:   []string{"$..address"},
+			input:    `[{"address":"a","nested":{"address":"b"}},{"other":"c"}]`,
+			expected: `[{"address":"[REDACTED]","nested":{"address":"[REDACTED]"}},{"other":"c"}]`,
+		},
+		{
+			name:     "mask every key",
+			fields:   []string{"$.secrets.*"},
+			input:    `{"secrets":{"a":"1","b":"2"},"public":"3"}`,
+			expected: `{"secrets":{"a":"[REDACTED]","b":"[REDACTED]"},"public":"3"}`,
+		},
+		{
+			name:     "mask whole object",
+			fields:   []string{"$.data"},
+			input:    `{"data":{"address":"a"}}`,
+			expected: `{"data":"[REDACTED]"}`,
+		},
+		{
+			name:     "missing path is ignored",
+			fields:   []string{"$.data.address"},
+			input:    `{"name":"John"}`,
+			expected: `{"name":"John"}`,
+		},
+		{
+			name:     "multiple paths",
+			fields:   []string{"$.name", "$.address"},
+			input:    `{"id":1,"name":"John","address":"a"}`,
+			expected: `{"id":1,"name":"[REDACTED]","address":"[REDACTED]"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var input interface{}
+			assert.NoError(t, json.Unmarshal([]byte(tt.input), &input))
+
+			redactor := New(&Config{Fields: tt.fields, SuccessSampleRate: 1})
+			masked, err := json.Marshal(redactor.mask(input))
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(masked))
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package redactor
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+)
+
+// truncatedPattern matches a value capped before being logged, like a body, so
+// it is not capped twice.
+var truncatedPattern *regexp.Regexp = regexp.MustCompile(`\.\.\.\(truncated \d+ bytes\)$`)
+
+// ownField reports the fields written by zerolog itself, only their secrets
+// are replaced.
+func ownField(key string) bool {
+	return key == zerolog.LevelFieldName ||
+		key == zerolog.TimestampFieldName ||
+		key == zerolog.MessageFieldName ||
+		key == zerolog.ErrorFieldName ||
+		key == zerolog.CallerFieldName
+}
+
+// maskedKey reports whether a single segment path, like $.address or
+// $..address, targets a whole field of the line.
+func (r *Redactor) maskedKey(key string) bool {
+	for i := range r.fieldPaths {
+		if len(r.fieldPaths[i]) == 1 && !r.fieldPaths[i][0].any && r.fieldPaths[i][0].key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Redactor) capValue(raw []byte) []byte {
+	var (
+		capped []byte
+		err    error
+	)
+
+	if r.maxBodyBytes <= 0 || len(raw) <= r.maxBodyBytes {
+		return raw
+	}
+
+	capped, err = json.Marshal(r.truncate(raw))
+	if err != nil {
+		return raw
+	}
+
+	return capped
+}
+
+// field masks the raw JSON value of a line field. The field paths are applied
+// with the value as root, the same way for a map field and a Str field.
+func (r *Redactor) field(key string, raw []byte) []byte {
+	var (
+		decoder *json.Decoder
+		decoded interface{}
+		text    string
+		ok      bool
+		masked  []byte
+		err     error
+	)
+
+	if len(raw) == 0 || (raw[0] != '"' && raw[0] != '{' && raw[0] != '[') {
+		return raw
+	}
+
+	if !ownField(key) && r.maskedKey(key) {
+		masked, _ = json.Marshal(RedactedValue)
+		return masked
+	}
+
+	decoder = json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	err = decoder.Decode(&decoded)
+	if err != nil {
+		return raw
+	}
+
+	text, ok = decoded.(string)
+	if ok {
+		text = r.replaceSecrets(text)
+		if !ownField(key) &&
+			r.maxBodyBytes > 0 &&
+			len(text) > r.maxBodyBytes &&
+			!truncatedPattern.MatchString(text) {
+			text = r.truncate([]byte(text))
+		}
+		decoded = text
+	} else if ownField(key) {
+		decoded = r.maskSecrets(decoded)
+	} else {
+		decoded = r.mask(decoded)
+	}
+
+	masked, err = json.Marshal(decoded)
+	if err != nil {
+		return raw
+	}
+
+	if ok || ownField(key) {
+		return masked
+	}
+
+	return r.capValue(masked)
+}
+
+// Line masks a JSON log line field by field and keeps the field order. Values
+// above the maximum body bytes are capped, lines that are not JSON only get
+// their secrets replaced.
+func (r *Redactor) Line(line []byte) []byte {
+	var (
+		decoder *json.Decoder
+		token   json.Token
+		key     string
+		ok      bool
+		raw     json.RawMessage
+		rawKey  []byte
+		buffer  *bytes.Buffer
+		err     error
+	)
+
+	if len(r.fieldPaths) == 0 && r.secrets == nil && r.maxBodyBytes <= 0 {
+		return line
+	}
+
+	decoder = json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+
+	token, err = decoder.Token()
+	if err != nil || token != json.Delim('{') {
+		return []byte(r.replaceSecrets(string(line)))
+	}
+
+	buffer = bytes.NewBuffer(make([]byte, 0, len(line)))
+	buffer.WriteByte('{')
+
+	for decoder.More() {
+		token, err = decoder.Token()
+		if err != nil {
+			return []byte(r.replaceSecrets(string(line)))
+		}
+
+		key, ok = token.(string)
+		if !ok {
+			return []byte(r.replaceSecrets(string(line)))
+		}
+
+		raw = nil
+		err = decoder.Decode(&raw)
+		if err != nil {
+			return []byte(r.replaceSecrets(string(line)))
+		}
+
+		rawKey, err = json.Marshal(key)
+		if err != nil {
+			return []byte(r.replaceSecrets(string(line)))
+		}
+
+		if buffer.Len() > 1 {
+			buffer.WriteByte(',')
+		}
+		buffer.Write(rawKey)
+		buffer.WriteByte(':')
+		buffer.Write(r.field(key, raw))
+	}
+
+	buffer.WriteByte('}')
+	if bytes.HasSuffix(line, []byte("\n")) {
+		buffer.WriteByte('\n')
+	}
+
+	return buffer.Bytes()
+}
@@ -0,0 +1,100 @@
+package redactor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactor_Line(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		line     string
+		expected string
+	}{
+		{
+			name:     "line is kept when no rules configured",
+			cfg:      nil,
+			line:     `{"level":"info","entity":{"address":"123 Main St"}}` + "\n",
+			expected: `{"level":"info","entity":{"address":"123 Main St"}}` + "\n",
+		},
+		{
+			name:     "map field is masked with the field as root",
+			cfg:      &Config{Fields: []string{"$.address"}},
+			line:     `{"level":"info","entity":{"name":"John","address":"123 Main St"},"message":"found"}` + "\n",
+			expected: `{"level":"info","entity":{"address":"[REDACTED]","name":"John"},"message":"found"}` + "\n",
+		},
+		{
+			name:     "nested field is masked at any depth",
+			cfg:      &Config{Fields: []string{"$..address"}},
+			line:     `{"requestDTO":{"list":[{"name":"John","address":"123 Main St"}]}}`,
+			expected: `{"requestDTO":{"list":[{"address":"[REDACTED]","name":"John"}]}}`,
+		},
+		{
+			name:     "str field named by the path is masked",
+			cfg:      &Config{Fields: []string{"$..address"}},
+			line:     `{"address":"123 Main St","id":"1"}`,
+			expected: `{"address":"[REDACTED]","id":"1"}`,
+		},
+		{
+			name:     "field order and numbers are kept",
+			cfg:      &Config{Fields: []string{"$..address"}},
+			line:     `{"time":"2024-01-01T00:00:00Z","page":9007199254740993,"ok":true,"nil":null,"ratio":0.5}`,
+			expected: `{"time":"2024-01-01T00:00:00Z","page":9007199254740993,"ok":true,"nil":null,"ratio":0.5}`,
+		},
+		{
+			name:     "secrets are replaced in every field",
+			cfg:      &Config{Secrets: []string{"s3cret"}},
+			line:     `{"error":"dial redis://:s3cret@localhost: refused","dsn":"s3cret","entity":{"name":"s3cret"},"message":"s3cret"}`,
+			expected: `{"error":"dial redis://:[REDACTED]@localhost: refused","dsn":"[REDACTED]","entity":{"name":"[REDACTED]"},"message":"[REDACTED]"}`,
+		},
+		{
+			name:     "long string is capped",
+			cfg:      &Config{MaxBodyBytes: 8},
+			line:     `{"key":"caches:entities:guests:1"}`,
+			expected: `{"key":"caches:e...(truncated 16 bytes)"}`,
+		},
+		{
+			name:     "large map is capped after masking",
+			cfg:      &Config{Fields: []string{"$..address"}, MaxBodyBytes: 16},
+			line:     `{"entity":{"name":"John Doe","address":"123 Main St"}}`,
+			expected: `{"entity":"{\"address\":\"[RED...(truncated 26 bytes)"}`,
+		},
+		{
+			name:     "capped value is not capped twice",
+			cfg:      &Config{MaxBodyBytes: 8},
+			line:     `{"request body":"{\"name\":...(truncated 20 bytes)"}`,
+			expected: `{"request body":"{\"name\":...(truncated 20 bytes)"}`,
+		},
+		{
+			name:     "zerolog fields are not capped",
+			cfg:      &Config{MaxBodyBytes: 4},
+			line:     `{"level":"error","error":"connection refused","message":"failed to connect"}`,
+			expected: `{"level":"error","error":"connection refused","message":"failed to connect"}`,
+		},
+		{
+			name:     "non json line only gets secrets replaced",
+			cfg:      &Config{Fields: []string{"$..address"}, Secrets: []string{"s3cret"}},
+			line:     "12:00 INF password=s3cret\n",
+			expected: "12:00 INF password=[REDACTED]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, string(New(tt.cfg).Line([]byte(tt.line))))
+		})
+	}
+
+	t.Run("line is not copied when no rules configured", func(t *testing.T) {
+		line := []byte(`{"address":"123 Main St"}`)
+		assert.Same(t, &line[0], &New(nil).Line(line)[0])
+	})
+
+	t.Run("large line is masked", func(t *testing.T) {
+		line := `{"entity":{"address":"123 Main St","name":"` + strings.Repeat("a", 1<<16) + `"}}`
+		assert.NotContains(t, string(New(&Config{Fields: []string{"$..address"}}).Line([]byte(line))), "123 Main St")
+	})
+}
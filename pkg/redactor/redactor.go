@@ -0,0 +1,191 @@
+package redactor
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+const RedactedValue string = "[REDACTED]"
+
+var defaultHeaders []string = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+type Config struct {
+	Headers           []string
+	Fields            []string
+	MaxBodyBytes      int
+	SuccessSampleRate float64
+	// Secrets are replaced wherever they appear in a logged value, e.g. the
+	// data source names resolved from secret references.
+	Secrets []string
+}
+
+type Redactor struct {
+	headers           map[string]bool
+	fieldPaths        [][]pathSegment
+	secrets           *strings.Replacer
+	maxBodyBytes      int
+	successSampleRate float64
+}
+
+var globalRedactor *Redactor
+
+func init() {
+	globalRedactor = New(nil)
+}
+
+func New(cfg *Config) *Redactor {
+	var (
+		redactor *Redactor = &Redactor{
+			headers:           map[string]bool{},
+			fieldPaths:        [][]pathSegment{},
+			successSampleRate: 1,
+		}
+		secrets []string
+		oldnew  []string
+	)
+
+	for i := range defaultHeaders {
+		redactor.headers[http.CanonicalHeaderKey(defaultHeaders[i])] = true
+	}
+
+	if cfg == nil {
+		return redactor
+	}
+
+	for i := range cfg.Headers {
+		if strings.TrimSpace(cfg.Headers[i]) == "" {
+			continue
+		}
+		redactor.headers[http.CanonicalHeaderKey(strings.TrimSpace(cfg.Headers[i]))] = true
+	}
+
+	for i := range cfg.Fields {
+		if strings.TrimSpace(cfg.Fields[i]) == "" {
+			continue
+		}
+		redactor.fieldPaths = append(redactor.fieldPaths, parsePath(strings.TrimSpace(cfg.Fields[i])))
+	}
+
+	for i := range cfg.Secrets {
+		if cfg.Secrets[i] == "" || slices.Contains(secrets, cfg.Secrets[i]) {
+			continue
+		}
+		secrets = append(secrets, cfg.Secrets[i])
+	}
+
+	if len(secrets) > 0 {
+		// longer secrets first, so a DSN is replaced as a whole before the
+		// password inside it
+		slices.SortFunc(secrets, func(a, b string) int {
+			return len(b) - len(a)
+		})
+		for i := range secrets {
+			oldnew = append(oldnew, secrets[i], RedactedValue)
+		}
+		redactor.secrets = strings.NewReplacer(oldnew...)
+	}
+
+	redactor.maxBodyBytes = cfg.MaxBodyBytes
+	redactor.successSampleRate = cfg.SuccessSampleRate
+
+	return redactor
+}
+
+func NewRedactor(cfg *Config) {
+	globalRedactor = New(cfg)
+}
+
+func (r *Redactor) Headers(headers map[string][]string) map[string][]string {
+	var redactedHeaders map[string][]string = make(map[string][]string, len(headers))
+
+	for key, values := range headers {
+		if r.headers[http.CanonicalHeaderKey(key)] {
+			redactedHeaders[key] = []string{RedactedValue}
+			continue
+		}
+		redactedHeaders[key] = values
+	}
+
+	return redactedHeaders
+}
+
+func (r *Redactor) replaceSecrets(value string) string {
+	if r.secrets == nil {
+		return value
+	}
+
+	return r.secrets.Replace(value)
+}
+
+func (r *Redactor) truncate(raw []byte) string {
+	if r.maxBodyBytes <= 0 || len(raw) <= r.maxBodyBytes {
+		return string(raw)
+	}
+
+	return fmt.Sprintf("%s...(truncated %d bytes)", raw[:r.maxBodyBytes], len(raw)-r.maxBodyBytes)
+}
+
+// Body masks JSON bodies and caps them to the maximum body bytes, non JSON
+// bodies are only capped.
+func (r *Redactor) Body(body []byte) string {
+	var (
+		decoded interface{}
+		masked  []byte
+		err     error
+	)
+
+	if len(body) == 0 || (len(r.fieldPaths) == 0 && r.secrets == nil) {
+		return r.truncate(body)
+	}
+
+	err = json.Unmarshal(body, &decoded)
+	if err != nil {
+		return r.truncate([]byte(r.replaceSecrets(string(body))))
+	}
+
+	masked, err = json.Marshal(r.mask(decoded))
+	if err != nil {
+		return r.truncate([]byte(r.replaceSecrets(string(body))))
+	}
+
+	return r.truncate(masked)
+}
+
+func (r *Redactor) SampleSuccess() bool {
+	if r.successSampleRate >= 1 {
+		return true
+	}
+
+	if r.successSampleRate <= 0 {
+		return false
+	}
+
+	return rand.Float64() < r.successSampleRate
+}
+
+func Headers(headers map[string][]string) map[string][]string {
+	return globalRedactor.Headers(headers)
+}
+
+func Body(body []byte) string {
+	return globalRedactor.Body(body)
+}
+
+func Line(line []byte) []byte {
+	return globalRedactor.Line(line)
+}
+
+func SampleSuccess() bool {
+	return globalRedactor.SampleSuccess()
+}
@@ -0,0 +1,50 @@
+package redactor
+
+import (
+	"io"
+
+	"github.com/rs/zerolog"
+)
+
+// Writer redacts every log line before writing it, whatever way its fields
+// were added, with the global redactor.
+type Writer struct {
+	out io.Writer
+}
+
+func NewWriter(out io.Writer) *Writer {
+	return &Writer{
+		out: out,
+	}
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	var err error
+
+	_, err = w.out.Write(Line(p))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (w *Writer) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	var (
+		levelWriter zerolog.LevelWriter
+		ok          bool
+		err         error
+	)
+
+	levelWriter, ok = w.out.(zerolog.LevelWriter)
+	if !ok {
+		return w.Write(p)
+	}
+
+	_, err = levelWriter.WriteLevel(level, Line(p))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,69 @@
+package redactor
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+type levelWriter struct {
+	bytes.Buffer
+	level zerolog.Level
+}
+
+func (w *levelWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	w.level = level
+	return w.Write(p)
+}
+
+func TestWriter(t *testing.T) {
+	originalRedactor := globalRedactor
+	t.Cleanup(func() {
+		globalRedactor = originalRedactor
+	})
+	NewRedactor(&Config{Fields: []string{"$..address"}})
+
+	t.Run("every way of adding fields is redacted", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := zerolog.New(NewWriter(&buf))
+
+		logger.Info().
+			Fields(map[string]interface{}{"entity": map[string]string{"address": "123 Main St"}}).
+			Interface("requestDTO", struct {
+				Address string `json:"address"`
+			}{Address: "456 Oak Ave"}).
+			Str("address", "789 Pine St").
+			Msg("created")
+
+		assert.NotContains(t, buf.String(), "123 Main St")
+		assert.NotContains(t, buf.String(), "456 Oak Ave")
+		assert.NotContains(t, buf.String(), "789 Pine St")
+		assert.Contains(t, buf.String(), `"message":"created"`)
+	})
+
+	t.Run("level is passed to a level writer", func(t *testing.T) {
+		out := &levelWriter{}
+		logger := zerolog.New(NewWriter(out))
+
+		logger.Warn().Str("address", "123 Main St").Msg("warned")
+
+		assert.Equal(t, zerolog.WarnLevel, out.level)
+		assert.Equal(t, `{"level":"warn","address":"[REDACTED]","message":"warned"}`+"\n", out.String())
+	})
+
+	t.Run("write error is returned", func(t *testing.T) {
+		n, err := NewWriter(failingWriter{}).Write([]byte(`{"address":"123 Main St"}`))
+
+		assert.EqualError(t, err, "disk full")
+		assert.Zero(t, n)
+	})
+}
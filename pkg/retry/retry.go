@@ -0,0 +1,88 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Policy struct {
+	// Attempts below 1 means a single attempt.
+	Attempts       int
+	InitialBackoff time.Duration
+	// MaxBackoff caps the backoff, which doubles after every failed attempt.
+	MaxBackoff time.Duration
+	// Deadline bounds all the attempts together, zero means no deadline.
+	Deadline time.Duration
+}
+
+func nextBackoff(policy *Policy, backoff time.Duration) time.Duration {
+	backoff *= 2
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+
+	return backoff
+}
+
+// Do calls fn until it succeeds, the attempts run out or the deadline passes,
+// and returns the last error naming the dependency.
+func Do(ctx context.Context, name string, policy *Policy, fn func(ctx context.Context) error) error {
+	var (
+		cancel   context.CancelFunc
+		attempts int
+		attempt  int
+		backoff  time.Duration
+		timer    *time.Timer
+		err      error
+	)
+
+	if policy == nil {
+		policy = &Policy{}
+	}
+
+	attempts = policy.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	if policy.Deadline > 0 {
+		ctx, cancel = context.WithTimeout(ctx, policy.Deadline)
+		defer cancel()
+	}
+
+	backoff = policy.InitialBackoff
+
+	for attempt = 1; attempt <= attempts; attempt++ {
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if attempt == attempts {
+			break
+		}
+
+		log.Warn().
+			Err(err).
+			Str("dependency", name).
+			Int("attempt", attempt).
+			Int("attempts", attempts).
+			Dur("backoff", backoff).
+			Msg("[retry][Do] dependency unavailable, retrying")
+
+		timer = time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%s is unavailable after %d attempt(s), %v: %w", name, attempt, ctx.Err(), err)
+		case <-timer.C:
+		}
+
+		backoff = nextBackoff(policy, backoff)
+	}
+
+	return fmt.Errorf("%s is unavailable after %d attempt(s): %w", name, attempts, err)
+}
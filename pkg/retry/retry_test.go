@@ -0,0 +1,110 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDo(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        *Policy
+		failures      int
+		expectedCalls int
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:          "nil policy should attempt once",
+			policy:        nil,
+			failures:      1,
+			expectedCalls: 1,
+			expectError:   true,
+			errorContains: "database is unavailable after 1 attempt(s): connection refused",
+		},
+		{
+			name:          "success on first attempt should not retry",
+			policy:        &Policy{Attempts: 3, InitialBackoff: time.Millisecond},
+			failures:      0,
+			expectedCalls: 1,
+			expectError:   false,
+		},
+		{
+			name:          "success after failures should retry",
+			policy:        &Policy{Attempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+			failures:      2,
+			expectedCalls: 3,
+			expectError:   false,
+		},
+		{
+			name:          "attempts running out should return the last error",
+			policy:        &Policy{Attempts: 3, InitialBackoff: time.Millisecond},
+			failures:      5,
+			expectedCalls: 3,
+			expectError:   true,
+			errorContains: "database is unavailable after 3 attempt(s): connection refused",
+		},
+		{
+			name:          "deadline should stop retrying",
+			policy:        &Policy{Attempts: 10, InitialBackoff: time.Second, Deadline: 10 * time.Millisecond},
+			failures:      10,
+			expectedCalls: 1,
+			expectError:   true,
+			errorContains: "database is unavailable after 1 attempt(s), context deadline exceeded: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+
+			err := Do(context.Background(), "database", tt.policy, func(ctx context.Context) error {
+				calls++
+				if calls <= tt.failures {
+					return errors.New("connection refused")
+				}
+				return nil
+			})
+
+			assert.Equal(t, tt.expectedCalls, calls)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_nextBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *Policy
+		backoff  time.Duration
+		expected time.Duration
+	}{
+		{
+			name:     "backoff should double",
+			policy:   &Policy{},
+			backoff:  time.Second,
+			expected: 2 * time.Second,
+		},
+		{
+			name:     "backoff should be capped",
+			policy:   &Policy{MaxBackoff: 3 * time.Second},
+			backoff:  2 * time.Second,
+			expected: 3 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, nextBackoff(tt.policy, tt.backoff))
+		})
+	}
+}
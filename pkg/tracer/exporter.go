@@ -0,0 +1,100 @@
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdk_metric "go.opentelemetry.io/otel/sdk/metric"
+	sdk_trace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// resolveExporter keeps the previous behavior of an empty exporter: OTLP when
+// an address is configured, disabled otherwise.
+func resolveExporter(cfg *Config) string {
+	var exporter string = strings.ToLower(strings.TrimSpace(cfg.Exporter))
+
+	if exporter == "" {
+		exporter = ExporterOTLP
+	}
+
+	if exporter == ExporterOTLP && strings.TrimSpace(cfg.ExporterGRPCAddress) == "" {
+		return ExporterNone
+	}
+
+	return exporter
+}
+
+func openExporterFile(filePath string) (io.Writer, error) {
+	var err error
+
+	if filePath == "" {
+		return nil, fmt.Errorf("exporter file path is required for the %s exporter", ExporterFile)
+	}
+
+	err = os.MkdirAll(filepath.Dir(filePath), 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+}
+
+func newSpanExporter(ctx context.Context, cfg *Config, exporter string) (sdk_trace.SpanExporter, error) {
+	var (
+		writer io.Writer
+		err    error
+	)
+
+	switch exporter {
+	case ExporterOTLP:
+		return otlptracegrpc.New(
+			ctx,
+			otlptracegrpc.WithEndpoint(cfg.ExporterGRPCAddress),
+			otlptracegrpc.WithInsecure(),
+		)
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterFile:
+		writer, err = openExporterFile(cfg.ExporterFilePath)
+		if err != nil {
+			return nil, err
+		}
+		return stdouttrace.New(stdouttrace.WithWriter(writer))
+	}
+
+	return nil, fmt.Errorf("unknown tracer exporter %q", exporter)
+}
+
+func newMetricExporter(ctx context.Context, cfg *Config, exporter string) (sdk_metric.Exporter, error) {
+	var (
+		writer io.Writer
+		err    error
+	)
+
+	switch exporter {
+	case ExporterOTLP:
+		return otlpmetricgrpc.New(
+			ctx,
+			otlpmetricgrpc.WithEndpoint(cfg.ExporterGRPCAddress),
+			otlpmetricgrpc.WithInsecure(),
+		)
+	case ExporterStdout:
+		return stdoutmetric.New(stdoutmetric.WithWriter(os.Stdout))
+	case ExporterFile:
+		writer, err = openExporterFile(cfg.ExporterFilePath)
+		if err != nil {
+			return nil, err
+		}
+		return stdoutmetric.New(stdoutmetric.WithWriter(writer))
+	}
+
+	return nil, fmt.Errorf("unknown tracer exporter %q", exporter)
+}
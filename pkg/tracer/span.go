@@ -0,0 +1,35 @@
+package tracer
+
+import (
+	"net/http"
+
+	"github.com/fikri240794/gocerr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const ErrorCodeKey attribute.Key = "error.code"
+
+// RecordError records err on span with its gocerr code. Only server errors and
+// errors without a code mark the span as failed, so expected client errors such
+// as a cache miss do not turn the trace red.
+func RecordError(span trace.Span, err error) {
+	var code int
+
+	if err == nil {
+		return
+	}
+
+	code = gocerr.GetErrorCode(err)
+
+	span.RecordError(err)
+
+	if code != 0 {
+		span.SetAttributes(ErrorCodeKey.Int(code))
+	}
+
+	if code == 0 || code >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
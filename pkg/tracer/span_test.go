@@ -0,0 +1,76 @@
+package tracer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdk_trace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRecordError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus codes.Code
+		expectedEvents int
+		expectedCode   *attribute.KeyValue
+	}{
+		{
+			name:           "nil error should not record anything",
+			err:            nil,
+			expectedStatus: codes.Unset,
+			expectedEvents: 0,
+		},
+		{
+			name:           "error without code should mark span as failed",
+			err:            errors.New("connection refused"),
+			expectedStatus: codes.Error,
+			expectedEvents: 1,
+		},
+		{
+			name:           "server error should mark span as failed with code",
+			err:            gocerr.New(http.StatusInternalServerError, "error"),
+			expectedStatus: codes.Error,
+			expectedEvents: 1,
+			expectedCode:   &attribute.KeyValue{Key: ErrorCodeKey, Value: attribute.IntValue(http.StatusInternalServerError)},
+		},
+		{
+			name:           "client error should be recorded without failing span",
+			err:            gocerr.New(http.StatusNotFound, "entity not found"),
+			expectedStatus: codes.Unset,
+			expectedEvents: 1,
+			expectedCode:   &attribute.KeyValue{Key: ErrorCodeKey, Value: attribute.IntValue(http.StatusNotFound)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdk_trace.NewTracerProvider(sdk_trace.WithSpanProcessor(recorder))
+
+			_, span := provider.Tracer("test").Start(context.Background(), "test")
+			RecordError(span, tt.err)
+			span.End()
+
+			spans := recorder.Ended()
+			assert.Len(t, spans, 1)
+			assert.Equal(t, tt.expectedStatus, spans[0].Status().Code)
+			assert.Len(t, spans[0].Events(), tt.expectedEvents)
+
+			if tt.expectedCode != nil {
+				assert.Contains(t, spans[0].Attributes(), *tt.expectedCode)
+			} else {
+				for _, attr := range spans[0].Attributes() {
+					assert.NotEqual(t, ErrorCodeKey, attr.Key)
+				}
+			}
+		})
+	}
+}
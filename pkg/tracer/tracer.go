@@ -2,19 +2,219 @@ package tracer
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
-	"github.com/fikri240794/goteletracer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+	prometheus_bridge "go.opentelemetry.io/contrib/bridges/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdk_metric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdk_trace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
-var globalTracer trace.Tracer
+const (
+	ExporterOTLP   string = "otlp"
+	ExporterStdout string = "stdout"
+	ExporterFile   string = "file"
+	ExporterNone   string = "none"
+
+	defaultMetricsExportInterval time.Duration = time.Minute
+)
+
+type MetricsConfig struct {
+	Enable         bool
+	ExportInterval time.Duration
+	// Gatherer is bridged into the OTLP pipeline so the Prometheus metrics are
+	// exported alongside traces.
+	Gatherer prometheus.Gatherer
+}
+
+type Config struct {
+	ServiceName         string
+	ServiceVersion      string
+	Environment         string
+	Exporter            string
+	ExporterGRPCAddress string
+	ExporterFilePath    string
+	SampleRatio         float64
+	Metrics             MetricsConfig
+}
+
+var (
+	globalTracer         trace.Tracer
+	globalTracerProvider *sdk_trace.TracerProvider
+	globalMeterProvider  *sdk_metric.MeterProvider
+)
 
 func init() {
-	globalTracer = goteletracer.NewTracer(nil)
+	globalTracer = noop.NewTracerProvider().Tracer("")
+}
+
+func newResource(ctx context.Context, cfg *Config) (*resource.Resource, error) {
+	return resource.New(
+		ctx,
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(cfg.ServiceName),
+			semconv.ServiceVersionKey.String(cfg.ServiceVersion),
+			semconv.DeploymentEnvironmentKey.String(cfg.Environment),
+		),
+	)
+}
+
+// newSampler keeps the sampling decision of the parent span, so a trace is
+// either fully sampled or not across services, and samples root spans by ratio.
+func newSampler(ratio float64) sdk_trace.Sampler {
+	return sdk_trace.ParentBased(sdk_trace.TraceIDRatioBased(ratio))
+}
+
+func newMeterProvider(ctx context.Context, cfg *Config, res *resource.Resource, exporter string) (*sdk_metric.MeterProvider, error) {
+	var (
+		metricExporter sdk_metric.Exporter
+		readerOptions  []sdk_metric.PeriodicReaderOption
+		interval       time.Duration
+		err            error
+	)
+
+	metricExporter, err = newMetricExporter(ctx, cfg, exporter)
+	if err != nil {
+		return nil, err
+	}
+
+	interval = cfg.Metrics.ExportInterval
+	if interval <= 0 {
+		interval = defaultMetricsExportInterval
+	}
+
+	readerOptions = []sdk_metric.PeriodicReaderOption{
+		sdk_metric.WithInterval(interval),
+	}
+
+	if cfg.Metrics.Gatherer != nil {
+		readerOptions = append(
+			readerOptions,
+			sdk_metric.WithProducer(prometheus_bridge.NewMetricProducer(prometheus_bridge.WithGatherer(cfg.Metrics.Gatherer))),
+		)
+	}
+
+	return sdk_metric.NewMeterProvider(
+		sdk_metric.WithResource(res),
+		sdk_metric.WithReader(sdk_metric.NewPeriodicReader(metricExporter, readerOptions...)),
+	), nil
+}
+
+func newProviders(cfg *Config) (*sdk_trace.TracerProvider, *sdk_metric.MeterProvider, error) {
+	var (
+		ctx            context.Context
+		cancel         context.CancelFunc
+		exporter       string
+		res            *resource.Resource
+		spanExporter   sdk_trace.SpanExporter
+		tracerProvider *sdk_trace.TracerProvider
+		meterProvider  *sdk_metric.MeterProvider
+		err            error
+	)
+
+	exporter = resolveExporter(cfg)
+	if exporter == ExporterNone {
+		return nil, nil, nil
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err = newResource(ctx, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	spanExporter, err = newSpanExporter(ctx, cfg, exporter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create span exporter: %w", err)
+	}
+
+	tracerProvider = sdk_trace.NewTracerProvider(
+		sdk_trace.WithResource(res),
+		sdk_trace.WithBatcher(spanExporter),
+		sdk_trace.WithSampler(newSampler(cfg.SampleRatio)),
+	)
+
+	if cfg.Metrics.Enable {
+		meterProvider, err = newMeterProvider(ctx, cfg, res, exporter)
+		if err != nil {
+			return nil, nil, errors.Join(
+				fmt.Errorf("failed to create meter provider: %w", err),
+				tracerProvider.Shutdown(ctx),
+			)
+		}
+	}
+
+	return tracerProvider, meterProvider, nil
+}
+
+// NewTracer falls back to a noop tracer when the exporter is disabled or can
+// not be created, so tracing never blocks the servers from starting.
+func NewTracer(cfg *Config) {
+	var (
+		tracerProvider *sdk_trace.TracerProvider
+		meterProvider  *sdk_metric.MeterProvider
+		err            error
+	)
+
+	globalTracer = noop.NewTracerProvider().Tracer("")
+	globalTracerProvider = nil
+	globalMeterProvider = nil
+
+	if cfg == nil {
+		return
+	}
+
+	tracerProvider, meterProvider, err = newProviders(cfg)
+	if err != nil {
+		log.Err(err).
+			Msg("[tracer][NewTracer][newProviders] failed to create providers, tracing is disabled")
+		return
+	}
+
+	if tracerProvider == nil {
+		return
+	}
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if meterProvider != nil {
+		otel.SetMeterProvider(meterProvider)
+	}
+
+	globalTracerProvider = tracerProvider
+	globalMeterProvider = meterProvider
+	globalTracer = tracerProvider.Tracer(cfg.ServiceName)
 }
 
-func NewTracer(cfg *goteletracer.Config) {
-	globalTracer = goteletracer.NewTracer(cfg)
+// Flush exports every buffered span and metric. It is safe to call more than
+// once, e.g. by every server sharing the process.
+func Flush(ctx context.Context) error {
+	var errs []error
+
+	if globalTracerProvider != nil {
+		errs = append(errs, globalTracerProvider.ForceFlush(ctx))
+	}
+
+	if globalMeterProvider != nil {
+		errs = append(errs, globalMeterProvider.ForceFlush(ctx))
+	}
+
+	return errors.Join(errs...)
 }
 
 func Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
@@ -2,65 +2,286 @@ package tracer
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
-	"github.com/fikri240794/goteletracer"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/otel/trace"
 )
 
-func TestNewTracer(t *testing.T) {
+func resetGlobals(t *testing.T) {
 	originalTracer := globalTracer
-	defer func() {
+	originalTracerProvider := globalTracerProvider
+	originalMeterProvider := globalMeterProvider
+	t.Cleanup(func() {
 		globalTracer = originalTracer
-	}()
+		globalTracerProvider = originalTracerProvider
+		globalMeterProvider = originalMeterProvider
+	})
+}
+
+func TestNewTracer(t *testing.T) {
+	resetGlobals(t)
 
 	tests := []struct {
-		name   string
-		config *goteletracer.Config
+		name     string
+		setupCfg func(t *testing.T) *Config
+		validate func(t *testing.T, cfg *Config)
 	}{
 		{
-			name:   "nil config should initialize tracer",
-			config: nil,
+			name: "nil config should initialize noop tracer",
+			setupCfg: func(t *testing.T) *Config {
+				return nil
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.NotNil(t, globalTracer)
+				assert.Nil(t, globalTracerProvider)
+				assert.Nil(t, globalMeterProvider)
+			},
 		},
 		{
-			name: "with config should initialize tracer",
-			config: &goteletracer.Config{
-				ServiceName:         "test-service",
-				ExporterGRPCAddress: "localhost:4317",
+			name: "none exporter should initialize noop tracer",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{ServiceName: "test-service", Exporter: ExporterNone}
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.NotNil(t, globalTracer)
+				assert.Nil(t, globalTracerProvider)
 			},
 		},
 		{
-			name: "with partial config should initialize tracer",
-			config: &goteletracer.Config{
-				ServiceName: "test-service",
+			name: "otlp exporter without address should initialize noop tracer",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{ServiceName: "test-service", Exporter: ExporterOTLP}
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Nil(t, globalTracerProvider)
 			},
 		},
 		{
-			name: "with empty config should initialize tracer",
-			config: &goteletracer.Config{
-				ServiceName:         "",
-				ExporterGRPCAddress: "",
+			name: "otlp exporter with address should initialize provider",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{
+					ServiceName:         "test-service",
+					ExporterGRPCAddress: "localhost:4317",
+					SampleRatio:         1,
+				}
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.NotNil(t, globalTracerProvider)
+				assert.Nil(t, globalMeterProvider)
+			},
+		},
+		{
+			name: "stdout exporter with metrics should initialize both providers",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{
+					ServiceName: "test-service",
+					Exporter:    ExporterStdout,
+					SampleRatio: 1,
+					Metrics: MetricsConfig{
+						Enable:   true,
+						Gatherer: prometheus.NewRegistry(),
+					},
+				}
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.NotNil(t, globalTracerProvider)
+				assert.NotNil(t, globalMeterProvider)
+			},
+		},
+		{
+			name: "file exporter should write spans with resource attributes on flush",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{
+					ServiceName:      "test-service",
+					ServiceVersion:   "1.2.3",
+					Environment:      "test",
+					Exporter:         ExporterFile,
+					ExporterFilePath: filepath.Join(t.TempDir(), "telemetry", "spans.log"),
+					SampleRatio:      1,
+				}
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				_, span := Start(context.Background(), "file-exporter-span")
+				span.End()
+
+				assert.NoError(t, Flush(context.Background()))
+
+				content, err := os.ReadFile(cfg.ExporterFilePath)
+				assert.NoError(t, err)
+				assert.Contains(t, string(content), "file-exporter-span")
+				assert.Contains(t, string(content), "1.2.3")
+				assert.Contains(t, string(content), "deployment.environment")
+			},
+		},
+		{
+			name: "file exporter without path should initialize noop tracer",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{ServiceName: "test-service", Exporter: ExporterFile}
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.NotNil(t, globalTracer)
+				assert.Nil(t, globalTracerProvider)
+			},
+		},
+		{
+			name: "unknown exporter should initialize noop tracer",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{ServiceName: "test-service", Exporter: "zipkin"}
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.NotNil(t, globalTracer)
+				assert.Nil(t, globalTracerProvider)
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			globalTracer = nil
+			cfg := tt.setupCfg(t)
 
-			NewTracer(tt.config)
+			NewTracer(cfg)
 
-			assert.NotNil(t, globalTracer, "Expected globalTracer to be initialized")
+			tt.validate(t, cfg)
+		})
+	}
+}
+
+func TestNewSampler(t *testing.T) {
+	resetGlobals(t)
+
+	remoteParent := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+
+	tests := []struct {
+		name            string
+		ratio           float64
+		ctx             context.Context
+		expectedSampled bool
+	}{
+		{
+			name:            "ratio 1 should sample root spans",
+			ratio:           1,
+			ctx:             context.Background(),
+			expectedSampled: true,
+		},
+		{
+			name:            "ratio 0 should drop root spans",
+			ratio:           0,
+			ctx:             context.Background(),
+			expectedSampled: false,
+		},
+		{
+			name:            "ratio 0 should follow sampled remote parent",
+			ratio:           0,
+			ctx:             remoteParent,
+			expectedSampled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			NewTracer(&Config{
+				ServiceName:      "test-service",
+				Exporter:         ExporterFile,
+				ExporterFilePath: filepath.Join(t.TempDir(), "spans.log"),
+				SampleRatio:      tt.ratio,
+			})
+
+			_, span := Start(tt.ctx, "sampled-span")
+			defer span.End()
+
+			assert.Equal(t, tt.expectedSampled, span.SpanContext().IsSampled())
+		})
+	}
+}
+
+func TestResolveExporter(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected string
+	}{
+		{
+			name:     "empty exporter with address should resolve to otlp",
+			cfg:      &Config{ExporterGRPCAddress: "localhost:4317"},
+			expected: ExporterOTLP,
+		},
+		{
+			name:     "empty exporter without address should resolve to none",
+			cfg:      &Config{},
+			expected: ExporterNone,
+		},
+		{
+			name:     "exporter should be case insensitive",
+			cfg:      &Config{Exporter: " STDOUT "},
+			expected: ExporterStdout,
+		},
+		{
+			name:     "file exporter should be kept",
+			cfg:      &Config{Exporter: ExporterFile},
+			expected: ExporterFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolveExporter(tt.cfg))
+		})
+	}
+}
+
+func TestFlush(t *testing.T) {
+	resetGlobals(t)
+
+	tests := []struct {
+		name     string
+		setupCfg func(t *testing.T) *Config
+	}{
+		{
+			name: "flush without providers should succeed",
+			setupCfg: func(t *testing.T) *Config {
+				return nil
+			},
+		},
+		{
+			name: "flush with trace and meter providers should succeed",
+			setupCfg: func(t *testing.T) *Config {
+				return &Config{
+					ServiceName:      "test-service",
+					Exporter:         ExporterFile,
+					ExporterFilePath: filepath.Join(t.TempDir(), "telemetry.log"),
+					SampleRatio:      1,
+					Metrics: MetricsConfig{
+						Enable:         true,
+						ExportInterval: time.Hour,
+						Gatherer:       prometheus.NewRegistry(),
+					},
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			NewTracer(tt.setupCfg(t))
+
+			assert.NoError(t, Flush(context.Background()))
+			assert.NoError(t, Flush(context.Background()), "Flush should be safe to call twice")
 		})
 	}
 }
 
 func TestStart(t *testing.T) {
-	originalTracer := globalTracer
-	defer func() {
-		globalTracer = originalTracer
-	}()
+	resetGlobals(t)
 
 	NewTracer(nil)
 
@@ -0,0 +1,28 @@
+//go:build wireinject
+// +build wireinject
+
+package cli
+
+import (
+	"go-boilerplate/configs"
+	"go-boilerplate/datasources"
+	"go-boilerplate/internal/repositories"
+	"go-boilerplate/internal/services"
+	"go-boilerplate/transports/cli/handlers"
+
+	"github.com/google/wire"
+)
+
+func BuildCLI(cfg *configs.Config) (*CLI, func(), error) {
+	wire.Build(
+		datasources.Provider,
+		wire.Struct(new(datasources.Datasources), "*"),
+		repositories.Provider,
+		services.Provider,
+		handlers.Provider,
+		wire.Struct(new(handlers.Handlers), "*"),
+		NewCLI,
+	)
+
+	return nil, nil, nil
+}
@@ -0,0 +1,43 @@
+package cli
+
+//go:generate go run github.com/google/wire/cmd/wire
+
+import (
+	"go-boilerplate/configs"
+	"go-boilerplate/datasources"
+	"go-boilerplate/transports/cli/handlers"
+)
+
+type CLI struct {
+	cfg         *configs.Config
+	datasources *datasources.Datasources
+	handlers    *handlers.Handlers
+}
+
+func NewCLI(
+	cfg *configs.Config,
+	ds *datasources.Datasources,
+	handlers *handlers.Handlers,
+) *CLI {
+	return &CLI{
+		cfg:         cfg,
+		datasources: ds,
+		handlers:    handlers,
+	}
+}
+
+func (c *CLI) Run(fn func(handlers *handlers.Handlers) error) error {
+	var (
+		errDisconnect error
+		err           error
+	)
+
+	err = fn(c.handlers)
+
+	errDisconnect = c.datasources.Disconnect()
+	if err == nil {
+		err = errDisconnect
+	}
+
+	return err
+}
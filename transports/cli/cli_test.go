@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"go-boilerplate/configs"
+	"go-boilerplate/datasources"
+	"go-boilerplate/transports/cli/handlers"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCLI(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *configs.Config
+		ds       *datasources.Datasources
+		handlers *handlers.Handlers
+		validate func(t *testing.T, cli *CLI)
+	}{
+		{
+			name:     "should_create_new_cli_successfully",
+			cfg:      &configs.Config{},
+			ds:       &datasources.Datasources{},
+			handlers: &handlers.Handlers{Guest: &handlers.GuestHandler{}},
+			validate: func(t *testing.T, cli *CLI) {
+				assert.NotNil(t, cli)
+				assert.NotNil(t, cli.cfg)
+				assert.NotNil(t, cli.datasources)
+				assert.NotNil(t, cli.handlers)
+				assert.NotNil(t, cli.handlers.Guest)
+			},
+		},
+		{
+			name:     "should_create_new_cli_with_nil_dependencies",
+			cfg:      nil,
+			ds:       nil,
+			handlers: nil,
+			validate: func(t *testing.T, cli *CLI) {
+				assert.NotNil(t, cli)
+				assert.Nil(t, cli.cfg)
+				assert.Nil(t, cli.datasources)
+				assert.Nil(t, cli.handlers)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.validate(t, NewCLI(tt.cfg, tt.ds, tt.handlers))
+		})
+	}
+}
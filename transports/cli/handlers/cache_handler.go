@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"go-boilerplate/internal/models/dtos"
+	"go-boilerplate/internal/services"
+	"go-boilerplate/pkg/tracer"
+	"go-boilerplate/transports/cli/models/vms"
+	"io"
+	"net/http"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type CacheHandler struct {
+	cacheService services.ICacheService
+}
+
+func NewCacheHandler(cacheService services.ICacheService) *CacheHandler {
+	return &CacheHandler{
+		cacheService: cacheService,
+	}
+}
+
+func (h *CacheHandler) Warm(ctx context.Context, out io.Writer) error {
+	var (
+		span        trace.Span
+		responseDTO *dtos.WarmCacheResponseDTO
+		err         error
+	)
+
+	ctx, span = tracer.Start(ctx, "[CacheHandler][Warm]")
+	defer span.End()
+
+	responseDTO, err = h.cacheService.Warm(ctx)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Msg("[CacheHandler][Warm][Warm] failed to warm caches")
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "%d pages and %d guests warmed, %d failed\n", responseDTO.Pages, responseDTO.IDs, responseDTO.Failed)
+	tracer.RecordError(span, err)
+
+	return err
+}
+
+func (h *CacheHandler) Stats(ctx context.Context, out io.Writer, requestVM *vms.CacheStatsRequestVM) error {
+	var (
+		span        trace.Span
+		logFields   map[string]interface{}
+		requestDTO  *dtos.CacheStatsRequestDTO
+		responseDTO *dtos.CacheStatsResponseDTO
+		logLevel    zerolog.Level
+		encoder     *json.Encoder
+		err         error
+	)
+
+	ctx, span = tracer.Start(ctx, "[CacheHandler][Stats]")
+	defer span.End()
+
+	if requestVM == nil {
+		return gocerr.New(http.StatusBadRequest, "requestVM is nil")
+	}
+
+	logFields = map[string]interface{}{
+		"requestVM": requestVM,
+	}
+
+	requestDTO = requestVM.ToDTO()
+	logFields["requestDTO"] = requestDTO
+
+	responseDTO, err = h.cacheService.Stats(ctx, requestDTO)
+	if err != nil {
+		logLevel = zerolog.WarnLevel
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			logLevel = zerolog.ErrorLevel
+		}
+
+		log.WithLevel(logLevel).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[CacheHandler][Stats][Stats] failed to get cache stats")
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	encoder = json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(vms.NewCacheStatsResponseVM(responseDTO))
+}
+
+func (h *CacheHandler) Flush(ctx context.Context, out io.Writer, requestVM *vms.FlushCacheRequestVM) error {
+	var (
+		span        trace.Span
+		logFields   map[string]interface{}
+		requestDTO  *dtos.FlushCacheRequestDTO
+		responseDTO *dtos.FlushCacheResponseDTO
+		logLevel    zerolog.Level
+		err         error
+	)
+
+	ctx, span = tracer.Start(ctx, "[CacheHandler][Flush]")
+	defer span.End()
+
+	if requestVM == nil {
+		return gocerr.New(http.StatusBadRequest, "requestVM is nil")
+	}
+
+	logFields = map[string]interface{}{
+		"requestVM": requestVM,
+	}
+
+	requestDTO = requestVM.ToDTO()
+	logFields["requestDTO"] = requestDTO
+
+	responseDTO, err = h.cacheService.Flush(ctx, requestDTO)
+	if err != nil {
+		logLevel = zerolog.WarnLevel
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			logLevel = zerolog.ErrorLevel
+		}
+
+		log.WithLevel(logLevel).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[CacheHandler][Flush][Flush] failed to flush caches")
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "%d caches flushed\n", responseDTO.Deleted)
+	tracer.RecordError(span, err)
+
+	return err
+}
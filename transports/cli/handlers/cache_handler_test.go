@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"go-boilerplate/internal/models/dtos"
+	service_mocks "go-boilerplate/internal/services/mocks"
+	"go-boilerplate/transports/cli/models/vms"
+	"net/http"
+	"testing"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCacheHandler_Warm(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(t *testing.T, mockService *service_mocks.CacheServiceMock)
+		validate  func(t *testing.T, output string, err error)
+	}{
+		{
+			name: "should_warm_caches_successfully",
+			setupMock: func(t *testing.T, mockService *service_mocks.CacheServiceMock) {
+				mockService.On("Warm", mock.Anything).Return(&dtos.WarmCacheResponseDTO{Pages: 3, IDs: 20, Failed: 1}, nil)
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "3 pages and 20 guests warmed, 1 failed\n", output)
+			},
+		},
+		{
+			name: "should_return_error_when_service_warm_fails",
+			setupMock: func(t *testing.T, mockService *service_mocks.CacheServiceMock) {
+				mockService.On("Warm", mock.Anything).
+					Return(&dtos.WarmCacheResponseDTO{}, gocerr.New(http.StatusRequestTimeout, "context canceled"))
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.Equal(t, http.StatusRequestTimeout, gocerr.GetErrorCode(err))
+				assert.Empty(t, output)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service_mocks.NewCacheServiceMock(t)
+			tt.setupMock(t, mockService)
+
+			handler := NewCacheHandler(mockService)
+			out := &bytes.Buffer{}
+
+			err := handler.Warm(context.Background(), out)
+
+			tt.validate(t, out.String(), err)
+		})
+	}
+}
+
+func TestCacheHandler_Stats(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestVM *vms.CacheStatsRequestVM
+		setupMock func(t *testing.T, mockService *service_mocks.CacheServiceMock)
+		validate  func(t *testing.T, output string, err error)
+	}{
+		{
+			name:      "should_print_stats_as_json",
+			requestVM: &vms.CacheStatsRequestVM{Pattern: "caches:*", Depth: 2},
+			setupMock: func(t *testing.T, mockService *service_mocks.CacheServiceMock) {
+				mockService.On("Stats", mock.Anything, &dtos.CacheStatsRequestDTO{Pattern: "caches:*", Depth: 2}).
+					Return(&dtos.CacheStatsResponseDTO{
+						Prefixes:    []dtos.CachePrefixStatsDTO{{Prefix: "caches:guests", Keys: 2, MemoryBytes: 64}},
+						Keys:        2,
+						MemoryBytes: 64,
+					}, nil)
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"prefixes":[{"prefix":"caches:guests","keys":2,"memory_bytes":64}],"keys":2,"memory_bytes":64}`, output)
+			},
+		},
+		{
+			name:      "should_return_error_when_service_stats_fails",
+			requestVM: &vms.CacheStatsRequestVM{Depth: -1},
+			setupMock: func(t *testing.T, mockService *service_mocks.CacheServiceMock) {
+				mockService.On("Stats", mock.Anything, mock.Anything).
+					Return(nil, gocerr.New(http.StatusBadRequest, "invalid depth"))
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.Equal(t, http.StatusBadRequest, gocerr.GetErrorCode(err))
+				assert.Empty(t, output)
+			},
+		},
+		{
+			name:      "should_return_error_when_request_is_nil",
+			setupMock: func(t *testing.T, mockService *service_mocks.CacheServiceMock) {},
+			validate: func(t *testing.T, output string, err error) {
+				assert.Equal(t, http.StatusBadRequest, gocerr.GetErrorCode(err))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service_mocks.NewCacheServiceMock(t)
+			tt.setupMock(t, mockService)
+
+			handler := NewCacheHandler(mockService)
+			out := &bytes.Buffer{}
+
+			err := handler.Stats(context.Background(), out, tt.requestVM)
+
+			tt.validate(t, out.String(), err)
+		})
+	}
+}
+
+func TestCacheHandler_Flush(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestVM *vms.FlushCacheRequestVM
+		setupMock func(t *testing.T, mockService *service_mocks.CacheServiceMock)
+		validate  func(t *testing.T, output string, err error)
+	}{
+		{
+			name:      "should_flush_caches_successfully",
+			requestVM: &vms.FlushCacheRequestVM{Pattern: "caches:guests:list:*"},
+			setupMock: func(t *testing.T, mockService *service_mocks.CacheServiceMock) {
+				mockService.On("Flush", mock.Anything, &dtos.FlushCacheRequestDTO{Pattern: "caches:guests:list:*"}).
+					Return(&dtos.FlushCacheResponseDTO{Deleted: 12}, nil)
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "12 caches flushed\n", output)
+			},
+		},
+		{
+			name:      "should_return_error_when_service_flush_fails",
+			requestVM: &vms.FlushCacheRequestVM{Pattern: "*"},
+			setupMock: func(t *testing.T, mockService *service_mocks.CacheServiceMock) {
+				mockService.On("Flush", mock.Anything, mock.Anything).
+					Return(nil, gocerr.New(http.StatusBadRequest, "pattern must start with the cache prefix"))
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.Equal(t, http.StatusBadRequest, gocerr.GetErrorCode(err))
+				assert.Empty(t, output)
+			},
+		},
+		{
+			name:      "should_return_error_when_request_is_nil",
+			setupMock: func(t *testing.T, mockService *service_mocks.CacheServiceMock) {},
+			validate: func(t *testing.T, output string, err error) {
+				assert.Equal(t, http.StatusBadRequest, gocerr.GetErrorCode(err))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service_mocks.NewCacheServiceMock(t)
+			tt.setupMock(t, mockService)
+
+			handler := NewCacheHandler(mockService)
+			out := &bytes.Buffer{}
+
+			err := handler.Flush(context.Background(), out, tt.requestVM)
+
+			tt.validate(t, out.String(), err)
+		})
+	}
+}
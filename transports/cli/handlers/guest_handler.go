@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"go-boilerplate/internal/models/dtos"
+	"go-boilerplate/internal/services"
+	"go-boilerplate/pkg/tracer"
+	"go-boilerplate/transports/cli/models/vms"
+	"io"
+	"net/http"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type GuestHandler struct {
+	guestService services.IGuestService
+}
+
+func NewGuestHandler(guestService services.IGuestService) *GuestHandler {
+	return &GuestHandler{
+		guestService: guestService,
+	}
+}
+
+func (h *GuestHandler) EraseByID(ctx context.Context, out io.Writer, requestVM *vms.EraseGuestByIDRequestVM) error {
+	var (
+		span       trace.Span
+		logFields  map[string]interface{}
+		requestDTO *dtos.EraseGuestByIDRequestDTO
+		logLevel   zerolog.Level
+		err        error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestHandler][EraseByID]")
+	defer span.End()
+
+	if requestVM == nil {
+		return gocerr.New(http.StatusBadRequest, "requestVM is nil")
+	}
+
+	logFields = map[string]interface{}{
+		"requestVM": requestVM,
+	}
+
+	requestDTO = requestVM.ToDTO()
+	logFields["requestDTO"] = requestDTO
+
+	err = h.guestService.EraseByID(ctx, requestDTO)
+	if err != nil {
+		logLevel = zerolog.WarnLevel
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			logLevel = zerolog.ErrorLevel
+		}
+
+		log.WithLevel(logLevel).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[GuestHandler][EraseByID][EraseByID] failed to erase by id")
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "guest %s erased\n", requestDTO.ID)
+
+	tracer.RecordError(span, err)
+	return err
+}
+
+func (h *GuestHandler) ExportByID(ctx context.Context, out io.Writer, requestVM *vms.ExportGuestByIDRequestVM) error {
+	var (
+		span        trace.Span
+		logFields   map[string]interface{}
+		requestDTO  *dtos.ExportGuestByIDRequestDTO
+		responseDTO *dtos.ExportGuestResponseDTO
+		logLevel    zerolog.Level
+		encoder     *json.Encoder
+		err         error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestHandler][ExportByID]")
+	defer span.End()
+
+	if requestVM == nil {
+		return gocerr.New(http.StatusBadRequest, "requestVM is nil")
+	}
+
+	logFields = map[string]interface{}{
+		"requestVM": requestVM,
+	}
+
+	requestDTO = requestVM.ToDTO()
+	logFields["requestDTO"] = requestDTO
+
+	responseDTO, err = h.guestService.ExportByID(ctx, requestDTO)
+	if err != nil {
+		logLevel = zerolog.WarnLevel
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			logLevel = zerolog.ErrorLevel
+		}
+
+		log.WithLevel(logLevel).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[GuestHandler][ExportByID][ExportByID] failed to export by id")
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	encoder = json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(vms.NewExportGuestResponseVM(responseDTO))
+}
+
+func (h *GuestHandler) PurgeCaches(ctx context.Context, out io.Writer) error {
+	var (
+		span   trace.Span
+		purged uint64
+		err    error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestHandler][PurgeCaches]")
+	defer span.End()
+
+	purged, err = h.guestService.PurgeCaches(ctx)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Uint64("purged", purged).
+			Msg("[GuestHandler][PurgeCaches][PurgeCaches] failed to purge caches")
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "%d guest caches purged\n", purged)
+
+	tracer.RecordError(span, err)
+	return err
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"go-boilerplate/internal/models/dtos"
+	service_mocks "go-boilerplate/internal/services/mocks"
+	"go-boilerplate/transports/cli/models/vms"
+	"net/http"
+	"testing"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGuestHandler_EraseByID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestVM *vms.EraseGuestByIDRequestVM
+		setupMock func(t *testing.T, mockService *service_mocks.GuestServiceMock)
+		validate  func(t *testing.T, output string, err error)
+	}{
+		{
+			name: "should_erase_guest_successfully",
+			requestVM: &vms.EraseGuestByIDRequestVM{
+				ID:       "550e8400-e29b-41d4-a716-446655440000",
+				ErasedBy: "admin",
+			},
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("EraseByID", mock.Anything, mock.MatchedBy(func(dto *dtos.EraseGuestByIDRequestDTO) bool {
+					return dto.ID == "550e8400-e29b-41d4-a716-446655440000" && dto.ErasedBy == "admin"
+				})).Return(nil)
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "guest 550e8400-e29b-41d4-a716-446655440000 erased\n", output)
+			},
+		},
+		{
+			name:      "should_return_error_when_request_vm_is_nil",
+			requestVM: nil,
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {},
+			validate: func(t *testing.T, output string, err error) {
+				assert.Error(t, err)
+				assert.Equal(t, http.StatusBadRequest, gocerr.GetErrorCode(err))
+				assert.Empty(t, output)
+			},
+		},
+		{
+			name: "should_return_error_when_service_erase_fails_with_4xx",
+			requestVM: &vms.EraseGuestByIDRequestVM{
+				ID:       "550e8400-e29b-41d4-a716-446655440000",
+				ErasedBy: "admin",
+			},
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("EraseByID", mock.Anything, mock.AnythingOfType("*dtos.EraseGuestByIDRequestDTO")).
+					Return(gocerr.New(http.StatusNotFound, "guest not found"))
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.Error(t, err)
+				assert.Equal(t, http.StatusNotFound, gocerr.GetErrorCode(err))
+				assert.Empty(t, output)
+			},
+		},
+		{
+			name: "should_return_error_when_service_erase_fails_with_5xx",
+			requestVM: &vms.EraseGuestByIDRequestVM{
+				ID:       "550e8400-e29b-41d4-a716-446655440000",
+				ErasedBy: "admin",
+			},
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("EraseByID", mock.Anything, mock.AnythingOfType("*dtos.EraseGuestByIDRequestDTO")).
+					Return(gocerr.New(http.StatusInternalServerError, "internal server error"))
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.Error(t, err)
+				assert.Equal(t, http.StatusInternalServerError, gocerr.GetErrorCode(err))
+				assert.Empty(t, output)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service_mocks.NewGuestServiceMock(t)
+			tt.setupMock(t, mockService)
+
+			handler := NewGuestHandler(mockService)
+			out := &bytes.Buffer{}
+
+			err := handler.EraseByID(context.Background(), out, tt.requestVM)
+
+			tt.validate(t, out.String(), err)
+		})
+	}
+}
+
+func TestGuestHandler_ExportByID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestVM *vms.ExportGuestByIDRequestVM
+		setupMock func(t *testing.T, mockService *service_mocks.GuestServiceMock)
+		validate  func(t *testing.T, output string, err error)
+	}{
+		{
+			name: "should_export_guest_successfully",
+			requestVM: &vms.ExportGuestByIDRequestVM{
+				ID: "550e8400-e29b-41d4-a716-446655440000",
+			},
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("ExportByID", mock.Anything, mock.MatchedBy(func(dto *dtos.ExportGuestByIDRequestDTO) bool {
+					return dto.ID == "550e8400-e29b-41d4-a716-446655440000"
+				})).Return(&dtos.ExportGuestResponseDTO{
+					Database: &dtos.GuestRecordDTO{
+						ID:        "550e8400-e29b-41d4-a716-446655440000",
+						Name:      "John Doe",
+						Address:   "123 Main St",
+						CreatedAt: 1700000000000,
+						CreatedBy: "admin",
+					},
+					ExportedAt: 1700000001000,
+				}, nil)
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.NoError(t, err)
+				assert.Contains(t, output, `"id": "550e8400-e29b-41d4-a716-446655440000"`)
+				assert.Contains(t, output, `"name": "John Doe"`)
+				assert.Contains(t, output, `"exported_at": 1700000001000`)
+				assert.NotContains(t, output, `"cache"`)
+			},
+		},
+		{
+			name:      "should_return_error_when_request_vm_is_nil",
+			requestVM: nil,
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {},
+			validate: func(t *testing.T, output string, err error) {
+				assert.Error(t, err)
+				assert.Equal(t, http.StatusBadRequest, gocerr.GetErrorCode(err))
+				assert.Empty(t, output)
+			},
+		},
+		{
+			name: "should_return_error_when_service_export_fails_with_4xx",
+			requestVM: &vms.ExportGuestByIDRequestVM{
+				ID: "550e8400-e29b-41d4-a716-446655440000",
+			},
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("ExportByID", mock.Anything, mock.AnythingOfType("*dtos.ExportGuestByIDRequestDTO")).
+					Return(nil, gocerr.New(http.StatusNotFound, "guest not found"))
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.Error(t, err)
+				assert.Equal(t, http.StatusNotFound, gocerr.GetErrorCode(err))
+				assert.Empty(t, output)
+			},
+		},
+		{
+			name: "should_return_error_when_service_export_fails_with_5xx",
+			requestVM: &vms.ExportGuestByIDRequestVM{
+				ID: "550e8400-e29b-41d4-a716-446655440000",
+			},
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("ExportByID", mock.Anything, mock.AnythingOfType("*dtos.ExportGuestByIDRequestDTO")).
+					Return(nil, gocerr.New(http.StatusInternalServerError, "internal server error"))
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.Error(t, err)
+				assert.Equal(t, http.StatusInternalServerError, gocerr.GetErrorCode(err))
+				assert.Empty(t, output)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service_mocks.NewGuestServiceMock(t)
+			tt.setupMock(t, mockService)
+
+			handler := NewGuestHandler(mockService)
+			out := &bytes.Buffer{}
+
+			err := handler.ExportByID(context.Background(), out, tt.requestVM)
+
+			tt.validate(t, out.String(), err)
+		})
+	}
+}
+
+func TestGuestHandler_PurgeCaches(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(t *testing.T, mockService *service_mocks.GuestServiceMock)
+		validate  func(t *testing.T, output string, err error)
+	}{
+		{
+			name: "should_purge_caches_successfully",
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("PurgeCaches", mock.Anything).Return(uint64(42), nil)
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "42 guest caches purged\n", output)
+			},
+		},
+		{
+			name: "should_return_error_when_service_purge_fails",
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("PurgeCaches", mock.Anything).
+					Return(uint64(3), gocerr.New(http.StatusInternalServerError, "redis error"))
+			},
+			validate: func(t *testing.T, output string, err error) {
+				assert.Equal(t, http.StatusInternalServerError, gocerr.GetErrorCode(err))
+				assert.Empty(t, output)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service_mocks.NewGuestServiceMock(t)
+			tt.setupMock(t, mockService)
+
+			handler := NewGuestHandler(mockService)
+			out := &bytes.Buffer{}
+
+			err := handler.PurgeCaches(context.Background(), out)
+
+			tt.validate(t, out.String(), err)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package handlers
+
+type Handlers struct {
+	Guest *GuestHandler
+	Cache *CacheHandler
+}
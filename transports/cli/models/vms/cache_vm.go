@@ -0,0 +1,57 @@
+package vms
+
+import (
+	"go-boilerplate/internal/models/dtos"
+)
+
+type CacheStatsRequestVM struct {
+	Pattern string
+	Depth   int
+}
+
+func (vm *CacheStatsRequestVM) ToDTO() *dtos.CacheStatsRequestDTO {
+	var dto *dtos.CacheStatsRequestDTO = &dtos.CacheStatsRequestDTO{
+		Pattern: vm.Pattern,
+		Depth:   vm.Depth,
+	}
+
+	return dto
+}
+
+type CachePrefixStatsVM struct {
+	Prefix      string `json:"prefix"`
+	Keys        uint64 `json:"keys"`
+	MemoryBytes int64  `json:"memory_bytes"`
+}
+
+type CacheStatsResponseVM struct {
+	Prefixes    []CachePrefixStatsVM `json:"prefixes"`
+	Keys        uint64               `json:"keys"`
+	MemoryBytes int64                `json:"memory_bytes"`
+}
+
+func NewCacheStatsResponseVM(dto *dtos.CacheStatsResponseDTO) *CacheStatsResponseVM {
+	var vm *CacheStatsResponseVM = &CacheStatsResponseVM{
+		Prefixes:    []CachePrefixStatsVM{},
+		Keys:        dto.Keys,
+		MemoryBytes: dto.MemoryBytes,
+	}
+
+	for i := range dto.Prefixes {
+		vm.Prefixes = append(vm.Prefixes, CachePrefixStatsVM(dto.Prefixes[i]))
+	}
+
+	return vm
+}
+
+type FlushCacheRequestVM struct {
+	Pattern string
+}
+
+func (vm *FlushCacheRequestVM) ToDTO() *dtos.FlushCacheRequestDTO {
+	var dto *dtos.FlushCacheRequestDTO = &dtos.FlushCacheRequestDTO{
+		Pattern: vm.Pattern,
+	}
+
+	return dto
+}
@@ -0,0 +1,62 @@
+package vms
+
+import (
+	"go-boilerplate/internal/models/dtos"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheStatsRequestVM_ToDTO(t *testing.T) {
+	vm := &CacheStatsRequestVM{
+		Pattern: "caches:*",
+		Depth:   3,
+	}
+
+	dto := vm.ToDTO()
+
+	assert.Equal(t, &dtos.CacheStatsRequestDTO{Pattern: "caches:*", Depth: 3}, dto)
+}
+
+func TestNewCacheStatsResponseVM(t *testing.T) {
+	tests := []struct {
+		name     string
+		dto      *dtos.CacheStatsResponseDTO
+		expected *CacheStatsResponseVM
+	}{
+		{
+			name: "should convert prefixes and totals",
+			dto: &dtos.CacheStatsResponseDTO{
+				Prefixes:    []dtos.CachePrefixStatsDTO{{Prefix: "caches:guests", Keys: 2, MemoryBytes: 64}},
+				Keys:        2,
+				MemoryBytes: 64,
+			},
+			expected: &CacheStatsResponseVM{
+				Prefixes:    []CachePrefixStatsVM{{Prefix: "caches:guests", Keys: 2, MemoryBytes: 64}},
+				Keys:        2,
+				MemoryBytes: 64,
+			},
+		},
+		{
+			name:     "should keep an empty list without keys",
+			dto:      &dtos.CacheStatsResponseDTO{},
+			expected: &CacheStatsResponseVM{Prefixes: []CachePrefixStatsVM{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewCacheStatsResponseVM(tt.dto))
+		})
+	}
+}
+
+func TestFlushCacheRequestVM_ToDTO(t *testing.T) {
+	vm := &FlushCacheRequestVM{
+		Pattern: "caches:guests:*",
+	}
+
+	dto := vm.ToDTO()
+
+	assert.Equal(t, &dtos.FlushCacheRequestDTO{Pattern: "caches:guests:*"}, dto)
+}
@@ -0,0 +1,70 @@
+package vms
+
+import (
+	"go-boilerplate/internal/models/dtos"
+)
+
+type EraseGuestByIDRequestVM struct {
+	ID       string
+	ErasedBy string
+}
+
+func (vm *EraseGuestByIDRequestVM) ToDTO() *dtos.EraseGuestByIDRequestDTO {
+	var dto *dtos.EraseGuestByIDRequestDTO = &dtos.EraseGuestByIDRequestDTO{
+		ID:       vm.ID,
+		ErasedBy: vm.ErasedBy,
+	}
+
+	return dto
+}
+
+type ExportGuestByIDRequestVM struct {
+	ID string
+}
+
+func (vm *ExportGuestByIDRequestVM) ToDTO() *dtos.ExportGuestByIDRequestDTO {
+	var dto *dtos.ExportGuestByIDRequestDTO = &dtos.ExportGuestByIDRequestDTO{
+		ID: vm.ID,
+	}
+
+	return dto
+}
+
+type GuestRecordVM struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Address   string `json:"address,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+	CreatedBy string `json:"created_by"`
+	UpdatedAt int64  `json:"updated_at,omitempty"`
+	UpdatedBy string `json:"updated_by,omitempty"`
+	DeletedAt int64  `json:"deleted_at,omitempty"`
+	DeletedBy string `json:"deleted_by,omitempty"`
+}
+
+func NewGuestRecordVM(dto *dtos.GuestRecordDTO) *GuestRecordVM {
+	var vm GuestRecordVM = GuestRecordVM(*dto)
+	return &vm
+}
+
+type ExportGuestResponseVM struct {
+	Database   *GuestRecordVM `json:"database"`
+	Cache      *GuestRecordVM `json:"cache,omitempty"`
+	ExportedAt int64          `json:"exported_at"`
+}
+
+func NewExportGuestResponseVM(dto *dtos.ExportGuestResponseDTO) *ExportGuestResponseVM {
+	var vm *ExportGuestResponseVM = &ExportGuestResponseVM{
+		ExportedAt: dto.ExportedAt,
+	}
+
+	if dto.Database != nil {
+		vm.Database = NewGuestRecordVM(dto.Database)
+	}
+
+	if dto.Cache != nil {
+		vm.Cache = NewGuestRecordVM(dto.Cache)
+	}
+
+	return vm
+}
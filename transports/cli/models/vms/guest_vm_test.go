@@ -0,0 +1,85 @@
+package vms
+
+import (
+	"go-boilerplate/internal/models/dtos"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEraseGuestByIDRequestVM_ToDTO(t *testing.T) {
+	vm := &EraseGuestByIDRequestVM{
+		ID:       "550e8400-e29b-41d4-a716-446655440000",
+		ErasedBy: "admin",
+	}
+
+	dto := vm.ToDTO()
+
+	assert.NotNil(t, dto)
+	assert.Equal(t, vm.ID, dto.ID)
+	assert.Equal(t, vm.ErasedBy, dto.ErasedBy)
+}
+
+func TestExportGuestByIDRequestVM_ToDTO(t *testing.T) {
+	vm := &ExportGuestByIDRequestVM{
+		ID: "550e8400-e29b-41d4-a716-446655440000",
+	}
+
+	dto := vm.ToDTO()
+
+	assert.NotNil(t, dto)
+	assert.Equal(t, vm.ID, dto.ID)
+}
+
+func TestNewExportGuestResponseVM(t *testing.T) {
+	record := &dtos.GuestRecordDTO{
+		ID:        "550e8400-e29b-41d4-a716-446655440000",
+		Name:      "John Doe",
+		Address:   "123 Main St",
+		CreatedAt: 1700000000000,
+		CreatedBy: "admin",
+		DeletedAt: 1700000002000,
+		DeletedBy: "admin",
+	}
+
+	tests := []struct {
+		name     string
+		dto      *dtos.ExportGuestResponseDTO
+		validate func(t *testing.T, vm *ExportGuestResponseVM)
+	}{
+		{
+			name: "should convert database and cache records",
+			dto: &dtos.ExportGuestResponseDTO{
+				Database:   record,
+				Cache:      record,
+				ExportedAt: 1700000003000,
+			},
+			validate: func(t *testing.T, vm *ExportGuestResponseVM) {
+				assert.Equal(t, int64(1700000003000), vm.ExportedAt)
+				assert.NotNil(t, vm.Database)
+				assert.NotNil(t, vm.Cache)
+				assert.Equal(t, record.ID, vm.Database.ID)
+				assert.Equal(t, record.Name, vm.Database.Name)
+				assert.Equal(t, record.DeletedAt, vm.Database.DeletedAt)
+				assert.Equal(t, record.DeletedBy, vm.Cache.DeletedBy)
+			},
+		},
+		{
+			name: "should leave cache nil when no cache record",
+			dto: &dtos.ExportGuestResponseDTO{
+				Database:   record,
+				ExportedAt: 1700000003000,
+			},
+			validate: func(t *testing.T, vm *ExportGuestResponseVM) {
+				assert.NotNil(t, vm.Database)
+				assert.Nil(t, vm.Cache)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.validate(t, NewExportGuestResponseVM(tt.dto))
+		})
+	}
+}
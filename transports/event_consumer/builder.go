@@ -14,7 +14,7 @@ import (
 	"github.com/google/wire"
 )
 
-func BuildEventConsumer(cfg *configs.Config) *EventConsumer {
+func BuildEventConsumer(cfg *configs.Config) (*EventConsumer, func(), error) {
 	wire.Build(
 		datasources.Provider,
 		wire.Struct(new(datasources.Datasources), "*"),
@@ -26,5 +26,20 @@ func BuildEventConsumer(cfg *configs.Config) *EventConsumer {
 		NewEventConsumer,
 	)
 
-	return &EventConsumer{}
+	return nil, nil, nil
+}
+
+// BuildEventConsumerWithDatasources builds on datasources shared with other transports.
+func BuildEventConsumerWithDatasources(cfg *configs.Config, ds *datasources.Datasources) (*EventConsumer, func(), error) {
+	wire.Build(
+		datasources.SharedProvider,
+		repositories.Provider,
+		services.Provider,
+		handlers.Provider,
+		consumers.Provider,
+		wire.Struct(new(consumers.Consumers), "*"),
+		NewEventConsumer,
+	)
+
+	return nil, nil, nil
 }
@@ -19,6 +19,10 @@ func (c *Consumers) ConsumeEvents() error {
 	return errTask.Wait()
 }
 
+func (c *Consumers) Check(ctx context.Context) error {
+	return c.Guest.Check(ctx)
+}
+
 func (c *Consumers) Stop() {
 	var task gotask.Task = gotask.NewTask(1)
 
@@ -2,8 +2,10 @@ package consumers
 
 import (
 	"context"
+	"errors"
 	"go-boilerplate/configs"
 	"go-boilerplate/transports/event_consumer/handlers"
+	"sync/atomic"
 
 	"github.com/fikri240794/gotask"
 	"github.com/nsqio/go-nsq"
@@ -11,12 +13,14 @@ import (
 
 type GuestConsumer struct {
 	cfg                      *configs.Config
+	connected                atomic.Bool
 	createdGuestConsumer     *nsq.Consumer
 	deletedGuestConsumer     *nsq.Consumer
 	updatedGuestConsumer     *nsq.Consumer
 	bulkCreatedGuestConsumer *nsq.Consumer
 	bulkUpdatedGuestConsumer *nsq.Consumer
 	bulkDeletedGuestConsumer *nsq.Consumer
+	erasedGuestConsumer      *nsq.Consumer
 }
 
 func NewGuestConsumer(cfg *configs.Config, handler *handlers.GuestHandler) *GuestConsumer {
@@ -41,7 +45,7 @@ func NewGuestConsumer(cfg *configs.Config, handler *handlers.GuestHandler) *Gues
 			panic(err)
 		}
 
-		consumer.createdGuestConsumer.AddHandler(handlers.NewMessageHandler(handler.HandleCreated))
+		consumer.createdGuestConsumer.AddHandler(handlers.NewMessageHandler(cfg.Guest.Event.Created.Topic, handler.HandleCreated))
 	}
 
 	if cfg.Guest.Event.Deleted.Enable {
@@ -54,7 +58,7 @@ func NewGuestConsumer(cfg *configs.Config, handler *handlers.GuestHandler) *Gues
 			panic(err)
 		}
 
-		consumer.deletedGuestConsumer.AddHandler(handlers.NewMessageHandler(handler.HandleDeleted))
+		consumer.deletedGuestConsumer.AddHandler(handlers.NewMessageHandler(cfg.Guest.Event.Deleted.Topic, handler.HandleDeleted))
 	}
 
 	if cfg.Guest.Event.Updated.Enable {
@@ -67,7 +71,7 @@ func NewGuestConsumer(cfg *configs.Config, handler *handlers.GuestHandler) *Gues
 			panic(err)
 		}
 
-		consumer.updatedGuestConsumer.AddHandler(handlers.NewMessageHandler(handler.HandleUpdated))
+		consumer.updatedGuestConsumer.AddHandler(handlers.NewMessageHandler(cfg.Guest.Event.Updated.Topic, handler.HandleUpdated))
 	}
 
 	if cfg.Guest.Event.BulkCreated.Enable {
@@ -80,7 +84,7 @@ func NewGuestConsumer(cfg *configs.Config, handler *handlers.GuestHandler) *Gues
 			panic(err)
 		}
 
-		consumer.bulkCreatedGuestConsumer.AddHandler(handlers.NewMessageHandler(handler.HandleBulkCreated))
+		consumer.bulkCreatedGuestConsumer.AddHandler(handlers.NewMessageHandler(cfg.Guest.Event.BulkCreated.Topic, handler.HandleBulkCreated))
 	}
 
 	if cfg.Guest.Event.BulkUpdated.Enable {
@@ -93,7 +97,7 @@ func NewGuestConsumer(cfg *configs.Config, handler *handlers.GuestHandler) *Gues
 			panic(err)
 		}
 
-		consumer.bulkUpdatedGuestConsumer.AddHandler(handlers.NewMessageHandler(handler.HandleBulkUpdated))
+		consumer.bulkUpdatedGuestConsumer.AddHandler(handlers.NewMessageHandler(cfg.Guest.Event.BulkUpdated.Topic, handler.HandleBulkUpdated))
 	}
 
 	if cfg.Guest.Event.BulkDeleted.Enable {
@@ -106,7 +110,20 @@ func NewGuestConsumer(cfg *configs.Config, handler *handlers.GuestHandler) *Gues
 			panic(err)
 		}
 
-		consumer.bulkDeletedGuestConsumer.AddHandler(handlers.NewMessageHandler(handler.HandleBulkDeleted))
+		consumer.bulkDeletedGuestConsumer.AddHandler(handlers.NewMessageHandler(cfg.Guest.Event.BulkDeleted.Topic, handler.HandleBulkDeleted))
+	}
+
+	if cfg.Guest.Event.Erased.Enable {
+		consumer.erasedGuestConsumer, err = nsq.NewConsumer(
+			cfg.Guest.Event.Erased.Topic,
+			cfg.Server.Name,
+			nsqConfig,
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		consumer.erasedGuestConsumer.AddHandler(handlers.NewMessageHandler(cfg.Guest.Event.Erased.Topic, handler.HandleErased))
 	}
 
 	return consumer
@@ -116,6 +133,7 @@ func (c *GuestConsumer) ConsumeEvents() error {
 	var (
 		taskCount int
 		errTask   gotask.ErrorTask
+		err       error
 	)
 
 	if c.createdGuestConsumer != nil {
@@ -136,6 +154,9 @@ func (c *GuestConsumer) ConsumeEvents() error {
 	if c.bulkDeletedGuestConsumer != nil {
 		taskCount++
 	}
+	if c.erasedGuestConsumer != nil {
+		taskCount++
+	}
 
 	errTask, _ = gotask.NewErrorTask(context.Background(), taskCount)
 
@@ -175,54 +196,79 @@ func (c *GuestConsumer) ConsumeEvents() error {
 		})
 	}
 
-	return errTask.Wait()
-}
-
-func (c *GuestConsumer) Stop() {
-	var (
-		taskCount int
-		task      gotask.Task
-	)
-
-	if c.createdGuestConsumer != nil {
-		taskCount++
-	}
-	if c.deletedGuestConsumer != nil {
-		taskCount++
-	}
-	if c.updatedGuestConsumer != nil {
-		taskCount++
-	}
-	if c.bulkCreatedGuestConsumer != nil {
-		taskCount++
-	}
-	if c.bulkUpdatedGuestConsumer != nil {
-		taskCount++
+	if c.erasedGuestConsumer != nil {
+		errTask.Go(func() error {
+			return c.erasedGuestConsumer.ConnectToNSQLookupd(c.cfg.Server.EventConsumer.DataSourceName)
+		})
 	}
-	if c.bulkDeletedGuestConsumer != nil {
-		taskCount++
+
+	err = errTask.Wait()
+	if err != nil {
+		return err
 	}
 
-	task = gotask.NewTask(taskCount)
+	c.connected.Store(true)
 
-	if c.createdGuestConsumer != nil {
-		task.Go(c.createdGuestConsumer.Stop)
-	}
-	if c.deletedGuestConsumer != nil {
-		task.Go(c.deletedGuestConsumer.Stop)
-	}
-	if c.updatedGuestConsumer != nil {
-		task.Go(c.updatedGuestConsumer.Stop)
+	return nil
+}
+
+func (c *GuestConsumer) nsqConsumers() []*nsq.Consumer {
+	var nsqConsumers []*nsq.Consumer
+
+	for _, consumer := range []*nsq.Consumer{
+		c.createdGuestConsumer,
+		c.deletedGuestConsumer,
+		c.updatedGuestConsumer,
+		c.bulkCreatedGuestConsumer,
+		c.bulkUpdatedGuestConsumer,
+		c.bulkDeletedGuestConsumer,
+		c.erasedGuestConsumer,
+	} {
+		if consumer != nil {
+			nsqConsumers = append(nsqConsumers, consumer)
+		}
 	}
-	if c.bulkCreatedGuestConsumer != nil {
-		task.Go(c.bulkCreatedGuestConsumer.Stop)
+
+	return nsqConsumers
+}
+
+// Check fails until the consumers are connected to nsqlookupd and once any of
+// them has stopped.
+func (c *GuestConsumer) Check(ctx context.Context) error {
+	var consumer *nsq.Consumer
+
+	if !c.connected.Load() {
+		return errors.New("guest consumers are not connected")
 	}
-	if c.bulkUpdatedGuestConsumer != nil {
-		task.Go(c.bulkUpdatedGuestConsumer.Stop)
+
+	for _, consumer = range c.nsqConsumers() {
+		select {
+		case <-consumer.StopChan:
+			return errors.New("guest consumer is stopped")
+		default:
+		}
 	}
-	if c.bulkDeletedGuestConsumer != nil {
-		task.Go(c.bulkDeletedGuestConsumer.Stop)
+
+	return nil
+}
+
+// Stop waits for every consumer to finish handling its in flight messages.
+func (c *GuestConsumer) Stop() {
+	var (
+		nsqConsumers []*nsq.Consumer = c.nsqConsumers()
+		task         gotask.Task
+	)
+
+	task = gotask.NewTask(len(nsqConsumers))
+
+	for _, consumer := range nsqConsumers {
+		task.Go(func() {
+			consumer.Stop()
+			<-consumer.StopChan
+		})
 	}
 
 	task.Wait()
+
+	c.connected.Store(false)
 }
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"go-boilerplate/configs"
 	"go-boilerplate/internal/services/mocks"
 	"go-boilerplate/transports/event_consumer/handlers"
@@ -34,6 +35,8 @@ func TestNewGuestConsumer(t *testing.T) {
 				cfg.Guest.Event.BulkUpdated.Topic = "guest-bulk-updated"
 				cfg.Guest.Event.BulkDeleted.Enable = true
 				cfg.Guest.Event.BulkDeleted.Topic = "guest-bulk-deleted"
+				cfg.Guest.Event.Erased.Enable = true
+				cfg.Guest.Event.Erased.Topic = "guest-erased"
 				return cfg
 			},
 			setupMock: func(t *testing.T) *mocks.GuestServiceMock {
@@ -48,6 +51,7 @@ func TestNewGuestConsumer(t *testing.T) {
 				assert.NotNil(t, consumer.bulkCreatedGuestConsumer)
 				assert.NotNil(t, consumer.bulkUpdatedGuestConsumer)
 				assert.NotNil(t, consumer.bulkDeletedGuestConsumer)
+				assert.NotNil(t, consumer.erasedGuestConsumer)
 			},
 			shouldPanic: false,
 		},
@@ -477,6 +481,57 @@ func TestGuestConsumer_ConsumeEvents(t *testing.T) {
 	}
 }
 
+func TestGuestConsumer_Check(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(t *testing.T, consumer *GuestConsumer)
+		expectError bool
+	}{
+		{
+			name:        "should_error_before_consume_events",
+			setup:       func(t *testing.T, consumer *GuestConsumer) {},
+			expectError: true,
+		},
+		{
+			name: "should_not_error_after_consume_events",
+			setup: func(t *testing.T, consumer *GuestConsumer) {
+				assert.NoError(t, consumer.ConsumeEvents())
+				t.Cleanup(consumer.Stop)
+			},
+			expectError: false,
+		},
+		{
+			name: "should_error_after_stop",
+			setup: func(t *testing.T, consumer *GuestConsumer) {
+				assert.NoError(t, consumer.ConsumeEvents())
+				consumer.Stop()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Server.Name = "test-service"
+			cfg.Server.EventConsumer.DataSourceName = "localhost:4161"
+			cfg.Guest.Event.Created.Enable = true
+			cfg.Guest.Event.Created.Topic = "guest-created-check-test"
+
+			consumer := NewGuestConsumer(cfg, handlers.NewGuestHandler(mocks.NewGuestServiceMock(t)))
+			tt.setup(t, consumer)
+
+			err := consumer.Check(context.Background())
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestGuestConsumer_Stop(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -506,6 +561,25 @@ func TestGuestConsumer_Stop(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "should_stop_only_erased_consumer_when_only_erased_enabled",
+			setupCfg: func(t *testing.T) *configs.Config {
+				cfg := &configs.Config{}
+				cfg.Server.Name = "test-service"
+				cfg.Guest.Event.Erased.Enable = true
+				cfg.Guest.Event.Erased.Topic = "guest-erased"
+				return cfg
+			},
+			setupMock: func(t *testing.T) *mocks.GuestServiceMock {
+				return mocks.NewGuestServiceMock(t)
+			},
+			validate: func(t *testing.T, consumer *GuestConsumer) {
+				assert.NotNil(t, consumer.erasedGuestConsumer)
+				assert.NotPanics(t, func() {
+					consumer.Stop()
+				})
+			},
+		},
 		{
 			name: "should_stop_only_created_consumer_when_only_created_enabled",
 			setupCfg: func(t *testing.T) *configs.Config {
@@ -3,16 +3,21 @@ package event_consumer
 //go:generate go run github.com/google/wire/cmd/wire
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"go-boilerplate/configs"
 	"go-boilerplate/datasources"
-	"go-boilerplate/pkg/logger"
+	"go-boilerplate/pkg/health"
+	"go-boilerplate/pkg/metrics"
+	"go-boilerplate/pkg/tracer"
 	"go-boilerplate/transports/event_consumer/consumers"
+	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
-	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
@@ -20,73 +25,139 @@ type EventConsumer struct {
 	cfg            *configs.Config
 	datasources    *datasources.Datasources
 	eventConsumers *consumers.Consumers
+	checker        *health.Checker
+	healthServer   *http.Server
 }
 
 func NewEventConsumer(
 	cfg *configs.Config,
 	ds *datasources.Datasources,
 	eventConsumers *consumers.Consumers,
+	checker *health.Checker,
 ) *EventConsumer {
-	return &EventConsumer{
+	var eventConsumer *EventConsumer = &EventConsumer{
 		cfg:            cfg,
 		datasources:    ds,
 		eventConsumers: eventConsumers,
+		checker:        checker,
+	}
+
+	checker.Register("event_consumer", eventConsumers.Check)
+
+	// the event consumer has no HTTP transport, so the probes get their own port
+	if cfg.Server.EventConsumer.HealthPort > 0 {
+		eventConsumer.healthServer = &http.Server{
+			Addr:              fmt.Sprintf(":%d", cfg.Server.EventConsumer.HealthPort),
+			Handler:           health.Handler(checker),
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+	}
+
+	return eventConsumer
+}
+
+func (c *EventConsumer) serveHealth() {
+	var err error
+
+	if c.healthServer == nil {
+		return
+	}
+
+	err = c.healthServer.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Err(err).
+			Msg("[EventConsumer][serveHealth][ListenAndServe] failed to serve health")
 	}
 }
 
 func (c *EventConsumer) gracefullyShutdown() {
 	var (
-		ticker               *time.Ticker
-		tickCounter          float64
-		tickMessage          string
-		maxTickMessageLength int
-		stopCompleteChan     = make(chan bool)
+		startedAt time.Time       = time.Now()
+		ctx       context.Context = context.Background()
+		err       error
 	)
 
-	tickCounter = 0
-	ticker = time.NewTicker(1 * time.Millisecond)
+	log.Info().
+		Dur("pre_stop_delay", c.cfg.Server.Shutdown.PreStopDelay).
+		Msg("[EventConsumer][gracefullyShutdown] shutting down event consumer")
 
-	go func() {
-		c.datasources.Disconnect()
-		c.eventConsumers.Stop()
-		stopCompleteChan <- true
-	}()
+	c.Drain()
+	time.Sleep(c.cfg.Server.Shutdown.PreStopDelay)
 
-	fmt.Print("\n\n")
+	err = c.Stop(ctx)
+	if err != nil {
+		log.Err(err).
+			Msg("[EventConsumer][gracefullyShutdown][Stop] failed to stop event consumer")
+	}
 
-	for {
-		select {
-		case <-ticker.C:
-			tickMessage = fmt.Sprintf("shutting down Event Consumer in %.3fs", tickCounter/1000)
+	err = c.datasources.Disconnect()
+	if err != nil {
+		log.Err(err).
+			Msg("[EventConsumer][gracefullyShutdown][Disconnect] failed to disconnect datasources")
+	}
 
-			if len(tickMessage) > maxTickMessageLength {
-				maxTickMessageLength = len(tickMessage)
-			}
+	err = tracer.Flush(ctx)
+	if err != nil {
+		log.Err(err).
+			Msg("[EventConsumer][gracefullyShutdown][Flush] failed to flush tracer")
+	}
 
-			fmt.Printf("\r%*s", maxTickMessageLength, "")
-			fmt.Printf("\r%s", tickMessage)
+	err = metrics.Shutdown(ctx)
+	if err != nil {
+		log.Err(err).
+			Msg("[EventConsumer][gracefullyShutdown][Shutdown] failed to shut down metrics server")
+	}
 
-			tickCounter++
+	log.Info().
+		Dur("duration", time.Since(startedAt)).
+		Msg("[EventConsumer][gracefullyShutdown] event consumer shutdown finished")
+}
 
-		case <-stopCompleteChan:
-			ticker.Stop()
+func (c *EventConsumer) Start(ctx context.Context) error {
+	var err error = c.eventConsumers.ConsumeEvents()
+	if err != nil {
+		return err
+	}
 
-			tickMessage = "Event Consumer shutdown process finished successfully\n\n"
+	go c.serveHealth()
 
-			fmt.Printf("\r%*s", maxTickMessageLength, "")
-			fmt.Printf("\r%s", tickMessage)
-			return
-		}
-	}
+	return nil
 }
 
-func (c *EventConsumer) setGlobalLog() {
-	zerolog.SetGlobalLevel(zerolog.Level(c.cfg.Server.LogLevel))
-	log.Logger = log.Output(zerolog.ConsoleWriter{
-		Out:        os.Stderr,
-		TimeFormat: time.RFC3339,
-	}).
-		Hook(logger.NewContextHook())
+// Drain fails readiness while the consumers keep handling messages.
+func (c *EventConsumer) Drain() {
+	c.checker.Drain()
+}
+
+// Stop waits for the in flight messages to be handled, at most for the
+// gracefully shutdown duration.
+func (c *EventConsumer) Stop(ctx context.Context) error {
+	var (
+		cancel  context.CancelFunc
+		stopped chan struct{} = make(chan struct{})
+		err     error
+	)
+
+	if c.cfg.Server.EventConsumer.GracefullyShutdownDuration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.cfg.Server.EventConsumer.GracefullyShutdownDuration)
+		defer cancel()
+	}
+
+	if c.healthServer != nil {
+		err = c.healthServer.Shutdown(ctx)
+	}
+
+	go func() {
+		c.eventConsumers.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return err
+	case <-ctx.Done():
+		return errors.Join(err, ctx.Err())
+	}
 }
 
 func (c *EventConsumer) ConsumeEvents() error {
@@ -95,15 +166,13 @@ func (c *EventConsumer) ConsumeEvents() error {
 		err            error
 	)
 
-	c.setGlobalLog()
-
-	err = c.eventConsumers.ConsumeEvents()
+	err = c.Start(context.Background())
 	if err != nil {
 		return err
 	}
 
 	signalListener = make(chan os.Signal, 1)
-	signal.Notify(signalListener, os.Interrupt)
+	signal.Notify(signalListener, os.Interrupt, syscall.SIGTERM)
 	<-signalListener
 
 	c.gracefullyShutdown()
@@ -1,15 +1,18 @@
 package event_consumer
 
 import (
+	"context"
 	"go-boilerplate/configs"
 	"go-boilerplate/datasources"
 	"go-boilerplate/datasources/boilerplate_database"
 	"go-boilerplate/datasources/event_producer"
 	"go-boilerplate/datasources/in_memory_database"
 	"go-boilerplate/internal/services/mocks"
+	"go-boilerplate/pkg/health"
 	"go-boilerplate/transports/event_consumer/consumers"
 	"go-boilerplate/transports/event_consumer/handlers"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -100,6 +103,25 @@ func TestNewEventConsumer(t *testing.T) {
 				assert.Equal(t, ds, ec.datasources)
 				assert.Equal(t, c, ec.eventConsumers)
 				assert.Equal(t, "production-service", ec.cfg.Server.Name)
+				assert.Nil(t, ec.healthServer)
+			},
+		},
+		{
+			name: "should_create_health_server_when_health_port_is_set",
+			setupCfg: func(t *testing.T) *configs.Config {
+				cfg := &configs.Config{}
+				cfg.Server.EventConsumer.HealthPort = 3002
+				return cfg
+			},
+			setupDatasources: func(t *testing.T) *datasources.Datasources {
+				return &datasources.Datasources{}
+			},
+			setupConsumers: func(t *testing.T) *consumers.Consumers {
+				return &consumers.Consumers{}
+			},
+			validate: func(t *testing.T, ec *EventConsumer, cfg *configs.Config, ds *datasources.Datasources, c *consumers.Consumers) {
+				assert.NotNil(t, ec.healthServer)
+				assert.Equal(t, ":3002", ec.healthServer.Addr)
 			},
 		},
 		{
@@ -128,8 +150,11 @@ func TestNewEventConsumer(t *testing.T) {
 			ds := tt.setupDatasources(t)
 			c := tt.setupConsumers(t)
 
-			ec := NewEventConsumer(cfg, ds, c)
+			checker := health.NewChecker(nil)
+
+			ec := NewEventConsumer(cfg, ds, c, checker)
 
+			assert.Equal(t, []string{"event_consumer"}, checker.Names())
 			if tt.validate != nil {
 				tt.validate(t, ec, cfg, ds, c)
 			}
@@ -137,94 +162,36 @@ func TestNewEventConsumer(t *testing.T) {
 	}
 }
 
-func TestEventConsumer_setGlobalLog(t *testing.T) {
+func TestEventConsumer_DrainStop(t *testing.T) {
 	tests := []struct {
-		name     string
-		setupCfg func(t *testing.T) *configs.Config
-		validate func(t *testing.T)
+		name                       string
+		gracefullyShutdownDuration time.Duration
 	}{
 		{
-			name: "should_set_global_log_with_info_level",
-			setupCfg: func(t *testing.T) *configs.Config {
-				cfg := &configs.Config{}
-				cfg.Server.LogLevel = int8(zerolog.InfoLevel)
-				return cfg
-			},
-			validate: func(t *testing.T) {
-				assert.NotPanics(t, func() {
-
-				})
-			},
+			name:                       "should stop without deadline",
+			gracefullyShutdownDuration: 0,
 		},
 		{
-			name: "should_set_global_log_with_debug_level",
-			setupCfg: func(t *testing.T) *configs.Config {
-				cfg := &configs.Config{}
-				cfg.Server.LogLevel = int8(zerolog.DebugLevel)
-				return cfg
-			},
-			validate: func(t *testing.T) {
-				assert.NotPanics(t, func() {
-
-				})
-			},
-		},
-		{
-			name: "should_set_global_log_with_warn_level",
-			setupCfg: func(t *testing.T) *configs.Config {
-				cfg := &configs.Config{}
-				cfg.Server.LogLevel = int8(zerolog.WarnLevel)
-				return cfg
-			},
-			validate: func(t *testing.T) {
-				assert.NotPanics(t, func() {
-
-				})
-			},
-		},
-		{
-			name: "should_set_global_log_with_error_level",
-			setupCfg: func(t *testing.T) *configs.Config {
-				cfg := &configs.Config{}
-				cfg.Server.LogLevel = int8(zerolog.ErrorLevel)
-				return cfg
-			},
-			validate: func(t *testing.T) {
-				assert.NotPanics(t, func() {
-
-				})
-			},
-		},
-		{
-			name: "should_set_global_log_with_trace_level",
-			setupCfg: func(t *testing.T) *configs.Config {
-				cfg := &configs.Config{}
-				cfg.Server.LogLevel = int8(zerolog.TraceLevel)
-				return cfg
-			},
-			validate: func(t *testing.T) {
-				assert.NotPanics(t, func() {
-
-				})
-			},
+			name:                       "should stop within deadline",
+			gracefullyShutdownDuration: time.Second,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cfg := tt.setupCfg(t)
-			ds := &datasources.Datasources{}
-			c := &consumers.Consumers{}
+			cfg := &configs.Config{}
+			cfg.Server.EventConsumer.GracefullyShutdownDuration = tt.gracefullyShutdownDuration
 
-			ec := NewEventConsumer(cfg, ds, c)
+			mockService := mocks.NewGuestServiceMock(t)
+			guestConsumer := consumers.NewGuestConsumer(cfg, handlers.NewGuestHandler(mockService))
+			checker := health.NewChecker(nil)
 
-			assert.NotPanics(t, func() {
-				ec.setGlobalLog()
-			})
+			ec := NewEventConsumer(cfg, &datasources.Datasources{}, &consumers.Consumers{Guest: guestConsumer}, checker)
 
-			if tt.validate != nil {
-				tt.validate(t)
-			}
+			ec.Drain()
+			assert.False(t, checker.Ready(context.Background()).IsUp())
+
+			assert.NoError(t, ec.Stop(context.Background()))
 		})
 	}
 }
@@ -54,6 +54,7 @@ func (h *GuestHandler) HandleCreated(ctx context.Context, m *nsq.Message) error
 			Fields(logFields).
 			Msg("[GuestHandler][HandleCreated][Unmarshal] failed to parse message body")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 	logFields["requestVM"] = requestVM
@@ -64,6 +65,7 @@ func (h *GuestHandler) HandleCreated(ctx context.Context, m *nsq.Message) error
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestHandler][HandleCreated] message is nil")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -76,6 +78,7 @@ func (h *GuestHandler) HandleCreated(ctx context.Context, m *nsq.Message) error
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestHandler][HandleCreated][ProcessEvent] failed to process event")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -111,6 +114,7 @@ func (h *GuestHandler) HandleDeleted(ctx context.Context, m *nsq.Message) error
 			Fields(logFields).
 			Msg("[GuestHandler][HandleDeleted][Unmarshal] failed to parse message body")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 	logFields["requestVM"] = requestVM
@@ -121,6 +125,7 @@ func (h *GuestHandler) HandleDeleted(ctx context.Context, m *nsq.Message) error
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestHandler][HandleDeleted] message is nil")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -133,6 +138,67 @@ func (h *GuestHandler) HandleDeleted(ctx context.Context, m *nsq.Message) error
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestHandler][HandleDeleted][ProcessEvent] failed to process event")
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (h *GuestHandler) HandleErased(ctx context.Context, m *nsq.Message) error {
+	var (
+		span       trace.Span
+		logFields  map[string]interface{}
+		requestVM  *vms.EventRequestVM[vms.GuestEventRequestVM]
+		requestDTO *dtos.GuestEventRequestDTO
+		err        error
+	)
+
+	ctx, span = tracer.Start(ctx, "[GuestHandler][HandleErased]")
+	defer span.End()
+
+	logFields = map[string]interface{}{
+		"messageBody": string(m.Body),
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Fields(logFields).
+		Msg("[GuestHandler][HandleErased] message received")
+
+	requestVM = &vms.EventRequestVM[vms.GuestEventRequestVM]{}
+	err = json.Unmarshal(m.Body, requestVM)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[GuestHandler][HandleErased][Unmarshal] failed to parse message body")
+		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
+		return err
+	}
+	logFields["requestVM"] = requestVM
+
+	if requestVM.Message == nil {
+		err = gocerr.New(http.StatusInternalServerError, "message is nil")
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[GuestHandler][HandleErased] message is nil")
+		tracer.RecordError(span, err)
+		return err
+	}
+
+	requestDTO = requestVM.Message.ToDTO()
+	logFields["requestDTO"] = requestDTO
+
+	_, err = h.guestService.ProcessEvent(ctx, requestDTO)
+	if err != nil {
+		log.Err(err).
+			Ctx(ctx).
+			Fields(logFields).
+			Msg("[GuestHandler][HandleErased][ProcessEvent] failed to process event")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -168,6 +234,7 @@ func (h *GuestHandler) HandleUpdated(ctx context.Context, m *nsq.Message) error
 			Fields(logFields).
 			Msg("[GuestHandler][HandleUpdated][Unmarshal] failed to parse message body")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 	logFields["requestVM"] = requestVM
@@ -178,6 +245,7 @@ func (h *GuestHandler) HandleUpdated(ctx context.Context, m *nsq.Message) error
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestHandler][HandleUpdated] message is nil")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -190,6 +258,7 @@ func (h *GuestHandler) HandleUpdated(ctx context.Context, m *nsq.Message) error
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestHandler][HandleUpdated][ProcessEvent] failed to process event")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -225,6 +294,7 @@ func (h *GuestHandler) HandleBulkCreated(ctx context.Context, m *nsq.Message) er
 			Fields(logFields).
 			Msg("[GuestHandler][HandleBulkCreated][Unmarshal] failed to parse message body")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 	logFields["requestVM"] = requestVM
@@ -235,6 +305,7 @@ func (h *GuestHandler) HandleBulkCreated(ctx context.Context, m *nsq.Message) er
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestHandler][HandleBulkCreated] message is nil")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -250,6 +321,7 @@ func (h *GuestHandler) HandleBulkCreated(ctx context.Context, m *nsq.Message) er
 				Ctx(ctx).
 				Fields(logFields).
 				Msg("[GuestHandler][HandleBulkCreated][ProcessEvent] failed to process event")
+			tracer.RecordError(span, err)
 			return err
 		}
 	}
@@ -286,6 +358,7 @@ func (h *GuestHandler) HandleBulkUpdated(ctx context.Context, m *nsq.Message) er
 			Fields(logFields).
 			Msg("[GuestHandler][HandleBulkUpdated][Unmarshal] failed to parse message body")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 	logFields["requestVM"] = requestVM
@@ -296,6 +369,7 @@ func (h *GuestHandler) HandleBulkUpdated(ctx context.Context, m *nsq.Message) er
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestHandler][HandleBulkUpdated] message is nil")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -311,6 +385,7 @@ func (h *GuestHandler) HandleBulkUpdated(ctx context.Context, m *nsq.Message) er
 				Ctx(ctx).
 				Fields(logFields).
 				Msg("[GuestHandler][HandleBulkUpdated][ProcessEvent] failed to process event")
+			tracer.RecordError(span, err)
 			return err
 		}
 	}
@@ -347,6 +422,7 @@ func (h *GuestHandler) HandleBulkDeleted(ctx context.Context, m *nsq.Message) er
 			Fields(logFields).
 			Msg("[GuestHandler][HandleBulkDeleted][Unmarshal] failed to parse message body")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		tracer.RecordError(span, err)
 		return err
 	}
 	logFields["requestVM"] = requestVM
@@ -357,6 +433,7 @@ func (h *GuestHandler) HandleBulkDeleted(ctx context.Context, m *nsq.Message) er
 			Ctx(ctx).
 			Fields(logFields).
 			Msg("[GuestHandler][HandleBulkDeleted] message is nil")
+		tracer.RecordError(span, err)
 		return err
 	}
 
@@ -372,6 +449,7 @@ func (h *GuestHandler) HandleBulkDeleted(ctx context.Context, m *nsq.Message) er
 				Ctx(ctx).
 				Fields(logFields).
 				Msg("[GuestHandler][HandleBulkDeleted][ProcessEvent] failed to process event")
+			tracer.RecordError(span, err)
 			return err
 		}
 	}
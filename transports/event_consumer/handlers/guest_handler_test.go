@@ -462,6 +462,192 @@ func TestGuestHandler_HandleDeleted(t *testing.T) {
 	}
 }
 
+func TestGuestHandler_HandleErased(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupContext func() context.Context
+		setupMessage func() *nsq.Message
+		setupMock    func(mock *mocks.GuestServiceMock)
+		wantErr      bool
+		validateErr  func(t *testing.T, err error)
+	}{
+		{
+			name: "should_handle_erased_event_successfully",
+			setupContext: func() context.Context {
+				ctx := context.Background()
+				ctx = context.WithValue(ctx, constants.ContextKeyRequestID, "req-del-123")
+				return ctx
+			},
+			setupMessage: func() *nsq.Message {
+				eventVM := vms.EventRequestVM[vms.GuestEventRequestVM]{
+					Name: "guest.erased",
+					Message: &vms.GuestEventRequestVM{
+						ID:        "guest-del-123",
+						Name:      "[erased]",
+						DeletedAt: 1700004000,
+						DeletedBy: "admin",
+					},
+					TracerPropagator: map[string]string{
+						"traceparent": "00-5bf92f3577b34da6a3ce929d0e0e4737-00f067aa0ba902b8-01",
+					},
+				}
+				body, _ := json.Marshal(eventVM)
+				return &nsq.Message{Body: body}
+			},
+			setupMock: func(mockService *mocks.GuestServiceMock) {
+				mockService.On("ProcessEvent", mock.Anything, &dtos.GuestEventRequestDTO{
+					ID:        "guest-del-123",
+					Name:      "[erased]",
+					DeletedAt: 1700004000,
+					DeletedBy: "admin",
+				}).Return(&dtos.GuestEventResponseDTO{
+					ID: "guest-del-123",
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_when_unmarshal_fails",
+			setupContext: func() context.Context {
+				ctx := context.Background()
+				ctx = context.WithValue(ctx, constants.ContextKeyRequestID, "req-del-invalid")
+				return ctx
+			},
+			setupMessage: func() *nsq.Message {
+				return &nsq.Message{Body: []byte("{invalid json}")}
+			},
+			setupMock: func(mock *mocks.GuestServiceMock) {
+
+			},
+			wantErr: true,
+			validateErr: func(t *testing.T, err error) {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), "invalid character")
+			},
+		},
+		{
+			name: "should_return_error_when_message_is_nil",
+			setupContext: func() context.Context {
+				ctx := context.Background()
+				ctx = context.WithValue(ctx, constants.ContextKeyRequestID, "req-del-nil")
+				return ctx
+			},
+			setupMessage: func() *nsq.Message {
+				eventVM := vms.EventRequestVM[vms.GuestEventRequestVM]{
+					Name:    "guest.erased",
+					Message: nil,
+				}
+				body, _ := json.Marshal(eventVM)
+				return &nsq.Message{Body: body}
+			},
+			setupMock: func(mock *mocks.GuestServiceMock) {
+
+			},
+			wantErr: true,
+			validateErr: func(t *testing.T, err error) {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), "message is nil")
+			},
+		},
+		{
+			name: "should_return_error_when_process_event_fails",
+			setupContext: func() context.Context {
+				ctx := context.Background()
+				ctx = context.WithValue(ctx, constants.ContextKeyRequestID, "req-del-fail")
+				return ctx
+			},
+			setupMessage: func() *nsq.Message {
+				eventVM := vms.EventRequestVM[vms.GuestEventRequestVM]{
+					Name: "guest.erased",
+					Message: &vms.GuestEventRequestVM{
+						ID:        "guest-del-fail",
+						Name:      "Fail Test",
+						DeletedAt: 1700005000,
+						DeletedBy: "admin",
+					},
+				}
+				body, _ := json.Marshal(eventVM)
+				return &nsq.Message{Body: body}
+			},
+			setupMock: func(mockService *mocks.GuestServiceMock) {
+				mockService.On("ProcessEvent", mock.Anything, &dtos.GuestEventRequestDTO{
+					ID:        "guest-del-fail",
+					Name:      "Fail Test",
+					DeletedAt: 1700005000,
+					DeletedBy: "admin",
+				}).Return((*dtos.GuestEventResponseDTO)(nil), errors.New("delete service error"))
+			},
+			wantErr: true,
+			validateErr: func(t *testing.T, err error) {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), "delete service error")
+			},
+		},
+		{
+			name: "should_handle_soft_delete_with_full_data",
+			setupContext: func() context.Context {
+				return context.WithValue(context.Background(), constants.ContextKeyRequestID, "req-soft-del")
+			},
+			setupMessage: func() *nsq.Message {
+				eventVM := vms.EventRequestVM[vms.GuestEventRequestVM]{
+					Name: "guest.erased",
+					Message: &vms.GuestEventRequestVM{
+						ID:        "guest-soft-del",
+						Name:      "Soft Delete",
+						Address:   "789 Pine Rd",
+						CreatedAt: 1700006000,
+						CreatedBy: "system",
+						UpdatedAt: 1700006100,
+						UpdatedBy: "system",
+						DeletedAt: 1700006200,
+						DeletedBy: "system",
+					},
+				}
+				body, _ := json.Marshal(eventVM)
+				return &nsq.Message{Body: body}
+			},
+			setupMock: func(mockService *mocks.GuestServiceMock) {
+				mockService.On("ProcessEvent", mock.Anything, &dtos.GuestEventRequestDTO{
+					ID:        "guest-soft-del",
+					Name:      "Soft Delete",
+					Address:   "789 Pine Rd",
+					CreatedAt: 1700006000,
+					CreatedBy: "system",
+					UpdatedAt: 1700006100,
+					UpdatedBy: "system",
+					DeletedAt: 1700006200,
+					DeletedBy: "system",
+				}).Return(&dtos.GuestEventResponseDTO{
+					ID: "guest-soft-del",
+				}, nil)
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewGuestServiceMock(t)
+			tt.setupMock(mockService)
+
+			handler := NewGuestHandler(mockService)
+			ctx := tt.setupContext()
+			msg := tt.setupMessage()
+
+			err := handler.HandleErased(ctx, msg)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.validateErr != nil {
+					tt.validateErr(t, err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestGuestHandler_HandleUpdated(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -2,21 +2,28 @@ package handlers
 
 import (
 	"context"
+	"go-boilerplate/pkg/metrics"
+	"go-boilerplate/pkg/tracer"
 	"go-boilerplate/transports/event_consumer/models/vms"
 	"net/http"
+	"time"
 
 	"github.com/fikri240794/gocerr"
 	"github.com/goccy/go-json"
 	"github.com/nsqio/go-nsq"
 	"github.com/rs/zerolog/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type messageHandler struct {
+	topic             string
 	handleMessageFunc func(ctx context.Context, m *nsq.Message) error
 }
 
-func NewMessageHandler(handleMessageFunc func(ctx context.Context, m *nsq.Message) error) nsq.Handler {
+func NewMessageHandler(topic string, handleMessageFunc func(ctx context.Context, m *nsq.Message) error) nsq.Handler {
 	return &messageHandler{
+		topic:             topic,
 		handleMessageFunc: handleMessageFunc,
 	}
 }
@@ -24,6 +31,7 @@ func NewMessageHandler(handleMessageFunc func(ctx context.Context, m *nsq.Messag
 func (h *messageHandler) HandleMessage(m *nsq.Message) error {
 	var (
 		ctx       context.Context
+		span      trace.Span
 		logFields map[string]interface{}
 		requestVM *vms.EventRequestVM[interface{}]
 		err       error
@@ -31,6 +39,8 @@ func (h *messageHandler) HandleMessage(m *nsq.Message) error {
 
 	ctx = context.TODO()
 
+	metrics.ObserveEventReceived(h.topic, m.Attempts, time.Since(time.Unix(0, m.Timestamp)))
+
 	logFields = map[string]interface{}{
 		"messageBody": string(m.Body),
 	}
@@ -43,10 +53,29 @@ func (h *messageHandler) HandleMessage(m *nsq.Message) error {
 			Fields(logFields).
 			Msg("[messageHandler][HandleMessage][Unmarshal] failed to parse message body")
 		err = gocerr.New(http.StatusInternalServerError, err.Error())
+		metrics.ObserveEventHandled(h.topic, err)
 		return err
 	}
 
 	ctx = requestVM.ExtractTracerPropagator(ctx)
 
-	return h.handleMessageFunc(ctx, m)
+	ctx, span = tracer.Start(
+		ctx,
+		"[messageHandler][HandleMessage]",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("nsq"),
+			semconv.MessagingDestinationName(h.topic),
+			semconv.MessagingOperationDeliver,
+			semconv.MessagingMessageID(string(m.ID[:])),
+			semconv.MessagingMessageBodySize(len(m.Body)),
+		),
+	)
+	defer span.End()
+
+	err = h.handleMessageFunc(ctx, m)
+	metrics.ObserveEventHandled(h.topic, err)
+	tracer.RecordError(span, err)
+
+	return err
 }
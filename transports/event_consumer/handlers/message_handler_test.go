@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"go-boilerplate/pkg/constants"
+	"go-boilerplate/pkg/metrics"
 	"testing"
+	"time"
 
 	"github.com/nsqio/go-nsq"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,6 +44,7 @@ func TestNewMessageHandler(t *testing.T) {
 
 				mh, ok := handler.(*messageHandler)
 				assert.True(t, ok)
+				assert.Equal(t, "test.topic", mh.topic)
 				assert.NotNil(t, mh.handleMessageFunc)
 			},
 		},
@@ -63,7 +67,7 @@ func TestNewMessageHandler(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			handleFunc := tt.setupHandleFunc()
 
-			handler := NewMessageHandler(handleFunc)
+			handler := NewMessageHandler("test.topic", handleFunc)
 
 			tt.validate(t, handler)
 		})
@@ -281,7 +285,7 @@ func TestMessageHandler_HandleMessage(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			msg := tt.setupMessage()
 			handleFunc := tt.setupHandleFunc(t)
-			handler := NewMessageHandler(handleFunc)
+			handler := NewMessageHandler("test.topic", handleFunc)
 
 			err := handler.HandleMessage(msg)
 
@@ -296,3 +300,53 @@ func TestMessageHandler_HandleMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageHandler_HandleMessage_Metrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		topic          string
+		body           []byte
+		handleErr      error
+		expectedMetric string
+	}{
+		{
+			name:           "should_count_processed_event",
+			topic:          "test.metrics.processed",
+			body:           []byte(`{"event_name":"test.event","message":{}}`),
+			expectedMetric: "boilerplate_event_consumer_processed_total",
+		},
+		{
+			name:           "should_count_failed_event_when_handler_fails",
+			topic:          "test.metrics.failed",
+			body:           []byte(`{"event_name":"test.event","message":{}}`),
+			handleErr:      errors.New("handler error"),
+			expectedMetric: "boilerplate_event_consumer_failed_total",
+		},
+		{
+			name:           "should_count_failed_event_when_body_is_invalid",
+			topic:          "test.metrics.invalid",
+			body:           []byte(`{invalid`),
+			expectedMetric: "boilerplate_event_consumer_failed_total",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := testutil.GatherAndCount(metrics.Registry(), tt.expectedMetric, "boilerplate_event_consumer_attempts")
+			assert.NoError(t, err)
+
+			msg := nsq.NewMessage(nsq.MessageID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, tt.body)
+			msg.Attempts = 1
+			msg.Timestamp = time.Now().UnixNano()
+			handler := NewMessageHandler(tt.topic, func(ctx context.Context, m *nsq.Message) error {
+				return tt.handleErr
+			})
+
+			_ = handler.HandleMessage(msg)
+
+			after, err := testutil.GatherAndCount(metrics.Registry(), tt.expectedMetric, "boilerplate_event_consumer_attempts")
+			assert.NoError(t, err)
+			assert.Equal(t, before+2, after, "Expected a new series for the topic in both metrics")
+		})
+	}
+}
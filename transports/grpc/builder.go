@@ -14,17 +14,34 @@ import (
 	"github.com/google/wire"
 )
 
-func BuildGRPCServer(cfg *configs.Config) *GRPCServer {
+func BuildGRPCServer(cfg *configs.Config) (*GRPCServer, func(), error) {
 	wire.Build(
 		datasources.Provider,
 		wire.Struct(new(datasources.Datasources), "*"),
 		repositories.Provider,
 		services.Provider,
 		handlers.NewImplementedBoilerplateServer,
+		handlers.NewHealthServer,
 		middlewares.Provider,
 		wire.Struct(new(middlewares.Middlewares), "*"),
 		NewGRPCServer,
 	)
 
-	return &GRPCServer{}
+	return nil, nil, nil
+}
+
+// BuildGRPCServerWithDatasources builds on datasources shared with other transports.
+func BuildGRPCServerWithDatasources(cfg *configs.Config, ds *datasources.Datasources) (*GRPCServer, func(), error) {
+	wire.Build(
+		datasources.SharedProvider,
+		repositories.Provider,
+		services.Provider,
+		handlers.NewImplementedBoilerplateServer,
+		handlers.NewHealthServer,
+		middlewares.Provider,
+		wire.Struct(new(middlewares.Middlewares), "*"),
+		NewGRPCServer,
+	)
+
+	return nil, nil, nil
 }
@@ -3,22 +3,27 @@ package grpc
 //go:generate go run github.com/google/wire/cmd/wire
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"go-boilerplate/configs"
 	"go-boilerplate/datasources"
-	"go-boilerplate/pkg/logger"
+	"go-boilerplate/pkg/health"
+	"go-boilerplate/pkg/metrics"
 	"go-boilerplate/pkg/protobuf_boilerplate"
+	"go-boilerplate/pkg/tracer"
 	"go-boilerplate/transports/grpc/handlers"
 	"go-boilerplate/transports/grpc/middlewares"
 	"net"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
-	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/zs5460/art"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type GRPCServer struct {
@@ -26,113 +31,161 @@ type GRPCServer struct {
 	datasources *datasources.Datasources
 	server      *grpc.Server
 	handlers    *handlers.ImplementedBoilerplateServer
+	health      *handlers.HealthServer
 	middlewares *middlewares.Middlewares
+	checker     *health.Checker
+	serverErr   chan error
 }
 
 func NewGRPCServer(
 	cfg *configs.Config,
 	ds *datasources.Datasources,
 	h *handlers.ImplementedBoilerplateServer,
+	hs *handlers.HealthServer,
 	mw *middlewares.Middlewares,
+	checker *health.Checker,
 ) *GRPCServer {
 	var grpcServer *grpc.Server = grpc.NewServer(
 		grpc.ChainUnaryInterceptor(mw.GetUnaryServerInterceptors()...),
 	)
 	protobuf_boilerplate.RegisterBoilerplateServer(grpcServer, h)
+	grpc_health_v1.RegisterHealthServer(grpcServer, hs)
 
 	return &GRPCServer{
 		cfg:         cfg,
 		datasources: ds,
 		server:      grpcServer,
 		handlers:    h,
+		health:      hs,
 		middlewares: mw,
+		checker:     checker,
+		serverErr:   make(chan error, 1),
 	}
 }
 
 func (s *GRPCServer) gracefullyShutdown() {
 	var (
-		ticker               *time.Ticker
-		tickCounter          float64
-		tickMessage          string
-		maxTickMessageLength int
-		shutdownCompleteChan = make(chan bool)
+		startedAt time.Time       = time.Now()
+		ctx       context.Context = context.Background()
+		err       error
 	)
 
-	tickCounter = 0
-	ticker = time.NewTicker(1 * time.Millisecond)
+	log.Info().
+		Dur("pre_stop_delay", s.cfg.Server.Shutdown.PreStopDelay).
+		Msg("[GRPCServer][gracefullyShutdown] shutting down GRPC server")
 
-	go func() {
-		s.datasources.Disconnect()
-		s.server.GracefulStop()
-		shutdownCompleteChan <- true
-	}()
-
-	fmt.Print("\n\n")
-
-	for {
-		select {
-		case <-ticker.C:
-			tickMessage = fmt.Sprintf("shutting down GRPC server in %.3fs", tickCounter/1000)
-
-			if len(tickMessage) > maxTickMessageLength {
-				maxTickMessageLength = len(tickMessage)
-			}
-
-			fmt.Printf("\r%*s", maxTickMessageLength, "")
-			fmt.Printf("\r%s", tickMessage)
+	s.Drain()
+	time.Sleep(s.cfg.Server.Shutdown.PreStopDelay)
 
-			tickCounter++
+	err = s.Stop(ctx)
+	if err != nil {
+		log.Err(err).
+			Msg("[GRPCServer][gracefullyShutdown][Stop] failed to stop GRPC server")
+	}
 
-		case <-shutdownCompleteChan:
-			ticker.Stop()
+	err = s.datasources.Disconnect()
+	if err != nil {
+		log.Err(err).
+			Msg("[GRPCServer][gracefullyShutdown][Disconnect] failed to disconnect datasources")
+	}
 
-			tickMessage = "GRPC server shutdown process finished successfully\n\n"
+	err = tracer.Flush(ctx)
+	if err != nil {
+		log.Err(err).
+			Msg("[GRPCServer][gracefullyShutdown][Flush] failed to flush tracer")
+	}
 
-			fmt.Printf("\r%*s", maxTickMessageLength, "")
-			fmt.Printf("\r%s", tickMessage)
-			return
-		}
+	err = metrics.Shutdown(ctx)
+	if err != nil {
+		log.Err(err).
+			Msg("[GRPCServer][gracefullyShutdown][Shutdown] failed to shut down metrics server")
 	}
-}
 
-func (s *GRPCServer) setGlobalLog() {
-	zerolog.SetGlobalLevel(zerolog.Level(s.cfg.Server.LogLevel))
-	log.Logger = log.Output(zerolog.ConsoleWriter{
-		Out:        os.Stderr,
-		TimeFormat: time.RFC3339,
-	}).
-		Hook(logger.NewContextHook())
+	log.Info().
+		Dur("duration", time.Since(startedAt)).
+		Msg("[GRPCServer][gracefullyShutdown] GRPC server shutdown finished")
 }
 
-func (s *GRPCServer) ServeGRPC() error {
+// Start binds the port right away and serves in the background, the result of
+// serving is sent to Errors.
+func (s *GRPCServer) Start(ctx context.Context) error {
 	var (
-		netListener       net.Listener
-		serverErrListener chan error
-		signalListener    chan os.Signal
-		err               error
+		netListener net.Listener
+		err         error
 	)
 
-	s.setGlobalLog()
-
 	netListener, err = net.Listen("tcp", fmt.Sprintf(":%d", s.cfg.Server.GRPC.Port))
 	if err != nil {
 		return err
 	}
 
-	serverErrListener = make(chan error, 1)
-	signalListener = make(chan os.Signal, 1)
-
 	go func() {
-		serverErrListener <- s.server.Serve(netListener)
+		var err error = s.server.Serve(netListener)
+		// stopping before Serve is scheduled is not a serving failure
+		if errors.Is(err, grpc.ErrServerStopped) {
+			err = nil
+		}
+		s.serverErr <- err
 	}()
 
 	fmt.Println(art.String("GRPC"))
 	fmt.Printf("GRPC Server is listening on :%d\n\n", s.cfg.Server.GRPC.Port)
 
-	signal.Notify(signalListener, os.Interrupt)
+	return nil
+}
+
+func (s *GRPCServer) Errors() <-chan error {
+	return s.serverErr
+}
+
+// Drain fails readiness while the server keeps serving.
+func (s *GRPCServer) Drain() {
+	s.checker.Drain()
+}
+
+// Stop forcefully closes the remaining connections once the gracefully
+// shutdown duration passes.
+func (s *GRPCServer) Stop(ctx context.Context) error {
+	var (
+		cancel  context.CancelFunc
+		stopped chan struct{} = make(chan struct{})
+	)
+
+	if s.cfg.Server.GRPC.GracefullyShutdownDuration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Server.GRPC.GracefullyShutdownDuration)
+		defer cancel()
+	}
+
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+func (s *GRPCServer) ServeGRPC() error {
+	var (
+		signalListener chan os.Signal
+		err            error
+	)
+
+	err = s.Start(context.Background())
+	if err != nil {
+		return err
+	}
+
+	signalListener = make(chan os.Signal, 1)
+	signal.Notify(signalListener, os.Interrupt, syscall.SIGTERM)
 
 	select {
-	case err = <-serverErrListener:
+	case err = <-s.serverErr:
 		s.gracefullyShutdown()
 		return err
 	case <-signalListener:
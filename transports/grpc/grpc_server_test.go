@@ -1,16 +1,18 @@
 package grpc
 
 import (
+	"context"
 	"go-boilerplate/configs"
 	"go-boilerplate/datasources"
 	"go-boilerplate/datasources/boilerplate_database"
 	"go-boilerplate/datasources/event_producer"
 	"go-boilerplate/datasources/in_memory_database"
+	"go-boilerplate/pkg/health"
 	"go-boilerplate/transports/grpc/handlers"
 	"go-boilerplate/transports/grpc/middlewares"
 	"testing"
+	"time"
 
-	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -47,6 +49,7 @@ func TestNewGRPCServer(t *testing.T) {
 				assert.NotNil(t, server.datasources)
 				assert.NotNil(t, server.server)
 				assert.NotNil(t, server.handlers)
+				assert.NotNil(t, server.health)
 				assert.NotNil(t, server.middlewares)
 			},
 		},
@@ -71,6 +74,9 @@ func TestNewGRPCServer(t *testing.T) {
 				info := server.server.GetServiceInfo()
 				_, exists := info["protobuf_boilerplate.Boilerplate"]
 				assert.True(t, exists, "Boilerplate service should be registered")
+
+				_, exists = info["grpc.health.v1.Health"]
+				assert.True(t, exists, "Health service should be registered")
 			},
 		},
 		{
@@ -101,88 +107,48 @@ func TestNewGRPCServer(t *testing.T) {
 			h := tt.setupHandlers()
 			mw := tt.setupMiddlewares()
 
-			server := NewGRPCServer(cfg, ds, h, mw)
+			checker := health.NewChecker(nil)
+			hs := handlers.NewHealthServer(checker)
+
+			server := NewGRPCServer(cfg, ds, h, hs, mw, checker)
 
 			tt.validate(t, server)
+			assert.Equal(t, checker, server.checker)
 		})
 	}
 }
 
-func TestGRPCServer_setGlobalLog(t *testing.T) {
+func TestGRPCServer_StartStop(t *testing.T) {
 	tests := []struct {
-		name        string
-		setupServer func() *GRPCServer
-		validate    func(t *testing.T)
+		name                       string
+		gracefullyShutdownDuration time.Duration
 	}{
 		{
-			name: "should_set_global_log_level_to_debug",
-			setupServer: func() *GRPCServer {
-				cfg := &configs.Config{}
-				cfg.Server.LogLevel = int8(zerolog.DebugLevel)
-				ds := &datasources.Datasources{}
-				h := &handlers.ImplementedBoilerplateServer{}
-				mw := &middlewares.Middlewares{}
-
-				return NewGRPCServer(cfg, ds, h, mw)
-			},
-			validate: func(t *testing.T) {
-				assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
-			},
-		},
-		{
-			name: "should_set_global_log_level_to_info",
-			setupServer: func() *GRPCServer {
-				cfg := &configs.Config{}
-				cfg.Server.LogLevel = int8(zerolog.InfoLevel)
-				ds := &datasources.Datasources{}
-				h := &handlers.ImplementedBoilerplateServer{}
-				mw := &middlewares.Middlewares{}
-
-				return NewGRPCServer(cfg, ds, h, mw)
-			},
-			validate: func(t *testing.T) {
-				assert.Equal(t, zerolog.InfoLevel, zerolog.GlobalLevel())
-			},
-		},
-		{
-			name: "should_set_global_log_level_to_error",
-			setupServer: func() *GRPCServer {
-				cfg := &configs.Config{}
-				cfg.Server.LogLevel = int8(zerolog.ErrorLevel)
-				ds := &datasources.Datasources{}
-				h := &handlers.ImplementedBoilerplateServer{}
-				mw := &middlewares.Middlewares{}
-
-				return NewGRPCServer(cfg, ds, h, mw)
-			},
-			validate: func(t *testing.T) {
-				assert.Equal(t, zerolog.ErrorLevel, zerolog.GlobalLevel())
-			},
+			name:                       "should stop without deadline",
+			gracefullyShutdownDuration: 0,
 		},
 		{
-			name: "should_set_global_log_level_to_warn",
-			setupServer: func() *GRPCServer {
-				cfg := &configs.Config{}
-				cfg.Server.LogLevel = int8(zerolog.WarnLevel)
-				ds := &datasources.Datasources{}
-				h := &handlers.ImplementedBoilerplateServer{}
-				mw := &middlewares.Middlewares{}
-
-				return NewGRPCServer(cfg, ds, h, mw)
-			},
-			validate: func(t *testing.T) {
-				assert.Equal(t, zerolog.WarnLevel, zerolog.GlobalLevel())
-			},
+			name:                       "should stop within deadline",
+			gracefullyShutdownDuration: time.Second,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			server := tt.setupServer()
+			cfg := &configs.Config{}
+			cfg.Server.GRPC.Port = 0
+			cfg.Server.GRPC.GracefullyShutdownDuration = tt.gracefullyShutdownDuration
+			checker := health.NewChecker(nil)
+
+			server := NewGRPCServer(cfg, &datasources.Datasources{}, &handlers.ImplementedBoilerplateServer{}, handlers.NewHealthServer(checker), &middlewares.Middlewares{}, checker)
+
+			assert.NoError(t, server.Start(context.Background()))
 
-			server.setGlobalLog()
+			server.Drain()
+			assert.False(t, checker.Ready(context.Background()).IsUp())
 
-			tt.validate(t)
+			assert.NoError(t, server.Stop(context.Background()))
+			assert.NoError(t, <-server.Errors())
 		})
 	}
 }
@@ -51,6 +51,7 @@ func (h *ImplementedBoilerplateServer) CreateGuest(ctx context.Context, requestV
 			logLevel = zerolog.ErrorLevel
 		}
 
+		tracer.RecordError(span, err)
 		err = grpc_error.FromError(err)
 		log.WithLevel(logLevel).
 			Ctx(ctx).
@@ -95,6 +96,7 @@ func (h *ImplementedBoilerplateServer) DeleteGuestByID(ctx context.Context, requ
 			logLevel = zerolog.ErrorLevel
 		}
 
+		tracer.RecordError(span, err)
 		err = grpc_error.FromError(err)
 		log.WithLevel(logLevel).
 			Ctx(ctx).
@@ -107,6 +109,97 @@ func (h *ImplementedBoilerplateServer) DeleteGuestByID(ctx context.Context, requ
 	return &emptypb.Empty{}, nil
 }
 
+func (h *ImplementedBoilerplateServer) EraseGuestByID(ctx context.Context, requestVM *protobuf_boilerplate.EraseGuestByIDRequestVM) (*emptypb.Empty, error) {
+	var (
+		span       trace.Span
+		logFields  map[string]interface{}
+		requestDTO *dtos.EraseGuestByIDRequestDTO
+		logLevel   zerolog.Level
+		err        error
+	)
+
+	ctx, span = tracer.Start(ctx, "[ImplementedBoilerplateServer][EraseGuestByID]")
+	defer span.End()
+
+	if requestVM == nil {
+		err = grpc_error.FromError(gocerr.New(http.StatusBadRequest, "requestVM is nil"))
+		return nil, err
+	}
+
+	logFields = map[string]interface{}{
+		"requestVM": requestVM,
+	}
+
+	requestDTO = vms.EraseGuestByIDRequestVMToDTO(requestVM, uuid.Nil.String())
+	logFields["requestDTO"] = requestDTO
+
+	err = h.guestService.EraseByID(ctx, requestDTO)
+	if err != nil {
+		logLevel = zerolog.WarnLevel
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			logLevel = zerolog.ErrorLevel
+		}
+
+		tracer.RecordError(span, err)
+		err = grpc_error.FromError(err)
+		log.WithLevel(logLevel).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[ImplementedBoilerplateServer][EraseGuestByID][EraseByID] failed to erase by id")
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *ImplementedBoilerplateServer) ExportGuestByID(ctx context.Context, requestVM *protobuf_boilerplate.ExportGuestByIDRequestVM) (*protobuf_boilerplate.ExportGuestResponseVM, error) {
+	var (
+		span        trace.Span
+		logFields   map[string]interface{}
+		requestDTO  *dtos.ExportGuestByIDRequestDTO
+		responseDTO *dtos.ExportGuestResponseDTO
+		logLevel    zerolog.Level
+		responseVM  *protobuf_boilerplate.ExportGuestResponseVM
+		err         error
+	)
+
+	ctx, span = tracer.Start(ctx, "[ImplementedBoilerplateServer][ExportGuestByID]")
+	defer span.End()
+
+	if requestVM == nil {
+		err = grpc_error.FromError(gocerr.New(http.StatusBadRequest, "requestVM is nil"))
+		return nil, err
+	}
+
+	logFields = map[string]interface{}{
+		"requestVM": requestVM,
+	}
+
+	requestDTO = vms.ExportGuestByIDRequestVMToDTO(requestVM)
+	logFields["requestDTO"] = requestDTO
+
+	responseDTO, err = h.guestService.ExportByID(ctx, requestDTO)
+	if err != nil {
+		logLevel = zerolog.WarnLevel
+		if gocerr.GetErrorCode(err) >= http.StatusInternalServerError {
+			logLevel = zerolog.ErrorLevel
+		}
+
+		tracer.RecordError(span, err)
+		err = grpc_error.FromError(err)
+		log.WithLevel(logLevel).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[ImplementedBoilerplateServer][ExportGuestByID][ExportByID] failed to export by id")
+		return nil, err
+	}
+
+	responseVM = vms.NewExportGuestResponseVM(responseDTO)
+	return responseVM, nil
+}
+
 func (h *ImplementedBoilerplateServer) FindAllGuest(ctx context.Context, requestVM *protobuf_boilerplate.FindAllGuestRequestVM) (*protobuf_boilerplate.FindAllGuestResponseVM, error) {
 	var (
 		span        trace.Span
@@ -138,6 +231,7 @@ func (h *ImplementedBoilerplateServer) FindAllGuest(ctx context.Context, request
 			logLevel = zerolog.ErrorLevel
 		}
 
+		tracer.RecordError(span, err)
 		err = grpc_error.FromError(err)
 		log.WithLevel(logLevel).
 			Ctx(ctx).
@@ -184,6 +278,7 @@ func (h *ImplementedBoilerplateServer) FindGuestByID(ctx context.Context, reques
 			logLevel = zerolog.ErrorLevel
 		}
 
+		tracer.RecordError(span, err)
 		err = grpc_error.FromError(err)
 		log.WithLevel(logLevel).
 			Ctx(ctx).
@@ -230,6 +325,7 @@ func (h *ImplementedBoilerplateServer) UpdateGuestByID(ctx context.Context, requ
 			logLevel = zerolog.ErrorLevel
 		}
 
+		tracer.RecordError(span, err)
 		err = grpc_error.FromError(err)
 		log.WithLevel(logLevel).
 			Ctx(ctx).
@@ -276,6 +372,7 @@ func (h *ImplementedBoilerplateServer) BulkCreateGuests(ctx context.Context, req
 			logLevel = zerolog.ErrorLevel
 		}
 
+		tracer.RecordError(span, err)
 		err = grpc_error.FromError(err)
 		log.WithLevel(logLevel).
 			Ctx(ctx).
@@ -322,6 +419,7 @@ func (h *ImplementedBoilerplateServer) BulkUpdateGuests(ctx context.Context, req
 			logLevel = zerolog.ErrorLevel
 		}
 
+		tracer.RecordError(span, err)
 		err = grpc_error.FromError(err)
 		log.WithLevel(logLevel).
 			Ctx(ctx).
@@ -366,6 +464,7 @@ func (h *ImplementedBoilerplateServer) BulkDeleteGuests(ctx context.Context, req
 			logLevel = zerolog.ErrorLevel
 		}
 
+		tracer.RecordError(span, err)
 		err = grpc_error.FromError(err)
 		log.WithLevel(logLevel).
 			Ctx(ctx).
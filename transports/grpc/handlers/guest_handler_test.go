@@ -245,6 +245,165 @@ func TestImplementedBoilerplateServer_DeleteGuestByID(t *testing.T) {
 	}
 }
 
+func TestImplementedBoilerplateServer_EraseGuestByID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestVM *protobuf_boilerplate.EraseGuestByIDRequestVM
+		setupMock func(t *testing.T, mockService *service_mocks.GuestServiceMock)
+		validate  func(t *testing.T, response *emptypb.Empty, err error)
+	}{
+		{
+			name: "should_erase_guest_successfully",
+			requestVM: &protobuf_boilerplate.EraseGuestByIDRequestVM{
+				Id: "550e8400-e29b-41d4-a716-446655440000",
+			},
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("EraseByID", mock.Anything, mock.MatchedBy(func(dto *dtos.EraseGuestByIDRequestDTO) bool {
+					return dto.ID == "550e8400-e29b-41d4-a716-446655440000"
+				})).Return(nil)
+			},
+			validate: func(t *testing.T, response *emptypb.Empty, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, response)
+			},
+		},
+		{
+			name:      "should_return_error_when_request_vm_is_nil",
+			requestVM: nil,
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {},
+			validate: func(t *testing.T, response *emptypb.Empty, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "requestVM is nil")
+			},
+		},
+		{
+			name: "should_return_error_when_service_erase_fails_with_4xx",
+			requestVM: &protobuf_boilerplate.EraseGuestByIDRequestVM{
+				Id: "550e8400-e29b-41d4-a716-446655440000",
+			},
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("EraseByID", mock.Anything, mock.AnythingOfType("*dtos.EraseGuestByIDRequestDTO")).
+					Return(gocerr.New(http.StatusNotFound, "guest not found"))
+			},
+			validate: func(t *testing.T, response *emptypb.Empty, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, response)
+			},
+		},
+		{
+			name: "should_return_error_when_service_erase_fails_with_5xx",
+			requestVM: &protobuf_boilerplate.EraseGuestByIDRequestVM{
+				Id: "550e8400-e29b-41d4-a716-446655440000",
+			},
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("EraseByID", mock.Anything, mock.AnythingOfType("*dtos.EraseGuestByIDRequestDTO")).
+					Return(gocerr.New(http.StatusInternalServerError, "database error"))
+			},
+			validate: func(t *testing.T, response *emptypb.Empty, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, response)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service_mocks.NewGuestServiceMock(t)
+			tt.setupMock(t, mockService)
+
+			handler := NewImplementedBoilerplateServer(mockService)
+
+			ctx := context.WithValue(context.Background(), constants.ContextKeyRequestID, "test-request-id")
+			response, err := handler.EraseGuestByID(ctx, tt.requestVM)
+
+			tt.validate(t, response, err)
+		})
+	}
+}
+
+func TestImplementedBoilerplateServer_ExportGuestByID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestVM *protobuf_boilerplate.ExportGuestByIDRequestVM
+		setupMock func(t *testing.T, mockService *service_mocks.GuestServiceMock)
+		validate  func(t *testing.T, response *protobuf_boilerplate.ExportGuestResponseVM, err error)
+	}{
+		{
+			name: "should_export_guest_successfully",
+			requestVM: &protobuf_boilerplate.ExportGuestByIDRequestVM{
+				Id: "550e8400-e29b-41d4-a716-446655440000",
+			},
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("ExportByID", mock.Anything, mock.AnythingOfType("*dtos.ExportGuestByIDRequestDTO")).
+					Return(&dtos.ExportGuestResponseDTO{
+						Database: &dtos.GuestRecordDTO{
+							ID:   "550e8400-e29b-41d4-a716-446655440000",
+							Name: "John Doe",
+						},
+						ExportedAt: 1000,
+					}, nil)
+			},
+			validate: func(t *testing.T, response *protobuf_boilerplate.ExportGuestResponseVM, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "John Doe", response.GetDatabase().GetName())
+				assert.Nil(t, response.GetCache())
+				assert.Equal(t, int64(1000), response.GetExportedAt())
+			},
+		},
+		{
+			name:      "should_return_error_when_request_vm_is_nil",
+			requestVM: nil,
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {},
+			validate: func(t *testing.T, response *protobuf_boilerplate.ExportGuestResponseVM, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "requestVM is nil")
+			},
+		},
+		{
+			name: "should_return_error_when_service_export_fails_with_4xx",
+			requestVM: &protobuf_boilerplate.ExportGuestByIDRequestVM{
+				Id: "550e8400-e29b-41d4-a716-446655440000",
+			},
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("ExportByID", mock.Anything, mock.AnythingOfType("*dtos.ExportGuestByIDRequestDTO")).
+					Return(nil, gocerr.New(http.StatusNotFound, "guest not found"))
+			},
+			validate: func(t *testing.T, response *protobuf_boilerplate.ExportGuestResponseVM, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, response)
+			},
+		},
+		{
+			name: "should_return_error_when_service_export_fails_with_5xx",
+			requestVM: &protobuf_boilerplate.ExportGuestByIDRequestVM{
+				Id: "550e8400-e29b-41d4-a716-446655440000",
+			},
+			setupMock: func(t *testing.T, mockService *service_mocks.GuestServiceMock) {
+				mockService.On("ExportByID", mock.Anything, mock.AnythingOfType("*dtos.ExportGuestByIDRequestDTO")).
+					Return(nil, gocerr.New(http.StatusInternalServerError, "database error"))
+			},
+			validate: func(t *testing.T, response *protobuf_boilerplate.ExportGuestResponseVM, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, response)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service_mocks.NewGuestServiceMock(t)
+			tt.setupMock(t, mockService)
+
+			handler := NewImplementedBoilerplateServer(mockService)
+
+			ctx := context.WithValue(context.Background(), constants.ContextKeyRequestID, "test-request-id")
+			response, err := handler.ExportGuestByID(ctx, tt.requestVM)
+
+			tt.validate(t, response, err)
+		})
+	}
+}
+
 func TestImplementedBoilerplateServer_FindAllGuest(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"go-boilerplate/pkg/health"
+	"go-boilerplate/pkg/protobuf_boilerplate"
+	"go-boilerplate/pkg/tracer"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// HealthServer implements grpc.health.v1 on top of the datasource checks. The
+// empty service and the boilerplate service share the same readiness.
+type HealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	checker *health.Checker
+}
+
+func NewHealthServer(checker *health.Checker) *HealthServer {
+	return &HealthServer{
+		checker: checker,
+	}
+}
+
+func (h *HealthServer) servingStatus(ctx context.Context) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	var report *health.Report = h.checker.Ready(ctx)
+
+	if !report.IsUp() {
+		log.Warn().
+			Ctx(ctx).
+			Interface("report", report).
+			Msg("[HealthServer][servingStatus][Ready] not ready")
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+func (h *HealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	var span trace.Span
+
+	ctx, span = tracer.Start(ctx, "[HealthServer][Check]")
+	defer span.End()
+
+	if req.GetService() != "" && req.GetService() != protobuf_boilerplate.Boilerplate_ServiceDesc.ServiceName {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+
+	return &grpc_health_v1.HealthCheckResponse{
+		Status: h.servingStatus(ctx),
+	}, nil
+}
+
+func (h *HealthServer) List(ctx context.Context, req *grpc_health_v1.HealthListRequest) (*grpc_health_v1.HealthListResponse, error) {
+	var (
+		span          trace.Span
+		servingStatus grpc_health_v1.HealthCheckResponse_ServingStatus
+	)
+
+	ctx, span = tracer.Start(ctx, "[HealthServer][List]")
+	defer span.End()
+
+	servingStatus = h.servingStatus(ctx)
+
+	return &grpc_health_v1.HealthListResponse{
+		Statuses: map[string]*grpc_health_v1.HealthCheckResponse{
+			"": {Status: servingStatus},
+			protobuf_boilerplate.Boilerplate_ServiceDesc.ServiceName: {Status: servingStatus},
+		},
+	}, nil
+}
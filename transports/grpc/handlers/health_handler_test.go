@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"go-boilerplate/pkg/health"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewHealthServer(t *testing.T) {
+	checker := health.NewChecker(nil)
+
+	server := NewHealthServer(checker)
+
+	assert.NotNil(t, server)
+	assert.Equal(t, checker, server.checker)
+}
+
+func TestHealthServer_Check(t *testing.T) {
+	tests := []struct {
+		name           string
+		service        string
+		check          health.CheckFunc
+		expectedStatus grpc_health_v1.HealthCheckResponse_ServingStatus
+		expectedCode   codes.Code
+	}{
+		{
+			name:           "should_return_serving_for_overall_health",
+			service:        "",
+			check:          func(ctx context.Context) error { return nil },
+			expectedStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           "should_return_serving_for_boilerplate_service",
+			service:        "protobuf_boilerplate.Boilerplate",
+			check:          func(ctx context.Context) error { return nil },
+			expectedStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           "should_return_not_serving_when_a_datasource_is_down",
+			service:        "",
+			check:          func(ctx context.Context) error { return errors.New("connection refused") },
+			expectedStatus: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:         "should_return_not_found_for_unknown_service",
+			service:      "unknown.Service",
+			check:        func(ctx context.Context) error { return nil },
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := health.NewChecker(nil)
+			checker.Register("in_memory_database", tt.check)
+
+			res, err := NewHealthServer(checker).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: tt.service})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, tt.expectedStatus, res.GetStatus())
+			}
+		})
+	}
+}
+
+func TestHealthServer_List(t *testing.T) {
+	checker := health.NewChecker(nil)
+	checker.Register("in_memory_database", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	res, err := NewHealthServer(checker).List(context.Background(), &grpc_health_v1.HealthListRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, res.GetStatuses(), 2)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, res.GetStatuses()[""].GetStatus())
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, res.GetStatuses()["protobuf_boilerplate.Boilerplate"].GetStatus())
+}
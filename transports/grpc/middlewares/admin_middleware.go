@@ -0,0 +1,78 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/subtle"
+	"go-boilerplate/configs"
+	"go-boilerplate/pkg/grpc_metadata"
+	"go-boilerplate/pkg/protobuf_boilerplate"
+	"go-boilerplate/pkg/tracer"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// adminMethods are the methods that need SERVER.HTTP.ADMIN.TOKEN, the same
+// token as the HTTP /admin routes serving them.
+var adminMethods map[string]bool = map[string]bool{
+	protobuf_boilerplate.Boilerplate_EraseGuestByID_FullMethodName:  true,
+	protobuf_boilerplate.Boilerplate_ExportGuestByID_FullMethodName: true,
+}
+
+// AdminMiddleware lets through the admin methods called with
+// "authorization: Bearer <SERVER.HTTP.ADMIN.TOKEN>", every admin call is
+// rejected while the token is empty.
+type AdminMiddleware struct {
+	cfg *configs.Config
+}
+
+func NewAdminMiddleware(cfg *configs.Config) *AdminMiddleware {
+	return &AdminMiddleware{
+		cfg: cfg,
+	}
+}
+
+func (mw *AdminMiddleware) Authenticate(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	var (
+		span  trace.Span
+		md    metadata.MD
+		token string
+		found bool
+		err   error
+	)
+
+	if !adminMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	ctx, span = tracer.Start(ctx, "[AdminMiddleware][Authenticate]")
+	defer span.End()
+
+	md, _ = metadata.FromIncomingContext(ctx)
+	token, found = strings.CutPrefix(grpc_metadata.MDGetString(md, "authorization"), "Bearer ")
+	if found &&
+		mw.cfg.Server.HTTP.Admin.Token != "" &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(mw.cfg.Server.HTTP.Admin.Token)) == 1 {
+		return handler(ctx, req)
+	}
+
+	err = status.Error(codes.Unauthenticated, "invalid admin token")
+	log.Warn().
+		Ctx(ctx).
+		Err(err).
+		Str("method", info.FullMethod).
+		Msg("[AdminMiddleware][Authenticate] unauthorized admin request")
+	tracer.RecordError(span, err)
+
+	return nil, err
+}
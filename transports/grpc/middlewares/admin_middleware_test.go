@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"context"
+	"go-boilerplate/configs"
+	"go-boilerplate/pkg/protobuf_boilerplate"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewAdminMiddleware(t *testing.T) {
+	cfg := &configs.Config{}
+
+	mw := NewAdminMiddleware(cfg)
+
+	assert.NotNil(t, mw)
+	assert.Equal(t, cfg, mw.cfg)
+}
+
+func TestAdminMiddleware_Authenticate(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		method        string
+		authorization string
+		expectedCode  codes.Code
+	}{
+		{
+			name:          "should_pass_erase_with_valid_token",
+			token:         "s3cret",
+			method:        protobuf_boilerplate.Boilerplate_EraseGuestByID_FullMethodName,
+			authorization: "Bearer s3cret",
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "should_pass_export_with_valid_token",
+			token:         "s3cret",
+			method:        protobuf_boilerplate.Boilerplate_ExportGuestByID_FullMethodName,
+			authorization: "Bearer s3cret",
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "should_reject_wrong_token",
+			token:         "s3cret",
+			method:        protobuf_boilerplate.Boilerplate_EraseGuestByID_FullMethodName,
+			authorization: "Bearer guess",
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name:          "should_reject_token_without_bearer_scheme",
+			token:         "s3cret",
+			method:        protobuf_boilerplate.Boilerplate_ExportGuestByID_FullMethodName,
+			authorization: "s3cret",
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name:         "should_reject_missing_metadata",
+			token:        "s3cret",
+			method:       protobuf_boilerplate.Boilerplate_EraseGuestByID_FullMethodName,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:          "should_reject_every_call_when_token_is_not_configured",
+			method:        protobuf_boilerplate.Boilerplate_ExportGuestByID_FullMethodName,
+			authorization: "Bearer ",
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name:         "should_pass_non_admin_method_without_token",
+			token:        "s3cret",
+			method:       protobuf_boilerplate.Boilerplate_FindGuestByID_FullMethodName,
+			expectedCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Server.HTTP.Admin.Token = tt.token
+			mw := NewAdminMiddleware(cfg)
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return "response", nil
+			}
+
+			res, err := mw.Authenticate(ctx, "request", &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedCode == codes.OK, called)
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, "response", res)
+			} else {
+				assert.Nil(t, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package middlewares
+
+import (
+	"context"
+	"go-boilerplate/pkg/constants"
+	"go-boilerplate/pkg/grpc_metadata"
+	"go-boilerplate/pkg/tracer"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// IdentityMiddleware puts the tenant and actor of the request in the context,
+// feature flags are evaluated against them.
+type IdentityMiddleware struct{}
+
+func NewIdentityMiddleware() *IdentityMiddleware {
+	return &IdentityMiddleware{}
+}
+
+func (mw *IdentityMiddleware) Identify(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	var (
+		span     trace.Span
+		md       metadata.MD
+		ok       bool
+		tenantID string
+		actorID  string
+	)
+
+	ctx, span = tracer.Start(ctx, "[IdentityMiddleware][Identify]")
+	defer span.End()
+
+	md, ok = metadata.FromIncomingContext(ctx)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	tenantID = grpc_metadata.MDGetString(md, constants.HeaderKeyTenantID)
+	if tenantID != "" {
+		ctx = context.WithValue(ctx, constants.ContextKeyTenantID, tenantID)
+	}
+
+	actorID = grpc_metadata.MDGetString(md, constants.HeaderKeyActorID)
+	if actorID != "" {
+		ctx = context.WithValue(ctx, constants.ContextKeyActorID, actorID)
+	}
+
+	return handler(ctx, req)
+}
@@ -0,0 +1,80 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"go-boilerplate/pkg/constants"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNewIdentityMiddleware(t *testing.T) {
+	mw := NewIdentityMiddleware()
+
+	assert.NotNil(t, mw)
+}
+
+func TestIdentityMiddleware_Identify(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupContext   func(t *testing.T) context.Context
+		handlerErr     error
+		expectedTenant interface{}
+		expectedActor  interface{}
+	}{
+		{
+			name: "should_set_tenant_and_actor_from_metadata",
+			setupContext: func(t *testing.T) context.Context {
+				md := metadata.New(map[string]string{
+					constants.HeaderKeyTenantID: "acme",
+					constants.HeaderKeyActorID:  "daenerys",
+				})
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			expectedTenant: "acme",
+			expectedActor:  "daenerys",
+		},
+		{
+			name: "should_set_only_actor_when_tenant_not_present",
+			setupContext: func(t *testing.T) context.Context {
+				md := metadata.New(map[string]string{
+					constants.HeaderKeyActorID: "daenerys",
+				})
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			expectedActor: "daenerys",
+		},
+		{
+			name: "should_call_handler_without_metadata",
+			setupContext: func(t *testing.T) context.Context {
+				return context.Background()
+			},
+		},
+		{
+			name: "should_return_handler_error",
+			setupContext: func(t *testing.T) context.Context {
+				return context.Background()
+			},
+			handlerErr: errors.New("handler error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := NewIdentityMiddleware()
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				assert.Equal(t, tt.expectedTenant, ctx.Value(constants.ContextKeyTenantID))
+				assert.Equal(t, tt.expectedActor, ctx.Value(constants.ContextKeyActorID))
+				return "response", tt.handlerErr
+			}
+
+			res, err := mw.Identify(tt.setupContext(t), "request", &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, handler)
+
+			assert.Equal(t, "response", res)
+			assert.Equal(t, tt.handlerErr, err)
+		})
+	}
+}
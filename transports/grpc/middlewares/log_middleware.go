@@ -3,6 +3,8 @@ package middlewares
 import (
 	"context"
 	"fmt"
+	"go-boilerplate/pkg/metrics"
+	"go-boilerplate/pkg/redactor"
 	"go-boilerplate/pkg/tracer"
 	"net/http"
 	"time"
@@ -49,6 +51,8 @@ func (mw *LogMiddleware) Log(
 	processEnd = time.Now()
 	latency = processEnd.Sub(processStart)
 
+	metrics.ObserveGRPCRequest(info.FullMethod, status.Code(err).String(), latency)
+
 	if err != nil {
 		grpcStatus, ok = status.FromError(err)
 		if ok && gostacode.HTTPStatusCodeFromGRPCCode(grpcStatus.Code()) >= http.StatusInternalServerError {
@@ -56,6 +60,10 @@ func (mw *LogMiddleware) Log(
 		}
 	}
 
+	if err == nil && !redactor.SampleSuccess() {
+		return res, err
+	}
+
 	logFields = map[string]interface{}{
 		"req":               req,
 		"unary server info": info,
@@ -1,11 +1,15 @@
 package middlewares
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"go-boilerplate/pkg/constants"
+	"go-boilerplate/pkg/redactor"
 	"testing"
 
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -284,3 +288,91 @@ func TestLogMiddleware_Log(t *testing.T) {
 		})
 	}
 }
+
+func TestLogMiddleware_Log_Redaction(t *testing.T) {
+	type payload struct {
+		Name    string `json:"name"`
+		Address string `json:"address"`
+	}
+
+	tests := []struct {
+		name        string
+		redactorCfg *redactor.Config
+		handlerErr  error
+		validateLog func(t *testing.T, output string)
+	}{
+		{
+			name: "should_redact_req_and_res_fields",
+			redactorCfg: &redactor.Config{
+				Fields:            []string{"$..address"},
+				SuccessSampleRate: 1,
+			},
+			validateLog: func(t *testing.T, output string) {
+				assert.Contains(t, output, "request response")
+				assert.NotContains(t, output, "123 Main St")
+				assert.Contains(t, output, redactor.RedactedValue)
+				assert.Contains(t, output, "John Doe")
+			},
+		},
+		{
+			name: "should_cap_payload_size",
+			redactorCfg: &redactor.Config{
+				MaxBodyBytes:      8,
+				SuccessSampleRate: 1,
+			},
+			validateLog: func(t *testing.T, output string) {
+				assert.Contains(t, output, "truncated")
+				assert.NotContains(t, output, "123 Main St")
+			},
+		},
+		{
+			name: "should_skip_unsampled_success_logs",
+			redactorCfg: &redactor.Config{
+				SuccessSampleRate: 0,
+			},
+			validateLog: func(t *testing.T, output string) {
+				assert.Empty(t, output)
+			},
+		},
+		{
+			name: "should_always_log_errors_regardless_of_sampling",
+			redactorCfg: &redactor.Config{
+				SuccessSampleRate: 0,
+			},
+			handlerErr: status.Error(codes.Internal, "internal error"),
+			validateLog: func(t *testing.T, output string) {
+				assert.Contains(t, output, "request response")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			originalLogger := log.Logger
+			log.Logger = zerolog.New(redactor.NewWriter(&buf))
+			redactor.NewRedactor(tt.redactorCfg)
+			t.Cleanup(func() {
+				log.Logger = originalLogger
+				redactor.NewRedactor(nil)
+			})
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				if tt.handlerErr != nil {
+					return nil, tt.handlerErr
+				}
+				return &payload{Name: "John Doe", Address: "123 Main St"}, nil
+			}
+
+			_, err := NewLogMiddleware().Log(
+				context.Background(),
+				&payload{Name: "John Doe", Address: "123 Main St"},
+				&grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"},
+				handler,
+			)
+
+			assert.Equal(t, tt.handlerErr, err)
+			tt.validateLog(t, buf.String())
+		})
+	}
+}
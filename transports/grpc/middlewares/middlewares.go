@@ -6,7 +6,9 @@ type Middlewares struct {
 	Recover   *RecoverMiddleware
 	Tracer    *TracerMiddleware
 	RequestID *RequestIDMiddleware
+	Identity  *IdentityMiddleware
 	Log       *LogMiddleware
+	Admin     *AdminMiddleware
 	Timeout   *TimeoutMiddleware
 }
 
@@ -15,7 +17,9 @@ func (mw *Middlewares) GetUnaryServerInterceptors() []grpc.UnaryServerIntercepto
 		mw.Recover.Recover,
 		mw.Tracer.Start,
 		mw.RequestID.Generate,
+		mw.Identity.Identify,
 		mw.Log.Log,
+		mw.Admin.Authenticate,
 		mw.Timeout.Timeout,
 	}
 }
@@ -19,13 +19,15 @@ func TestMiddlewares_GetUnaryServerInterceptors(t *testing.T) {
 					Recover:   NewRecoverMiddleware(),
 					Tracer:    NewTracerMiddleware(),
 					RequestID: NewRequestIDMiddleware(),
+					Identity:  NewIdentityMiddleware(),
 					Log:       NewLogMiddleware(),
+					Admin:     NewAdminMiddleware(nil),
 					Timeout:   NewTimeoutMiddleware(nil),
 				}
 			},
 			validate: func(t *testing.T, interceptors []interface{}) {
 				assert.NotNil(t, interceptors)
-				assert.Len(t, interceptors, 5)
+				assert.Len(t, interceptors, 7)
 
 				for _, interceptor := range interceptors {
 					assert.NotNil(t, interceptor)
@@ -39,13 +41,15 @@ func TestMiddlewares_GetUnaryServerInterceptors(t *testing.T) {
 					Recover:   NewRecoverMiddleware(),
 					Tracer:    NewTracerMiddleware(),
 					RequestID: NewRequestIDMiddleware(),
+					Identity:  NewIdentityMiddleware(),
 					Log:       NewLogMiddleware(),
+					Admin:     NewAdminMiddleware(nil),
 					Timeout:   NewTimeoutMiddleware(nil),
 				}
 			},
 			validate: func(t *testing.T, interceptors []interface{}) {
 				assert.NotNil(t, interceptors)
-				assert.Len(t, interceptors, 5)
+				assert.Len(t, interceptors, 7)
 
 				for i, interceptor := range interceptors {
 					assert.NotNil(t, interceptor, "Interceptor at index %d should not be nil", i)
@@ -60,7 +64,7 @@ func TestMiddlewares_GetUnaryServerInterceptors(t *testing.T) {
 			validate: func(t *testing.T, interceptors []interface{}) {
 
 				assert.NotNil(t, interceptors)
-				assert.Len(t, interceptors, 5)
+				assert.Len(t, interceptors, 7)
 			},
 		},
 	}
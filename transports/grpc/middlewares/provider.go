@@ -9,6 +9,8 @@ var Provider wire.ProviderSet = wire.NewSet(
 	NewRecoverMiddleware,
 	NewTracerMiddleware,
 	NewRequestIDMiddleware,
+	NewIdentityMiddleware,
 	NewLogMiddleware,
-	NewTimeoutMiddleware,
+	NewAdminMiddleware,
+	ProvideTimeoutMiddleware,
 )
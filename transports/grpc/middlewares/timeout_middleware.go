@@ -4,6 +4,8 @@ import (
 	"context"
 	"go-boilerplate/configs"
 	"go-boilerplate/pkg/tracer"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
@@ -13,12 +15,52 @@ import (
 
 type TimeoutMiddleware struct {
 	cfg *configs.Config
+
+	// reloadedCfg is the latest hot reloaded snapshot, nil until a reload
+	reloadedCfg atomic.Pointer[configs.Config]
+
+	// unsubscribe stops following the config hot reload
+	unsubscribe func()
 }
 
 func NewTimeoutMiddleware(cfg *configs.Config) *TimeoutMiddleware {
-	return &TimeoutMiddleware{
+	var mw *TimeoutMiddleware = &TimeoutMiddleware{
 		cfg: cfg,
 	}
+
+	mw.unsubscribe = configs.Subscribe(func(change *configs.Change) {
+		if change.Changed("SERVER.GRPC.REQUEST_TIMEOUT") {
+			mw.reloadedCfg.Store(change.Current)
+		}
+	})
+
+	return mw
+}
+
+// ProvideTimeoutMiddleware is NewTimeoutMiddleware for wire, the cleanup
+// closes the middleware.
+func ProvideTimeoutMiddleware(cfg *configs.Config) (*TimeoutMiddleware, func()) {
+	var mw *TimeoutMiddleware = NewTimeoutMiddleware(cfg)
+
+	return mw, mw.Close
+}
+
+// Close stops following the config hot reload, the middleware keeps the last
+// request timeout.
+func (mw *TimeoutMiddleware) Close() {
+	if mw.unsubscribe != nil {
+		mw.unsubscribe()
+	}
+}
+
+func (mw *TimeoutMiddleware) requestTimeout() time.Duration {
+	var cfg *configs.Config = mw.reloadedCfg.Load()
+
+	if cfg == nil {
+		cfg = mw.cfg
+	}
+
+	return cfg.Server.GRPC.RequestTimeout
 }
 
 func (mw *TimeoutMiddleware) Timeout(
@@ -40,7 +82,7 @@ func (mw *TimeoutMiddleware) Timeout(
 	ctx, span = tracer.Start(ctx, "[TimeoutMiddleware][Timeout]")
 	defer span.End()
 
-	ctxTimeout, cancel = context.WithTimeout(ctx, mw.cfg.Server.GRPC.RequestTimeout)
+	ctxTimeout, cancel = context.WithTimeout(ctx, mw.requestTimeout())
 	defer cancel()
 
 	resChan = make(chan interface{})
@@ -3,11 +3,18 @@ package middlewares
 import (
 	"context"
 	"go-boilerplate/pkg/grpc_metadata"
+	"go-boilerplate/pkg/tracer"
+	"strings"
 
+	"github.com/fikri240794/gocerr"
+	"github.com/fikri240794/gostacode"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type TracerMiddleware struct{}
@@ -16,6 +23,22 @@ func NewTracerMiddleware() *TracerMiddleware {
 	return &TracerMiddleware{}
 }
 
+// splitFullMethod splits "/package.Service/Method" into its service and method.
+func splitFullMethod(fullMethod string) (string, string) {
+	var (
+		service string
+		method  string
+		found   bool
+	)
+
+	service, method, found = strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !found {
+		return "", service
+	}
+
+	return service, method
+}
+
 func (mw *TracerMiddleware) Start(
 	ctx context.Context,
 	req interface{},
@@ -26,6 +49,10 @@ func (mw *TracerMiddleware) Start(
 		md             metadata.MD
 		ok             bool
 		traceparentMap map[string]string
+		span           trace.Span
+		service        string
+		method         string
+		grpcStatus     *status.Status
 		res            interface{}
 		err            error
 	)
@@ -36,7 +63,28 @@ func (mw *TracerMiddleware) Start(
 		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(traceparentMap))
 	}
 
+	service, method = splitFullMethod(info.FullMethod)
+
+	ctx, span = tracer.Start(
+		ctx,
+		strings.TrimPrefix(info.FullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(method),
+		),
+	)
+	defer span.End()
+
 	res, err = handler(ctx, req)
 
+	grpcStatus = status.Convert(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(grpcStatus.Code())))
+
+	if err != nil {
+		tracer.RecordError(span, gocerr.New(gostacode.HTTPStatusCodeFromGRPCCode(grpcStatus.Code()), grpcStatus.Message()))
+	}
+
 	return res, err
 }
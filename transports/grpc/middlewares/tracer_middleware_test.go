@@ -34,6 +34,37 @@ func TestNewTracerMiddleware(t *testing.T) {
 	}
 }
 
+func TestSplitFullMethod(t *testing.T) {
+	tests := []struct {
+		name            string
+		fullMethod      string
+		expectedService string
+		expectedMethod  string
+	}{
+		{
+			name:            "should_split_service_and_method",
+			fullMethod:      "/guest.GuestService/FindByID",
+			expectedService: "guest.GuestService",
+			expectedMethod:  "FindByID",
+		},
+		{
+			name:            "should_return_method_only_when_service_is_missing",
+			fullMethod:      "FindByID",
+			expectedService: "",
+			expectedMethod:  "FindByID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, method := splitFullMethod(tt.fullMethod)
+
+			assert.Equal(t, tt.expectedService, service)
+			assert.Equal(t, tt.expectedMethod, method)
+		})
+	}
+}
+
 func TestTracerMiddleware_Start(t *testing.T) {
 	tests := []struct {
 		name          string
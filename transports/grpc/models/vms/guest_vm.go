@@ -24,6 +24,53 @@ func DeleteGuestByIDRequestVMToDTO(vm *protobuf_boilerplate.DeleteGuestByIDReque
 	return dto
 }
 
+func EraseGuestByIDRequestVMToDTO(vm *protobuf_boilerplate.EraseGuestByIDRequestVM, erasedBy string) *dtos.EraseGuestByIDRequestDTO {
+	var dto *dtos.EraseGuestByIDRequestDTO = &dtos.EraseGuestByIDRequestDTO{
+		ID:       vm.GetId(),
+		ErasedBy: erasedBy,
+	}
+
+	return dto
+}
+
+func ExportGuestByIDRequestVMToDTO(vm *protobuf_boilerplate.ExportGuestByIDRequestVM) *dtos.ExportGuestByIDRequestDTO {
+	var dto *dtos.ExportGuestByIDRequestDTO = &dtos.ExportGuestByIDRequestDTO{
+		ID: vm.GetId(),
+	}
+
+	return dto
+}
+
+func NewGuestRecordVM(dto *dtos.GuestRecordDTO) *protobuf_boilerplate.GuestRecordVM {
+	return &protobuf_boilerplate.GuestRecordVM{
+		Id:        dto.ID,
+		Name:      dto.Name,
+		Address:   dto.Address,
+		CreatedAt: dto.CreatedAt,
+		CreatedBy: dto.CreatedBy,
+		UpdatedAt: dto.UpdatedAt,
+		UpdatedBy: dto.UpdatedBy,
+		DeletedAt: dto.DeletedAt,
+		DeletedBy: dto.DeletedBy,
+	}
+}
+
+func NewExportGuestResponseVM(dto *dtos.ExportGuestResponseDTO) *protobuf_boilerplate.ExportGuestResponseVM {
+	var vm *protobuf_boilerplate.ExportGuestResponseVM = &protobuf_boilerplate.ExportGuestResponseVM{
+		ExportedAt: dto.ExportedAt,
+	}
+
+	if dto.Database != nil {
+		vm.Database = NewGuestRecordVM(dto.Database)
+	}
+
+	if dto.Cache != nil {
+		vm.Cache = NewGuestRecordVM(dto.Cache)
+	}
+
+	return vm
+}
+
 func FindAllGuestRequestVMToDTO(vm *protobuf_boilerplate.FindAllGuestRequestVM) *dtos.FindAllGuestRequestDTO {
 	var dto *dtos.FindAllGuestRequestDTO = dtos.NewFindAllGuestRequestDTO()
 
@@ -104,6 +104,85 @@ func TestDeleteGuestByIDRequestVMToDTO(t *testing.T) {
 	}
 }
 
+func TestEraseGuestByIDRequestVMToDTO(t *testing.T) {
+	vm := &protobuf_boilerplate.EraseGuestByIDRequestVM{
+		Id: "550e8400-e29b-41d4-a716-446655440000",
+	}
+
+	dto := EraseGuestByIDRequestVMToDTO(vm, "dpo")
+
+	assert.Equal(t, &dtos.EraseGuestByIDRequestDTO{
+		ID:       "550e8400-e29b-41d4-a716-446655440000",
+		ErasedBy: "dpo",
+	}, dto)
+}
+
+func TestExportGuestByIDRequestVMToDTO(t *testing.T) {
+	vm := &protobuf_boilerplate.ExportGuestByIDRequestVM{
+		Id: "550e8400-e29b-41d4-a716-446655440000",
+	}
+
+	dto := ExportGuestByIDRequestVMToDTO(vm)
+
+	assert.Equal(t, &dtos.ExportGuestByIDRequestDTO{
+		ID: "550e8400-e29b-41d4-a716-446655440000",
+	}, dto)
+}
+
+func TestNewExportGuestResponseVM(t *testing.T) {
+	record := &dtos.GuestRecordDTO{
+		ID:        "550e8400-e29b-41d4-a716-446655440000",
+		Name:      "John Doe",
+		Address:   "123 Main St",
+		CreatedAt: 1000,
+		CreatedBy: "admin",
+		UpdatedAt: 2000,
+		UpdatedBy: "editor",
+		DeletedAt: 3000,
+		DeletedBy: "remover",
+	}
+
+	tests := []struct {
+		name     string
+		dto      *dtos.ExportGuestResponseDTO
+		validate func(t *testing.T, vm *protobuf_boilerplate.ExportGuestResponseVM)
+	}{
+		{
+			name: "should_convert_export_dto_with_cache_record",
+			dto: &dtos.ExportGuestResponseDTO{
+				Database:   record,
+				Cache:      record,
+				ExportedAt: 4000,
+			},
+			validate: func(t *testing.T, vm *protobuf_boilerplate.ExportGuestResponseVM) {
+				assert.Equal(t, "John Doe", vm.GetDatabase().GetName())
+				assert.Equal(t, int64(3000), vm.GetDatabase().GetDeletedAt())
+				assert.Equal(t, "remover", vm.GetDatabase().GetDeletedBy())
+				assert.Equal(t, "123 Main St", vm.GetCache().GetAddress())
+				assert.Equal(t, int64(4000), vm.GetExportedAt())
+			},
+		},
+		{
+			name: "should_convert_export_dto_without_cache_record",
+			dto: &dtos.ExportGuestResponseDTO{
+				Database:   record,
+				ExportedAt: 4000,
+			},
+			validate: func(t *testing.T, vm *protobuf_boilerplate.ExportGuestResponseVM) {
+				assert.NotNil(t, vm.GetDatabase())
+				assert.Nil(t, vm.GetCache())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewExportGuestResponseVM(tt.dto)
+			tt.validate(t, vm)
+		})
+	}
+}
+
 func TestFindAllGuestRequestVMToDTO(t *testing.T) {
 	tests := []struct {
 		name     string
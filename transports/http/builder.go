@@ -14,7 +14,7 @@ import (
 	"github.com/google/wire"
 )
 
-func BuildHTTPServer(cfg *configs.Config) *HTTPServer {
+func BuildHTTPServer(cfg *configs.Config) (*HTTPServer, func(), error) {
 	wire.Build(
 		datasources.Provider,
 		wire.Struct(new(datasources.Datasources), "*"),
@@ -27,5 +27,21 @@ func BuildHTTPServer(cfg *configs.Config) *HTTPServer {
 		NewHTTPServer,
 	)
 
-	return &HTTPServer{}
+	return nil, nil, nil
+}
+
+// BuildHTTPServerWithDatasources builds on datasources shared with other transports.
+func BuildHTTPServerWithDatasources(cfg *configs.Config, ds *datasources.Datasources) (*HTTPServer, func(), error) {
+	wire.Build(
+		datasources.SharedProvider,
+		repositories.Provider,
+		services.Provider,
+		handlers.Provider,
+		wire.Struct(new(handlers.Handlers), "*"),
+		middlewares.Provider,
+		wire.Struct(new(middlewares.Middlewares), "*"),
+		NewHTTPServer,
+	)
+
+	return nil, nil, nil
 }
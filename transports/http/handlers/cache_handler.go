@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"context"
+	"go-boilerplate/internal/models/dtos"
+	"go-boilerplate/internal/services"
+	"go-boilerplate/pkg/tracer"
+	"go-boilerplate/transports/http/models/vms"
+	"sync/atomic"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/fikri240794/gores"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CacheHandler serves the /admin/cache routes, the HTTP server guards them
+// with the admin token.
+type CacheHandler struct {
+	cacheService services.ICacheService
+
+	// warming is set while a warm up started by Warm runs
+	warming atomic.Bool
+}
+
+func NewCacheHandler(cacheService services.ICacheService) *CacheHandler {
+	return &CacheHandler{
+		cacheService: cacheService,
+	}
+}
+
+func (h *CacheHandler) SetupRoutes(server *fiber.App) {
+	server.Route("/admin/cache", func(api fiber.Router) {
+		api.Post("/warm", h.Warm)
+		api.Get("/stats", h.Stats)
+		api.Delete("/", h.Flush)
+	})
+}
+
+// @Summary	Warm Cache
+// @Description	Start loading the configured guest pages and IDs into the cache, the warm up outlives the request
+// @Tags	cache-admin
+// @Produce	application/json
+// @Param	Authorization	header	string	true	"Bearer admin token"
+// @Success	202	{object}	gores.ResponseVM[bool]
+// @Failure	401	{object}	gores.ResponseVM[bool]
+// @Failure	409	{object}	gores.ResponseVM[bool]
+// @Router	/admin/cache/warm	[post]
+func (h *CacheHandler) Warm(c *fiber.Ctx) error {
+	var (
+		ctx        context.Context
+		span       trace.Span
+		responseVM *gores.ResponseVM[bool]
+		err        error
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[CacheHandler][Warm]")
+	defer span.End()
+
+	if !h.warming.CompareAndSwap(false, true) {
+		err = gocerr.New(fiber.StatusConflict, "cache warm up is already running")
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[bool]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+
+	// the request timeout would cut the warm up short
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		var (
+			responseDTO *dtos.WarmCacheResponseDTO
+			err         error
+		)
+
+		defer h.warming.Store(false)
+
+		responseDTO, err = h.cacheService.Warm(ctx)
+		if err != nil {
+			log.Err(err).
+				Ctx(ctx).
+				Msg("[CacheHandler][Warm][Warm] failed to warm caches")
+			return
+		}
+
+		log.Info().
+			Ctx(ctx).
+			Uint64("pages", responseDTO.Pages).
+			Uint64("ids", responseDTO.IDs).
+			Uint64("failed", responseDTO.Failed).
+			Msg("[CacheHandler][Warm] caches warmed")
+	}()
+
+	responseVM = gores.NewResponseVM[bool]().
+		SetCode(fiber.StatusAccepted).
+		SetData(true)
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
+
+// @Summary	Cache Stats
+// @Description	Count the cache keys and their memory per prefix, the keys are walked with SCAN
+// @Tags	cache-admin
+// @Produce	application/json
+// @Param	Authorization	header	string	true	"Bearer admin token"
+// @Param	pattern	query	string	false	"pattern, every guest cache key by default"	example(caches:entities:guests:*)
+// @Param	depth	query	int	false	"number of key segments a prefix keeps"	example(4)
+// @Success	200	{object}	gores.ResponseVM[vms.CacheStatsResponseVM]
+// @Failure	400	{object}	gores.ResponseVM[vms.CacheStatsResponseVM]
+// @Failure	401	{object}	gores.ResponseVM[vms.CacheStatsResponseVM]
+// @Failure	500	{object}	gores.ResponseVM[vms.CacheStatsResponseVM]
+// @Router	/admin/cache/stats	[get]
+func (h *CacheHandler) Stats(c *fiber.Ctx) error {
+	var (
+		ctx         context.Context
+		span        trace.Span
+		logFields   map[string]interface{}
+		requestVM   *vms.CacheStatsRequestVM
+		requestDTO  *dtos.CacheStatsRequestDTO
+		responseDTO *dtos.CacheStatsResponseDTO
+		responseVM  *gores.ResponseVM[*vms.CacheStatsResponseVM]
+		err         error
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[CacheHandler][Stats]")
+	defer span.End()
+
+	logFields = map[string]interface{}{}
+
+	requestVM = &vms.CacheStatsRequestVM{}
+	err = c.QueryParser(requestVM)
+	if err != nil {
+		log.Warn().
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[CacheHandler][Stats][QueryParser] failed to parse query")
+		err = gocerr.New(fiber.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[*vms.CacheStatsResponseVM]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+	logFields["requestVM"] = requestVM
+
+	requestDTO = requestVM.ToDTO()
+	logFields["requestDTO"] = requestDTO
+
+	responseDTO, err = h.cacheService.Stats(ctx, requestDTO)
+	if err != nil {
+		log.WithLevel(errorLogLevel(err)).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[CacheHandler][Stats][Stats] failed to get cache stats")
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[*vms.CacheStatsResponseVM]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+
+	responseVM = gores.NewResponseVM[*vms.CacheStatsResponseVM]().
+		SetCode(fiber.StatusOK).
+		SetData(vms.NewCacheStatsResponseVM(responseDTO))
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
+
+// @Summary	Flush Cache
+// @Description	Delete the cache keys matching the pattern with SCAN, the pattern has to start with the guest cache prefix
+// @Tags	cache-admin
+// @Produce	application/json
+// @Param	Authorization	header	string	true	"Bearer admin token"
+// @Param	pattern	query	string	true	"pattern"	example(caches:entities:guests:list:*)
+// @Success	200	{object}	gores.ResponseVM[vms.FlushCacheResponseVM]
+// @Failure	400	{object}	gores.ResponseVM[vms.FlushCacheResponseVM]
+// @Failure	401	{object}	gores.ResponseVM[vms.FlushCacheResponseVM]
+// @Failure	500	{object}	gores.ResponseVM[vms.FlushCacheResponseVM]
+// @Router	/admin/cache	[delete]
+func (h *CacheHandler) Flush(c *fiber.Ctx) error {
+	var (
+		ctx         context.Context
+		span        trace.Span
+		logFields   map[string]interface{}
+		requestVM   *vms.FlushCacheRequestVM
+		requestDTO  *dtos.FlushCacheRequestDTO
+		responseDTO *dtos.FlushCacheResponseDTO
+		responseVM  *gores.ResponseVM[*vms.FlushCacheResponseVM]
+		err         error
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[CacheHandler][Flush]")
+	defer span.End()
+
+	logFields = map[string]interface{}{}
+
+	requestVM = &vms.FlushCacheRequestVM{}
+	c.QueryParser(requestVM)
+	logFields["requestVM"] = requestVM
+
+	requestDTO = requestVM.ToDTO()
+	logFields["requestDTO"] = requestDTO
+
+	responseDTO, err = h.cacheService.Flush(ctx, requestDTO)
+	if err != nil {
+		log.WithLevel(errorLogLevel(err)).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[CacheHandler][Flush][Flush] failed to flush caches")
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[*vms.FlushCacheResponseVM]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+
+	responseVM = gores.NewResponseVM[*vms.FlushCacheResponseVM]().
+		SetCode(fiber.StatusOK).
+		SetData(vms.NewFlushCacheResponseVM(responseDTO))
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
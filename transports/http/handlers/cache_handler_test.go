@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-boilerplate/internal/models/dtos"
+	"go-boilerplate/internal/services/mocks"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewCacheHandler(t *testing.T) {
+	mockService := mocks.NewCacheServiceMock(t)
+
+	handler := NewCacheHandler(mockService)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockService, handler.cacheService)
+}
+
+func TestCacheHandler_SetupRoutes(t *testing.T) {
+	app := fiber.New()
+	handler := NewCacheHandler(mocks.NewCacheServiceMock(t))
+
+	handler.SetupRoutes(app)
+
+	expectedRoutes := map[string]bool{
+		"POST /admin/cache/warm": false,
+		"GET /admin/cache/stats": false,
+		"DELETE /admin/cache/":   false,
+	}
+	for _, route := range app.GetRoutes() {
+		key := route.Method + " " + route.Path
+		if _, ok := expectedRoutes[key]; ok {
+			expectedRoutes[key] = true
+		}
+	}
+
+	for route, found := range expectedRoutes {
+		assert.True(t, found, "%s route should be registered", route)
+	}
+}
+
+func readCacheResponse(t *testing.T, resp *http.Response) map[string]interface{} {
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err)
+
+	return response
+}
+
+func TestCacheHandler_Warm(t *testing.T) {
+	tests := []struct {
+		name           string
+		warming        bool
+		setupMock      func(m *mocks.CacheServiceMock, done chan struct{})
+		expectedStatus int
+	}{
+		{
+			name: "should_start_warm_up_in_background",
+			setupMock: func(m *mocks.CacheServiceMock, done chan struct{}) {
+				m.On("Warm", mock.Anything).
+					Run(func(args mock.Arguments) { close(done) }).
+					Return(&dtos.WarmCacheResponseDTO{Pages: 1, IDs: 2}, nil)
+			},
+			expectedStatus: fiber.StatusAccepted,
+		},
+		{
+			name: "should_accept_even_when_warm_up_fails",
+			setupMock: func(m *mocks.CacheServiceMock, done chan struct{}) {
+				m.On("Warm", mock.Anything).
+					Run(func(args mock.Arguments) { close(done) }).
+					Return(&dtos.WarmCacheResponseDTO{}, gocerr.New(fiber.StatusInternalServerError, "redis down"))
+			},
+			expectedStatus: fiber.StatusAccepted,
+		},
+		{
+			name:    "should_return_conflict_while_warm_up_runs",
+			warming: true,
+			setupMock: func(m *mocks.CacheServiceMock, done chan struct{}) {
+				close(done)
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan struct{})
+			mockService := mocks.NewCacheServiceMock(t)
+			tt.setupMock(mockService, done)
+			handler := NewCacheHandler(mockService)
+			handler.warming.Store(tt.warming)
+			app := fiber.New()
+			app.Post("/admin/cache/warm", handler.Warm)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/admin/cache/warm", nil), -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("warm up did not run")
+			}
+
+			assert.Eventually(t, func() bool {
+				return handler.warming.Load() == tt.warming
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestCacheHandler_Stats(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		setupMock      func(m *mocks.CacheServiceMock)
+		expectedStatus int
+		validate       func(t *testing.T, response map[string]interface{})
+	}{
+		{
+			name: "should_return_stats",
+			url:  "/admin/cache/stats?pattern=caches:*&depth=2",
+			setupMock: func(m *mocks.CacheServiceMock) {
+				m.On("Stats", mock.Anything, &dtos.CacheStatsRequestDTO{Pattern: "caches:*", Depth: 2}).
+					Return(&dtos.CacheStatsResponseDTO{
+						Prefixes:    []dtos.CachePrefixStatsDTO{{Prefix: "caches:entities", Keys: 2, MemoryBytes: 64}},
+						Keys:        2,
+						MemoryBytes: 64,
+					}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			validate: func(t *testing.T, response map[string]interface{}) {
+				data := response["data"].(map[string]interface{})
+				assert.Equal(t, float64(2), data["keys"])
+				assert.Equal(t, float64(64), data["memory_bytes"])
+				assert.Len(t, data["prefixes"], 1)
+			},
+		},
+		{
+			name:           "should_return_bad_request_when_query_is_invalid",
+			url:            "/admin/cache/stats?depth=deep",
+			setupMock:      func(m *mocks.CacheServiceMock) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "should_return_service_error",
+			url:  "/admin/cache/stats",
+			setupMock: func(m *mocks.CacheServiceMock) {
+				m.On("Stats", mock.Anything, &dtos.CacheStatsRequestDTO{}).
+					Return(nil, gocerr.New(fiber.StatusInternalServerError, "redis down"))
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewCacheServiceMock(t)
+			tt.setupMock(mockService)
+			app := fiber.New()
+			app.Get("/admin/cache/stats", NewCacheHandler(mockService).Stats)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.url, nil), -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.validate != nil {
+				tt.validate(t, readCacheResponse(t, resp))
+			}
+		})
+	}
+}
+
+func TestCacheHandler_Flush(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		setupMock      func(m *mocks.CacheServiceMock)
+		expectedStatus int
+		validate       func(t *testing.T, response map[string]interface{})
+	}{
+		{
+			name: "should_flush_caches",
+			url:  "/admin/cache?pattern=caches:entities:guests:*",
+			setupMock: func(m *mocks.CacheServiceMock) {
+				m.On("Flush", mock.Anything, &dtos.FlushCacheRequestDTO{Pattern: "caches:entities:guests:*"}).
+					Return(&dtos.FlushCacheResponseDTO{Deleted: 7}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			validate: func(t *testing.T, response map[string]interface{}) {
+				data := response["data"].(map[string]interface{})
+				assert.Equal(t, float64(7), data["deleted"])
+			},
+		},
+		{
+			name: "should_return_bad_request_without_pattern",
+			url:  "/admin/cache",
+			setupMock: func(m *mocks.CacheServiceMock) {
+				m.On("Flush", mock.Anything, &dtos.FlushCacheRequestDTO{}).
+					Return(nil, gocerr.New(fiber.StatusBadRequest, "pattern is required"))
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewCacheServiceMock(t)
+			tt.setupMock(mockService)
+			app := fiber.New()
+			app.Delete("/admin/cache", NewCacheHandler(mockService).Flush)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodDelete, tt.url, nil), -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.validate != nil {
+				tt.validate(t, readCacheResponse(t, resp))
+			}
+		})
+	}
+}
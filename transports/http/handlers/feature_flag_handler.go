@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"context"
+	"go-boilerplate/internal/services"
+	"go-boilerplate/pkg/feature_flag"
+	"go-boilerplate/pkg/tracer"
+	"go-boilerplate/transports/http/models/vms"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/fikri240794/gores"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type FeatureFlagHandler struct {
+	featureFlagService services.IFeatureFlagService
+}
+
+func NewFeatureFlagHandler(featureFlagService services.IFeatureFlagService) *FeatureFlagHandler {
+	return &FeatureFlagHandler{
+		featureFlagService: featureFlagService,
+	}
+}
+
+func (h *FeatureFlagHandler) SetupRoutes(server *fiber.App) {
+	server.Route("/admin/feature-flags", func(api fiber.Router) {
+		api.Get("/", h.List)
+		api.Get("/:name", h.Get)
+		api.Put("/:name", h.Set)
+		api.Delete("/:name", h.Delete)
+	})
+}
+
+func errorLogLevel(err error) zerolog.Level {
+	if gocerr.GetErrorCode(err) >= fiber.StatusInternalServerError {
+		return zerolog.ErrorLevel
+	}
+
+	return zerolog.WarnLevel
+}
+
+// @Summary	List Feature Flags
+// @Description	List the feature flags of the configured backend
+// @Tags	feature-flag-admin
+// @Produce	application/json
+// @Success	200	{object}	gores.ResponseVM[[]vms.FeatureFlagResponseVM]
+// @Failure	500	{object}	gores.ResponseVM[[]vms.FeatureFlagResponseVM]
+// @Failure	501	{object}	gores.ResponseVM[[]vms.FeatureFlagResponseVM]
+// @Router	/admin/feature-flags	[get]
+func (h *FeatureFlagHandler) List(c *fiber.Ctx) error {
+	var (
+		ctx        context.Context
+		span       trace.Span
+		flags      []feature_flag.Flag
+		responseVM *gores.ResponseVM[*[]vms.FeatureFlagResponseVM]
+		err        error
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagHandler][List]")
+	defer span.End()
+
+	flags, err = h.featureFlagService.List(ctx)
+	if err != nil {
+		log.WithLevel(errorLogLevel(err)).
+			Ctx(ctx).
+			Err(err).
+			Msg("[FeatureFlagHandler][List][List] failed to list feature flags")
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[*[]vms.FeatureFlagResponseVM]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+
+	responseVM = gores.NewResponseVM[*[]vms.FeatureFlagResponseVM]().
+		SetCode(fiber.StatusOK).
+		SetData(vms.NewFeatureFlagResponseVMs(flags))
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
+
+// @Summary	Get Feature Flag
+// @Description	Get a feature flag by name
+// @Tags	feature-flag-admin
+// @Produce	application/json
+// @Param	name	path	string	true	"name"	example(guest.cache)
+// @Success	200	{object}	gores.ResponseVM[vms.FeatureFlagResponseVM]
+// @Failure	404	{object}	gores.ResponseVM[vms.FeatureFlagResponseVM]
+// @Failure	500	{object}	gores.ResponseVM[vms.FeatureFlagResponseVM]
+// @Failure	501	{object}	gores.ResponseVM[vms.FeatureFlagResponseVM]
+// @Router	/admin/feature-flags/{name}	[get]
+func (h *FeatureFlagHandler) Get(c *fiber.Ctx) error {
+	var (
+		ctx        context.Context
+		span       trace.Span
+		logFields  map[string]interface{}
+		requestVM  *vms.FeatureFlagNameRequestVM
+		flag       *feature_flag.Flag
+		responseVM *gores.ResponseVM[*vms.FeatureFlagResponseVM]
+		err        error
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagHandler][Get]")
+	defer span.End()
+
+	logFields = map[string]interface{}{}
+
+	requestVM = &vms.FeatureFlagNameRequestVM{}
+	c.ParamsParser(requestVM)
+	logFields["requestVM"] = requestVM
+
+	flag, err = h.featureFlagService.Get(ctx, requestVM.Name)
+	if err != nil {
+		log.WithLevel(errorLogLevel(err)).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[FeatureFlagHandler][Get][Get] failed to get feature flag")
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[*vms.FeatureFlagResponseVM]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+
+	responseVM = gores.NewResponseVM[*vms.FeatureFlagResponseVM]().
+		SetCode(fiber.StatusOK).
+		SetData(vms.NewFeatureFlagResponseVM(flag))
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
+
+// @Summary	Set Feature Flag
+// @Description	Create or replace a feature flag, the change applies without a restart
+// @Tags	feature-flag-admin
+// @Accept	application/json
+// @Produce	application/json
+// @Param	name	path	string	true	"name"	example(guest.cache)
+// @Param	SetFeatureFlagRequestVM	body	vms.SetFeatureFlagRequestVM	true	"SetFeatureFlagRequestVM"
+// @Success	200	{object}	gores.ResponseVM[vms.FeatureFlagResponseVM]
+// @Failure	400	{object}	gores.ResponseVM[vms.FeatureFlagResponseVM]
+// @Failure	500	{object}	gores.ResponseVM[vms.FeatureFlagResponseVM]
+// @Failure	501	{object}	gores.ResponseVM[vms.FeatureFlagResponseVM]
+// @Router	/admin/feature-flags/{name}	[put]
+func (h *FeatureFlagHandler) Set(c *fiber.Ctx) error {
+	var (
+		ctx        context.Context
+		span       trace.Span
+		logFields  map[string]interface{}
+		requestVM  *vms.SetFeatureFlagRequestVM
+		flag       *feature_flag.Flag
+		responseVM *gores.ResponseVM[*vms.FeatureFlagResponseVM]
+		err        error
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagHandler][Set]")
+	defer span.End()
+
+	logFields = map[string]interface{}{}
+
+	requestVM = &vms.SetFeatureFlagRequestVM{}
+	err = c.BodyParser(requestVM)
+	if err != nil {
+		log.Warn().
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[FeatureFlagHandler][Set][BodyParser] failed to parse body")
+		err = gocerr.New(fiber.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[*vms.FeatureFlagResponseVM]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+	c.ParamsParser(requestVM)
+	logFields["requestVM"] = requestVM
+
+	flag = requestVM.ToFlag()
+
+	err = h.featureFlagService.Set(ctx, flag)
+	if err != nil {
+		log.WithLevel(errorLogLevel(err)).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[FeatureFlagHandler][Set][Set] failed to set feature flag")
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[*vms.FeatureFlagResponseVM]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+
+	responseVM = gores.NewResponseVM[*vms.FeatureFlagResponseVM]().
+		SetCode(fiber.StatusOK).
+		SetData(vms.NewFeatureFlagResponseVM(flag))
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
+
+// @Summary	Delete Feature Flag
+// @Description	Delete a feature flag so its fallback config applies again
+// @Tags	feature-flag-admin
+// @Produce	application/json
+// @Param	name	path	string	true	"name"	example(guest.cache)
+// @Success	200	{object}	gores.ResponseVM[bool]
+// @Failure	404	{object}	gores.ResponseVM[bool]
+// @Failure	500	{object}	gores.ResponseVM[bool]
+// @Failure	501	{object}	gores.ResponseVM[bool]
+// @Router	/admin/feature-flags/{name}	[delete]
+func (h *FeatureFlagHandler) Delete(c *fiber.Ctx) error {
+	var (
+		ctx        context.Context
+		span       trace.Span
+		logFields  map[string]interface{}
+		requestVM  *vms.FeatureFlagNameRequestVM
+		responseVM *gores.ResponseVM[bool]
+		err        error
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[FeatureFlagHandler][Delete]")
+	defer span.End()
+
+	logFields = map[string]interface{}{}
+
+	requestVM = &vms.FeatureFlagNameRequestVM{}
+	c.ParamsParser(requestVM)
+	logFields["requestVM"] = requestVM
+
+	err = h.featureFlagService.Delete(ctx, requestVM.Name)
+	if err != nil {
+		log.WithLevel(errorLogLevel(err)).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[FeatureFlagHandler][Delete][Delete] failed to delete feature flag")
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[bool]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+
+	responseVM = gores.NewResponseVM[bool]().
+		SetCode(fiber.StatusOK).
+		SetData(true)
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-boilerplate/internal/services/mocks"
+	"go-boilerplate/pkg/feature_flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewFeatureFlagHandler(t *testing.T) {
+	mockService := mocks.NewFeatureFlagServiceMock(t)
+
+	handler := NewFeatureFlagHandler(mockService)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockService, handler.featureFlagService)
+}
+
+func TestFeatureFlagHandler_SetupRoutes(t *testing.T) {
+	app := fiber.New()
+	handler := NewFeatureFlagHandler(mocks.NewFeatureFlagServiceMock(t))
+
+	handler.SetupRoutes(app)
+
+	expectedRoutes := map[string]bool{
+		"GET /admin/feature-flags/":         false,
+		"GET /admin/feature-flags/:name":    false,
+		"PUT /admin/feature-flags/:name":    false,
+		"DELETE /admin/feature-flags/:name": false,
+	}
+	for _, route := range app.GetRoutes() {
+		key := route.Method + " " + route.Path
+		if _, ok := expectedRoutes[key]; ok {
+			expectedRoutes[key] = true
+		}
+	}
+
+	for route, found := range expectedRoutes {
+		assert.True(t, found, "%s route should be registered", route)
+	}
+}
+
+func readFeatureFlagResponse(t *testing.T, resp *http.Response) map[string]interface{} {
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err)
+
+	return response
+}
+
+func TestFeatureFlagHandler_List(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m *mocks.FeatureFlagServiceMock)
+		expectedStatus int
+		validate       func(t *testing.T, response map[string]interface{})
+	}{
+		{
+			name: "should_list_flags",
+			setupMock: func(m *mocks.FeatureFlagServiceMock) {
+				m.On("List", mock.Anything).Return([]feature_flag.Flag{{Name: "guest.cache", Enabled: true, Percentage: 100}}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			validate: func(t *testing.T, response map[string]interface{}) {
+				data := response["data"].([]interface{})
+				assert.Len(t, data, 1)
+				assert.Equal(t, "guest.cache", data[0].(map[string]interface{})["name"])
+			},
+		},
+		{
+			name: "should_return_not_implemented_without_backend",
+			setupMock: func(m *mocks.FeatureFlagServiceMock) {
+				m.On("List", mock.Anything).Return(nil, gocerr.New(fiber.StatusNotImplemented, "feature flag backend is not configured"))
+			},
+			expectedStatus: fiber.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewFeatureFlagServiceMock(t)
+			tt.setupMock(mockService)
+			app := fiber.New()
+			app.Get("/admin/feature-flags", NewFeatureFlagHandler(mockService).List)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/admin/feature-flags", nil), -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.validate != nil {
+				tt.validate(t, readFeatureFlagResponse(t, resp))
+			}
+		})
+	}
+}
+
+func TestFeatureFlagHandler_Get(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m *mocks.FeatureFlagServiceMock)
+		expectedStatus int
+	}{
+		{
+			name: "should_get_flag",
+			setupMock: func(m *mocks.FeatureFlagServiceMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(&feature_flag.Flag{Name: "guest.cache"}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "should_return_not_found_for_unknown_flag",
+			setupMock: func(m *mocks.FeatureFlagServiceMock) {
+				m.On("Get", mock.Anything, "guest.cache").Return(nil, gocerr.New(fiber.StatusNotFound, "feature flag not found"))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewFeatureFlagServiceMock(t)
+			tt.setupMock(mockService)
+			app := fiber.New()
+			app.Get("/admin/feature-flags/:name", NewFeatureFlagHandler(mockService).Get)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/admin/feature-flags/guest.cache", nil), -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestFeatureFlagHandler_Set(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(m *mocks.FeatureFlagServiceMock)
+		expectedStatus int
+		validate       func(t *testing.T, response map[string]interface{})
+	}{
+		{
+			name: "should_set_flag_named_by_path",
+			body: `{"name":"ignored","enabled":true,"tenants":["acme"],"percentage":25}`,
+			setupMock: func(m *mocks.FeatureFlagServiceMock) {
+				m.On("Set", mock.Anything, &feature_flag.Flag{Name: "guest.cache", Enabled: true, Tenants: []string{"acme"}, Percentage: 25}).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			validate: func(t *testing.T, response map[string]interface{}) {
+				data := response["data"].(map[string]interface{})
+				assert.Equal(t, "guest.cache", data["name"])
+				assert.Equal(t, float64(25), data["percentage"])
+			},
+		},
+		{
+			name:           "should_return_bad_request_when_body_is_invalid",
+			body:           `{invalid`,
+			setupMock:      func(m *mocks.FeatureFlagServiceMock) {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "should_return_service_error",
+			body: `{"enabled":true,"percentage":150}`,
+			setupMock: func(m *mocks.FeatureFlagServiceMock) {
+				m.On("Set", mock.Anything, mock.Anything).Return(gocerr.New(fiber.StatusBadRequest, "percentage must be between 0 and 100, got 150"))
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "should_return_internal_server_error",
+			body: `{"enabled":true}`,
+			setupMock: func(m *mocks.FeatureFlagServiceMock) {
+				m.On("Set", mock.Anything, mock.Anything).Return(gocerr.New(fiber.StatusInternalServerError, "disk failure"))
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewFeatureFlagServiceMock(t)
+			tt.setupMock(mockService)
+			app := fiber.New()
+			app.Put("/admin/feature-flags/:name", NewFeatureFlagHandler(mockService).Set)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/feature-flags/guest.cache", strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.validate != nil {
+				tt.validate(t, readFeatureFlagResponse(t, resp))
+			}
+		})
+	}
+}
+
+func TestFeatureFlagHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m *mocks.FeatureFlagServiceMock)
+		expectedStatus int
+	}{
+		{
+			name: "should_delete_flag",
+			setupMock: func(m *mocks.FeatureFlagServiceMock) {
+				m.On("Delete", mock.Anything, "guest.cache").Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "should_return_not_found_for_unknown_flag",
+			setupMock: func(m *mocks.FeatureFlagServiceMock) {
+				m.On("Delete", mock.Anything, "guest.cache").Return(gocerr.New(fiber.StatusNotFound, "feature flag not found"))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewFeatureFlagServiceMock(t)
+			tt.setupMock(mockService)
+			app := fiber.New()
+			app.Delete("/admin/feature-flags/:name", NewFeatureFlagHandler(mockService).Delete)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/admin/feature-flags/guest.cache", nil), -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
@@ -37,6 +37,10 @@ func (h *GuestHandler) SetupRoutes(server *fiber.App) {
 		api.Get("/:id", h.FindByID)
 		api.Put("/:id", h.UpdateByID)
 	})
+	server.Route("/admin/guests", func(api fiber.Router) {
+		api.Post("/:id/erase", h.EraseByID)
+		api.Get("/:id/export", h.ExportByID)
+	})
 }
 
 // @Summary	Create Guest
@@ -78,6 +82,7 @@ func (h *GuestHandler) Create(c *fiber.Ctx) error {
 			Fields(logFields).
 			Msg("[GuestHandler][Create][BodyParser] failed to parse request body")
 		err = gocerr.New(fiber.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[*vms.GuestResponseVM]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -100,6 +105,7 @@ func (h *GuestHandler) Create(c *fiber.Ctx) error {
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestHandler][Create][Create] failed to create")
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[*vms.GuestResponseVM]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -162,6 +168,7 @@ func (h *GuestHandler) DeleteByID(c *fiber.Ctx) error {
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestHandler][DeleteByID][DeleteByID] failed to delete by id")
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[bool]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -176,12 +183,139 @@ func (h *GuestHandler) DeleteByID(c *fiber.Ctx) error {
 		JSON(responseVM)
 }
 
+// @Summary	Erase Guest by ID
+// @Description	Anonymise Guest personal data by ID and remove related caches
+// @Tags	guest-admin
+// @Produce	application/json
+// @Param	id	path	string	true	"id"	example(01932293-d710-7f55-a9f6-66e6248ae72f)
+// @Success	200	{object}	gores.ResponseVM[bool]
+// @Failure	400	{object}	gores.ResponseVM[bool]
+// @Failure	404	{object}	gores.ResponseVM[bool]
+// @Failure	500	{object}	gores.ResponseVM[bool]
+// @Router	/admin/guests/{id}/erase	[post]
+func (h *GuestHandler) EraseByID(c *fiber.Ctx) error {
+	var (
+		ctx        context.Context
+		span       trace.Span
+		logFields  map[string]interface{}
+		requestVM  *vms.EraseGuestByIDRequestVM
+		requestDTO *dtos.EraseGuestByIDRequestDTO
+		logLevel   zerolog.Level
+		responseVM *gores.ResponseVM[bool]
+		err        error
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[GuestHandler][EraseByID]")
+	defer span.End()
+
+	logFields = map[string]interface{}{}
+
+	requestVM = &vms.EraseGuestByIDRequestVM{}
+	c.ParamsParser(requestVM)
+	logFields["requestVM"] = requestVM
+
+	requestDTO = requestVM.ToDTO(uuid.Nil.String())
+	logFields["requestDTO"] = requestDTO
+
+	err = h.guestService.EraseByID(ctx, requestDTO)
+	if err != nil {
+		logLevel = zerolog.WarnLevel
+		if gocerr.GetErrorCode(err) >= fiber.StatusInternalServerError {
+			logLevel = zerolog.ErrorLevel
+		}
+
+		log.WithLevel(logLevel).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[GuestHandler][EraseByID][EraseByID] failed to erase by id")
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[bool]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+
+	responseVM = gores.NewResponseVM[bool]().
+		SetCode(fiber.StatusOK).
+		SetData(true)
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
+
+// @Summary	Export Guest by ID
+// @Description	Export everything stored about a Guest by ID
+// @Tags	guest-admin
+// @Produce	application/json
+// @Param	id	path	string	true	"id"	example(01932293-d710-7f55-a9f6-66e6248ae72f)
+// @Success	200	{object}	gores.ResponseVM[vms.ExportGuestResponseVM]
+// @Failure	400	{object}	gores.ResponseVM[vms.ExportGuestResponseVM]
+// @Failure	404	{object}	gores.ResponseVM[vms.ExportGuestResponseVM]
+// @Failure	500	{object}	gores.ResponseVM[vms.ExportGuestResponseVM]
+// @Router	/admin/guests/{id}/export	[get]
+func (h *GuestHandler) ExportByID(c *fiber.Ctx) error {
+	var (
+		ctx         context.Context
+		span        trace.Span
+		logFields   map[string]interface{}
+		requestVM   *vms.ExportGuestByIDRequestVM
+		requestDTO  *dtos.ExportGuestByIDRequestDTO
+		responseDTO *dtos.ExportGuestResponseDTO
+		logLevel    zerolog.Level
+		responseVM  *gores.ResponseVM[*vms.ExportGuestResponseVM]
+		err         error
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[GuestHandler][ExportByID]")
+	defer span.End()
+
+	logFields = map[string]interface{}{}
+
+	requestVM = &vms.ExportGuestByIDRequestVM{}
+	c.ParamsParser(requestVM)
+	logFields["requestVM"] = requestVM
+
+	requestDTO = requestVM.ToDTO()
+	logFields["requestDTO"] = requestDTO
+
+	responseDTO, err = h.guestService.ExportByID(ctx, requestDTO)
+	if err != nil {
+		logLevel = zerolog.WarnLevel
+		if gocerr.GetErrorCode(err) >= fiber.StatusInternalServerError {
+			logLevel = zerolog.ErrorLevel
+		}
+
+		log.WithLevel(logLevel).
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[GuestHandler][ExportByID][ExportByID] failed to export by id")
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[*vms.ExportGuestResponseVM]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+
+	responseVM = gores.NewResponseVM[*vms.ExportGuestResponseVM]().
+		SetCode(fiber.StatusOK).
+		SetData(vms.NewExportGuestResponseVM(responseDTO))
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
+
 // @Summary	Find All Guest
 // @Description	Find All Guest
 // @Tags	guest
 // @Produce	application/json
-// @Param	keyword	query	string	false	"name or address"	example(John Snow or 123 Main Street)
-// @Param	sorts	query	string	false	"sorts"	example(name.asc,address.desc)
+// @Param	keyword	query	string	false	"name"	example(John Snow)
+// @Param	sorts	query	string	false	"sorts"	example(name.desc)
 // @Param	take	query	number	true	"take"	example(10)	minimum(1)
 // @Param	skip	query	number	false	"skip"	example(0)	minimum(0)
 // @Success	200	{object}	gores.ResponseVM[vms.FindAllGuestResponseVM]
@@ -217,6 +351,7 @@ func (h *GuestHandler) FindAll(c *fiber.Ctx) error {
 			Fields(logFields).
 			Msg("[GuestHandler][FindAll][QueryParser] failed to parse request query")
 		err = gocerr.New(fiber.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[*vms.FindAllGuestResponseVM]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -239,6 +374,7 @@ func (h *GuestHandler) FindAll(c *fiber.Ctx) error {
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestHandler][FindAll][FindAll] failed to find all")
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[*vms.FindAllGuestResponseVM]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -302,6 +438,7 @@ func (h *GuestHandler) FindByID(c *fiber.Ctx) error {
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestHandler][FindByID][FindByID] failed to find by id")
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[*vms.GuestResponseVM]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -359,6 +496,7 @@ func (h *GuestHandler) UpdateByID(c *fiber.Ctx) error {
 			Fields(logFields).
 			Msg("[GuestHandler][UpdateByID][BodyParser] failed to parse request body")
 		err = gocerr.New(fiber.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[*vms.GuestResponseVM]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -381,6 +519,7 @@ func (h *GuestHandler) UpdateByID(c *fiber.Ctx) error {
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestHandler][UpdateByID][UpdateByID] failed to update by id")
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[*vms.GuestResponseVM]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -434,6 +573,7 @@ func (h *GuestHandler) BulkCreate(c *fiber.Ctx) error {
 			Fields(logFields).
 			Msg("[GuestHandler][BulkCreate][BodyParser] failed to parse request body")
 		err = gocerr.New(fiber.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[*[]vms.GuestResponseVM]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -456,6 +596,7 @@ func (h *GuestHandler) BulkCreate(c *fiber.Ctx) error {
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestHandler][BulkCreate][BulkCreate] failed to bulk create")
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[*[]vms.GuestResponseVM]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -509,6 +650,7 @@ func (h *GuestHandler) BulkUpdate(c *fiber.Ctx) error {
 			Fields(logFields).
 			Msg("[GuestHandler][BulkUpdate][BodyParser] failed to parse request body")
 		err = gocerr.New(fiber.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[*[]vms.GuestResponseVM]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -531,6 +673,7 @@ func (h *GuestHandler) BulkUpdate(c *fiber.Ctx) error {
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestHandler][BulkUpdate][BulkUpdate] failed to bulk update")
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[*[]vms.GuestResponseVM]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -583,6 +726,7 @@ func (h *GuestHandler) BulkDelete(c *fiber.Ctx) error {
 			Fields(logFields).
 			Msg("[GuestHandler][BulkDelete][BodyParser] failed to parse request body")
 		err = gocerr.New(fiber.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[bool]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -605,6 +749,7 @@ func (h *GuestHandler) BulkDelete(c *fiber.Ctx) error {
 			Err(err).
 			Fields(logFields).
 			Msg("[GuestHandler][BulkDelete][BulkDelete] failed to bulk delete")
+		tracer.RecordError(span, err)
 		responseVM = gores.NewResponseVM[bool]().
 			SetErrorFromError(err)
 		return c.Status(responseVM.Code).
@@ -130,6 +130,9 @@ func TestGuestHandler_SetupRoutes(t *testing.T) {
 				assert.True(t, hasPostGuestsBulk, "Expected POST /guests/bulk route to be registered")
 				assert.True(t, hasPutGuestsBulk, "Expected PUT /guests/bulk route to be registered")
 				assert.True(t, hasDeleteGuestsBulk, "Expected DELETE /guests/bulk route to be registered")
+
+				assert.True(t, routeMap["POST /admin/guests/:id/erase"], "Expected POST /admin/guests/:id/erase route to be registered")
+				assert.True(t, routeMap["GET /admin/guests/:id/export"], "Expected GET /admin/guests/:id/export route to be registered")
 			},
 		},
 		{
@@ -610,6 +613,197 @@ func TestGuestHandler_DeleteByID(t *testing.T) {
 	}
 }
 
+func TestGuestHandler_EraseByID(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupHandler   func(t *testing.T) *GuestHandler
+		setupRequest   func(t *testing.T) *http.Request
+		expectedStatus int
+		validate       func(t *testing.T, resp *http.Response)
+	}{
+		{
+			name: "should_erase_guest_successfully",
+			setupHandler: func(t *testing.T) *GuestHandler {
+				mockService := mocks.NewGuestServiceMock(t)
+				mockService.On("EraseByID", mock.Anything, mock.MatchedBy(func(dto *dtos.EraseGuestByIDRequestDTO) bool {
+					return dto.ID == "01932293-d710-7f55-a9f6-66e6248ae72f"
+				})).Return(nil)
+				return NewGuestHandler(mockService)
+			},
+			setupRequest: func(t *testing.T) *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/admin/guests/01932293-d710-7f55-a9f6-66e6248ae72f/erase", nil)
+				ctx := context.WithValue(req.Context(), constants.ContextKeyRequestID, "test-request-id")
+				return req.WithContext(ctx)
+			},
+			expectedStatus: fiber.StatusOK,
+			validate: func(t *testing.T, resp *http.Response) {
+				bodyBytes, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err)
+
+				var response map[string]interface{}
+				err = json.Unmarshal(bodyBytes, &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, float64(fiber.StatusOK), response["code"])
+				assert.Equal(t, true, response["data"])
+			},
+		},
+		{
+			name: "should_return_error_when_service_fails_with_error_code_<_500",
+			setupHandler: func(t *testing.T) *GuestHandler {
+				mockService := mocks.NewGuestServiceMock(t)
+				mockService.On("EraseByID", mock.Anything, mock.AnythingOfType("*dtos.EraseGuestByIDRequestDTO")).
+					Return(gocerr.New(fiber.StatusNotFound, "guest not found"))
+				return NewGuestHandler(mockService)
+			},
+			setupRequest: func(t *testing.T) *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/admin/guests/01932293-d710-7f55-a9f6-66e6248ae72f/erase", nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+			validate: func(t *testing.T, resp *http.Response) {
+				bodyBytes, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err)
+
+				var response map[string]interface{}
+				err = json.Unmarshal(bodyBytes, &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, float64(fiber.StatusNotFound), response["code"])
+				assert.NotNil(t, response["error"])
+			},
+		},
+		{
+			name: "should_return_error_when_service_fails_with_error_code_>=_500",
+			setupHandler: func(t *testing.T) *GuestHandler {
+				mockService := mocks.NewGuestServiceMock(t)
+				mockService.On("EraseByID", mock.Anything, mock.AnythingOfType("*dtos.EraseGuestByIDRequestDTO")).
+					Return(gocerr.New(fiber.StatusInternalServerError, "internal server error"))
+				return NewGuestHandler(mockService)
+			},
+			setupRequest: func(t *testing.T) *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/admin/guests/01932293-d710-7f55-a9f6-66e6248ae72f/erase", nil)
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := tt.setupHandler(t)
+			app := fiber.New()
+
+			app.Post("/admin/guests/:id/erase", handler.EraseByID)
+
+			req := tt.setupRequest(t)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.validate != nil {
+				tt.validate(t, resp)
+			}
+		})
+	}
+}
+
+func TestGuestHandler_ExportByID(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupHandler   func(t *testing.T) *GuestHandler
+		setupRequest   func(t *testing.T) *http.Request
+		expectedStatus int
+		validate       func(t *testing.T, resp *http.Response)
+	}{
+		{
+			name: "should_export_guest_successfully",
+			setupHandler: func(t *testing.T) *GuestHandler {
+				mockService := mocks.NewGuestServiceMock(t)
+				mockService.On("ExportByID", mock.Anything, mock.MatchedBy(func(dto *dtos.ExportGuestByIDRequestDTO) bool {
+					return dto.ID == "01932293-d710-7f55-a9f6-66e6248ae72f"
+				})).Return(&dtos.ExportGuestResponseDTO{
+					Database: &dtos.GuestRecordDTO{
+						ID:        "01932293-d710-7f55-a9f6-66e6248ae72f",
+						Name:      "John Snow",
+						Address:   "Winterfell",
+						CreatedAt: 1731452061534,
+						CreatedBy: "Daenerys",
+					},
+					ExportedAt: 1731452062000,
+				}, nil)
+				return NewGuestHandler(mockService)
+			},
+			setupRequest: func(t *testing.T) *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/admin/guests/01932293-d710-7f55-a9f6-66e6248ae72f/export", nil)
+			},
+			expectedStatus: fiber.StatusOK,
+			validate: func(t *testing.T, resp *http.Response) {
+				bodyBytes, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err)
+
+				var response map[string]interface{}
+				err = json.Unmarshal(bodyBytes, &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, float64(fiber.StatusOK), response["code"])
+				data := response["data"].(map[string]interface{})
+				database := data["database"].(map[string]interface{})
+				assert.Equal(t, "John Snow", database["name"])
+				assert.Equal(t, "Winterfell", database["address"])
+				assert.Nil(t, data["cache"])
+				assert.Equal(t, float64(1731452062000), data["exported_at"])
+			},
+		},
+		{
+			name: "should_return_error_when_service_fails_with_error_code_<_500",
+			setupHandler: func(t *testing.T) *GuestHandler {
+				mockService := mocks.NewGuestServiceMock(t)
+				mockService.On("ExportByID", mock.Anything, mock.AnythingOfType("*dtos.ExportGuestByIDRequestDTO")).
+					Return(nil, gocerr.New(fiber.StatusNotFound, "guest not found"))
+				return NewGuestHandler(mockService)
+			},
+			setupRequest: func(t *testing.T) *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/admin/guests/01932293-d710-7f55-a9f6-66e6248ae72f/export", nil)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name: "should_return_error_when_service_fails_with_error_code_>=_500",
+			setupHandler: func(t *testing.T) *GuestHandler {
+				mockService := mocks.NewGuestServiceMock(t)
+				mockService.On("ExportByID", mock.Anything, mock.AnythingOfType("*dtos.ExportGuestByIDRequestDTO")).
+					Return(nil, errors.New("database connection failed"))
+				return NewGuestHandler(mockService)
+			},
+			setupRequest: func(t *testing.T) *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/admin/guests/01932293-d710-7f55-a9f6-66e6248ae72f/export", nil)
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := tt.setupHandler(t)
+			app := fiber.New()
+
+			app.Get("/admin/guests/:id/export", handler.ExportByID)
+
+			req := tt.setupRequest(t)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.validate != nil {
+				tt.validate(t, resp)
+			}
+		})
+	}
+}
+
 func TestGuestHandler_FindAll(t *testing.T) {
 	tests := []struct {
 		name           string
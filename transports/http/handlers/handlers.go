@@ -3,9 +3,17 @@ package handlers
 import "github.com/gofiber/fiber/v2"
 
 type Handlers struct {
-	Guest *GuestHandler
+	Guest       *GuestHandler
+	Log         *LogHandler
+	FeatureFlag *FeatureFlagHandler
+	Health      *HealthHandler
+	Cache       *CacheHandler
 }
 
 func (r *Handlers) SetupRoutes(server *fiber.App) {
+	r.Health.SetupRoutes(server)
 	r.Guest.SetupRoutes(server)
+	r.Log.SetupRoutes(server)
+	r.FeatureFlag.SetupRoutes(server)
+	r.Cache.SetupRoutes(server)
 }
@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"go-boilerplate/internal/services/mocks"
+	"go-boilerplate/pkg/health"
+	"go-boilerplate/pkg/logger"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,7 +23,11 @@ func TestHandlers_SetupRoutes(t *testing.T) {
 				mockService := mocks.NewGuestServiceMock(t)
 				guestHandler := NewGuestHandler(mockService)
 				return &Handlers{
-					Guest: guestHandler,
+					Guest:       guestHandler,
+					Log:         NewLogHandler(logger.NewLevels(zerolog.GlobalLevel())),
+					FeatureFlag: NewFeatureFlagHandler(mocks.NewFeatureFlagServiceMock(t)),
+					Cache:       NewCacheHandler(mocks.NewCacheServiceMock(t)),
+					Health:      NewHealthHandler(health.NewChecker(nil)),
 				}
 			},
 			validate: func(t *testing.T, app *fiber.App) {
@@ -31,6 +38,10 @@ func TestHandlers_SetupRoutes(t *testing.T) {
 				var foundDeleteGuest bool
 				var foundGetGuestByID bool
 				var foundPutGuest bool
+				var foundLogLevel bool
+				var foundFeatureFlag bool
+				var foundCacheStats bool
+				var foundReadyz bool
 
 				for _, route := range routes {
 					if route.Method == "DELETE" && route.Path == "/guests/:id" {
@@ -42,11 +53,27 @@ func TestHandlers_SetupRoutes(t *testing.T) {
 					if route.Method == "PUT" && route.Path == "/guests/:id" {
 						foundPutGuest = true
 					}
+					if route.Method == "PUT" && route.Path == "/admin/log/level/" {
+						foundLogLevel = true
+					}
+					if route.Method == "PUT" && route.Path == "/admin/feature-flags/:name" {
+						foundFeatureFlag = true
+					}
+					if route.Method == "GET" && route.Path == "/admin/cache/stats" {
+						foundCacheStats = true
+					}
+					if route.Method == "GET" && route.Path == "/readyz" {
+						foundReadyz = true
+					}
 				}
 
 				assert.True(t, foundDeleteGuest, "DELETE /guests/:id route should be registered")
 				assert.True(t, foundGetGuestByID, "GET /guests/:id route should be registered")
 				assert.True(t, foundPutGuest, "PUT /guests/:id route should be registered")
+				assert.True(t, foundLogLevel, "PUT /admin/log/level route should be registered")
+				assert.True(t, foundFeatureFlag, "PUT /admin/feature-flags/:name route should be registered")
+				assert.True(t, foundCacheStats, "GET /admin/cache/stats route should be registered")
+				assert.True(t, foundReadyz, "GET /readyz route should be registered")
 			},
 		},
 	}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"go-boilerplate/pkg/health"
+	"go-boilerplate/pkg/tracer"
+	"go-boilerplate/transports/http/models/vms"
+
+	"github.com/fikri240794/gores"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{
+		checker: checker,
+	}
+}
+
+func (h *HealthHandler) SetupRoutes(server *fiber.App) {
+	server.Get("/healthz", h.Liveness)
+	server.Get("/readyz", h.Readiness)
+}
+
+// @Summary	Liveness
+// @Description	Report whether the server is running, without checking the datasources
+// @Tags	health
+// @Produce	application/json
+// @Success	200	{object}	gores.ResponseVM[vms.HealthResponseVM]
+// @Router	/healthz	[get]
+func (h *HealthHandler) Liveness(c *fiber.Ctx) error {
+	var responseVM *gores.ResponseVM[*vms.HealthResponseVM] = gores.NewResponseVM[*vms.HealthResponseVM]().
+		SetCode(fiber.StatusOK).
+		SetData(&vms.HealthResponseVM{Status: health.StatusUp})
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
+
+// @Summary	Readiness
+// @Description	Report whether every datasource is reachable
+// @Tags	health
+// @Produce	application/json
+// @Success	200	{object}	gores.ResponseVM[vms.HealthResponseVM]
+// @Failure	503	{object}	gores.ResponseVM[vms.HealthResponseVM]
+// @Router	/readyz	[get]
+func (h *HealthHandler) Readiness(c *fiber.Ctx) error {
+	var (
+		ctx        context.Context
+		span       trace.Span
+		report     *health.Report
+		statusCode int
+		responseVM *gores.ResponseVM[*vms.HealthResponseVM]
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[HealthHandler][Readiness]")
+	defer span.End()
+
+	report = h.checker.Ready(ctx)
+
+	statusCode = fiber.StatusOK
+	if !report.IsUp() {
+		statusCode = fiber.StatusServiceUnavailable
+		log.Warn().
+			Ctx(ctx).
+			Interface("report", report).
+			Msg("[HealthHandler][Readiness][Ready] not ready")
+	}
+
+	responseVM = gores.NewResponseVM[*vms.HealthResponseVM]().
+		SetCode(statusCode).
+		SetData(vms.NewHealthResponseVM(report))
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"go-boilerplate/pkg/health"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHealthHandler(t *testing.T) {
+	checker := health.NewChecker(nil)
+
+	handler := NewHealthHandler(checker)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, checker, handler.checker)
+}
+
+func TestHealthHandler_SetupRoutes(t *testing.T) {
+	app := fiber.New()
+	handler := NewHealthHandler(health.NewChecker(nil))
+
+	handler.SetupRoutes(app)
+
+	var foundHealthz, foundReadyz bool
+	for _, route := range app.GetRoutes() {
+		if route.Method == "GET" && route.Path == "/healthz" {
+			foundHealthz = true
+		}
+		if route.Method == "GET" && route.Path == "/readyz" {
+			foundReadyz = true
+		}
+	}
+
+	assert.True(t, foundHealthz, "GET /healthz route should be registered")
+	assert.True(t, foundReadyz, "GET /readyz route should be registered")
+}
+
+func TestHealthHandler_Liveness(t *testing.T) {
+	checker := health.NewChecker(nil)
+	checker.Register("in_memory_database", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	app := fiber.New()
+	app.Get("/healthz", NewHealthHandler(checker).Liveness)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/healthz", nil), -1)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	response := readLogLevelResponse(t, resp)
+	assert.Equal(t, health.StatusUp, response["data"].(map[string]interface{})["status"])
+}
+
+func TestHealthHandler_Readiness(t *testing.T) {
+	tests := []struct {
+		name           string
+		check          health.CheckFunc
+		expectedStatus int
+		validate       func(t *testing.T, data map[string]interface{})
+	}{
+		{
+			name:           "should_return_ok_when_datasources_are_up",
+			check:          func(ctx context.Context) error { return nil },
+			expectedStatus: fiber.StatusOK,
+			validate: func(t *testing.T, data map[string]interface{}) {
+				assert.Equal(t, health.StatusUp, data["status"])
+			},
+		},
+		{
+			name:           "should_return_service_unavailable_when_a_datasource_is_down",
+			check:          func(ctx context.Context) error { return errors.New("connection refused") },
+			expectedStatus: fiber.StatusServiceUnavailable,
+			validate: func(t *testing.T, data map[string]interface{}) {
+				assert.Equal(t, health.StatusDown, data["status"])
+				checks := data["checks"].(map[string]interface{})
+				assert.Equal(t, "connection refused", checks["in_memory_database"].(map[string]interface{})["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := health.NewChecker(nil)
+			checker.Register("in_memory_database", tt.check)
+
+			app := fiber.New()
+			app.Get("/readyz", NewHealthHandler(checker).Readiness)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/readyz", nil), -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			response := readLogLevelResponse(t, resp)
+			tt.validate(t, response["data"].(map[string]interface{}))
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"go-boilerplate/pkg/logger"
+	"go-boilerplate/pkg/tracer"
+	"go-boilerplate/transports/http/models/vms"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/fikri240794/gores"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type LogHandler struct {
+	levels *logger.Levels
+}
+
+func NewLogHandler(levels *logger.Levels) *LogHandler {
+	return &LogHandler{
+		levels: levels,
+	}
+}
+
+func (h *LogHandler) SetupRoutes(server *fiber.App) {
+	server.Route("/admin/log/level", func(api fiber.Router) {
+		api.Get("/", h.GetLevel)
+		api.Put("/", h.SetLevel)
+		api.Delete("/:component", h.ResetLevel)
+	})
+}
+
+// @Summary	Get Log Level
+// @Description	Get the base log level and the per component overrides
+// @Tags	log-admin
+// @Produce	application/json
+// @Success	200	{object}	gores.ResponseVM[vms.LogLevelResponseVM]
+// @Router	/admin/log/level	[get]
+func (h *LogHandler) GetLevel(c *fiber.Ctx) error {
+	var (
+		ctx        context.Context
+		span       trace.Span
+		responseVM *gores.ResponseVM[*vms.LogLevelResponseVM]
+	)
+
+	ctx = c.UserContext()
+
+	_, span = tracer.Start(ctx, "[LogHandler][GetLevel]")
+	defer span.End()
+
+	responseVM = gores.NewResponseVM[*vms.LogLevelResponseVM]().
+		SetCode(fiber.StatusOK).
+		SetData(vms.NewLogLevelResponseVM(h.levels))
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
+
+// @Summary	Set Log Level
+// @Description	Set the base log level, or the level of a single component, without a restart
+// @Tags	log-admin
+// @Accept	application/json
+// @Produce	application/json
+// @Param	SetLogLevelRequestVM	body	vms.SetLogLevelRequestVM	true	"SetLogLevelRequestVM"
+// @Success	200	{object}	gores.ResponseVM[vms.LogLevelResponseVM]
+// @Failure	400	{object}	gores.ResponseVM[vms.LogLevelResponseVM]
+// @Router	/admin/log/level	[put]
+func (h *LogHandler) SetLevel(c *fiber.Ctx) error {
+	var (
+		ctx        context.Context
+		span       trace.Span
+		logFields  map[string]interface{}
+		requestVM  *vms.SetLogLevelRequestVM
+		level      zerolog.Level
+		responseVM *gores.ResponseVM[*vms.LogLevelResponseVM]
+		err        error
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[LogHandler][SetLevel]")
+	defer span.End()
+
+	logFields = map[string]interface{}{}
+
+	requestVM = &vms.SetLogLevelRequestVM{}
+	err = c.BodyParser(requestVM)
+	if err != nil {
+		log.Warn().
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[LogHandler][SetLevel][BodyParser] failed to parse body")
+		err = gocerr.New(fiber.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[*vms.LogLevelResponseVM]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+	logFields["requestVM"] = requestVM
+
+	level, err = requestVM.ParseLevel()
+	if err != nil {
+		log.Warn().
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[LogHandler][SetLevel][ParseLevel] failed to parse level")
+		err = gocerr.New(fiber.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[*vms.LogLevelResponseVM]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+
+	err = h.levels.SetLevel(requestVM.Component, level)
+	if err != nil {
+		log.Warn().
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[LogHandler][SetLevel][SetLevel] failed to set level")
+		err = gocerr.New(fiber.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[*vms.LogLevelResponseVM]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Fields(logFields).
+		Msg("[LogHandler][SetLevel] log level changed")
+
+	responseVM = gores.NewResponseVM[*vms.LogLevelResponseVM]().
+		SetCode(fiber.StatusOK).
+		SetData(vms.NewLogLevelResponseVM(h.levels))
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
+
+// @Summary	Reset Component Log Level
+// @Description	Remove the level override of a component so it follows the base level again
+// @Tags	log-admin
+// @Produce	application/json
+// @Param	component	path	string	true	"component"	example(repository)
+// @Success	200	{object}	gores.ResponseVM[vms.LogLevelResponseVM]
+// @Failure	400	{object}	gores.ResponseVM[vms.LogLevelResponseVM]
+// @Router	/admin/log/level/{component}	[delete]
+func (h *LogHandler) ResetLevel(c *fiber.Ctx) error {
+	var (
+		ctx        context.Context
+		span       trace.Span
+		logFields  map[string]interface{}
+		requestVM  *vms.ResetLogLevelRequestVM
+		responseVM *gores.ResponseVM[*vms.LogLevelResponseVM]
+		err        error
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[LogHandler][ResetLevel]")
+	defer span.End()
+
+	logFields = map[string]interface{}{}
+
+	requestVM = &vms.ResetLogLevelRequestVM{}
+	c.ParamsParser(requestVM)
+	logFields["requestVM"] = requestVM
+
+	err = h.levels.ResetLevel(requestVM.Component)
+	if err != nil {
+		log.Warn().
+			Ctx(ctx).
+			Err(err).
+			Fields(logFields).
+			Msg("[LogHandler][ResetLevel][ResetLevel] failed to reset level")
+		err = gocerr.New(fiber.StatusBadRequest, err.Error())
+		tracer.RecordError(span, err)
+		responseVM = gores.NewResponseVM[*vms.LogLevelResponseVM]().
+			SetErrorFromError(err)
+		return c.Status(responseVM.Code).
+			JSON(responseVM)
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Fields(logFields).
+		Msg("[LogHandler][ResetLevel] log level reset")
+
+	responseVM = gores.NewResponseVM[*vms.LogLevelResponseVM]().
+		SetCode(fiber.StatusOK).
+		SetData(vms.NewLogLevelResponseVM(h.levels))
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-boilerplate/pkg/logger"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogHandler(t *testing.T) {
+	levels := logger.NewLevels(zerolog.GlobalLevel())
+
+	handler := NewLogHandler(levels)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, levels, handler.levels)
+}
+
+func TestLogHandler_SetupRoutes(t *testing.T) {
+	app := fiber.New()
+	handler := NewLogHandler(logger.NewLevels(zerolog.GlobalLevel()))
+
+	handler.SetupRoutes(app)
+
+	var foundGet, foundPut, foundDelete bool
+	for _, route := range app.GetRoutes() {
+		if route.Method == "GET" && route.Path == "/admin/log/level/" {
+			foundGet = true
+		}
+		if route.Method == "PUT" && route.Path == "/admin/log/level/" {
+			foundPut = true
+		}
+		if route.Method == "DELETE" && route.Path == "/admin/log/level/:component" {
+			foundDelete = true
+		}
+	}
+
+	assert.True(t, foundGet, "GET /admin/log/level route should be registered")
+	assert.True(t, foundPut, "PUT /admin/log/level route should be registered")
+	assert.True(t, foundDelete, "DELETE /admin/log/level/:component route should be registered")
+}
+
+func readLogLevelResponse(t *testing.T, resp *http.Response) map[string]interface{} {
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err)
+
+	return response
+}
+
+func TestLogHandler_GetLevel(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	levels := logger.NewLevels(zerolog.InfoLevel)
+	assert.NoError(t, levels.SetLevel(logger.ComponentRepository, zerolog.WarnLevel))
+
+	app := fiber.New()
+	app.Get("/admin/log/level", NewLogHandler(levels).GetLevel)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/admin/log/level", nil), -1)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	response := readLogLevelResponse(t, resp)
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "info", data["level"])
+	assert.Equal(t, map[string]interface{}{"repository": "warn"}, data["components"])
+}
+
+func TestLogHandler_SetLevel(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	tests := []struct {
+		name           string
+		body           string
+		contentType    string
+		expectedStatus int
+		validate       func(t *testing.T, levels *logger.Levels, response map[string]interface{})
+	}{
+		{
+			name:           "should_set_base_level",
+			body:           `{"level":"debug"}`,
+			contentType:    fiber.MIMEApplicationJSON,
+			expectedStatus: fiber.StatusOK,
+			validate: func(t *testing.T, levels *logger.Levels, response map[string]interface{}) {
+				assert.Equal(t, zerolog.DebugLevel, levels.Base())
+				assert.Equal(t, "debug", response["data"].(map[string]interface{})["level"])
+			},
+		},
+		{
+			name:           "should_set_component_level",
+			body:           `{"component":"repository","level":"error"}`,
+			contentType:    fiber.MIMEApplicationJSON,
+			expectedStatus: fiber.StatusOK,
+			validate: func(t *testing.T, levels *logger.Levels, response map[string]interface{}) {
+				assert.Equal(t, zerolog.InfoLevel, levels.Base())
+				assert.Equal(t, map[string]zerolog.Level{logger.ComponentRepository: zerolog.ErrorLevel}, levels.Components())
+			},
+		},
+		{
+			name:           "should_return_bad_request_when_body_is_invalid",
+			body:           `{invalid`,
+			contentType:    fiber.MIMEApplicationJSON,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "should_return_bad_request_when_level_is_missing",
+			body:           `{"component":"repository"}`,
+			contentType:    fiber.MIMEApplicationJSON,
+			expectedStatus: fiber.StatusBadRequest,
+			validate: func(t *testing.T, levels *logger.Levels, response map[string]interface{}) {
+				assert.Empty(t, levels.Components())
+				assert.NotNil(t, response["error"])
+			},
+		},
+		{
+			name:           "should_return_bad_request_when_component_is_unknown",
+			body:           `{"component":"unknown","level":"warn"}`,
+			contentType:    fiber.MIMEApplicationJSON,
+			expectedStatus: fiber.StatusBadRequest,
+			validate: func(t *testing.T, levels *logger.Levels, response map[string]interface{}) {
+				assert.Empty(t, levels.Components())
+				assert.NotNil(t, response["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels := logger.NewLevels(zerolog.InfoLevel)
+			app := fiber.New()
+			app.Put("/admin/log/level", NewLogHandler(levels).SetLevel)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/log/level", strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, tt.contentType)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.validate != nil {
+				tt.validate(t, levels, readLogLevelResponse(t, resp))
+			}
+		})
+	}
+}
+
+func TestLogHandler_ResetLevel(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	tests := []struct {
+		name               string
+		component          string
+		expectedStatus     int
+		expectedComponents map[string]zerolog.Level
+	}{
+		{
+			name:               "should_reset_component_level",
+			component:          "repository",
+			expectedStatus:     fiber.StatusOK,
+			expectedComponents: map[string]zerolog.Level{},
+		},
+		{
+			name:               "should_return_bad_request_when_component_is_unknown",
+			component:          "unknown",
+			expectedStatus:     fiber.StatusBadRequest,
+			expectedComponents: map[string]zerolog.Level{logger.ComponentRepository: zerolog.WarnLevel},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels := logger.NewLevels(zerolog.InfoLevel)
+			assert.NoError(t, levels.SetLevel(logger.ComponentRepository, zerolog.WarnLevel))
+			app := fiber.New()
+			app.Delete("/admin/log/level/:component", NewLogHandler(levels).ResetLevel)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/admin/log/level/"+tt.component, nil), -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedComponents, levels.Components())
+		})
+	}
+}
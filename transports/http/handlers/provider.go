@@ -3,9 +3,26 @@
 
 package handlers
 
-import "github.com/google/wire"
+import (
+	"go-boilerplate/pkg/logger"
+
+	"github.com/google/wire"
+)
 
 var Provider wire.ProviderSet = wire.NewSet(
 	// guests
 	NewGuestHandler,
+
+	// logs
+	logger.GetLevels,
+	NewLogHandler,
+
+	// feature flags
+	NewFeatureFlagHandler,
+
+	// health
+	NewHealthHandler,
+
+	// caches
+	NewCacheHandler,
 )
@@ -4,14 +4,18 @@ package http
 //go:generate sh -c "cd ./../../ && go run github.com/swaggo/swag/cmd/swag init --generalInfo ./transports/http/http_server.go --parseDependency --output ./transports/http/docs/swagger"
 
 import (
+	"context"
 	"fmt"
 	"go-boilerplate/configs"
 	"go-boilerplate/datasources"
-	"go-boilerplate/pkg/logger"
+	"go-boilerplate/pkg/health"
+	"go-boilerplate/pkg/metrics"
+	"go-boilerplate/pkg/tracer"
 	"go-boilerplate/transports/http/handlers"
 	"go-boilerplate/transports/http/middlewares"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/fikri240794/gocerr"
@@ -22,7 +26,6 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/etag"
 	"github.com/gofiber/fiber/v2/middleware/favicon"
-	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
@@ -39,6 +42,8 @@ type HTTPServer struct {
 	datasources *datasources.Datasources
 	middlewares *middlewares.Middlewares
 	handlers    *handlers.Handlers
+	checker     *health.Checker
+	serverErr   chan error
 }
 
 func NewHTTPServer(
@@ -46,6 +51,7 @@ func NewHTTPServer(
 	ds *datasources.Datasources,
 	mw *middlewares.Middlewares,
 	h *handlers.Handlers,
+	checker *health.Checker,
 ) *HTTPServer {
 	var httpServer *HTTPServer = &HTTPServer{
 		cfg: cfg,
@@ -67,6 +73,8 @@ func NewHTTPServer(
 		datasources: ds,
 		middlewares: mw,
 		handlers:    h,
+		checker:     checker,
+		serverErr:   make(chan error, 1),
 	}
 
 	return httpServer
@@ -74,57 +82,45 @@ func NewHTTPServer(
 
 func (s *HTTPServer) gracefullyShutdown() {
 	var (
-		ticker               *time.Ticker
-		tickCounter          float64
-		tickMessage          string
-		maxTickMessageLength int
-		shutdownCompleteChan = make(chan bool)
+		startedAt time.Time       = time.Now()
+		ctx       context.Context = context.Background()
+		err       error
 	)
 
-	tickCounter = 0
-	ticker = time.NewTicker(1 * time.Millisecond)
+	log.Info().
+		Dur("pre_stop_delay", s.cfg.Server.Shutdown.PreStopDelay).
+		Msg("[HTTPServer][gracefullyShutdown] shutting down HTTP server")
 
-	go func() {
-		s.datasources.Disconnect()
-		s.server.ShutdownWithTimeout(s.cfg.Server.HTTP.GracefullyShutdownDuration)
-		shutdownCompleteChan <- true
-	}()
-
-	fmt.Print("\n\n")
-
-	for {
-		select {
-		case <-ticker.C:
-			tickMessage = fmt.Sprintf("shutting down HTTP server in %.3fs", tickCounter/1000)
-
-			if len(tickMessage) > maxTickMessageLength {
-				maxTickMessageLength = len(tickMessage)
-			}
+	s.Drain()
+	time.Sleep(s.cfg.Server.Shutdown.PreStopDelay)
 
-			fmt.Printf("\r%*s", maxTickMessageLength, "")
-			fmt.Printf("\r%s", tickMessage)
-
-			tickCounter++
+	err = s.Stop(ctx)
+	if err != nil {
+		log.Err(err).
+			Msg("[HTTPServer][gracefullyShutdown][Stop] failed to stop HTTP server")
+	}
 
-		case <-shutdownCompleteChan:
-			ticker.Stop()
+	err = s.datasources.Disconnect()
+	if err != nil {
+		log.Err(err).
+			Msg("[HTTPServer][gracefullyShutdown][Disconnect] failed to disconnect datasources")
+	}
 
-			tickMessage = "HTTP server shutdown process finished successfully\n\n"
+	err = tracer.Flush(ctx)
+	if err != nil {
+		log.Err(err).
+			Msg("[HTTPServer][gracefullyShutdown][Flush] failed to flush tracer")
+	}
 
-			fmt.Printf("\r%*s", maxTickMessageLength, "")
-			fmt.Printf("\r%s", tickMessage)
-			return
-		}
+	err = metrics.Shutdown(ctx)
+	if err != nil {
+		log.Err(err).
+			Msg("[HTTPServer][gracefullyShutdown][Shutdown] failed to shut down metrics server")
 	}
-}
 
-func (s *HTTPServer) setGlobalLog() {
-	zerolog.SetGlobalLevel(zerolog.Level(s.cfg.Server.LogLevel))
-	log.Logger = log.Output(zerolog.ConsoleWriter{
-		Out:        os.Stderr,
-		TimeFormat: time.RFC3339,
-	}).
-		Hook(logger.NewContextHook())
+	log.Info().
+		Dur("duration", time.Since(startedAt)).
+		Msg("[HTTPServer][gracefullyShutdown] HTTP server shutdown finished")
 }
 
 func (s *HTTPServer) setupGlobalMiddlewares() {
@@ -132,6 +128,7 @@ func (s *HTTPServer) setupGlobalMiddlewares() {
 		s.middlewares.Recover.Recover,
 		s.middlewares.Tracer.Start,
 		s.middlewares.RequestID.Generate,
+		s.middlewares.Identity.Identify,
 		s.middlewares.Log.Log,
 		cors.New(cors.Config{
 			AllowOrigins: s.cfg.Server.HTTP.CORS.AllowOrigins,
@@ -151,30 +148,58 @@ func (s *HTTPServer) setupGlobalMiddlewares() {
 	}
 
 	s.server.Use(s.middlewares.Timeout.Timeout)
-}
 
-func (s *HTTPServer) ServeHTTP() error {
-	var (
-		serverErrListener chan error
-		signalListener    chan os.Signal
-		err               error
-	)
+	// every handler serves its operator routes under /admin
+	s.server.Use("/admin", s.middlewares.Admin.Authenticate)
+}
 
-	s.setGlobalLog()
+// Start serves in the background, the result of serving is sent to Errors.
+func (s *HTTPServer) Start(ctx context.Context) error {
 	s.setupGlobalMiddlewares()
 	s.handlers.SetupRoutes(s.server)
 
-	serverErrListener = make(chan error, 1)
-	signalListener = make(chan os.Signal, 1)
-
 	go func() {
-		serverErrListener <- s.server.Listen(fmt.Sprintf(":%d", s.cfg.Server.HTTP.Port))
+		s.serverErr <- s.server.Listen(fmt.Sprintf(":%d", s.cfg.Server.HTTP.Port))
 	}()
 
-	signal.Notify(signalListener, os.Interrupt)
+	return nil
+}
+
+func (s *HTTPServer) Errors() <-chan error {
+	return s.serverErr
+}
+
+// Drain fails readiness while the server keeps serving.
+func (s *HTTPServer) Drain() {
+	s.checker.Drain()
+}
+
+// Stop stops waiting for the remaining connections once ctx is done or the
+// gracefully shutdown duration passes, whichever comes first.
+func (s *HTTPServer) Stop(ctx context.Context) error {
+	var cancel context.CancelFunc
+
+	if s.cfg.Server.HTTP.GracefullyShutdownDuration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Server.HTTP.GracefullyShutdownDuration)
+		defer cancel()
+	}
+
+	return s.server.ShutdownWithContext(ctx)
+}
+
+func (s *HTTPServer) ServeHTTP() error {
+	var (
+		signalListener chan os.Signal
+		err            error
+	)
+
+	s.Start(context.Background())
+
+	signalListener = make(chan os.Signal, 1)
+	signal.Notify(signalListener, os.Interrupt, syscall.SIGTERM)
 
 	select {
-	case err = <-serverErrListener:
+	case err = <-s.serverErr:
 		s.gracefullyShutdown()
 		return err
 	case <-signalListener:
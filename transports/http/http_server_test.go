@@ -1,11 +1,15 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"go-boilerplate/configs"
 	"go-boilerplate/datasources"
+	"go-boilerplate/pkg/health"
 	"go-boilerplate/transports/http/handlers"
 	"go-boilerplate/transports/http/middlewares"
+	"net"
+	nethttp "net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -44,6 +48,7 @@ func TestNewHTTPServer(t *testing.T) {
 					Recover:   &middlewares.RecoverMiddleware{},
 					Tracer:    &middlewares.TracerMiddleware{},
 					RequestID: &middlewares.RequestIDMiddleware{},
+					Identity:  &middlewares.IdentityMiddleware{},
 					Log:       &middlewares.LogMiddleware{},
 					Timeout:   &middlewares.TimeoutMiddleware{},
 				}
@@ -81,6 +86,7 @@ func TestNewHTTPServer(t *testing.T) {
 					Recover:   &middlewares.RecoverMiddleware{},
 					Tracer:    &middlewares.TracerMiddleware{},
 					RequestID: &middlewares.RequestIDMiddleware{},
+					Identity:  &middlewares.IdentityMiddleware{},
 					Log:       &middlewares.LogMiddleware{},
 					Timeout:   &middlewares.TimeoutMiddleware{},
 				}
@@ -118,6 +124,7 @@ func TestNewHTTPServer(t *testing.T) {
 					Recover:   &middlewares.RecoverMiddleware{},
 					Tracer:    &middlewares.TracerMiddleware{},
 					RequestID: &middlewares.RequestIDMiddleware{},
+					Identity:  &middlewares.IdentityMiddleware{},
 					Log:       &middlewares.LogMiddleware{},
 					Timeout:   &middlewares.TimeoutMiddleware{},
 				}
@@ -155,79 +162,13 @@ func TestNewHTTPServer(t *testing.T) {
 			mw := tt.setupMW(t)
 			h := tt.setupH(t)
 
-			s := NewHTTPServer(cfg, ds, mw, h)
+			s := NewHTTPServer(cfg, ds, mw, h, health.NewChecker(nil))
 
 			tt.validate(t, s, cfg, ds, mw, h)
 		})
 	}
 }
 
-func TestHTTPServer_setGlobalLog(t *testing.T) {
-	tests := []struct {
-		name     string
-		setupCfg func(t *testing.T) *configs.Config
-		validate func(t *testing.T, s *HTTPServer)
-	}{
-		{
-			name: "should_set_global_log_with_info_level",
-			setupCfg: func(t *testing.T) *configs.Config {
-				cfg := &configs.Config{}
-				cfg.Server.Name = "test-server"
-				cfg.Server.LogLevel = 1
-				return cfg
-			},
-			validate: func(t *testing.T, s *HTTPServer) {
-
-				assert.NotNil(t, s)
-			},
-		},
-		{
-			name: "should_set_global_log_with_debug_level",
-			setupCfg: func(t *testing.T) *configs.Config {
-				cfg := &configs.Config{}
-				cfg.Server.Name = "test-server"
-				cfg.Server.LogLevel = 0
-				return cfg
-			},
-			validate: func(t *testing.T, s *HTTPServer) {
-				assert.NotNil(t, s)
-			},
-		},
-		{
-			name: "should_set_global_log_with_error_level",
-			setupCfg: func(t *testing.T) *configs.Config {
-				cfg := &configs.Config{}
-				cfg.Server.Name = "test-server"
-				cfg.Server.LogLevel = 3
-				return cfg
-			},
-			validate: func(t *testing.T, s *HTTPServer) {
-				assert.NotNil(t, s)
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			cfg := tt.setupCfg(t)
-			ds := &datasources.Datasources{}
-			mw := &middlewares.Middlewares{
-				Recover:   &middlewares.RecoverMiddleware{},
-				Tracer:    &middlewares.TracerMiddleware{},
-				RequestID: &middlewares.RequestIDMiddleware{},
-				Log:       &middlewares.LogMiddleware{},
-				Timeout:   &middlewares.TimeoutMiddleware{},
-			}
-			h := &handlers.Handlers{}
-
-			s := NewHTTPServer(cfg, ds, mw, h)
-			s.setGlobalLog()
-
-			tt.validate(t, s)
-		})
-	}
-}
-
 func TestHTTPServer_setupGlobalMiddlewares(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -254,6 +195,7 @@ func TestHTTPServer_setupGlobalMiddlewares(t *testing.T) {
 					Recover:   middlewares.NewRecoverMiddleware(),
 					Tracer:    middlewares.NewTracerMiddleware(),
 					RequestID: middlewares.NewRequestIDMiddleware(),
+					Identity:  middlewares.NewIdentityMiddleware(),
 					Log:       middlewares.NewLogMiddleware(),
 					Timeout:   middlewares.NewTimeoutMiddleware(cfg),
 				}
@@ -285,6 +227,7 @@ func TestHTTPServer_setupGlobalMiddlewares(t *testing.T) {
 					Recover:   middlewares.NewRecoverMiddleware(),
 					Tracer:    middlewares.NewTracerMiddleware(),
 					RequestID: middlewares.NewRequestIDMiddleware(),
+					Identity:  middlewares.NewIdentityMiddleware(),
 					Log:       middlewares.NewLogMiddleware(),
 					Timeout:   middlewares.NewTimeoutMiddleware(cfg),
 				}
@@ -313,6 +256,7 @@ func TestHTTPServer_setupGlobalMiddlewares(t *testing.T) {
 					Recover:   middlewares.NewRecoverMiddleware(),
 					Tracer:    middlewares.NewTracerMiddleware(),
 					RequestID: middlewares.NewRequestIDMiddleware(),
+					Identity:  middlewares.NewIdentityMiddleware(),
 					Log:       middlewares.NewLogMiddleware(),
 					Timeout:   middlewares.NewTimeoutMiddleware(cfg),
 				}
@@ -322,6 +266,69 @@ func TestHTTPServer_setupGlobalMiddlewares(t *testing.T) {
 				assert.NotNil(t, s.server)
 			},
 		},
+		{
+			name: "should_authenticate_every_admin_route",
+			setupCfg: func(t *testing.T) *configs.Config {
+				cfg := &configs.Config{}
+				cfg.Server.Name = "test-server"
+				cfg.Server.HTTP.CORS.AllowOrigins = "*"
+				cfg.Server.HTTP.CORS.AllowMethods = "GET,POST,PUT,DELETE"
+				cfg.Server.HTTP.RequestTimeout = 5 * time.Second
+				return cfg
+			},
+			setupMW: func(t *testing.T) *middlewares.Middlewares {
+				cfg := &configs.Config{}
+				cfg.Server.HTTP.RequestTimeout = 5 * time.Second
+				cfg.Server.HTTP.Admin.Token = "secret"
+
+				return &middlewares.Middlewares{
+					Recover:   middlewares.NewRecoverMiddleware(),
+					Tracer:    middlewares.NewTracerMiddleware(),
+					RequestID: middlewares.NewRequestIDMiddleware(),
+					Identity:  middlewares.NewIdentityMiddleware(),
+					Log:       middlewares.NewLogMiddleware(),
+					Timeout:   middlewares.NewTimeoutMiddleware(cfg),
+					Admin:     middlewares.NewAdminMiddleware(cfg),
+				}
+			},
+			validate: func(t *testing.T, s *HTTPServer) {
+				routes := []struct {
+					method string
+					path   string
+				}{
+					{method: fiber.MethodGet, path: "/admin/guests/019681d0-c726-72c2-8c41-110cbca4e67f/export"},
+					{method: fiber.MethodPost, path: "/admin/guests/019681d0-c726-72c2-8c41-110cbca4e67f/erase"},
+					{method: fiber.MethodPut, path: "/admin/log/level"},
+					{method: fiber.MethodPut, path: "/admin/feature-flags/new-checkout"},
+					{method: fiber.MethodDelete, path: "/admin/cache"},
+				}
+				ok := func(c *fiber.Ctx) error {
+					return c.SendStatus(fiber.StatusOK)
+				}
+
+				s.server.Get("/guests", ok)
+				for _, route := range routes {
+					s.server.Add(route.method, route.path, ok)
+				}
+
+				for _, route := range routes {
+					req := httptest.NewRequest(route.method, route.path, nil)
+					resp, err := s.server.Test(req, -1)
+					assert.NoError(t, err)
+					assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode, route.path)
+
+					req = httptest.NewRequest(route.method, route.path, nil)
+					req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+					resp, err = s.server.Test(req, -1)
+					assert.NoError(t, err)
+					assert.Equal(t, fiber.StatusOK, resp.StatusCode, route.path)
+				}
+
+				resp, err := s.server.Test(httptest.NewRequest(fiber.MethodGet, "/guests", nil), -1)
+				assert.NoError(t, err)
+				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -331,10 +338,76 @@ func TestHTTPServer_setupGlobalMiddlewares(t *testing.T) {
 			mw := tt.setupMW(t)
 			h := &handlers.Handlers{}
 
-			s := NewHTTPServer(cfg, ds, mw, h)
+			s := NewHTTPServer(cfg, ds, mw, h, health.NewChecker(nil))
 			s.setupGlobalMiddlewares()
 
 			tt.validate(t, s)
 		})
 	}
 }
+
+func TestHTTPServer_Drain(t *testing.T) {
+	checker := health.NewChecker(nil)
+	s := NewHTTPServer(&configs.Config{}, &datasources.Datasources{}, &middlewares.Middlewares{}, &handlers.Handlers{}, checker)
+
+	assert.True(t, checker.Ready(context.Background()).IsUp())
+
+	s.Drain()
+
+	assert.False(t, checker.Ready(context.Background()).IsUp())
+}
+
+func TestHTTPServer_Stop(t *testing.T) {
+	tests := []struct {
+		name                       string
+		gracefullyShutdownDuration time.Duration
+		setupContext               func(t *testing.T) context.Context
+		expectedErr                error
+	}{
+		{
+			name:                       "should give up when the context is done",
+			gracefullyShutdownDuration: time.Minute,
+			setupContext: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+				t.Cleanup(cancel)
+				return ctx
+			},
+			expectedErr: context.DeadlineExceeded,
+		},
+		{
+			name:                       "should give up when the gracefully shutdown duration passes",
+			gracefullyShutdownDuration: 50 * time.Millisecond,
+			setupContext: func(t *testing.T) context.Context {
+				return context.Background()
+			},
+			expectedErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Server.HTTP.GracefullyShutdownDuration = tt.gracefullyShutdownDuration
+			s := NewHTTPServer(cfg, &datasources.Datasources{}, &middlewares.Middlewares{}, &handlers.Handlers{}, health.NewChecker(nil))
+			received := make(chan struct{})
+			release := make(chan struct{})
+			t.Cleanup(func() { close(release) })
+			s.server.Get("/slow", func(c *fiber.Ctx) error {
+				close(received)
+				<-release
+				return c.SendStatus(fiber.StatusOK)
+			})
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			assert.NoError(t, err)
+			go func() { _ = s.server.Listener(listener) }()
+			go func() { _, _ = nethttp.Get("http://" + listener.Addr().String() + "/slow") }()
+			<-received
+
+			startedAt := time.Now()
+			err = s.Stop(tt.setupContext(t))
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Less(t, time.Since(startedAt), 5*time.Second)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/subtle"
+	"go-boilerplate/configs"
+	"go-boilerplate/pkg/tracer"
+	"strings"
+
+	"github.com/fikri240794/gocerr"
+	"github.com/fikri240794/gores"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AdminMiddleware lets through the requests bearing SERVER.HTTP.ADMIN.TOKEN,
+// every request is rejected while the token is empty.
+type AdminMiddleware struct {
+	cfg *configs.Config
+}
+
+func NewAdminMiddleware(cfg *configs.Config) *AdminMiddleware {
+	return &AdminMiddleware{
+		cfg: cfg,
+	}
+}
+
+func (mw *AdminMiddleware) Authenticate(c *fiber.Ctx) error {
+	var (
+		ctx        context.Context
+		span       trace.Span
+		token      string
+		found      bool
+		responseVM *gores.ResponseVM[string]
+		err        error
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[AdminMiddleware][Authenticate]")
+	defer span.End()
+
+	token, found = strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if found &&
+		mw.cfg.Server.HTTP.Admin.Token != "" &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(mw.cfg.Server.HTTP.Admin.Token)) == 1 {
+		return c.Next()
+	}
+
+	err = gocerr.New(fiber.StatusUnauthorized, "invalid admin token")
+	log.Warn().
+		Ctx(ctx).
+		Err(err).
+		Str("path", c.Path()).
+		Msg("[AdminMiddleware][Authenticate] unauthorized admin request")
+	tracer.RecordError(span, err)
+	responseVM = gores.NewResponseVM[string]().
+		SetErrorFromError(err)
+
+	return c.Status(responseVM.Code).
+		JSON(responseVM)
+}
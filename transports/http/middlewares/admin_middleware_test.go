@@ -0,0 +1,83 @@
+package middlewares
+
+import (
+	"go-boilerplate/configs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAdminMiddleware(t *testing.T) {
+	cfg := &configs.Config{}
+
+	middleware := NewAdminMiddleware(cfg)
+
+	assert.NotNil(t, middleware)
+	assert.Equal(t, cfg, middleware.cfg)
+}
+
+func TestAdminMiddleware_Authenticate(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		authorization  string
+		expectedStatus int
+	}{
+		{
+			name:           "should_pass_with_valid_token",
+			token:          "s3cret",
+			authorization:  "Bearer s3cret",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "should_reject_wrong_token",
+			token:          "s3cret",
+			authorization:  "Bearer guess",
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "should_reject_token_without_bearer_scheme",
+			token:          "s3cret",
+			authorization:  "s3cret",
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "should_reject_missing_header",
+			token:          "s3cret",
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "should_reject_every_request_when_token_is_not_configured",
+			authorization:  "Bearer ",
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			cfg.Server.HTTP.Admin.Token = tt.token
+			middleware := NewAdminMiddleware(cfg)
+			app := fiber.New()
+
+			app.Use(middleware.Authenticate)
+			app.Get("/test", func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
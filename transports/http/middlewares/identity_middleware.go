@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"context"
+	"go-boilerplate/pkg/constants"
+	"go-boilerplate/pkg/tracer"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// IdentityMiddleware puts the tenant and actor of the request in the context,
+// feature flags are evaluated against them.
+type IdentityMiddleware struct{}
+
+func NewIdentityMiddleware() *IdentityMiddleware {
+	return &IdentityMiddleware{}
+}
+
+func (mw *IdentityMiddleware) Identify(c *fiber.Ctx) error {
+	var (
+		ctx      context.Context
+		span     trace.Span
+		tenantID string
+		actorID  string
+	)
+
+	ctx = c.UserContext()
+
+	ctx, span = tracer.Start(ctx, "[IdentityMiddleware][Identify]")
+	defer span.End()
+
+	tenantID = c.Get(constants.HeaderKeyTenantID)
+	if tenantID != "" {
+		ctx = context.WithValue(ctx, constants.ContextKeyTenantID, tenantID)
+	}
+
+	actorID = c.Get(constants.HeaderKeyActorID)
+	if actorID != "" {
+		ctx = context.WithValue(ctx, constants.ContextKeyActorID, actorID)
+	}
+
+	c.SetUserContext(ctx)
+
+	return c.Next()
+}
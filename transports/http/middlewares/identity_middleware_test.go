@@ -0,0 +1,73 @@
+package middlewares
+
+import (
+	"go-boilerplate/pkg/constants"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIdentityMiddleware(t *testing.T) {
+	middleware := NewIdentityMiddleware()
+
+	assert.NotNil(t, middleware)
+}
+
+func TestIdentityMiddleware_Identify(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupRequest   func(t *testing.T) *http.Request
+		expectedTenant interface{}
+		expectedActor  interface{}
+	}{
+		{
+			name: "should_set_tenant_and_actor_when_provided",
+			setupRequest: func(t *testing.T) *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/test", nil)
+				req.Header.Set(constants.HeaderKeyTenantID, "acme")
+				req.Header.Set(constants.HeaderKeyActorID, "daenerys")
+				return req
+			},
+			expectedTenant: "acme",
+			expectedActor:  "daenerys",
+		},
+		{
+			name: "should_set_only_tenant_when_actor_not_provided",
+			setupRequest: func(t *testing.T) *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/test", nil)
+				req.Header.Set(constants.HeaderKeyTenantID, "acme")
+				return req
+			},
+			expectedTenant: "acme",
+		},
+		{
+			name: "should_leave_context_untouched_when_not_provided",
+			setupRequest: func(t *testing.T) *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/test", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewIdentityMiddleware()
+			app := fiber.New()
+
+			app.Use(middleware.Identify)
+			app.Get("/test", func(c *fiber.Ctx) error {
+				assert.Equal(t, tt.expectedTenant, c.UserContext().Value(constants.ContextKeyTenantID))
+				assert.Equal(t, tt.expectedActor, c.UserContext().Value(constants.ContextKeyActorID))
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(tt.setupRequest(t), -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		})
+	}
+}
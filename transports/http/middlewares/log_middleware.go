@@ -3,6 +3,8 @@ package middlewares
 import (
 	"context"
 	"fmt"
+	"go-boilerplate/pkg/metrics"
+	"go-boilerplate/pkg/redactor"
 	"go-boilerplate/pkg/tracer"
 	"time"
 
@@ -42,6 +44,8 @@ func (mw *LogMiddleware) Log(c *fiber.Ctx) error {
 	processEnd = time.Now()
 	latency = processEnd.Sub(processStart)
 
+	metrics.ObserveHTTPRequest(c.Method(), c.Route().Path, c.Response().StatusCode(), latency)
+
 	if err != nil || c.Response().StatusCode() >= fiber.StatusInternalServerError {
 		logLevel = zerolog.ErrorLevel
 	}
@@ -50,16 +54,20 @@ func (mw *LogMiddleware) Log(c *fiber.Ctx) error {
 		logLevel = zerolog.WarnLevel
 	}
 
+	if logLevel == zerolog.InfoLevel && !redactor.SampleSuccess() {
+		return err
+	}
+
 	logFields = map[string]interface{}{
 		"path":                 c.Path(),
 		"method":               c.Method(),
 		"response status code": c.Response().StatusCode(),
 		"latency":              fmt.Sprintf("%.3f ms", (float64(latency) / float64(time.Millisecond))),
-		"request headers":      c.GetReqHeaders(),
+		"request headers":      redactor.Headers(c.GetReqHeaders()),
 		"request queries":      c.Queries(),
-		"request body":         string(c.Body()),
-		"response headers":     c.GetRespHeaders(),
-		"response body":        string(c.Response().Body()),
+		"request body":         redactor.Body(c.Body()),
+		"response headers":     redactor.Headers(c.GetRespHeaders()),
+		"response body":        redactor.Body(c.Response().Body()),
 	}
 
 	log.WithLevel(logLevel).
@@ -5,11 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"go-boilerplate/pkg/constants"
+	"go-boilerplate/pkg/metrics"
+	"go-boilerplate/pkg/redactor"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -261,3 +265,150 @@ func TestLogMiddleware_Log(t *testing.T) {
 		})
 	}
 }
+
+func TestLogMiddleware_Log_Redaction(t *testing.T) {
+	tests := []struct {
+		name         string
+		redactorCfg  *redactor.Config
+		responseCode int
+		validateLog  func(t *testing.T, output string)
+	}{
+		{
+			name: "should_redact_headers_and_body_fields",
+			redactorCfg: &redactor.Config{
+				Fields:            []string{"$..address"},
+				SuccessSampleRate: 1,
+			},
+			responseCode: fiber.StatusOK,
+			validateLog: func(t *testing.T, output string) {
+				assert.Contains(t, output, "request response")
+				assert.NotContains(t, output, "Bearer secret-token")
+				assert.NotContains(t, output, "123 Main St")
+				assert.Contains(t, output, redactor.RedactedValue)
+				assert.Contains(t, output, "John Doe")
+			},
+		},
+		{
+			name: "should_cap_body_size",
+			redactorCfg: &redactor.Config{
+				MaxBodyBytes:      8,
+				SuccessSampleRate: 1,
+			},
+			responseCode: fiber.StatusOK,
+			validateLog: func(t *testing.T, output string) {
+				assert.Contains(t, output, "truncated")
+				assert.NotContains(t, output, "123 Main St")
+			},
+		},
+		{
+			name: "should_skip_unsampled_success_logs",
+			redactorCfg: &redactor.Config{
+				SuccessSampleRate: 0,
+			},
+			responseCode: fiber.StatusOK,
+			validateLog: func(t *testing.T, output string) {
+				assert.Empty(t, output)
+			},
+		},
+		{
+			name: "should_always_log_errors_regardless_of_sampling",
+			redactorCfg: &redactor.Config{
+				SuccessSampleRate: 0,
+			},
+			responseCode: fiber.StatusBadRequest,
+			validateLog: func(t *testing.T, output string) {
+				assert.Contains(t, output, "request response")
+				assert.NotContains(t, output, "Bearer secret-token")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			originalLogger := log.Logger
+			log.Logger = zerolog.New(redactor.NewWriter(&buf))
+			redactor.NewRedactor(tt.redactorCfg)
+			t.Cleanup(func() {
+				log.Logger = originalLogger
+				redactor.NewRedactor(nil)
+			})
+
+			app := fiber.New()
+			app.Use(NewLogMiddleware().Log)
+			app.Post("/test", func(c *fiber.Ctx) error {
+				return c.Status(tt.responseCode).JSON(fiber.Map{"name": "John Doe", "address": "123 Main St"})
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewBufferString(`{"name":"John Doe","address":"123 Main St"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer secret-token")
+
+			resp, err := app.Test(req, -1)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.responseCode, resp.StatusCode)
+			tt.validateLog(t, buf.String())
+		})
+	}
+}
+
+func TestLogMiddleware_Log_Metrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		route          string
+		requestPath    string
+		responseCode   int
+		expectedStatus string
+	}{
+		{
+			name:           "should_observe_successful_request_by_route",
+			route:          "/metrics-test/:id",
+			requestPath:    "/metrics-test/1",
+			responseCode:   fiber.StatusOK,
+			expectedStatus: "200",
+		},
+		{
+			name:           "should_observe_failed_request_by_route",
+			route:          "/metrics-test-failed/:id",
+			requestPath:    "/metrics-test-failed/1",
+			responseCode:   fiber.StatusInternalServerError,
+			expectedStatus: "500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(NewLogMiddleware().Log)
+			app.Get(tt.route, func(c *fiber.Ctx) error {
+				return c.SendStatus(tt.responseCode)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.requestPath, nil), -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			families, err := metrics.Registry().Gather()
+			assert.NoError(t, err)
+
+			var found bool
+			for _, family := range families {
+				if family.GetName() != "boilerplate_http_requests_total" {
+					continue
+				}
+				for _, metric := range family.GetMetric() {
+					labels := map[string]string{}
+					for _, label := range metric.GetLabel() {
+						labels[label.GetName()] = label.GetValue()
+					}
+					if labels["route"] == tt.route && labels["status"] == tt.expectedStatus && labels["method"] == http.MethodGet {
+						found = true
+						assert.Equal(t, float64(1), metric.GetCounter().GetValue())
+					}
+				}
+			}
+			assert.True(t, found, "Expected request to be observed by route")
+		})
+	}
+}
@@ -4,6 +4,8 @@ type Middlewares struct {
 	Recover   *RecoverMiddleware
 	Tracer    *TracerMiddleware
 	RequestID *RequestIDMiddleware
+	Identity  *IdentityMiddleware
 	Log       *LogMiddleware
 	Timeout   *TimeoutMiddleware
+	Admin     *AdminMiddleware
 }
@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"fmt"
+	"go-boilerplate/pkg/redactor"
 	"runtime/debug"
 
 	"github.com/fikri240794/gocerr"
@@ -36,12 +37,12 @@ func (mw *RecoverMiddleware) Recover(c *fiber.Ctx) error {
 			logFields = map[string]interface{}{
 				"path":                 c.Path(),
 				"method":               c.Method(),
-				"request headers":      c.GetReqHeaders(),
+				"request headers":      redactor.Headers(c.GetReqHeaders()),
 				"request queries":      c.Queries(),
-				"request body":         string(c.Body()),
+				"request body":         redactor.Body(c.Body()),
 				"response status code": c.Response().StatusCode(),
-				"response headers":     c.GetRespHeaders(),
-				"response body":        string(c.Response().Body()),
+				"response headers":     redactor.Headers(c.GetRespHeaders()),
+				"response body":        redactor.Body(c.Response().Body()),
 			}
 
 			log.Error().
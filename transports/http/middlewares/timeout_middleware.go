@@ -4,6 +4,8 @@ import (
 	"context"
 	"go-boilerplate/configs"
 	"go-boilerplate/pkg/tracer"
+	"sync/atomic"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.opentelemetry.io/otel/trace"
@@ -11,12 +13,52 @@ import (
 
 type TimeoutMiddleware struct {
 	cfg *configs.Config
+
+	// reloadedCfg is the latest hot reloaded snapshot, nil until a reload
+	reloadedCfg atomic.Pointer[configs.Config]
+
+	// unsubscribe stops following the config hot reload
+	unsubscribe func()
 }
 
 func NewTimeoutMiddleware(cfg *configs.Config) *TimeoutMiddleware {
-	return &TimeoutMiddleware{
+	var mw *TimeoutMiddleware = &TimeoutMiddleware{
 		cfg: cfg,
 	}
+
+	mw.unsubscribe = configs.Subscribe(func(change *configs.Change) {
+		if change.Changed("SERVER.HTTP.REQUEST_TIMEOUT") {
+			mw.reloadedCfg.Store(change.Current)
+		}
+	})
+
+	return mw
+}
+
+// ProvideTimeoutMiddleware is NewTimeoutMiddleware for wire, the cleanup
+// closes the middleware.
+func ProvideTimeoutMiddleware(cfg *configs.Config) (*TimeoutMiddleware, func()) {
+	var mw *TimeoutMiddleware = NewTimeoutMiddleware(cfg)
+
+	return mw, mw.Close
+}
+
+// Close stops following the config hot reload, the middleware keeps the last
+// request timeout.
+func (mw *TimeoutMiddleware) Close() {
+	if mw.unsubscribe != nil {
+		mw.unsubscribe()
+	}
+}
+
+func (mw *TimeoutMiddleware) requestTimeout() time.Duration {
+	var cfg *configs.Config = mw.reloadedCfg.Load()
+
+	if cfg == nil {
+		cfg = mw.cfg
+	}
+
+	return cfg.Server.HTTP.RequestTimeout
 }
 
 func (mw *TimeoutMiddleware) Timeout(c *fiber.Ctx) error {
@@ -31,7 +73,7 @@ func (mw *TimeoutMiddleware) Timeout(c *fiber.Ctx) error {
 	ctx, span = tracer.Start(ctx, "[TimeoutMiddleware][Timeout]")
 	defer span.End()
 
-	ctx, cancel = context.WithTimeout(ctx, mw.cfg.Server.HTTP.RequestTimeout)
+	ctx, cancel = context.WithTimeout(ctx, mw.requestTimeout())
 	defer cancel()
 
 	c.SetUserContext(ctx)
@@ -42,6 +42,27 @@ func TestNewTimeoutMiddleware(t *testing.T) {
 	}
 }
 
+func TestProvideTimeoutMiddleware(t *testing.T) {
+	cfg := &configs.Config{}
+
+	mw, cleanup := ProvideTimeoutMiddleware(cfg)
+
+	assert.NotNil(t, mw)
+	assert.Equal(t, cfg, mw.cfg)
+	assert.NotNil(t, mw.unsubscribe)
+	assert.NotPanics(t, cleanup)
+}
+
+func TestTimeoutMiddleware_Close(t *testing.T) {
+	calls := 0
+	mw := &TimeoutMiddleware{unsubscribe: func() { calls++ }}
+
+	mw.Close()
+
+	assert.Equal(t, 1, calls)
+	assert.NotPanics(t, (&TimeoutMiddleware{}).Close)
+}
+
 func TestTimeoutMiddleware_Timeout(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -249,3 +270,43 @@ func TestTimeoutMiddleware_Timeout(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeoutMiddleware_requestTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T) *TimeoutMiddleware
+		expected time.Duration
+	}{
+		{
+			name: "should_use_the_built_config_before_any_reload",
+			setup: func(t *testing.T) *TimeoutMiddleware {
+				cfg := &configs.Config{}
+				cfg.Server.HTTP.RequestTimeout = 5 * time.Second
+				return NewTimeoutMiddleware(cfg)
+			},
+			expected: 5 * time.Second,
+		},
+		{
+			name: "should_use_the_reloaded_config",
+			setup: func(t *testing.T) *TimeoutMiddleware {
+				cfg := &configs.Config{}
+				cfg.Server.HTTP.RequestTimeout = 5 * time.Second
+				mw := NewTimeoutMiddleware(cfg)
+
+				reloadedCfg := &configs.Config{}
+				reloadedCfg.Server.HTTP.RequestTimeout = 2 * time.Second
+				mw.reloadedCfg.Store(reloadedCfg)
+
+				return mw
+			},
+			expected: 2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := tt.setup(t)
+			assert.Equal(t, tt.expected, mw.requestTimeout())
+		})
+	}
+}
@@ -2,10 +2,14 @@ package middlewares
 
 import (
 	"context"
+	"go-boilerplate/pkg/tracer"
 
 	"github.com/gofiber/fiber/v2"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type TracerMiddleware struct{}
@@ -15,7 +19,44 @@ func NewTracerMiddleware() *TracerMiddleware {
 }
 
 func (mw *TracerMiddleware) Start(c *fiber.Ctx) error {
-	var ctx context.Context = otel.GetTextMapPropagator().Extract(c.UserContext(), propagation.HeaderCarrier(c.GetReqHeaders()))
+	var (
+		ctx        context.Context
+		span       trace.Span
+		statusCode int
+		err        error
+	)
+
+	ctx = otel.GetTextMapPropagator().Extract(c.UserContext(), propagation.HeaderCarrier(c.GetReqHeaders()))
+
+	ctx, span = tracer.Start(
+		ctx,
+		c.Method(),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(c.Method()),
+			semconv.URLPath(c.Path()),
+			semconv.URLScheme(c.Protocol()),
+			semconv.ServerAddress(c.Hostname()),
+			semconv.ClientAddress(c.IP()),
+			semconv.UserAgentOriginal(string(c.Request().Header.UserAgent())),
+		),
+	)
+	defer span.End()
+
 	c.SetUserContext(ctx)
-	return c.Next()
+	err = c.Next()
+
+	statusCode = c.Response().StatusCode()
+	span.SetName(c.Method() + " " + c.Route().Path)
+	span.SetAttributes(
+		semconv.HTTPRoute(c.Route().Path),
+		semconv.HTTPResponseStatusCode(statusCode),
+	)
+
+	tracer.RecordError(span, err)
+	if statusCode >= fiber.StatusInternalServerError {
+		span.SetStatus(codes.Error, "")
+	}
+
+	return err
 }
@@ -116,6 +116,38 @@ func TestTracerMiddleware_Start(t *testing.T) {
 				assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
 			},
 		},
+		{
+			name: "should_keep_server_error_response",
+			setupRequest: func(t *testing.T) *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/test", nil)
+				return req
+			},
+			setupHandler: func(t *testing.T) fiber.Handler {
+				return func(c *fiber.Ctx) error {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"message": "error"})
+				}
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+			validate: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+			},
+		},
+		{
+			name: "should_return_error_from_next_handler",
+			setupRequest: func(t *testing.T) *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/test", nil)
+				return req
+			},
+			setupHandler: func(t *testing.T) fiber.Handler {
+				return func(c *fiber.Ctx) error {
+					return fiber.NewError(fiber.StatusBadRequest, "bad request")
+				}
+			},
+			expectedStatus: fiber.StatusBadRequest,
+			validate: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			},
+		},
 		{
 			name: "should_continue_to_next_middleware_after_extracting_context",
 			setupRequest: func(t *testing.T) *http.Request {
@@ -0,0 +1,67 @@
+package vms
+
+import (
+	"go-boilerplate/internal/models/dtos"
+)
+
+type CacheStatsRequestVM struct {
+	Pattern string `query:"pattern"`
+	Depth   int    `query:"depth"`
+}
+
+func (vm *CacheStatsRequestVM) ToDTO() *dtos.CacheStatsRequestDTO {
+	var dto *dtos.CacheStatsRequestDTO = &dtos.CacheStatsRequestDTO{
+		Pattern: vm.Pattern,
+		Depth:   vm.Depth,
+	}
+
+	return dto
+}
+
+type CachePrefixStatsResponseVM struct {
+	Prefix      string `json:"prefix" example:"caches:entities:guests:list"`
+	Keys        uint64 `json:"keys" example:"120"`
+	MemoryBytes int64  `json:"memory_bytes" example:"245760"`
+}
+
+type CacheStatsResponseVM struct {
+	Prefixes    []CachePrefixStatsResponseVM `json:"prefixes"`
+	Keys        uint64                       `json:"keys" example:"1200"`
+	MemoryBytes int64                        `json:"memory_bytes" example:"1048576"`
+}
+
+func NewCacheStatsResponseVM(dto *dtos.CacheStatsResponseDTO) *CacheStatsResponseVM {
+	var vm *CacheStatsResponseVM = &CacheStatsResponseVM{
+		Prefixes:    []CachePrefixStatsResponseVM{},
+		Keys:        dto.Keys,
+		MemoryBytes: dto.MemoryBytes,
+	}
+
+	for i := range dto.Prefixes {
+		vm.Prefixes = append(vm.Prefixes, CachePrefixStatsResponseVM(dto.Prefixes[i]))
+	}
+
+	return vm
+}
+
+type FlushCacheRequestVM struct {
+	Pattern string `query:"pattern"`
+}
+
+func (vm *FlushCacheRequestVM) ToDTO() *dtos.FlushCacheRequestDTO {
+	var dto *dtos.FlushCacheRequestDTO = &dtos.FlushCacheRequestDTO{
+		Pattern: vm.Pattern,
+	}
+
+	return dto
+}
+
+type FlushCacheResponseVM struct {
+	Deleted uint64 `json:"deleted" example:"42"`
+}
+
+func NewFlushCacheResponseVM(dto *dtos.FlushCacheResponseDTO) *FlushCacheResponseVM {
+	return &FlushCacheResponseVM{
+		Deleted: dto.Deleted,
+	}
+}
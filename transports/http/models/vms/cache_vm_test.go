@@ -0,0 +1,63 @@
+package vms
+
+import (
+	"go-boilerplate/internal/models/dtos"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CacheStatsRequestVM_ToDTO(t *testing.T) {
+	vm := &CacheStatsRequestVM{Pattern: "caches:*", Depth: 4}
+
+	assert.Equal(t, &dtos.CacheStatsRequestDTO{Pattern: "caches:*", Depth: 4}, vm.ToDTO())
+}
+
+func Test_NewCacheStatsResponseVM(t *testing.T) {
+	tests := []struct {
+		name     string
+		dto      *dtos.CacheStatsResponseDTO
+		expected *CacheStatsResponseVM
+	}{
+		{
+			name: "should_convert_prefixes_and_totals",
+			dto: &dtos.CacheStatsResponseDTO{
+				Prefixes: []dtos.CachePrefixStatsDTO{
+					{Prefix: "caches:entities:guests:id", Keys: 3, MemoryBytes: 96},
+					{Prefix: "caches:entities:guests:list", Keys: 1, MemoryBytes: 512},
+				},
+				Keys:        4,
+				MemoryBytes: 608,
+			},
+			expected: &CacheStatsResponseVM{
+				Prefixes: []CachePrefixStatsResponseVM{
+					{Prefix: "caches:entities:guests:id", Keys: 3, MemoryBytes: 96},
+					{Prefix: "caches:entities:guests:list", Keys: 1, MemoryBytes: 512},
+				},
+				Keys:        4,
+				MemoryBytes: 608,
+			},
+		},
+		{
+			name:     "should_return_empty_prefixes_without_keys",
+			dto:      &dtos.CacheStatsResponseDTO{},
+			expected: &CacheStatsResponseVM{Prefixes: []CachePrefixStatsResponseVM{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewCacheStatsResponseVM(tt.dto))
+		})
+	}
+}
+
+func Test_FlushCacheRequestVM_ToDTO(t *testing.T) {
+	vm := &FlushCacheRequestVM{Pattern: "caches:entities:guests:*"}
+
+	assert.Equal(t, &dtos.FlushCacheRequestDTO{Pattern: "caches:entities:guests:*"}, vm.ToDTO())
+}
+
+func Test_NewFlushCacheResponseVM(t *testing.T) {
+	assert.Equal(t, &FlushCacheResponseVM{Deleted: 42}, NewFlushCacheResponseVM(&dtos.FlushCacheResponseDTO{Deleted: 42}))
+}
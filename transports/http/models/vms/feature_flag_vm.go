@@ -0,0 +1,53 @@
+package vms
+
+import "go-boilerplate/pkg/feature_flag"
+
+type FeatureFlagNameRequestVM struct {
+	Name string `params:"name"`
+}
+
+type SetFeatureFlagRequestVM struct {
+	Name       string   `params:"name" json:"-"`
+	Enabled    bool     `json:"enabled" example:"true"`
+	Tenants    []string `json:"tenants" example:"acme"`
+	Actors     []string `json:"actors" example:"daenerys"`
+	Percentage int      `json:"percentage" example:"25"`
+}
+
+func (vm *SetFeatureFlagRequestVM) ToFlag() *feature_flag.Flag {
+	return &feature_flag.Flag{
+		Name:       vm.Name,
+		Enabled:    vm.Enabled,
+		Tenants:    vm.Tenants,
+		Actors:     vm.Actors,
+		Percentage: vm.Percentage,
+	}
+}
+
+type FeatureFlagResponseVM struct {
+	Name       string   `json:"name" example:"guest.cache"`
+	Enabled    bool     `json:"enabled" example:"true"`
+	Tenants    []string `json:"tenants" example:"acme"`
+	Actors     []string `json:"actors" example:"daenerys"`
+	Percentage int      `json:"percentage" example:"25"`
+}
+
+func NewFeatureFlagResponseVM(flag *feature_flag.Flag) *FeatureFlagResponseVM {
+	return &FeatureFlagResponseVM{
+		Name:       flag.Name,
+		Enabled:    flag.Enabled,
+		Tenants:    flag.Tenants,
+		Actors:     flag.Actors,
+		Percentage: flag.Percentage,
+	}
+}
+
+func NewFeatureFlagResponseVMs(flags []feature_flag.Flag) *[]FeatureFlagResponseVM {
+	var vms []FeatureFlagResponseVM = make([]FeatureFlagResponseVM, 0, len(flags))
+
+	for i := range flags {
+		vms = append(vms, *NewFeatureFlagResponseVM(&flags[i]))
+	}
+
+	return &vms
+}
@@ -0,0 +1,57 @@
+package vms
+
+import (
+	"go-boilerplate/pkg/feature_flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SetFeatureFlagRequestVM_ToFlag(t *testing.T) {
+	vm := &SetFeatureFlagRequestVM{
+		Name:       "guest.cache",
+		Enabled:    true,
+		Tenants:    []string{"acme"},
+		Actors:     []string{"daenerys"},
+		Percentage: 25,
+	}
+
+	assert.Equal(t, &feature_flag.Flag{
+		Name:       "guest.cache",
+		Enabled:    true,
+		Tenants:    []string{"acme"},
+		Actors:     []string{"daenerys"},
+		Percentage: 25,
+	}, vm.ToFlag())
+}
+
+func Test_NewFeatureFlagResponseVMs(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags []feature_flag.Flag
+		want  []FeatureFlagResponseVM
+	}{
+		{
+			name:  "success - no flags",
+			flags: nil,
+			want:  []FeatureFlagResponseVM{},
+		},
+		{
+			name: "success - flags are mapped in order",
+			flags: []feature_flag.Flag{
+				{Name: "guest.cache", Enabled: true, Percentage: 100},
+				{Name: "guest.event.created", Tenants: []string{"acme"}},
+			},
+			want: []FeatureFlagResponseVM{
+				{Name: "guest.cache", Enabled: true, Percentage: 100},
+				{Name: "guest.event.created", Tenants: []string{"acme"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, &tt.want, NewFeatureFlagResponseVMs(tt.flags))
+		})
+	}
+}
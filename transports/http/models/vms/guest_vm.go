@@ -32,6 +32,72 @@ func (vm *DeleteGuestByIDRequestVM) ToDTO(deletedBy string) *dtos.DeleteGuestByI
 	return dto
 }
 
+type EraseGuestByIDRequestVM struct {
+	ID string `params:"id"`
+}
+
+func (vm *EraseGuestByIDRequestVM) ToDTO(erasedBy string) *dtos.EraseGuestByIDRequestDTO {
+	var dto *dtos.EraseGuestByIDRequestDTO = &dtos.EraseGuestByIDRequestDTO{
+		ID:       vm.ID,
+		ErasedBy: erasedBy,
+	}
+
+	return dto
+}
+
+type ExportGuestByIDRequestVM struct {
+	ID string `params:"id"`
+}
+
+func (vm *ExportGuestByIDRequestVM) ToDTO() *dtos.ExportGuestByIDRequestDTO {
+	var dto *dtos.ExportGuestByIDRequestDTO = &dtos.ExportGuestByIDRequestDTO{
+		ID: vm.ID,
+	}
+
+	return dto
+}
+
+type GuestRecordVM struct {
+	ID        string `json:"id" example:"01932293-d710-7f55-a9f6-66e6248ae72f"`
+	Name      string `json:"name" example:"John Snow"`
+	Address   string `json:"address,omitempty" example:"123 Main Street, Apt. 4B, New York, NY 10001, USA"`
+	CreatedAt int64  `json:"created_at" example:"1731452061534"`
+	CreatedBy string `json:"created_by" example:"Daenerys"`
+	UpdatedAt int64  `json:"updated_at,omitempty" example:"1731452061534"`
+	UpdatedBy string `json:"updated_by,omitempty" example:"Daenerys"`
+	DeletedAt int64  `json:"deleted_at,omitempty" example:"1731452061534"`
+	DeletedBy string `json:"deleted_by,omitempty" example:"Daenerys"`
+}
+
+func NewGuestRecordVM(dto *dtos.GuestRecordDTO) *GuestRecordVM {
+	var vm GuestRecordVM = GuestRecordVM(*dto)
+	return &vm
+}
+
+// ExportGuestResponseVM holds the stored guest data, events and webhook
+// deliveries are not stored so they are never exported.
+type ExportGuestResponseVM struct {
+	Database   *GuestRecordVM `json:"database"`
+	Cache      *GuestRecordVM `json:"cache,omitempty"`
+	ExportedAt int64          `json:"exported_at" example:"1731452061534"`
+}
+
+func NewExportGuestResponseVM(dto *dtos.ExportGuestResponseDTO) *ExportGuestResponseVM {
+	var vm *ExportGuestResponseVM = &ExportGuestResponseVM{
+		ExportedAt: dto.ExportedAt,
+	}
+
+	if dto.Database != nil {
+		vm.Database = NewGuestRecordVM(dto.Database)
+	}
+
+	if dto.Cache != nil {
+		vm.Cache = NewGuestRecordVM(dto.Cache)
+	}
+
+	return vm
+}
+
 type FindAllGuestRequestVM struct {
 	Keyword string `query:"keyword"`
 	Sorts   string `query:"sorts"`
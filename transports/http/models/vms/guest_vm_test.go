@@ -199,6 +199,94 @@ func Test_DeleteGuestByIDRequestVM_ToDTO(t *testing.T) {
 	}
 }
 
+func Test_EraseGuestByIDRequestVM_ToDTO(t *testing.T) {
+	tests := []struct {
+		name     string
+		vm       *EraseGuestByIDRequestVM
+		erasedBy string
+		want     *dtos.EraseGuestByIDRequestDTO
+	}{
+		{
+			name:     "success - convert VM to DTO with valid ID and erasedBy",
+			vm:       &EraseGuestByIDRequestVM{ID: "01932293-d710-7f55-a9f6-66e6248ae72f"},
+			erasedBy: "Daenerys",
+			want: &dtos.EraseGuestByIDRequestDTO{
+				ID:       "01932293-d710-7f55-a9f6-66e6248ae72f",
+				ErasedBy: "Daenerys",
+			},
+		},
+		{
+			name:     "success - convert VM to DTO with all empty fields",
+			vm:       &EraseGuestByIDRequestVM{},
+			erasedBy: "",
+			want:     &dtos.EraseGuestByIDRequestDTO{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.vm.ToDTO(tt.erasedBy)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ExportGuestByIDRequestVM_ToDTO(t *testing.T) {
+	vm := &ExportGuestByIDRequestVM{ID: "01932293-d710-7f55-a9f6-66e6248ae72f"}
+
+	got := vm.ToDTO()
+
+	assert.Equal(t, &dtos.ExportGuestByIDRequestDTO{ID: "01932293-d710-7f55-a9f6-66e6248ae72f"}, got)
+}
+
+func TestNewExportGuestResponseVM(t *testing.T) {
+	record := &dtos.GuestRecordDTO{
+		ID:        "01932293-d710-7f55-a9f6-66e6248ae72f",
+		Name:      "John Snow",
+		Address:   "Winterfell",
+		CreatedAt: 1731452061534,
+		CreatedBy: "Daenerys",
+		DeletedAt: 1731452061999,
+		DeletedBy: "Daenerys",
+	}
+
+	tests := []struct {
+		name string
+		dto  *dtos.ExportGuestResponseDTO
+		want *ExportGuestResponseVM
+	}{
+		{
+			name: "success - convert DTO with database and cache records",
+			dto: &dtos.ExportGuestResponseDTO{
+				Database:   record,
+				Cache:      record,
+				ExportedAt: 1731452062000,
+			},
+			want: &ExportGuestResponseVM{
+				Database:   NewGuestRecordVM(record),
+				Cache:      NewGuestRecordVM(record),
+				ExportedAt: 1731452062000,
+			},
+		},
+		{
+			name: "success - convert DTO without cache record",
+			dto: &dtos.ExportGuestResponseDTO{
+				Database:   record,
+				ExportedAt: 1731452062000,
+			},
+			want: &ExportGuestResponseVM{
+				Database:   NewGuestRecordVM(record),
+				ExportedAt: 1731452062000,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewExportGuestResponseVM(tt.dto)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_FindAllGuestRequestVM_ToDTO(t *testing.T) {
 	type fields struct {
 		Keyword string
@@ -0,0 +1,33 @@
+package vms
+
+import "go-boilerplate/pkg/health"
+
+type HealthCheckResponseVM struct {
+	Status string `json:"status" example:"up"`
+	Error  string `json:"error,omitempty" example:"dial tcp: connection refused"`
+}
+
+type HealthResponseVM struct {
+	Status string                           `json:"status" example:"up"`
+	Checks map[string]HealthCheckResponseVM `json:"checks,omitempty"`
+}
+
+func NewHealthResponseVM(report *health.Report) *HealthResponseVM {
+	var vm *HealthResponseVM = &HealthResponseVM{
+		Status: report.Status,
+	}
+
+	if len(report.Checks) == 0 {
+		return vm
+	}
+
+	vm.Checks = make(map[string]HealthCheckResponseVM, len(report.Checks))
+	for name, result := range report.Checks {
+		vm.Checks[name] = HealthCheckResponseVM{
+			Status: result.Status,
+			Error:  result.Error,
+		}
+	}
+
+	return vm
+}
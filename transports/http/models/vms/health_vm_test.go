@@ -0,0 +1,45 @@
+package vms
+
+import (
+	"go-boilerplate/pkg/health"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHealthResponseVM(t *testing.T) {
+	tests := []struct {
+		name     string
+		report   *health.Report
+		expected *HealthResponseVM
+	}{
+		{
+			name:     "report without checks should only set status",
+			report:   &health.Report{Status: health.StatusUp},
+			expected: &HealthResponseVM{Status: health.StatusUp},
+		},
+		{
+			name: "report with checks should map every check",
+			report: &health.Report{
+				Status: health.StatusDown,
+				Checks: map[string]health.CheckResult{
+					"in_memory_database": {Status: health.StatusDown, Error: "connection refused"},
+					"event_producer":     {Status: health.StatusUp},
+				},
+			},
+			expected: &HealthResponseVM{
+				Status: health.StatusDown,
+				Checks: map[string]HealthCheckResponseVM{
+					"in_memory_database": {Status: health.StatusDown, Error: "connection refused"},
+					"event_producer":     {Status: health.StatusUp},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewHealthResponseVM(tt.report))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package vms
+
+import (
+	"errors"
+	"go-boilerplate/pkg/logger"
+
+	"github.com/rs/zerolog"
+)
+
+type SetLogLevelRequestVM struct {
+	Component string `json:"component" example:"repository"`
+	Level     string `json:"level" example:"warn"`
+}
+
+func (vm *SetLogLevelRequestVM) ParseLevel() (zerolog.Level, error) {
+	if vm.Level == "" {
+		return zerolog.NoLevel, errors.New("level is required")
+	}
+
+	return zerolog.ParseLevel(vm.Level)
+}
+
+type ResetLogLevelRequestVM struct {
+	Component string `params:"component"`
+}
+
+type LogLevelResponseVM struct {
+	Level      string            `json:"level" example:"info"`
+	Components map[string]string `json:"components"`
+}
+
+func NewLogLevelResponseVM(levels *logger.Levels) *LogLevelResponseVM {
+	var vm *LogLevelResponseVM = &LogLevelResponseVM{
+		Level:      levels.Base().String(),
+		Components: map[string]string{},
+	}
+
+	for component, level := range levels.Components() {
+		vm.Components[component] = level.String()
+	}
+
+	return vm
+}
@@ -0,0 +1,98 @@
+package vms
+
+import (
+	"go-boilerplate/pkg/logger"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SetLogLevelRequestVM_ParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   string
+		want    zerolog.Level
+		wantErr bool
+	}{
+		{
+			name:  "success - parse named level",
+			level: "warn",
+			want:  zerolog.WarnLevel,
+		},
+		{
+			name:  "success - parse numeric level",
+			level: "0",
+			want:  zerolog.DebugLevel,
+		},
+		{
+			name:    "error - empty level",
+			level:   "",
+			want:    zerolog.NoLevel,
+			wantErr: true,
+		},
+		{
+			name:    "error - unknown level",
+			level:   "loud",
+			want:    zerolog.NoLevel,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := &SetLogLevelRequestVM{Level: tt.level}
+
+			got, err := vm.ParseLevel()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_NewLogLevelResponseVM(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	tests := []struct {
+		name        string
+		setupLevels func(t *testing.T) *logger.Levels
+		want        *LogLevelResponseVM
+	}{
+		{
+			name: "success - base level without overrides",
+			setupLevels: func(t *testing.T) *logger.Levels {
+				return logger.NewLevels(zerolog.InfoLevel)
+			},
+			want: &LogLevelResponseVM{
+				Level:      "info",
+				Components: map[string]string{},
+			},
+		},
+		{
+			name: "success - base level with overrides",
+			setupLevels: func(t *testing.T) *logger.Levels {
+				levels := logger.NewLevels(zerolog.InfoLevel)
+				assert.NoError(t, levels.SetLevel(logger.ComponentRepository, zerolog.WarnLevel))
+				return levels
+			},
+			want: &LogLevelResponseVM{
+				Level:      "info",
+				Components: map[string]string{logger.ComponentRepository: "warn"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewLogLevelResponseVM(tt.setupLevels(t))
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}